@@ -0,0 +1,235 @@
+// mavlink-feeder 在 UDP 端口接收 MAVLink v1/v2 遥测，按系统 ID 聚合飞行状态，
+// 并定期将位置批量上报到 /api/ingest/drone-telemetry。
+//
+// 用法：
+//
+//	go run ./cmd/mavlink-feeder -listen :14550 -api http://localhost:8080 -token <JWT> -drones 1=DJI-001,2=DJI-002
+//
+// MAVLink 系统 ID 通过 -drones 映射为无人机序列号，未映射的系统按 -serial-format 生成序列号。
+// token 需要具备 admin 或 feeder 角色，也可以通过环境变量 MAVLINK_FEEDER_TOKEN 提供。
+// feeder 账号和长期令牌通过 go run scripts/create_feeder.go 创建。
+package main
+
+import (
+	"backend/internal/dto"
+	"backend/pkg/mavlink"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+func main() {
+	listen := flag.String("listen", ":"+mavlink.DefaultPort, "UDP 监听地址")
+	api := flag.String("api", "http://localhost:8080", "后端服务地址")
+	token := flag.String("token", os.Getenv("MAVLINK_FEEDER_TOKEN"), "访问令牌（admin 或 feeder 角色）")
+	drones := flag.String("drones", "", "系统 ID 与无人机序列号映射，如 1=DJI-001,2=DJI-002")
+	serialFormat := flag.String("serial-format", "MAV-%03d", "未映射系统 ID 的序列号格式")
+	interval := flag.Duration("interval", time.Second, "上报间隔")
+	maxAge := flag.Duration("max-age", 5*time.Minute, "超过该时长未出现的飞行器将被移除")
+	flag.Parse()
+
+	serials, err := parseDroneMap(*drones)
+	if err != nil {
+		log.Fatalf("无效的 -drones 参数: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	f := &feeder{
+		tracker:      mavlink.NewTracker(),
+		endpoint:     *api + "/api/ingest/drone-telemetry",
+		token:        *token,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		serials:      serials,
+		serialFormat: *serialFormat,
+		dirty:        make(map[uint8]struct{}),
+	}
+	go f.flushLoop(ctx, *interval, *maxAge)
+
+	listener := &mavlink.Listener{
+		Addr:     *listen,
+		OnListen: func(addr net.Addr) { log.Printf("正在监听 MAVLink 遥测 %s", addr) },
+	}
+	if err := listener.Run(ctx, f.handle); err != nil && ctx.Err() == nil {
+		log.Fatalf("MAVLink 监听退出: %v", err)
+	}
+	f.flush(context.Background())
+	log.Println("mavlink-feeder 已退出")
+}
+
+type feeder struct {
+	tracker      *mavlink.Tracker
+	endpoint     string
+	token        string
+	client       *http.Client
+	serials      map[uint8]string
+	serialFormat string
+
+	mu    sync.Mutex
+	dirty map[uint8]struct{} // 自上次上报以来位置有更新的飞行器
+}
+
+func (f *feeder) handle(pkt *mavlink.Packet) {
+	if _, positioned := f.tracker.Update(pkt.Frame.SystemID, pkt.Message, pkt.Received); positioned {
+		f.mu.Lock()
+		f.dirty[pkt.Frame.SystemID] = struct{}{}
+		f.mu.Unlock()
+	}
+}
+
+func (f *feeder) flushLoop(ctx context.Context, interval, maxAge time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.flush(ctx)
+			f.tracker.Prune(time.Now().Add(-maxAge))
+		}
+	}
+}
+
+// flush 上报自上次以来位置有更新的飞行器
+func (f *feeder) flush(ctx context.Context) {
+	f.mu.Lock()
+	ids := make([]uint8, 0, len(f.dirty))
+	for id := range f.dirty {
+		ids = append(ids, id)
+	}
+	f.dirty = make(map[uint8]struct{})
+	f.mu.Unlock()
+
+	reports := make([]dto.DroneTelemetryReport, 0, len(ids))
+	for _, id := range ids {
+		if state, ok := f.tracker.Get(id); ok && state.HasPosition() {
+			reports = append(reports, toReport(state, f.serial(id)))
+		}
+	}
+
+	for start := 0; start < len(reports); start += dto.MaxIngestBatchSize {
+		end := min(start+dto.MaxIngestBatchSize, len(reports))
+		result, err := f.post(ctx, reports[start:end])
+		if err != nil {
+			log.Printf("上报遥测失败: %v", err)
+			continue
+		}
+		log.Printf("上报遥测: received=%d, accepted=%d, rejected=%d, breaches=%d",
+			result.Received, result.Accepted, result.Rejected, result.Breaches)
+	}
+}
+
+func (f *feeder) serial(systemID uint8) string {
+	if serial, ok := f.serials[systemID]; ok {
+		return serial
+	}
+	return fmt.Sprintf(f.serialFormat, systemID)
+}
+
+func (f *feeder) post(ctx context.Context, reports []dto.DroneTelemetryReport) (*dto.DroneIngestResult, error) {
+	body, err := json.Marshal(dto.IngestDroneTelemetryRequest{Positions: reports})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Success bool                  `json:"success"`
+		Message string                `json:"message"`
+		Error   string                `json:"error"`
+		Data    dto.DroneIngestResult `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("HTTP %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || !envelope.Success {
+		return nil, fmt.Errorf("HTTP %d: %s %s", resp.StatusCode, envelope.Message, envelope.Error)
+	}
+	return &envelope.Data, nil
+}
+
+// toReport 将飞行器状态转换为遥测报告，速度换算为 km/h，高度和垂直速度取整到米
+func toReport(state mavlink.Vehicle, serial string) dto.DroneTelemetryReport {
+	timestamp := state.PositionTime.UTC()
+	report := dto.DroneTelemetryReport{
+		SerialNumber:  serial,
+		Latitude:      state.Latitude,
+		Longitude:     state.Longitude,
+		Altitude:      roundPtr(state.Altitude),
+		AltitudeMSL:   roundPtr(state.AltitudeMSL),
+		VerticalSpeed: roundPtr(state.VerticalSpeed),
+		BatteryLevel:  state.BatteryLevel,
+		GpsSatellites: state.GpsSatellites,
+		GpsAccuracy:   state.GpsAccuracy,
+		FlightMode:    state.FlightMode,
+		Timestamp:     &timestamp,
+	}
+	if state.GroundSpeed != nil {
+		speed := int(math.Round(*state.GroundSpeed * 3.6))
+		report.Speed = &speed
+	}
+	if state.Heading != nil {
+		heading := int(math.Round(*state.Heading)) % 360
+		report.Heading = &heading
+	}
+	return report
+}
+
+func roundPtr(v *float64) *int {
+	if v == nil {
+		return nil
+	}
+	r := int(math.Round(*v))
+	return &r
+}
+
+// parseDroneMap 解析 "系统ID=序列号" 逗号分隔列表
+func parseDroneMap(raw string) (map[uint8]string, error) {
+	serials := make(map[uint8]string)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, serial, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(serial) == "" {
+			return nil, fmt.Errorf("格式应为 系统ID=序列号: %q", part)
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(id), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("系统 ID 无效: %q", id)
+		}
+		serials[uint8(n)] = strings.TrimSpace(serial)
+	}
+	return serials, nil
+}
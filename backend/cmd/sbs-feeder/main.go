@@ -0,0 +1,187 @@
+// sbs-feeder 连接 SBS-1（BaseStation 30003 端口）数据源，按 ICAO 地址聚合飞机状态，
+// 并定期将位置批量上报到 /api/ingest/flight-positions。
+//
+// 用法：
+//
+//	go run ./cmd/sbs-feeder -source localhost:30003 -api http://localhost:8080 -token <JWT>
+//
+// token 需要具备 admin 或 feeder 角色，也可以通过环境变量 SBS_FEEDER_TOKEN 提供。
+// feeder 账号和长期令牌通过 go run scripts/create_feeder.go 创建。
+package main
+
+import (
+	"backend/internal/dto"
+	"backend/pkg/sbs"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+func main() {
+	source := flag.String("source", "localhost:"+sbs.DefaultPort, "SBS-1 数据源地址")
+	api := flag.String("api", "http://localhost:8080", "后端服务地址")
+	token := flag.String("token", os.Getenv("SBS_FEEDER_TOKEN"), "访问令牌（admin 或 feeder 角色）")
+	interval := flag.Duration("interval", 2*time.Second, "上报间隔")
+	tz := flag.String("tz", "UTC", "接收机时间戳所在时区，如 Asia/Shanghai 或 Local")
+	maxAge := flag.Duration("max-age", 5*time.Minute, "超过该时长未出现的飞机将被移除")
+	flag.Parse()
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		log.Fatalf("无效的时区 %q: %v", *tz, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	f := &feeder{
+		tracker:  sbs.NewTracker(),
+		endpoint: *api + "/api/ingest/flight-positions",
+		token:    *token,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		dirty:    make(map[string]struct{}),
+	}
+	go f.flushLoop(ctx, *interval, *maxAge)
+
+	client := &sbs.Client{
+		Addr:         *source,
+		Parser:       sbs.Parser{Location: loc},
+		OnConnect:    func() { log.Printf("已连接 SBS 数据源 %s", *source) },
+		OnDisconnect: func(err error) { log.Printf("SBS 数据源连接断开: %v", err) },
+	}
+	if err := client.Run(ctx, f.handle); err != nil && ctx.Err() == nil {
+		log.Fatalf("SBS 客户端退出: %v", err)
+	}
+	f.flush(context.Background())
+	log.Println("sbs-feeder 已退出")
+}
+
+type feeder struct {
+	tracker  *sbs.Tracker
+	endpoint string
+	token    string
+	client   *http.Client
+
+	mu    sync.Mutex
+	dirty map[string]struct{} // 自上次上报以来位置有更新的飞机
+}
+
+func (f *feeder) handle(msg *sbs.Message) {
+	if _, positioned := f.tracker.Update(msg); positioned {
+		f.mu.Lock()
+		f.dirty[msg.HexIdent] = struct{}{}
+		f.mu.Unlock()
+	}
+}
+
+func (f *feeder) flushLoop(ctx context.Context, interval, maxAge time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.flush(ctx)
+			f.tracker.Prune(time.Now().Add(-maxAge))
+		}
+	}
+}
+
+// flush 上报自上次以来位置有更新的飞机
+func (f *feeder) flush(ctx context.Context) {
+	f.mu.Lock()
+	hexes := make([]string, 0, len(f.dirty))
+	for hex := range f.dirty {
+		hexes = append(hexes, hex)
+	}
+	f.dirty = make(map[string]struct{})
+	f.mu.Unlock()
+
+	reports := make([]dto.FlightPositionReport, 0, len(hexes))
+	for _, hex := range hexes {
+		if state, ok := f.tracker.Get(hex); ok && state.HasPosition() {
+			reports = append(reports, toReport(state))
+		}
+	}
+
+	for start := 0; start < len(reports); start += dto.MaxIngestBatchSize {
+		end := min(start+dto.MaxIngestBatchSize, len(reports))
+		result, err := f.post(ctx, reports[start:end])
+		if err != nil {
+			log.Printf("上报位置失败: %v", err)
+			continue
+		}
+		log.Printf("上报位置: received=%d, accepted=%d, rejected=%d", result.Received, result.Accepted, result.Rejected)
+	}
+}
+
+func (f *feeder) post(ctx context.Context, reports []dto.FlightPositionReport) (*dto.IngestResult, error) {
+	body, err := json.Marshal(dto.IngestFlightPositionsRequest{Positions: reports})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Success bool             `json:"success"`
+		Message string           `json:"message"`
+		Error   string           `json:"error"`
+		Data    dto.IngestResult `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("HTTP %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || !envelope.Success {
+		return nil, fmt.Errorf("HTTP %d: %s %s", resp.StatusCode, envelope.Message, envelope.Error)
+	}
+	return &envelope.Data, nil
+}
+
+// toReport 将飞机状态转换为位置报告，呼号作为航班号，ICAO 地址作为备用关联键
+func toReport(state sbs.Aircraft) dto.FlightPositionReport {
+	timestamp := state.PositionTime.UTC()
+	report := dto.FlightPositionReport{
+		FlightNumber:  state.Callsign,
+		ICAOHex:       state.HexIdent,
+		Latitude:      state.Latitude,
+		Longitude:     state.Longitude,
+		Altitude:      state.Altitude,
+		VerticalSpeed: state.VerticalRate,
+		Timestamp:     &timestamp,
+	}
+	if state.GroundSpeed != nil {
+		speed := int(math.Round(*state.GroundSpeed))
+		report.Speed = &speed
+	}
+	if state.Track != nil {
+		heading := int(math.Round(*state.Track)) % 360
+		report.Heading = &heading
+	}
+	return report
+}
@@ -0,0 +1,62 @@
+// sbs-replay 在本地端口回放录制的 SBS-1 消息文件，用于在没有接收机时联调 sbs-feeder。
+//
+// 用法：
+//
+//	go run ./cmd/sbs-replay -file pkg/sbs/testdata/basestation.txt -listen :30003 -interval 200ms
+package main
+
+import (
+	"backend/pkg/sbs"
+	"bufio"
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	file := flag.String("file", "", "录制的 SBS-1 消息文件")
+	listen := flag.String("listen", ":"+sbs.DefaultPort, "监听地址")
+	interval := flag.Duration("interval", 100*time.Millisecond, "逐行发送间隔")
+	flag.Parse()
+
+	if *file == "" {
+		log.Fatal("必须通过 -file 指定回放文件")
+	}
+	lines, err := readLines(*file)
+	if err != nil {
+		log.Fatalf("读取回放文件失败: %v", err)
+	}
+
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatalf("监听 %s 失败: %v", *listen, err)
+	}
+	log.Printf("在 %s 回放 %d 行消息", ln.Addr(), len(lines))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := sbs.Replay(ctx, ln, lines, *interval); err != nil {
+		log.Fatalf("回放失败: %v", err)
+	}
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
@@ -72,6 +72,11 @@ func main() {
 	}
 	appContainer.Router.SetupRoutes(r)
 
+	// 启动后台任务（含启动时的状态恢复），服务器关闭时停止
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	appContainer.StartJobs(jobCtx)
+
 	// 配置可信代理 (消除启动警告)
 	// 在生产环境中，应该设置为实际的负载均衡器或反向代理的 IP
 	// 这里设置为 nil 表示不信任任何代理，或者设置为 "*" 信任所有（仅限内网安全环境）
@@ -104,6 +109,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("正在关闭服务器...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -36,6 +36,20 @@ type Config struct {
 	IPWhitelist       string // 逗号分隔的IP列表
 	EnableIPBlacklist bool
 	IPBlacklist       string // 逗号分隔的IP列表
+
+	// 航线偏离检测配置
+	RouteDeviationThreshold float64 // 偏航告警阈值（米）
+
+	// 禁飞区配置
+	NoFlyZoneExpiryInterval int // 临时禁飞区过期检查间隔（秒）
+
+	// 事件检测配置
+	SignalLossCheckInterval int // 空中失联无人机检查间隔（秒）
+
+	// 接近检测配置
+	NearMissHorizontalSeparation float64 // 水平最小间隔（米）
+	NearMissVerticalSeparation   float64 // 垂直最小间隔（米）
+	NearMissLookahead            int     // 最近会遇点预测时长（秒）
 }
 
 var AppConfig *Config
@@ -79,6 +93,20 @@ func LoadConfig() *Config {
 		IPWhitelist:       getEnv("IP_WHITELIST", ""),
 		EnableIPBlacklist: getEnvAsBool("ENABLE_IP_BLACKLIST", false),
 		IPBlacklist:       getEnv("IP_BLACKLIST", ""),
+
+		// 航线偏离检测配置
+		RouteDeviationThreshold: getEnvAsFloat("ROUTE_DEVIATION_THRESHOLD", 9260),
+
+		// 禁飞区配置
+		NoFlyZoneExpiryInterval: getEnvAsInt("NO_FLY_ZONE_EXPIRY_INTERVAL", 60),
+
+		// 事件检测配置
+		SignalLossCheckInterval: getEnvAsInt("SIGNAL_LOSS_CHECK_INTERVAL", 30),
+
+		// 接近检测配置
+		NearMissHorizontalSeparation: getEnvAsFloat("NEAR_MISS_HORIZONTAL_SEPARATION", 500),
+		NearMissVerticalSeparation:   getEnvAsFloat("NEAR_MISS_VERTICAL_SEPARATION", 150),
+		NearMissLookahead:            getEnvAsInt("NEAR_MISS_LOOKAHEAD", 60),
 	}
 }
 
@@ -136,6 +164,16 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsFloat 从环境变量获取浮点数值
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatVal
+		}
+	}
+	return defaultValue
+}
+
 // getEnvAsBool 从环境变量获取布尔值
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
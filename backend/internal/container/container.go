@@ -1,29 +1,72 @@
 package container
 
 import (
+	"backend/internal/config"
 	"backend/internal/database"
 	"backend/internal/handlers"
+	"backend/internal/proximity"
 	"backend/internal/repositories"
 	"backend/internal/routes"
 	"backend/internal/services"
+	"backend/internal/stream"
+	"context"
+	"time"
 )
 
 // Container 依赖注入容器
 type Container struct {
 	Router *routes.Router
+	jobs   []func(ctx context.Context)
 }
 
 // ModuleHolders 内部结构，用于在初始化过程中传递模块
 type repositoriesHolder struct {
-	Task repositories.TaskRepository
-	User repositories.UserRepository
-	Menu repositories.MenuRepository
+	Task           repositories.TaskRepository
+	User           repositories.UserRepository
+	Menu           repositories.MenuRepository
+	Airport        repositories.AirportRepository
+	Airline        repositories.AirlineRepository
+	Aircraft       repositories.AircraftRepository
+	Flight         repositories.FlightRepository
+	FlightPosition repositories.FlightPositionRepository
+	FlightRoute    repositories.FlightRouteRepository
+	Alert          repositories.AlertRepository
+	FlightHistory  repositories.FlightHistoryRepository
+	Operator       repositories.OperatorRepository
+	Drone          repositories.DroneRepository
+	DroneMission   repositories.DroneMissionRepository
+	NoFlyZone      repositories.NoFlyZoneRepository
+	DronePosition  repositories.DronePositionRepository
+	DroneFlightLog repositories.DroneFlightLogRepository
+	DroneIncident  repositories.DroneIncidentRepository
+	Maintenance    repositories.MaintenanceRepository
 }
 
 type servicesHolder struct {
-	Task   services.TaskService
-	User   services.UserService
-	Health services.HealthService
+	Task           services.TaskService
+	User           services.UserService
+	Health         services.HealthService
+	Airport        services.AirportService
+	Airline        services.AirlineService
+	Aircraft       services.AircraftService
+	Flight         services.FlightService
+	FlightPosition services.FlightPositionService
+	FlightRoute    services.FlightRouteService
+	Alert          services.AlertService
+	Analytics      services.AnalyticsService
+	Operator       services.OperatorService
+	Drone          services.DroneService
+	Mission        services.MissionService
+	NoFlyZone      services.NoFlyZoneService
+	Airspace       services.AirspaceService
+	DroneTelemetry services.DroneTelemetryService
+	FlightLog      services.FlightLogService
+	Incident       services.IncidentService
+	Detection      services.IncidentDetectionService
+	Compliance     services.ComplianceService
+	Maintenance    services.MaintenanceService
+	NearMiss       services.ProximityService
+	Stream         *stream.Hub
 }
 
 // InitializeContainer 初始化容器
@@ -43,33 +86,128 @@ func InitializeContainer(manager *database.Manager) (*Container, error) {
 
 	return &Container{
 		Router: router,
+		jobs:   initJobs(svcs),
 	}, nil
 }
 
+// StartJobs 在后台启动启动钩子和定时任务，ctx 取消时全部退出
+func (c *Container) StartJobs(ctx context.Context) {
+	for _, job := range c.jobs {
+		go job(ctx)
+	}
+}
+
 // initRepositories 初始化所有 Repository
 func initRepositories(manager *database.Manager) *repositoriesHolder {
 	return &repositoriesHolder{
-		Task: ProvideTaskRepository(manager),
-		User: ProvideUserRepository(manager),
-		Menu: ProvideMenuRepository(manager),
+		Task:           ProvideTaskRepository(manager),
+		User:           ProvideUserRepository(manager),
+		Menu:           ProvideMenuRepository(manager),
+		Airport:        ProvideAirportRepository(manager),
+		Airline:        ProvideAirlineRepository(manager),
+		Aircraft:       ProvideAircraftRepository(manager),
+		Flight:         ProvideFlightRepository(manager),
+		FlightPosition: ProvideFlightPositionRepository(manager),
+		FlightRoute:    ProvideFlightRouteRepository(manager),
+		Alert:          ProvideAlertRepository(manager),
+		FlightHistory:  ProvideFlightHistoryRepository(manager),
+		Operator:       ProvideOperatorRepository(manager),
+		Drone:          ProvideDroneRepository(manager),
+		DroneMission:   ProvideDroneMissionRepository(manager),
+		NoFlyZone:      ProvideNoFlyZoneRepository(manager),
+		DronePosition:  ProvideDronePositionRepository(manager),
+		DroneFlightLog: ProvideDroneFlightLogRepository(manager),
+		DroneIncident:  ProvideDroneIncidentRepository(manager),
+		Maintenance:    ProvideMaintenanceRepository(manager),
 	}
 }
 
 // initServices 初始化所有 Service
 func initServices(repos *repositoriesHolder) *servicesHolder {
+	// 实时事件分发中心，由数据接入服务发布、推送接口订阅
+	hub := stream.NewHub()
+	alerts := services.NewAlertService(repos.Alert, hub)
+	deviation := services.NewRouteDeviationService(repos.FlightRoute, alerts, config.AppConfig.RouteDeviationThreshold)
+	flightLogs := services.NewFlightLogService(repos.DroneFlightLog, repos.DronePosition, repos.Drone, repos.Alert, repos.User)
+	detection := services.NewIncidentDetectionService(repos.DroneIncident, repos.DronePosition, repos.Drone, repos.DroneMission)
+	nearMiss := services.NewProximityService(alerts, proximity.Minima{
+		Horizontal: config.AppConfig.NearMissHorizontalSeparation,
+		Vertical:   config.AppConfig.NearMissVerticalSeparation,
+	}, time.Duration(config.AppConfig.NearMissLookahead)*time.Second)
+	maintenance := services.NewMaintenanceService(repos.Maintenance, repos.Drone, repos.DroneFlightLog, repos.User)
+
 	return &servicesHolder{
-		Task:   services.NewTaskService(repos.Task),
-		User:   services.NewUserService(repos.User, repos.Menu),
-		Health: services.NewHealthService(),
+		Task:           services.NewTaskService(repos.Task),
+		User:           services.NewUserService(repos.User, repos.Menu),
+		Health:         services.NewHealthService(),
+		Airport:        services.NewAirportService(repos.Airport),
+		Airline:        services.NewAirlineService(repos.Airline, repos.Aircraft),
+		Aircraft:       services.NewAircraftService(repos.Aircraft, repos.Airline),
+		Flight:         services.NewFlightService(repos.Flight, repos.Airport, repos.Airline, repos.Aircraft),
+		FlightPosition: services.NewFlightPositionService(repos.FlightPosition, repos.Flight, repos.Aircraft, deviation, nearMiss, hub),
+		FlightRoute:    services.NewFlightRouteService(repos.FlightRoute, repos.Flight, deviation),
+		Alert:          alerts,
+		Analytics:      services.NewAnalyticsService(repos.FlightHistory),
+		Operator:       services.NewOperatorService(repos.Operator, repos.Drone, repos.User),
+		Drone:          services.NewDroneService(repos.Drone, repos.Operator, repos.User),
+		Mission:        services.NewMissionService(repos.DroneMission, repos.Drone, repos.User, services.NewNoFlyZoneChecker(repos.NoFlyZone), flightLogs, maintenance),
+		NoFlyZone:      services.NewNoFlyZoneService(repos.NoFlyZone),
+		Airspace:       services.NewAirspaceService(repos.NoFlyZone),
+		DroneTelemetry: services.NewDroneTelemetryService(repos.DronePosition, repos.Drone, repos.DroneMission, repos.NoFlyZone, alerts, flightLogs, detection, nearMiss, hub),
+		FlightLog:      flightLogs,
+		Incident:       services.NewIncidentService(repos.DroneIncident, repos.Drone, repos.DroneMission, repos.Operator, repos.User),
+		Detection:      detection,
+		Compliance:     services.NewComplianceService(repos.Operator, repos.DroneMission, repos.DroneFlightLog, repos.Alert, repos.DroneIncident, repos.Drone, repos.User, maintenance),
+		Maintenance:    maintenance,
+		NearMiss:       nearMiss,
+		Stream:         hub,
 	}
 }
 
 // initHandlers 初始化所有 Handler 并组装成 Handlers 结构体
 func initHandlers(svcs *servicesHolder) *handlers.Handlers {
 	return &handlers.Handlers{
-		Task:    handlers.NewTaskHandler(svcs.Task),
-		User:    handlers.NewUserHandler(svcs.User),
-		Health:  handlers.NewHealthHandler(svcs.Health),
-		Captcha: handlers.NewCaptchaHandler(),
+		Task:        handlers.NewTaskHandler(svcs.Task),
+		User:        handlers.NewUserHandler(svcs.User),
+		Health:      handlers.NewHealthHandler(svcs.Health),
+		Captcha:     handlers.NewCaptchaHandler(),
+		Airport:     handlers.NewAirportHandler(svcs.Airport),
+		Airline:     handlers.NewAirlineHandler(svcs.Airline),
+		Aircraft:    handlers.NewAircraftHandler(svcs.Aircraft),
+		Flight:      handlers.NewFlightHandler(svcs.Flight, svcs.FlightPosition),
+		FlightRoute: handlers.NewFlightRouteHandler(svcs.FlightRoute),
+		Ingest:      handlers.NewIngestHandler(svcs.FlightPosition, svcs.DroneTelemetry),
+		Stream:      handlers.NewStreamHandler(svcs.Stream),
+		Alert:       handlers.NewAlertHandler(svcs.Alert),
+		Analytics:   handlers.NewAnalyticsHandler(svcs.Analytics),
+		Operator:    handlers.NewOperatorHandler(svcs.Operator, svcs.Drone),
+		Drone:       handlers.NewDroneHandler(svcs.Drone),
+		Mission:     handlers.NewMissionHandler(svcs.Mission),
+		NoFlyZone:   handlers.NewNoFlyZoneHandler(svcs.NoFlyZone),
+		Airspace:    handlers.NewAirspaceHandler(svcs.Airspace),
+		FlightLog:   handlers.NewFlightLogHandler(svcs.FlightLog),
+		Incident:    handlers.NewIncidentHandler(svcs.Incident),
+		Compliance:  handlers.NewComplianceHandler(svcs.Compliance),
+		Maintenance: handlers.NewMaintenanceHandler(svcs.Maintenance),
+	}
+}
+
+// initJobs 初始化后台任务：启动时恢复接近告警状态，以及各定时任务（间隔不大于 0 的不启用）
+func initJobs(svcs *servicesHolder) []func(ctx context.Context) {
+	jobs := []func(ctx context.Context){
+		func(ctx context.Context) {
+			services.RunProximityRestore(ctx, svcs.NearMiss)
+		},
+	}
+	if interval := config.AppConfig.NoFlyZoneExpiryInterval; interval > 0 {
+		jobs = append(jobs, func(ctx context.Context) {
+			services.RunZoneExpiry(ctx, svcs.NoFlyZone, time.Duration(interval)*time.Second)
+		})
+	}
+	if interval := config.AppConfig.SignalLossCheckInterval; interval > 0 {
+		jobs = append(jobs, func(ctx context.Context) {
+			services.RunSignalLossDetection(ctx, svcs.Detection, time.Duration(interval)*time.Second)
+		})
 	}
+	return jobs
 }
@@ -23,3 +23,86 @@ func ProvideUserRepository(manager *database.Manager) repositories.UserRepositor
 func ProvideMenuRepository(manager *database.Manager) repositories.MenuRepository {
 	return repositories.NewMenuRepository(manager.GetDB())
 }
+
+// ProvideAirportRepository 根据数据库类型提供 AirportRepository
+func ProvideAirportRepository(manager *database.Manager) repositories.AirportRepository {
+	if manager.IsMongo() {
+		return repositories.NewMongoAirportRepository(manager.GetMongoDatabase())
+	}
+	return repositories.NewDBAirportRepository(manager.GetDB())
+}
+
+// ProvideAirlineRepository 提供 AirlineRepository
+func ProvideAirlineRepository(manager *database.Manager) repositories.AirlineRepository {
+	return repositories.NewDBAirlineRepository(manager.GetDB())
+}
+
+// ProvideAircraftRepository 提供 AircraftRepository
+func ProvideAircraftRepository(manager *database.Manager) repositories.AircraftRepository {
+	return repositories.NewDBAircraftRepository(manager.GetDB())
+}
+
+// ProvideFlightRepository 提供 FlightRepository
+func ProvideFlightRepository(manager *database.Manager) repositories.FlightRepository {
+	return repositories.NewDBFlightRepository(manager.GetDB())
+}
+
+// ProvideFlightPositionRepository 提供 FlightPositionRepository
+func ProvideFlightPositionRepository(manager *database.Manager) repositories.FlightPositionRepository {
+	return repositories.NewDBFlightPositionRepository(manager.GetDB())
+}
+
+// ProvideDronePositionRepository 提供 DronePositionRepository
+func ProvideDronePositionRepository(manager *database.Manager) repositories.DronePositionRepository {
+	return repositories.NewDBDronePositionRepository(manager.GetDB())
+}
+
+// ProvideDroneFlightLogRepository 提供 DroneFlightLogRepository
+func ProvideDroneFlightLogRepository(manager *database.Manager) repositories.DroneFlightLogRepository {
+	return repositories.NewDBDroneFlightLogRepository(manager.GetDB())
+}
+
+// ProvideDroneIncidentRepository 提供 DroneIncidentRepository
+func ProvideDroneIncidentRepository(manager *database.Manager) repositories.DroneIncidentRepository {
+	return repositories.NewDBDroneIncidentRepository(manager.GetDB())
+}
+
+// ProvideMaintenanceRepository 提供 MaintenanceRepository
+func ProvideMaintenanceRepository(manager *database.Manager) repositories.MaintenanceRepository {
+	return repositories.NewDBMaintenanceRepository(manager.GetDB())
+}
+
+// ProvideFlightRouteRepository 提供 FlightRouteRepository
+func ProvideFlightRouteRepository(manager *database.Manager) repositories.FlightRouteRepository {
+	return repositories.NewDBFlightRouteRepository(manager.GetDB())
+}
+
+// ProvideAlertRepository 提供 AlertRepository
+func ProvideAlertRepository(manager *database.Manager) repositories.AlertRepository {
+	return repositories.NewDBAlertRepository(manager.GetDB())
+}
+
+// ProvideFlightHistoryRepository 提供 FlightHistoryRepository
+func ProvideFlightHistoryRepository(manager *database.Manager) repositories.FlightHistoryRepository {
+	return repositories.NewDBFlightHistoryRepository(manager.GetDB())
+}
+
+// ProvideOperatorRepository 提供 OperatorRepository
+func ProvideOperatorRepository(manager *database.Manager) repositories.OperatorRepository {
+	return repositories.NewDBOperatorRepository(manager.GetDB())
+}
+
+// ProvideDroneMissionRepository 提供 DroneMissionRepository
+func ProvideDroneMissionRepository(manager *database.Manager) repositories.DroneMissionRepository {
+	return repositories.NewDBDroneMissionRepository(manager.GetDB())
+}
+
+// ProvideDroneRepository 提供 DroneRepository
+func ProvideDroneRepository(manager *database.Manager) repositories.DroneRepository {
+	return repositories.NewDBDroneRepository(manager.GetDB())
+}
+
+// ProvideNoFlyZoneRepository 提供 NoFlyZoneRepository
+func ProvideNoFlyZoneRepository(manager *database.Manager) repositories.NoFlyZoneRepository {
+	return repositories.NewDBNoFlyZoneRepository(manager.GetDB())
+}
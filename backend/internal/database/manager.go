@@ -6,6 +6,7 @@ import (
 	"backend/pkg/utils/logger"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 )
 
@@ -53,6 +54,15 @@ func (m *Manager) GetDB() *gorm.DB {
 	return m.db.GetDB()
 }
 
+// GetMongoDatabase 获取MongoDB数据库实例
+// 非MongoDB数据库时返回nil
+func (m *Manager) GetMongoDatabase() *mongo.Database {
+	if mg, ok := m.db.(*MgdbDatabase); ok {
+		return mg.GetDatabase()
+	}
+	return nil
+}
+
 // Migrate 执行数据库迁移
 // 自动创建或更新所有模型对应的表结构
 func (m *Manager) Migrate() error {
@@ -77,10 +87,15 @@ func (m *Manager) Migrate() error {
 		&models.FlightPosition{},
 		&models.FlightRoute{},
 		&models.FlightHistory{},
+		&models.FlightStatusLog{},
+		&models.Alert{},
 		&models.DroneMission{},
+		&models.DroneMissionLog{},
 		&models.DronePosition{},
 		&models.DroneFlightLog{},
 		&models.DroneIncident{},
+		&models.MaintenanceRule{},
+		&models.MaintenanceWorkOrder{},
 	}
 
 	// 执行迁移
@@ -112,3 +127,8 @@ func (m *Manager) GetDatabaseType() DatabaseType {
 func (m *Manager) IsMySQL() bool {
 	return m.GetDatabaseType() == MySQL
 }
+
+// IsMongo 判断是否为MongoDB数据库
+func (m *Manager) IsMongo() bool {
+	return m.GetDatabaseType() == Mgdb
+}
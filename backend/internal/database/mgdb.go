@@ -169,6 +169,24 @@ func (d *MgdbDatabase) Migrate(models ...any) error {
 		if err != nil {
 			log.Printf("创建tasks索引失败: %v", err)
 		}
+
+		// 为airports集合创建代码唯一索引和经纬度索引
+		airportsCollection := d.db.Collection("airports")
+		_, err = airportsCollection.Indexes().CreateMany(
+			context.Background(),
+			[]mongo.IndexModel{
+				{
+					Keys:    bson.M{"code": 1},
+					Options: options.Index().SetUnique(true),
+				},
+				{
+					Keys: bson.D{{Key: "latitude", Value: 1}, {Key: "longitude", Value: 1}},
+				},
+			},
+		)
+		if err != nil {
+			log.Printf("创建airports索引失败: %v", err)
+		}
 	}
 
 	log.Println("MongoDB数据库迁移完成")
@@ -0,0 +1,146 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAircraftRequest 创建飞机请求
+// 新飞机状态固定为 active，状态变更通过专用接口完成
+type CreateAircraftRequest struct {
+	Registration string     `json:"registration" binding:"required,max=20"`
+	ICAOHex      string     `json:"icao_hex" binding:"omitempty,len=6,hexadecimal"`
+	AirlineID    *uuid.UUID `json:"airline_id"`
+	Model        string     `json:"model" binding:"max=100"`
+	Manufacturer string     `json:"manufacturer" binding:"max=100"`
+	SerialNumber string     `json:"serial_number" binding:"max=100"`
+	YearBuilt    int        `json:"year_built" binding:"omitempty,min=1900,max=2100"`
+	Capacity     int        `json:"capacity" binding:"omitempty,min=0"`
+	MaxRange     int        `json:"max_range" binding:"omitempty,min=0"`
+	CruiseSpeed  int        `json:"cruise_speed" binding:"omitempty,min=0"`
+	EngineType   string     `json:"engine_type" binding:"max=100"`
+	EngineCount  int        `json:"engine_count" binding:"omitempty,min=0,max=8"`
+	Description  string     `json:"description" binding:"max=1000"`
+}
+
+// UpdateAircraftRequest 更新飞机请求（字段均可选，不含状态）
+type UpdateAircraftRequest struct {
+	ICAOHex      *string    `json:"icao_hex" binding:"omitempty,len=6,hexadecimal"`
+	AirlineID    *uuid.UUID `json:"airline_id"`
+	Model        *string    `json:"model" binding:"omitempty,max=100"`
+	Manufacturer *string    `json:"manufacturer" binding:"omitempty,max=100"`
+	SerialNumber *string    `json:"serial_number" binding:"omitempty,max=100"`
+	YearBuilt    *int       `json:"year_built" binding:"omitempty,min=1900,max=2100"`
+	Capacity     *int       `json:"capacity" binding:"omitempty,min=0"`
+	MaxRange     *int       `json:"max_range" binding:"omitempty,min=0"`
+	CruiseSpeed  *int       `json:"cruise_speed" binding:"omitempty,min=0"`
+	EngineType   *string    `json:"engine_type" binding:"omitempty,max=100"`
+	EngineCount  *int       `json:"engine_count" binding:"omitempty,min=0,max=8"`
+	Description  *string    `json:"description" binding:"omitempty,max=1000"`
+}
+
+// AircraftStatusRequest 飞机状态变更请求
+type AircraftStatusRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// AircraftQuery 飞机列表查询参数
+type AircraftQuery struct {
+	PageQuery
+	AirlineID string `form:"airline_id" binding:"omitempty,uuid"`
+	Status    string `form:"status"`
+	Model     string `form:"model"`
+	Q         string `form:"q"`
+}
+
+// AircraftResponse 飞机响应
+type AircraftResponse struct {
+	ID           uuid.UUID        `json:"id"`
+	Registration string           `json:"registration"`
+	ICAOHex      string           `json:"icao_hex"`
+	AirlineID    *uuid.UUID       `json:"airline_id"`
+	Model        string           `json:"model"`
+	Manufacturer string           `json:"manufacturer"`
+	SerialNumber string           `json:"serial_number"`
+	YearBuilt    int              `json:"year_built"`
+	Age          *int             `json:"age,omitempty"` // 机龄（年）
+	Capacity     int              `json:"capacity"`
+	MaxRange     int              `json:"max_range"`
+	CruiseSpeed  int              `json:"cruise_speed"`
+	EngineType   string           `json:"engine_type"`
+	EngineCount  int              `json:"engine_count"`
+	Status       string           `json:"status"`
+	Description  string           `json:"description"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	Airline      *AirlineResponse `json:"airline,omitempty"`
+}
+
+// FleetModelSummary 机队中单一机型的汇总
+type FleetModelSummary struct {
+	Model         string  `json:"model"`
+	Manufacturer  string  `json:"manufacturer"`
+	Count         int     `json:"count"`
+	TotalCapacity int     `json:"total_capacity"`
+	AverageAge    float64 `json:"average_age"`
+}
+
+// FleetSummary 机队汇总统计
+type FleetSummary struct {
+	TotalAircraft int                 `json:"total_aircraft"`
+	TotalCapacity int                 `json:"total_capacity"`
+	AverageAge    float64             `json:"average_age"`
+	OldestYear    int                 `json:"oldest_year,omitempty"`
+	NewestYear    int                 `json:"newest_year,omitempty"`
+	ByStatus      map[string]int      `json:"by_status"`
+	ByModel       []FleetModelSummary `json:"by_model"`
+}
+
+// FleetResponse 航空公司机队响应
+type FleetResponse struct {
+	Airline  AirlineResponse    `json:"airline"`
+	Summary  FleetSummary       `json:"summary"`
+	Aircraft []AircraftResponse `json:"aircraft"`
+}
+
+// ToAircraftResponse 转换为飞机响应
+func ToAircraftResponse(aircraft *models.Aircraft) *AircraftResponse {
+	resp := &AircraftResponse{
+		ID:           aircraft.ID,
+		Registration: aircraft.Registration,
+		ICAOHex:      aircraft.ICAOHex,
+		AirlineID:    aircraft.AirlineID,
+		Model:        aircraft.Model,
+		Manufacturer: aircraft.Manufacturer,
+		SerialNumber: aircraft.SerialNumber,
+		YearBuilt:    aircraft.YearBuilt,
+		Capacity:     aircraft.Capacity,
+		MaxRange:     aircraft.MaxRange,
+		CruiseSpeed:  aircraft.CruiseSpeed,
+		EngineType:   aircraft.EngineType,
+		EngineCount:  aircraft.EngineCount,
+		Status:       aircraft.Status,
+		Description:  aircraft.Description,
+		CreatedAt:    aircraft.CreatedAt,
+		UpdatedAt:    aircraft.UpdatedAt,
+	}
+	if aircraft.YearBuilt > 0 {
+		age := time.Now().Year() - aircraft.YearBuilt
+		resp.Age = &age
+	}
+	if aircraft.Airline != nil {
+		resp.Airline = ToAirlineResponse(aircraft.Airline)
+	}
+	return resp
+}
+
+// ToAircraftResponseList 转换为飞机响应列表
+func ToAircraftResponseList(aircraft []models.Aircraft) []AircraftResponse {
+	list := make([]AircraftResponse, len(aircraft))
+	for i := range aircraft {
+		list[i] = *ToAircraftResponse(&aircraft[i])
+	}
+	return list
+}
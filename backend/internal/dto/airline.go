@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAirlineRequest 创建航空公司请求
+type CreateAirlineRequest struct {
+	Code        string `json:"code" binding:"required,len=2,alphanum"`
+	Name        string `json:"name" binding:"required,max=200"`
+	NameEn      string `json:"name_en" binding:"max=200"`
+	Country     string `json:"country" binding:"max=100"`
+	Logo        string `json:"logo" binding:"omitempty,url"`
+	Website     string `json:"website" binding:"omitempty,url"`
+	Callsign    string `json:"callsign" binding:"max=50"`
+	Type        string `json:"type" binding:"omitempty,oneof=passenger cargo charter"`
+	Status      string `json:"status" binding:"omitempty,oneof=active inactive"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+// UpdateAirlineRequest 更新航空公司请求（字段均可选）
+type UpdateAirlineRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=200"`
+	NameEn      *string `json:"name_en" binding:"omitempty,max=200"`
+	Country     *string `json:"country" binding:"omitempty,max=100"`
+	Logo        *string `json:"logo" binding:"omitempty,url"`
+	Website     *string `json:"website" binding:"omitempty,url"`
+	Callsign    *string `json:"callsign" binding:"omitempty,max=50"`
+	Type        *string `json:"type" binding:"omitempty,oneof=passenger cargo charter"`
+	Status      *string `json:"status" binding:"omitempty,oneof=active inactive"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+// AirlineQuery 航空公司列表查询参数
+type AirlineQuery struct {
+	PageQuery
+	Country string `form:"country"`
+	Type    string `form:"type"`
+	Status  string `form:"status"`
+	Q       string `form:"q"`
+}
+
+// AirlineResponse 航空公司响应
+type AirlineResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	NameEn      string    `json:"name_en"`
+	Country     string    `json:"country"`
+	Logo        string    `json:"logo"`
+	Website     string    `json:"website"`
+	Callsign    string    `json:"callsign"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToAirlineResponse 转换为航空公司响应
+func ToAirlineResponse(airline *models.Airline) *AirlineResponse {
+	return &AirlineResponse{
+		ID:          airline.ID,
+		Code:        airline.Code,
+		Name:        airline.Name,
+		NameEn:      airline.NameEn,
+		Country:     airline.Country,
+		Logo:        airline.Logo,
+		Website:     airline.Website,
+		Callsign:    airline.Callsign,
+		Type:        airline.Type,
+		Status:      airline.Status,
+		Description: airline.Description,
+		CreatedAt:   airline.CreatedAt,
+		UpdatedAt:   airline.UpdatedAt,
+	}
+}
+
+// ToAirlineResponseList 转换为航空公司响应列表
+func ToAirlineResponseList(airlines []models.Airline) []AirlineResponse {
+	list := make([]AirlineResponse, len(airlines))
+	for i := range airlines {
+		list[i] = *ToAirlineResponse(&airlines[i])
+	}
+	return list
+}
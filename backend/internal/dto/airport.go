@@ -0,0 +1,126 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateAirportRequest 创建机场请求
+type CreateAirportRequest struct {
+	Code        string  `json:"code" binding:"required,len=3,alpha"`
+	Name        string  `json:"name" binding:"required,max=200"`
+	NameEn      string  `json:"name_en" binding:"max=200"`
+	City        string  `json:"city" binding:"max=100"`
+	Country     string  `json:"country" binding:"max=100"`
+	Latitude    float64 `json:"latitude" binding:"gte=-90,lte=90"`
+	Longitude   float64 `json:"longitude" binding:"gte=-180,lte=180"`
+	Altitude    float64 `json:"altitude"`
+	Timezone    string  `json:"timezone" binding:"max=64"`
+	Type        string  `json:"type" binding:"omitempty,oneof=civil military mixed"`
+	Status      string  `json:"status" binding:"omitempty,oneof=active inactive closed"`
+	Description string  `json:"description" binding:"max=1000"`
+}
+
+// UpdateAirportRequest 更新机场请求（字段均可选）
+type UpdateAirportRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,min=1,max=200"`
+	NameEn      *string  `json:"name_en" binding:"omitempty,max=200"`
+	City        *string  `json:"city" binding:"omitempty,max=100"`
+	Country     *string  `json:"country" binding:"omitempty,max=100"`
+	Latitude    *float64 `json:"latitude" binding:"omitempty,gte=-90,lte=90"`
+	Longitude   *float64 `json:"longitude" binding:"omitempty,gte=-180,lte=180"`
+	Altitude    *float64 `json:"altitude"`
+	Timezone    *string  `json:"timezone" binding:"omitempty,max=64"`
+	Type        *string  `json:"type" binding:"omitempty,oneof=civil military mixed"`
+	Status      *string  `json:"status" binding:"omitempty,oneof=active inactive closed"`
+	Description *string  `json:"description" binding:"omitempty,max=1000"`
+}
+
+// AirportQuery 机场列表查询参数
+type AirportQuery struct {
+	PageQuery
+	Country string `form:"country"`
+	City    string `form:"city"`
+	Type    string `form:"type"`
+	Status  string `form:"status"`
+	Q       string `form:"q"` // 搜索关键字，匹配代码/中文名/英文名
+}
+
+// NearbyAirportQuery 附近机场查询参数
+type NearbyAirportQuery struct {
+	Lat      *float64 `form:"lat" binding:"required,gte=-90,lte=90"`
+	Lng      *float64 `form:"lng" binding:"required,gte=-180,lte=180"`
+	RadiusKm float64  `form:"radius_km" binding:"omitempty,gt=0,lte=1000"`
+	Type     string   `form:"type"`
+	Status   string   `form:"status"`
+	Limit    int      `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// BBoxAirportQuery 地图视口范围查询参数
+// minLng > maxLng 表示视口跨越 180° 经线
+type BBoxAirportQuery struct {
+	MinLat *float64 `form:"minLat" binding:"required,gte=-90,lte=90"`
+	MinLng *float64 `form:"minLng" binding:"required,gte=-180,lte=180"`
+	MaxLat *float64 `form:"maxLat" binding:"required,gte=-90,lte=90"`
+	MaxLng *float64 `form:"maxLng" binding:"required,gte=-180,lte=180"`
+	Type   string   `form:"type"`
+	Status string   `form:"status"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1,max=2000"`
+}
+
+// NearbyAirportResponse 附近机场响应，附带与查询点的距离
+type NearbyAirportResponse struct {
+	AirportResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// AirportResponse 机场响应
+type AirportResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	NameEn      string    `json:"name_en"`
+	City        string    `json:"city"`
+	Country     string    `json:"country"`
+	Latitude    float64   `json:"latitude"`
+	Longitude   float64   `json:"longitude"`
+	Altitude    float64   `json:"altitude"`
+	Timezone    string    `json:"timezone"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToAirportResponse 转换为机场响应
+func ToAirportResponse(airport *models.Airport) *AirportResponse {
+	return &AirportResponse{
+		ID:          airport.ID,
+		Code:        airport.Code,
+		Name:        airport.Name,
+		NameEn:      airport.NameEn,
+		City:        airport.City,
+		Country:     airport.Country,
+		Latitude:    airport.Latitude,
+		Longitude:   airport.Longitude,
+		Altitude:    airport.Altitude,
+		Timezone:    airport.Timezone,
+		Type:        airport.Type,
+		Status:      airport.Status,
+		Description: airport.Description,
+		CreatedAt:   airport.CreatedAt,
+		UpdatedAt:   airport.UpdatedAt,
+	}
+}
+
+// ToAirportResponseList 转换为机场响应列表
+func ToAirportResponseList(airports []models.Airport) []AirportResponse {
+	list := make([]AirportResponse, len(airports))
+	for i := range airports {
+		list[i] = *ToAirportResponse(&airports[i])
+	}
+	return list
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 空域检查结论
+const (
+	AirspaceVerdictGo   = "go"
+	AirspaceVerdictNoGo = "no_go"
+)
+
+// AirspacePoint 空域检查的位置，高度为相对地面高度（米），未指定时按从地面到无上限判断
+type AirspacePoint struct {
+	Lat      float64  `json:"lat" binding:"min=-90,max=90"`
+	Lng      float64  `json:"lng" binding:"min=-180,max=180"`
+	Altitude *float64 `json:"altitude" binding:"omitempty,min=0"`
+}
+
+// AirspaceCheckRequest 空域检查请求，point 与 path 二选一
+// path 为按顺序连接的航线，每个点的高度构成高度剖面
+// time 缺省为当前时间；设置 end_time 时检查 [time, end_time] 内任一时刻生效的禁飞区
+type AirspaceCheckRequest struct {
+	Point   *AirspacePoint  `json:"point"`
+	Path    []AirspacePoint `json:"path" binding:"omitempty,min=2,max=1000,dive"`
+	Time    *time.Time      `json:"time"`
+	EndTime *time.Time      `json:"end_time"`
+}
+
+// AirspaceZone 与检查位置相关的禁飞区
+type AirspaceZone struct {
+	ZoneID           uuid.UUID  `json:"zone_id"`
+	ZoneName         string     `json:"zone_name"`
+	ZoneType         string     `json:"zone_type"`
+	Authority        string     `json:"authority"`
+	Reason           string     `json:"reason"`
+	MinAltitude      float64    `json:"min_altitude"`
+	MaxAltitude      float64    `json:"max_altitude"`
+	StartTime        *time.Time `json:"start_time"`
+	EndTime          *time.Time `json:"end_time"`
+	Segment          *int       `json:"segment,omitempty"`          // 首个进入禁飞区的航段起点下标，仅航线检查返回
+	BoundaryDistance float64    `json:"boundary_distance"`          // 到禁飞区水平边界的最短距离（米）
+	InvalidGeometry  bool       `json:"invalid_geometry,omitempty"` // 禁飞区几何数据无效，无法判定
+}
+
+// AirspaceCheckResponse 空域检查结果
+// zones 为限制该位置或航线的禁飞区，非空时结论为 no_go
+// nearest 为边界距离最近的有效禁飞区（不论高度），没有有效禁飞区时为空
+// warnings 为几何数据无效、无法判定的生效禁飞区，不影响结论，需人工核实
+type AirspaceCheckResponse struct {
+	Verdict  string         `json:"verdict"` // go | no_go
+	From     time.Time      `json:"from"`
+	To       time.Time      `json:"to"`
+	Zones    []AirspaceZone `json:"zones"`
+	Nearest  *AirspaceZone  `json:"nearest,omitempty"`
+	Warnings []AirspaceZone `json:"warnings,omitempty"`
+}
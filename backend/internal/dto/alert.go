@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertQuery 告警列表查询参数
+type AlertQuery struct {
+	PageQuery
+	Type       string     `form:"type"`     // 多个类型以逗号分隔
+	Status     string     `form:"status"`   // 多个状态以逗号分隔
+	Severity   string     `form:"severity"` // 多个级别以逗号分隔
+	EntityType string     `form:"entity_type" binding:"omitempty,oneof=flight drone"`
+	EntityID   *uuid.UUID `form:"entity_id"`
+	From       *time.Time `form:"from"`
+	To         *time.Time `form:"to"`
+}
+
+// AlertResponse 告警响应
+type AlertResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Type           string     `json:"type"`
+	Severity       string     `json:"severity"`
+	Status         string     `json:"status"`
+	EntityType     string     `json:"entity_type"`
+	EntityID       uuid.UUID  `json:"entity_id"`
+	RelatedType    *string    `json:"related_type,omitempty"`
+	RelatedID      *uuid.UUID `json:"related_id,omitempty"`
+	Message        string     `json:"message"`
+	Value          float64    `json:"value"`
+	Threshold      float64    `json:"threshold"`
+	Latitude       *float64   `json:"latitude"`
+	Longitude      *float64   `json:"longitude"`
+	TriggeredAt    time.Time  `json:"triggered_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ToAlertResponse 转换为告警响应
+func ToAlertResponse(alert *models.Alert) *AlertResponse {
+	return &AlertResponse{
+		ID:             alert.ID,
+		Type:           alert.Type,
+		Severity:       alert.Severity,
+		Status:         alert.Status,
+		EntityType:     alert.EntityType,
+		EntityID:       alert.EntityID,
+		RelatedType:    alert.RelatedType,
+		RelatedID:      alert.RelatedID,
+		Message:        alert.Message,
+		Value:          alert.Value,
+		Threshold:      alert.Threshold,
+		Latitude:       alert.Latitude,
+		Longitude:      alert.Longitude,
+		TriggeredAt:    alert.TriggeredAt,
+		LastSeenAt:     alert.LastSeenAt,
+		AcknowledgedBy: alert.AcknowledgedBy,
+		AcknowledgedAt: alert.AcknowledgedAt,
+		ResolvedAt:     alert.ResolvedAt,
+		CreatedAt:      alert.CreatedAt,
+	}
+}
+
+// ToAlertResponseList 转换为告警响应列表
+func ToAlertResponseList(alerts []models.Alert) []AlertResponse {
+	list := make([]AlertResponse, len(alerts))
+	for i := range alerts {
+		list[i] = *ToAlertResponse(&alerts[i])
+	}
+	return list
+}
@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+// 准点率统计分组维度
+const (
+	OTPGroupAirline = "airline"
+	OTPGroupRoute   = "route"
+	OTPGroupAirport = "airport"
+)
+
+// 准点率统计时间粒度
+const (
+	OTPIntervalDay   = "day"
+	OTPIntervalWeek  = "week"
+	OTPIntervalMonth = "month"
+)
+
+// OTPQuery 准点率统计查询参数
+// 未指定日期范围时统计最近 30 天，范围最长 366 天
+type OTPQuery struct {
+	From      *time.Time `form:"from" time_format:"2006-01-02"` // 航班日期下限（含）
+	To        *time.Time `form:"to" time_format:"2006-01-02"`   // 航班日期上限（含）
+	GroupBy   string     `form:"group_by" binding:"omitempty,oneof=airline route airport"`
+	Interval  string     `form:"interval" binding:"omitempty,oneof=day week month"` // 为空时不按时间分桶
+	Airline   string     `form:"airline"`                                           // 航空公司IATA代码
+	Airport   string     `form:"airport"`                                           // 出发或到达机场代码
+	Departure string     `form:"departure"`
+	Arrival   string     `form:"arrival"`
+	Threshold *int       `form:"threshold" binding:"omitempty,min=0,max=180"` // 准点判定阈值（分钟），默认 15
+}
+
+// OTPStats 准点率统计指标
+// 准点率按已到达航班计算，取消率和备降率按全部航班计算，比率均为百分比
+type OTPStats struct {
+	Total            int     `json:"total"`
+	Arrived          int     `json:"arrived"`
+	OnTime           int     `json:"on_time"`
+	Cancelled        int     `json:"cancelled"`
+	Diverted         int     `json:"diverted"`
+	OnTimeRate       float64 `json:"on_time_rate"`
+	CancellationRate float64 `json:"cancellation_rate"`
+	DiversionRate    float64 `json:"diversion_rate"`
+	AvgDelayMinutes  float64 `json:"avg_delay_minutes"` // 到达航班的平均延误，提前到达计为 0
+	P50DelayMinutes  float64 `json:"p50_delay_minutes"`
+	P90DelayMinutes  float64 `json:"p90_delay_minutes"`
+	P95DelayMinutes  float64 `json:"p95_delay_minutes"`
+}
+
+// OTPBucket 时间分桶统计
+type OTPBucket struct {
+	Start time.Time `json:"start"`
+	OTPStats
+}
+
+// OTPGroup 分组统计
+type OTPGroup struct {
+	Key     string      `json:"key"` // 航空公司代码、航线（PEK-SHA）或机场代码
+	Buckets []OTPBucket `json:"buckets,omitempty"`
+	OTPStats
+}
+
+// OTPResponse 准点率统计响应
+type OTPResponse struct {
+	From             time.Time  `json:"from"`
+	To               time.Time  `json:"to"`
+	GroupBy          string     `json:"group_by"`
+	Interval         string     `json:"interval,omitempty"`
+	ThresholdMinutes int        `json:"threshold_minutes"`
+	Overall          OTPStats   `json:"overall"`
+	Groups           []OTPGroup `json:"groups"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+const (
+	// DefaultPageSize 默认每页条数
+	DefaultPageSize = 20
+	// MaxPageSize 每页最大条数
+	MaxPageSize = 100
+)
+
+// PageQuery 分页查询参数
+type PageQuery struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// Normalize 填充默认值并限制每页条数
+func (q *PageQuery) Normalize() {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPageSize
+	}
+	if q.PageSize > MaxPageSize {
+		q.PageSize = MaxPageSize
+	}
+}
+
+// Offset 计算查询偏移量
+func (q *PageQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// PageResponse 分页响应
+type PageResponse[T any] struct {
+	Items    []T   `json:"items"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+}
+
+// NewPageResponse 创建分页响应
+func NewPageResponse[T any](items []T, total int64, q PageQuery) *PageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &PageResponse[T]{
+		Items:    items,
+		Total:    total,
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	}
+}
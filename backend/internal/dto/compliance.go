@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+// 运营商许可证状态
+const (
+	LicenseStatusValid    = "valid"
+	LicenseStatusExpiring = "expiring" // 30 天内到期
+	LicenseStatusExpired  = "expired"
+	LicenseStatusMissing  = "missing" // 未登记许可证号
+)
+
+// ComplianceQuery 运营商合规统计查询参数
+// 未指定日期范围时统计最近 30 天，范围最长 366 天
+type ComplianceQuery struct {
+	From *time.Time `form:"from" time_format:"2006-01-02"` // 统计日期下限（含）
+	To   *time.Time `form:"to" time_format:"2006-01-02"`   // 统计日期上限（含）
+}
+
+// ComplianceRankingQuery 运营商合规排名查询参数
+type ComplianceRankingQuery struct {
+	PageQuery
+	ComplianceQuery
+	Type   string `form:"type"`
+	Status string `form:"status"`
+}
+
+// MissionCompliance 统计期内的飞行审批情况
+type MissionCompliance struct {
+	Flown      int64 `json:"flown"`      // 实际起飞的任务
+	Approved   int64 `json:"approved"`   // 经审批通过
+	Waived     int64 `json:"waived"`     // 豁免审批即起飞
+	Unapproved int64 `json:"unapproved"` // 需要审批但未获批准即起飞
+	Unplanned  int64 `json:"unplanned"`  // 未关联任务的飞行
+}
+
+// IncidentCompliance 统计期内已确认事件按严重程度的分布，不含草稿和已驳回的事件
+type IncidentCompliance struct {
+	Total    int64 `json:"total"`
+	Minor    int64 `json:"minor"`
+	Moderate int64 `json:"moderate"`
+	Serious  int64 `json:"serious"`
+	Critical int64 `json:"critical"`
+}
+
+// FleetCompliance 当前机队状态
+type FleetCompliance struct {
+	Total              int64 `json:"total"`
+	InMaintenance      int64 `json:"in_maintenance"`
+	OverdueMaintenance int64 `json:"overdue_maintenance"` // 按维护规则已超期的无人机
+}
+
+// LicenseCompliance 许可证状态
+type LicenseCompliance struct {
+	Number        string     `json:"number"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	Status        string     `json:"status"`                   // valid/expiring/expired/missing
+	DaysRemaining *int       `json:"days_remaining,omitempty"` // 距到期天数，已过期为负数
+}
+
+// OperatorCompliance 运营商合规概况
+// 评分满分 100，按未经审批起飞、无任务飞行、禁飞区违规、事件、维护超期和许可证问题扣分，findings 列出各扣分项
+type OperatorCompliance struct {
+	Operator       *OperatorBrief     `json:"operator"`
+	OperatorStatus string             `json:"operator_status"`
+	From           time.Time          `json:"from"`
+	To             time.Time          `json:"to"`
+	Rank           int                `json:"rank,omitempty"` // 排名列表中的名次，1 为评分最低
+	Score          int                `json:"score"`
+	Missions       MissionCompliance  `json:"missions"`
+	ZoneViolations int64              `json:"zone_violations"` // 禁飞区闯入告警次数
+	Incidents      IncidentCompliance `json:"incidents"`
+	Fleet          FleetCompliance    `json:"fleet"`
+	License        LicenseCompliance  `json:"license"`
+	Findings       []string           `json:"findings"`
+}
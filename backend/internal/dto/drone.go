@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateDroneRequest 创建无人机请求
+// 新无人机状态固定为 idle；运营商用户创建时 operator_id 固定为其所属运营商
+type CreateDroneRequest struct {
+	SerialNumber string     `json:"serial_number" binding:"required,max=50"`
+	Name         string     `json:"name" binding:"required,max=100"`
+	OperatorID   *uuid.UUID `json:"operator_id"`
+	Model        string     `json:"model" binding:"max=100"`
+	Manufacturer string     `json:"manufacturer" binding:"max=100"`
+	MaxAltitude  float64    `json:"max_altitude" binding:"omitempty,min=0"`
+	MaxSpeed     float64    `json:"max_speed" binding:"omitempty,min=0"`
+	MaxRange     float64    `json:"max_range" binding:"omitempty,min=0"`
+	BatteryLife  int        `json:"battery_life" binding:"omitempty,min=0"`
+	Weight       float64    `json:"weight" binding:"omitempty,min=0"`
+	CameraModel  string     `json:"camera_model" binding:"max=100"`
+	Description  string     `json:"description" binding:"max=1000"`
+}
+
+// UpdateDroneRequest 更新无人机请求（字段均可选，不含状态）
+type UpdateDroneRequest struct {
+	Name         *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	OperatorID   *uuid.UUID `json:"operator_id"` // 仅管理员可以变更
+	Model        *string    `json:"model" binding:"omitempty,max=100"`
+	Manufacturer *string    `json:"manufacturer" binding:"omitempty,max=100"`
+	MaxAltitude  *float64   `json:"max_altitude" binding:"omitempty,min=0"`
+	MaxSpeed     *float64   `json:"max_speed" binding:"omitempty,min=0"`
+	MaxRange     *float64   `json:"max_range" binding:"omitempty,min=0"`
+	BatteryLife  *int       `json:"battery_life" binding:"omitempty,min=0"`
+	Weight       *float64   `json:"weight" binding:"omitempty,min=0"`
+	CameraModel  *string    `json:"camera_model" binding:"omitempty,max=100"`
+	Description  *string    `json:"description" binding:"omitempty,max=1000"`
+}
+
+// DroneStatusRequest 无人机状态变更请求
+type DroneStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=idle flying maintenance offline"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// DroneQuery 无人机列表查询参数
+type DroneQuery struct {
+	PageQuery
+	OperatorID *uuid.UUID `form:"operator_id"` // 运营商用户忽略该参数
+	Status     string     `form:"status"`
+	Model      string     `form:"model"`
+	Q          string     `form:"q"`
+}
+
+// DroneResponse 无人机响应
+type DroneResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	SerialNumber   string         `json:"serial_number"`
+	Name           string         `json:"name"`
+	OperatorID     *uuid.UUID     `json:"operator_id"`
+	Model          string         `json:"model"`
+	Manufacturer   string         `json:"manufacturer"`
+	MaxAltitude    float64        `json:"max_altitude"`
+	MaxSpeed       float64        `json:"max_speed"`
+	MaxRange       float64        `json:"max_range"`
+	BatteryLife    int            `json:"battery_life"`
+	Weight         float64        `json:"weight"`
+	CameraModel    string         `json:"camera_model"`
+	Status         string         `json:"status"`
+	LastLatitude   *float64       `json:"last_latitude"`
+	LastLongitude  *float64       `json:"last_longitude"`
+	LastAltitude   *float64       `json:"last_altitude"`
+	LastUpdateTime *time.Time     `json:"last_update_time"`
+	Description    string         `json:"description"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Operator       *OperatorBrief `json:"operator,omitempty"`
+}
+
+// ToDroneResponse 转换为无人机响应
+func ToDroneResponse(drone *models.Drone) *DroneResponse {
+	return &DroneResponse{
+		ID:             drone.ID,
+		SerialNumber:   drone.SerialNumber,
+		Name:           drone.Name,
+		OperatorID:     drone.OperatorID,
+		Model:          drone.Model,
+		Manufacturer:   drone.Manufacturer,
+		MaxAltitude:    drone.MaxAltitude,
+		MaxSpeed:       drone.MaxSpeed,
+		MaxRange:       drone.MaxRange,
+		BatteryLife:    drone.BatteryLife,
+		Weight:         drone.Weight,
+		CameraModel:    drone.CameraModel,
+		Status:         drone.Status,
+		LastLatitude:   drone.LastLatitude,
+		LastLongitude:  drone.LastLongitude,
+		LastAltitude:   drone.LastAltitude,
+		LastUpdateTime: drone.LastUpdateTime,
+		Description:    drone.Description,
+		CreatedAt:      drone.CreatedAt,
+		UpdatedAt:      drone.UpdatedAt,
+		Operator:       ToOperatorBrief(drone.Operator),
+	}
+}
+
+// ToDroneResponseList 转换为无人机响应列表
+func ToDroneResponseList(drones []models.Drone) []DroneResponse {
+	list := make([]DroneResponse, len(drones))
+	for i := range drones {
+		list[i] = *ToDroneResponse(&drones[i])
+	}
+	return list
+}
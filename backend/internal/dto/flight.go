@@ -0,0 +1,239 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateFlightRequest 创建航班请求
+// 新航班状态固定为 scheduled
+type CreateFlightRequest struct {
+	FlightNumber  string     `json:"flight_number" binding:"required,max=10"`
+	AirlineID     *uuid.UUID `json:"airline_id"`
+	AircraftID    *uuid.UUID `json:"aircraft_id"`
+	DepartureID   *uuid.UUID `json:"departure_id" binding:"required"`
+	ArrivalID     *uuid.UUID `json:"arrival_id" binding:"required"`
+	DepartureTime time.Time  `json:"departure_time" binding:"required"`
+	ArrivalTime   time.Time  `json:"arrival_time" binding:"required"`
+	Gate          string     `json:"gate" binding:"max=10"`
+	Terminal      string     `json:"terminal" binding:"max=10"`
+}
+
+// UpdateFlightRequest 更新航班请求（字段均可选，不含状态）
+type UpdateFlightRequest struct {
+	FlightNumber  *string    `json:"flight_number" binding:"omitempty,min=1,max=10"`
+	AirlineID     *uuid.UUID `json:"airline_id"`
+	AircraftID    *uuid.UUID `json:"aircraft_id"`
+	DepartureID   *uuid.UUID `json:"departure_id"`
+	ArrivalID     *uuid.UUID `json:"arrival_id"`
+	DepartureTime *time.Time `json:"departure_time"`
+	ArrivalTime   *time.Time `json:"arrival_time"`
+	Gate          *string    `json:"gate" binding:"omitempty,max=10"`
+	Terminal      *string    `json:"terminal" binding:"omitempty,max=10"`
+}
+
+// FlightQuery 航班列表查询参数
+type FlightQuery struct {
+	PageQuery
+	FlightNumber string     `form:"flight_number"`
+	Airline      string     `form:"airline"` // 航空公司ID或IATA代码
+	Status       string     `form:"status"`  // 多个状态以逗号分隔
+	Date         *time.Time `form:"date" time_format:"2006-01-02"`
+	From         *time.Time `form:"from"`
+	To           *time.Time `form:"to"`
+}
+
+// FlightBoardQuery 机场航班显示屏查询参数
+// 未指定时间窗口时默认为当前时间前 2 小时至后 12 小时
+type FlightBoardQuery struct {
+	From   *time.Time `form:"from"`
+	To     *time.Time `form:"to"`
+	Status string     `form:"status"`
+	Limit  int        `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+// AirportBrief 航班中的机场摘要
+type AirportBrief struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+	City string    `json:"city"`
+}
+
+// AirlineBrief 航班中的航空公司摘要
+type AirlineBrief struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+	Logo string    `json:"logo"`
+}
+
+// AircraftBrief 航班中的飞机摘要
+type AircraftBrief struct {
+	ID           uuid.UUID `json:"id"`
+	Registration string    `json:"registration"`
+	Model        string    `json:"model"`
+}
+
+// FlightResponse 航班响应
+type FlightResponse struct {
+	ID                  uuid.UUID      `json:"id"`
+	FlightNumber        string         `json:"flight_number"`
+	Status              string         `json:"status"`
+	DepartureTime       time.Time      `json:"departure_time"`
+	ArrivalTime         time.Time      `json:"arrival_time"`
+	Gate                string         `json:"gate"`
+	Terminal            string         `json:"terminal"`
+	DelayMinutes        int            `json:"delay_minutes"`
+	ActualDepartureTime *time.Time     `json:"actual_departure_time"`
+	ActualArrivalTime   *time.Time     `json:"actual_arrival_time"`
+	Altitude            float64        `json:"altitude"`
+	Speed               float64        `json:"speed"`
+	Latitude            *float64       `json:"latitude"`
+	Longitude           *float64       `json:"longitude"`
+	Airline             *AirlineBrief  `json:"airline,omitempty"`
+	Aircraft            *AircraftBrief `json:"aircraft,omitempty"`
+	Departure           *AirportBrief  `json:"departure,omitempty"`
+	Arrival             *AirportBrief  `json:"arrival,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
+// FlightBoardEntry 航班显示屏条目
+type FlightBoardEntry struct {
+	FlightID      uuid.UUID      `json:"flight_id"`
+	FlightNumber  string         `json:"flight_number"`
+	Airline       *AirlineBrief  `json:"airline,omitempty"`
+	Aircraft      *AircraftBrief `json:"aircraft,omitempty"`
+	Airport       *AirportBrief  `json:"airport,omitempty"` // 出发屏为目的地，到达屏为出发地
+	ScheduledTime time.Time      `json:"scheduled_time"`
+	EstimatedTime time.Time      `json:"estimated_time"` // 计划时间加延误
+	Gate          string         `json:"gate"`
+	Terminal      string         `json:"terminal"`
+	Status        string         `json:"status"`
+	DelayMinutes  int            `json:"delay_minutes"`
+}
+
+// FlightBoardResponse 机场航班显示屏响应
+type FlightBoardResponse struct {
+	Airport   AirportBrief       `json:"airport"`
+	Direction string             `json:"direction"`
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Flights   []FlightBoardEntry `json:"flights"`
+}
+
+// ToAirportBrief 转换为机场摘要
+func ToAirportBrief(airport *models.Airport) *AirportBrief {
+	if airport == nil {
+		return nil
+	}
+	return &AirportBrief{
+		ID:   airport.ID,
+		Code: airport.Code,
+		Name: airport.Name,
+		City: airport.City,
+	}
+}
+
+// ToAirlineBrief 转换为航空公司摘要
+func ToAirlineBrief(airline *models.Airline) *AirlineBrief {
+	if airline == nil {
+		return nil
+	}
+	return &AirlineBrief{
+		ID:   airline.ID,
+		Code: airline.Code,
+		Name: airline.Name,
+		Logo: airline.Logo,
+	}
+}
+
+// ToAircraftBrief 转换为飞机摘要
+func ToAircraftBrief(aircraft *models.Aircraft) *AircraftBrief {
+	if aircraft == nil {
+		return nil
+	}
+	return &AircraftBrief{
+		ID:           aircraft.ID,
+		Registration: aircraft.Registration,
+		Model:        aircraft.Model,
+	}
+}
+
+// ToFlightResponse 转换为航班响应
+func ToFlightResponse(flight *models.Flight) *FlightResponse {
+	return &FlightResponse{
+		ID:                  flight.ID,
+		FlightNumber:        flight.FlightNumber,
+		Status:              flight.Status,
+		DepartureTime:       flight.DepartureTime,
+		ArrivalTime:         flight.ArrivalTime,
+		Gate:                flight.Gate,
+		Terminal:            flight.Terminal,
+		DelayMinutes:        flight.DelayMinutes,
+		ActualDepartureTime: flight.ActualDepartureTime,
+		ActualArrivalTime:   flight.ActualArrivalTime,
+		Altitude:            flight.Altitude,
+		Speed:               flight.Speed,
+		Latitude:            flight.Latitude,
+		Longitude:           flight.Longitude,
+		Airline:             ToAirlineBrief(flight.Airline),
+		Aircraft:            ToAircraftBrief(flight.Aircraft),
+		Departure:           ToAirportBrief(flight.Departure),
+		Arrival:             ToAirportBrief(flight.Arrival),
+		CreatedAt:           flight.CreatedAt,
+		UpdatedAt:           flight.UpdatedAt,
+	}
+}
+
+// ToFlightResponseList 转换为航班响应列表
+func ToFlightResponseList(flights []models.Flight) []FlightResponse {
+	list := make([]FlightResponse, len(flights))
+	for i := range flights {
+		list[i] = *ToFlightResponse(&flights[i])
+	}
+	return list
+}
+
+// FlightStatusRequest 航班状态变更请求
+type FlightStatusRequest struct {
+	Status       string     `json:"status" binding:"required,oneof=scheduled boarding departed arrived delayed cancelled"`
+	DelayMinutes *int       `json:"delay_minutes" binding:"omitempty,min=0,max=10080"` // 仅 delayed 时使用
+	OccurredAt   *time.Time `json:"occurred_at"`                                       // 状态实际发生时间，默认当前时间
+	Reason       string     `json:"reason" binding:"max=500"`
+}
+
+// FlightStatusLogResponse 航班状态变更记录响应
+type FlightStatusLogResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	FromStatus   string     `json:"from_status"`
+	ToStatus     string     `json:"to_status"`
+	DelayMinutes int        `json:"delay_minutes"`
+	Reason       string     `json:"reason"`
+	ActorID      *uuid.UUID `json:"actor_id"`
+	ActorName    string     `json:"actor_name"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ToFlightStatusLogResponseList 转换为航班状态变更记录响应列表
+func ToFlightStatusLogResponseList(logs []models.FlightStatusLog) []FlightStatusLogResponse {
+	list := make([]FlightStatusLogResponse, len(logs))
+	for i, log := range logs {
+		list[i] = FlightStatusLogResponse{
+			ID:           log.ID,
+			FromStatus:   log.FromStatus,
+			ToStatus:     log.ToStatus,
+			DelayMinutes: log.DelayMinutes,
+			Reason:       log.Reason,
+			ActorID:      log.ActorID,
+			ActorName:    log.ActorName,
+			OccurredAt:   log.OccurredAt,
+			CreatedAt:    log.CreatedAt,
+		}
+	}
+	return list
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightLogQuery 飞行日志列表查询参数，时间范围按降落时间过滤
+type FlightLogQuery struct {
+	PageQuery
+	MissionID *uuid.UUID `form:"mission_id"`
+	From      *time.Time `form:"from"`
+	To        *time.Time `form:"to"`
+}
+
+// FlightLogEvent 飞行事件
+type FlightLogEvent struct {
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+	Message   string    `json:"message"`
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	Value     *float64  `json:"value,omitempty"`
+}
+
+// FlightLogResponse 飞行日志响应
+type FlightLogResponse struct {
+	ID                 uuid.UUID       `json:"id"`
+	DroneID            uuid.UUID       `json:"drone_id"`
+	MissionID          *uuid.UUID      `json:"mission_id"`
+	FlightDate         time.Time       `json:"flight_date"`
+	TakeoffTime        time.Time       `json:"takeoff_time"`
+	LandingTime        time.Time       `json:"landing_time"`
+	FlightDuration     *int            `json:"flight_duration"` // 分钟
+	TakeoffLocation    json.RawMessage `json:"takeoff_location" swaggertype:"object"`
+	LandingLocation    json.RawMessage `json:"landing_location" swaggertype:"object"`
+	MaxAltitude        *int            `json:"max_altitude"`
+	MaxSpeed           *int            `json:"max_speed"`
+	AverageSpeed       *int            `json:"average_speed"`
+	TotalDistance      *float64        `json:"total_distance"` // km
+	BatteryConsumed    *int            `json:"battery_consumed"`
+	FlightPath         *geo.Geometry   `json:"flight_path,omitempty" swaggertype:"object"`
+	Events             json.RawMessage `json:"events,omitempty" swaggertype:"array,object"`   // []FlightLogEvent
+	Warnings           json.RawMessage `json:"warnings,omitempty" swaggertype:"array,string"` // 警告说明
+	Errors             json.RawMessage `json:"errors,omitempty" swaggertype:"array,string"`   // 严重问题说明
+	FlightQualityScore *int            `json:"flight_quality_score"`
+	PilotNotes         *string         `json:"pilot_notes"`
+	CreatedAt          time.Time       `json:"created_at"`
+}
+
+// ToFlightLogResponse 转换为飞行日志响应，withPath 为 false 时省略轨迹
+func ToFlightLogResponse(log *models.DroneFlightLog, withPath bool) *FlightLogResponse {
+	resp := &FlightLogResponse{
+		ID:                 log.ID,
+		DroneID:            log.DroneID,
+		MissionID:          log.MissionID,
+		FlightDate:         log.FlightDate,
+		TakeoffTime:        log.TakeoffTime,
+		LandingTime:        log.LandingTime,
+		FlightDuration:     log.FlightDuration,
+		MaxAltitude:        log.MaxAltitude,
+		MaxSpeed:           log.MaxSpeed,
+		AverageSpeed:       log.AverageSpeed,
+		TotalDistance:      log.TotalDistance,
+		BatteryConsumed:    log.BatteryConsumed,
+		FlightQualityScore: log.FlightQualityScore,
+		PilotNotes:         log.PilotNotes,
+		TakeoffLocation:    rawJSON(&log.TakeoffLocation),
+		LandingLocation:    rawJSON(&log.LandingLocation),
+		Events:             rawJSON(log.Events),
+		Warnings:           rawJSON(log.Warnings),
+		Errors:             rawJSON(log.Errors),
+		CreatedAt:          log.CreatedAt,
+	}
+	if withPath {
+		resp.FlightPath = log.FlightPath
+	}
+	return resp
+}
+
+// ToFlightLogResponseList 转换为飞行日志响应列表，列表中不返回轨迹
+func ToFlightLogResponseList(logs []models.DroneFlightLog) []FlightLogResponse {
+	list := make([]FlightLogResponse, len(logs))
+	for i := range logs {
+		list[i] = *ToFlightLogResponse(&logs[i], false)
+	}
+	return list
+}
@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightRouteWaypointRequest 计划航线中的航点
+type FlightRouteWaypointRequest struct {
+	WaypointName  string     `json:"waypoint_name" binding:"max=50"`
+	Latitude      *float64   `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude     *float64   `json:"longitude" binding:"required,min=-180,max=180"`
+	Altitude      *int       `json:"altitude" binding:"omitempty,min=0,max=60000"` // 英尺
+	EstimatedTime *time.Time `json:"estimated_time"`
+}
+
+// ReplaceFlightRouteRequest 整体设置航班计划航线请求，航点顺序即数组顺序
+type ReplaceFlightRouteRequest struct {
+	Waypoints []FlightRouteWaypointRequest `json:"waypoints" binding:"required,min=2,max=500,dive"`
+}
+
+// UpdateFlightWaypointRequest 更新单个航点请求（字段均可选）
+type UpdateFlightWaypointRequest struct {
+	WaypointName  *string    `json:"waypoint_name" binding:"omitempty,max=50"`
+	Latitude      *float64   `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude     *float64   `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Altitude      *int       `json:"altitude" binding:"omitempty,min=0,max=60000"`
+	EstimatedTime *time.Time `json:"estimated_time"`
+	ActualTime    *time.Time `json:"actual_time"`
+}
+
+// FlightWaypointResponse 航点响应
+type FlightWaypointResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Sequence      int        `json:"sequence"`
+	WaypointName  string     `json:"waypoint_name"`
+	Latitude      float64    `json:"latitude"`
+	Longitude     float64    `json:"longitude"`
+	Altitude      *int       `json:"altitude"`
+	EstimatedTime *time.Time `json:"estimated_time"`
+	ActualTime    *time.Time `json:"actual_time"`
+}
+
+// RouteDeviation 航班当前位置相对计划航线的偏离情况
+type RouteDeviation struct {
+	CrossTrackMeters float64 `json:"cross_track_meters"`
+	ThresholdMeters  float64 `json:"threshold_meters"`
+	Segment          int     `json:"segment"` // 最近航段起点航点的下标
+	Deviated         bool    `json:"deviated"`
+}
+
+// FlightRouteResponse 航班计划航线响应
+type FlightRouteResponse struct {
+	FlightID     uuid.UUID                `json:"flight_id"`
+	FlightNumber string                   `json:"flight_number"`
+	DistanceKm   float64                  `json:"distance_km"` // 沿航点的大圆总距离
+	Waypoints    []FlightWaypointResponse `json:"waypoints"`
+	Deviation    *RouteDeviation          `json:"deviation,omitempty"` // 航班有当前位置时返回
+}
+
+// ToFlightWaypointResponse 转换为航点响应
+func ToFlightWaypointResponse(waypoint *models.FlightRoute) *FlightWaypointResponse {
+	return &FlightWaypointResponse{
+		ID:            waypoint.ID,
+		Sequence:      waypoint.Sequence,
+		WaypointName:  waypoint.WaypointName,
+		Latitude:      waypoint.Latitude,
+		Longitude:     waypoint.Longitude,
+		Altitude:      waypoint.Altitude,
+		EstimatedTime: waypoint.EstimatedTime,
+		ActualTime:    waypoint.ActualTime,
+	}
+}
+
+// ToFlightWaypointResponseList 转换为航点响应列表
+func ToFlightWaypointResponseList(waypoints []models.FlightRoute) []FlightWaypointResponse {
+	list := make([]FlightWaypointResponse, len(waypoints))
+	for i := range waypoints {
+		list[i] = *ToFlightWaypointResponse(&waypoints[i])
+	}
+	return list
+}
@@ -0,0 +1,190 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateIncidentRequest 上报无人机事件请求
+// 关联任务时无人机和运营商随任务确定，关联无人机时运营商随无人机确定
+// property_damage、injuries 为 true 时需填写对应说明
+type CreateIncidentRequest struct {
+	DroneID                   *uuid.UUID      `json:"drone_id"`
+	MissionID                 *uuid.UUID      `json:"mission_id"`
+	OperatorID                *uuid.UUID      `json:"operator_id"` // 运营商用户和飞手忽略该参数
+	IncidentType              string          `json:"incident_type" binding:"required,oneof=crash flyaway near_miss violation malfunction"`
+	Severity                  string          `json:"severity" binding:"required,oneof=minor moderate serious critical"`
+	IncidentDate              time.Time       `json:"incident_date" binding:"required"`
+	Location                  MissionLocation `json:"location" binding:"required"`
+	Altitude                  *int            `json:"altitude" binding:"omitempty,min=0"`
+	Description               string          `json:"description" binding:"required,max=5000"`
+	Cause                     *string         `json:"cause" binding:"omitempty,max=5000"`
+	ContributingFactors       []string        `json:"contributing_factors" binding:"omitempty,max=20,dive,max=200"`
+	DroneDamage               *string         `json:"drone_damage" binding:"omitempty,oneof=none minor major total_loss"`
+	PropertyDamage            bool            `json:"property_damage"`
+	PropertyDamageDescription *string         `json:"property_damage_description" binding:"omitempty,max=5000"`
+	Injuries                  bool            `json:"injuries"`
+	InjuryDescription         *string         `json:"injury_description" binding:"omitempty,max=5000"`
+	ReportedToAuthority       bool            `json:"reported_to_authority"`
+	AuthorityCaseNumber       *string         `json:"authority_case_number" binding:"omitempty,max=100"`
+}
+
+// ConfirmIncidentRequest 确认自动检测的事件草稿，未填写的字段保留检测结果
+// incident_type、severity 仅监管人员和管理员可以修改
+// property_damage、injuries 为 true 时需填写对应说明
+type ConfirmIncidentRequest struct {
+	IncidentType              *string `json:"incident_type" binding:"omitempty,oneof=crash flyaway near_miss violation malfunction"`
+	Severity                  *string `json:"severity" binding:"omitempty,oneof=minor moderate serious critical"`
+	Description               *string `json:"description" binding:"omitempty,max=5000"`
+	Cause                     *string `json:"cause" binding:"omitempty,max=5000"`
+	DroneDamage               *string `json:"drone_damage" binding:"omitempty,oneof=none minor major total_loss"`
+	PropertyDamage            bool    `json:"property_damage"`
+	PropertyDamageDescription *string `json:"property_damage_description" binding:"omitempty,max=5000"`
+	Injuries                  bool    `json:"injuries"`
+	InjuryDescription         *string `json:"injury_description" binding:"omitempty,max=5000"`
+}
+
+// DismissIncidentRequest 驳回事件草稿请求，须说明误报原因
+type DismissIncidentRequest struct {
+	Reason string `json:"reason" binding:"required,max=5000"`
+}
+
+// AssignInvestigatorRequest 指派调查人请求，调查人须为监管人员或管理员
+type AssignInvestigatorRequest struct {
+	InvestigatorID uuid.UUID `json:"investigator_id" binding:"required"`
+}
+
+// StartInvestigationRequest 开始调查请求，须填写调查计划
+type StartInvestigationRequest struct {
+	Notes string `json:"notes" binding:"required,max=5000"`
+}
+
+// CloseIncidentRequest 结案请求，须填写根本原因和纠正措施
+// 严重及以上或有人员受伤的事件须已上报主管部门并填写案件编号
+type CloseIncidentRequest struct {
+	RootCause           string  `json:"root_cause" binding:"required,max=5000"`
+	CorrectiveActions   string  `json:"corrective_actions" binding:"required,max=5000"`
+	PreventiveMeasures  *string `json:"preventive_measures" binding:"omitempty,max=5000"`
+	Cause               *string `json:"cause" binding:"omitempty,max=5000"`
+	Notes               string  `json:"notes" binding:"max=5000"` // 非空时覆盖调查记录
+	ReportedToAuthority *bool   `json:"reported_to_authority"`
+	AuthorityCaseNumber *string `json:"authority_case_number" binding:"omitempty,max=100"`
+}
+
+// IncidentQuery 无人机事件列表查询参数，结果按严重程度由重到轻排序
+type IncidentQuery struct {
+	PageQuery
+	Status         string     `form:"status"`      // 多个状态以逗号分隔，draft 为待确认的自动检测事件
+	Severity       string     `form:"severity"`    // 多个级别以逗号分隔
+	Type           string     `form:"type"`        // 多个类型以逗号分隔
+	Open           bool       `form:"open"`        // 仅已确认未结案的事件，指定 status 时忽略
+	OperatorID     *uuid.UUID `form:"operator_id"` // 运营商用户和飞手忽略该参数
+	DroneID        *uuid.UUID `form:"drone_id"`
+	MissionID      *uuid.UUID `form:"mission_id"`
+	InvestigatorID *uuid.UUID `form:"investigator_id"`
+	From           *time.Time `form:"from"`
+	To             *time.Time `form:"to"`
+}
+
+// IncidentResponse 无人机事件响应
+type IncidentResponse struct {
+	ID                        uuid.UUID         `json:"id"`
+	DroneID                   *uuid.UUID        `json:"drone_id"`
+	MissionID                 *uuid.UUID        `json:"mission_id"`
+	OperatorID                *uuid.UUID        `json:"operator_id"`
+	IncidentType              string            `json:"incident_type"`
+	Severity                  string            `json:"severity"`
+	IncidentDate              time.Time         `json:"incident_date"`
+	Location                  json.RawMessage   `json:"location" swaggertype:"object"`
+	Altitude                  *int              `json:"altitude"`
+	Description               string            `json:"description"`
+	Cause                     *string           `json:"cause"`
+	ContributingFactors       json.RawMessage   `json:"contributing_factors,omitempty" swaggertype:"array,string"`
+	DroneDamage               *string           `json:"drone_damage"`
+	PropertyDamage            bool              `json:"property_damage"`
+	PropertyDamageDescription *string           `json:"property_damage_description"`
+	Injuries                  bool              `json:"injuries"`
+	InjuryDescription         *string           `json:"injury_description"`
+	DetectionRule             *string           `json:"detection_rule"`
+	InvestigationStatus       string            `json:"investigation_status"`
+	InvestigatorID            *uuid.UUID        `json:"investigator_id"`
+	InvestigationNotes        *string           `json:"investigation_notes"`
+	RootCause                 *string           `json:"root_cause"`
+	CorrectiveActions         *string           `json:"corrective_actions"`
+	PreventiveMeasures        *string           `json:"preventive_measures"`
+	ReportedToAuthority       bool              `json:"reported_to_authority"`
+	AuthorityCaseNumber       *string           `json:"authority_case_number"`
+	CreatedAt                 time.Time         `json:"created_at"`
+	UpdatedAt                 time.Time         `json:"updated_at"`
+	Drone                     *DroneBrief       `json:"drone,omitempty"`
+	Investigator              *InvestigatorInfo `json:"investigator,omitempty"`
+}
+
+// InvestigatorInfo 调查人摘要
+type InvestigatorInfo struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	FullName *string   `json:"full_name,omitempty"`
+}
+
+// ToIncidentResponse 转换为无人机事件响应
+func ToIncidentResponse(incident *models.DroneIncident) *IncidentResponse {
+	resp := &IncidentResponse{
+		ID:                        incident.ID,
+		DroneID:                   incident.DroneID,
+		MissionID:                 incident.MissionID,
+		OperatorID:                incident.OperatorID,
+		IncidentType:              incident.IncidentType,
+		Severity:                  incident.Severity,
+		IncidentDate:              incident.IncidentDate,
+		Location:                  rawJSON(&incident.Location),
+		Altitude:                  incident.Altitude,
+		Description:               incident.Description,
+		Cause:                     incident.Cause,
+		ContributingFactors:       rawJSON(incident.ContributingFactors),
+		DroneDamage:               incident.DroneDamage,
+		PropertyDamage:            incident.PropertyDamage,
+		PropertyDamageDescription: incident.PropertyDamageDescription,
+		Injuries:                  incident.Injuries,
+		InjuryDescription:         incident.InjuryDescription,
+		DetectionRule:             incident.DetectionRule,
+		InvestigationStatus:       incident.InvestigationStatus,
+		InvestigatorID:            incident.InvestigatorID,
+		InvestigationNotes:        incident.InvestigationNotes,
+		RootCause:                 incident.RootCause,
+		CorrectiveActions:         incident.CorrectiveActions,
+		PreventiveMeasures:        incident.PreventiveMeasures,
+		ReportedToAuthority:       incident.ReportedToAuthority,
+		AuthorityCaseNumber:       incident.AuthorityCaseNumber,
+		CreatedAt:                 incident.CreatedAt,
+		UpdatedAt:                 incident.UpdatedAt,
+	}
+	if incident.Drone != nil {
+		resp.Drone = &DroneBrief{
+			ID:           incident.Drone.ID,
+			SerialNumber: incident.Drone.SerialNumber,
+			Name:         incident.Drone.Name,
+			Status:       incident.Drone.Status,
+		}
+	}
+	if incident.Investigator != nil {
+		resp.Investigator = &InvestigatorInfo{
+			ID:       incident.Investigator.ID,
+			Username: incident.Investigator.Username,
+			FullName: incident.Investigator.FullName,
+		}
+	}
+	return resp
+}
+
+// ToIncidentResponseList 转换为无人机事件响应列表
+func ToIncidentResponseList(incidents []models.DroneIncident) []IncidentResponse {
+	list := make([]IncidentResponse, len(incidents))
+	for i := range incidents {
+		list[i] = *ToIncidentResponse(&incidents[i])
+	}
+	return list
+}
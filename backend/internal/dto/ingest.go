@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIngestBatchSize 单次上报的最大位置点数量
+const MaxIngestBatchSize = 5000
+
+// FlightPositionReport 航班位置报告（ADS-B 风格）
+// 通过 flight_number 或 icao_hex 关联航班，至少提供其一
+type FlightPositionReport struct {
+	FlightNumber  string     `json:"flight_number"`
+	ICAOHex       string     `json:"icao_hex"`
+	Latitude      *float64   `json:"latitude"`
+	Longitude     *float64   `json:"longitude"`
+	Altitude      *int       `json:"altitude"`       // 英尺
+	Speed         *int       `json:"speed"`          // 地速（节）
+	Heading       *int       `json:"heading"`        // 航向（度，0-360）
+	VerticalSpeed *int       `json:"vertical_speed"` // 英尺/分钟
+	Timestamp     *time.Time `json:"timestamp"`      // 缺省为接收时间
+}
+
+// IngestFlightPositionsRequest 批量上报航班位置请求
+type IngestFlightPositionsRequest struct {
+	Positions []FlightPositionReport `json:"positions" binding:"required,min=1,max=5000"`
+}
+
+// IngestError 单条上报被拒绝的原因
+type IngestError struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// IngestResult 批量上报结果
+type IngestResult struct {
+	Received int           `json:"received"`
+	Accepted int           `json:"accepted"`
+	Rejected int           `json:"rejected"`
+	Flights  []uuid.UUID   `json:"flights"` // 本次更新了位置的航班
+	Errors   []IngestError `json:"errors,omitempty"`
+}
+
+// DroneTelemetryReport 无人机遥测报告
+// 通过 drone_id 或 serial_number 关联无人机，至少提供其一
+type DroneTelemetryReport struct {
+	DroneID        *uuid.UUID `json:"drone_id"`
+	SerialNumber   string     `json:"serial_number"`
+	Latitude       *float64   `json:"latitude"`
+	Longitude      *float64   `json:"longitude"`
+	Altitude       *int       `json:"altitude"`        // 米（相对地面）
+	AltitudeMSL    *int       `json:"altitude_msl"`    // 米（海拔）
+	Speed          *int       `json:"speed"`           // km/h
+	Heading        *int       `json:"heading"`         // 度（0-360）
+	VerticalSpeed  *int       `json:"vertical_speed"`  // m/s
+	BatteryLevel   *int       `json:"battery_level"`   // 百分比
+	SignalStrength *int       `json:"signal_strength"` // dBm
+	GpsSatellites  *int       `json:"gps_satellites"`
+	GpsAccuracy    *float64   `json:"gps_accuracy"` // 米
+	FlightMode     string     `json:"flight_mode"`  // manual/auto/rtl/loiter等
+	Temperature    *float64   `json:"temperature"`  // 摄氏度
+	Humidity       *int       `json:"humidity"`     // 百分比
+	AirPressure    *float64   `json:"air_pressure"` // hPa
+	Timestamp      *time.Time `json:"timestamp"`    // 缺省为接收时间
+}
+
+// IngestDroneTelemetryRequest 批量上报无人机遥测请求
+type IngestDroneTelemetryRequest struct {
+	Positions []DroneTelemetryReport `json:"positions" binding:"required,min=1,max=5000"`
+}
+
+// DroneIngestResult 无人机遥测上报结果
+type DroneIngestResult struct {
+	Received int           `json:"received"`
+	Accepted int           `json:"accepted"`
+	Rejected int           `json:"rejected"`
+	Drones   []uuid.UUID   `json:"drones"`   // 本次更新了位置的无人机
+	Breaches int           `json:"breaches"` // 触发禁飞区或飞行区域越界的位置点数
+	Errors   []IngestError `json:"errors,omitempty"`
+}
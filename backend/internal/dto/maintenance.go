@@ -0,0 +1,199 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateMaintenanceRuleRequest 创建维护规则请求，飞行小时、飞行次数、日历天数至少设置一项
+type CreateMaintenanceRuleRequest struct {
+	Model           string   `json:"model" binding:"required,max=100"`
+	Name            string   `json:"name" binding:"required,max=200"`
+	Description     *string  `json:"description" binding:"omitempty,max=2000"`
+	IntervalHours   *float64 `json:"interval_hours" binding:"omitempty,gt=0"`
+	IntervalFlights *int     `json:"interval_flights" binding:"omitempty,min=1"`
+	IntervalDays    *int     `json:"interval_days" binding:"omitempty,min=1"`
+}
+
+// UpdateMaintenanceRuleRequest 更新维护规则请求，未填写的字段保持不变，间隔填 0 表示取消该项
+type UpdateMaintenanceRuleRequest struct {
+	Model           *string  `json:"model" binding:"omitempty,min=1,max=100"`
+	Name            *string  `json:"name" binding:"omitempty,min=1,max=200"`
+	Description     *string  `json:"description" binding:"omitempty,max=2000"`
+	IntervalHours   *float64 `json:"interval_hours" binding:"omitempty,min=0"`
+	IntervalFlights *int     `json:"interval_flights" binding:"omitempty,min=0"`
+	IntervalDays    *int     `json:"interval_days" binding:"omitempty,min=0"`
+}
+
+// MaintenanceRuleQuery 维护规则列表查询参数
+type MaintenanceRuleQuery struct {
+	PageQuery
+	Model string `form:"model"`
+}
+
+// MaintenanceRuleResponse 维护规则响应
+type MaintenanceRuleResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Model           string    `json:"model"`
+	Name            string    `json:"name"`
+	Description     *string   `json:"description"`
+	IntervalHours   *float64  `json:"interval_hours"`
+	IntervalFlights *int      `json:"interval_flights"`
+	IntervalDays    *int      `json:"interval_days"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// MaintenanceItem 单条维护规则的执行情况
+// 自上次签核该规则的工单起计算，从未签核时从无人机登记起计算
+type MaintenanceItem struct {
+	RuleID           uuid.UUID  `json:"rule_id"`
+	RuleName         string     `json:"rule_name"`
+	Status           string     `json:"status"` // ok/due/overdue
+	HoursSince       float64    `json:"hours_since"`
+	FlightsSince     int64      `json:"flights_since"`
+	DaysSince        int        `json:"days_since"`
+	HoursRemaining   *float64   `json:"hours_remaining,omitempty"` // 已超期为负数
+	FlightsRemaining *int64     `json:"flights_remaining,omitempty"`
+	DaysRemaining    *int       `json:"days_remaining,omitempty"`
+	DueDate          *time.Time `json:"due_date,omitempty"` // 按日历天数计算的到期时间
+	LastCompletedAt  *time.Time `json:"last_completed_at"`
+}
+
+// DroneMaintenanceStatus 无人机维护状态，status 取各规则中最紧迫的状态
+type DroneMaintenanceStatus struct {
+	Drone            *DroneBrief       `json:"drone"`
+	Model            string            `json:"model"`
+	Status           string            `json:"status"` // ok/due/overdue
+	TotalFlightHours float64           `json:"total_flight_hours"`
+	TotalFlights     int64             `json:"total_flights"`
+	Items            []MaintenanceItem `json:"items"`
+}
+
+// MaintenanceDueQuery 待维护无人机查询参数，结果按超期优先排序
+type MaintenanceDueQuery struct {
+	PageQuery
+	OperatorID *uuid.UUID `form:"operator_id"`                                  // 运营商用户忽略该参数
+	Status     string     `form:"status" binding:"omitempty,oneof=due overdue"` // 为空时返回即将到期和已超期
+}
+
+// CreateWorkOrderRequest 开立维护工单请求，关联规则时标题默认为规则名称
+type CreateWorkOrderRequest struct {
+	DroneID     uuid.UUID  `json:"drone_id" binding:"required"`
+	RuleID      *uuid.UUID `json:"rule_id"`
+	Title       string     `json:"title" binding:"max=200"`
+	Description *string    `json:"description" binding:"omitempty,max=5000"`
+}
+
+// SignOffWorkOrderRequest 签核维护工单请求，须填写维护内容
+type SignOffWorkOrderRequest struct {
+	WorkPerformed string `json:"work_performed" binding:"required,max=5000"`
+}
+
+// CancelWorkOrderRequest 取消维护工单请求，须说明原因
+type CancelWorkOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=5000"`
+}
+
+// WorkOrderQuery 维护工单列表查询参数
+type WorkOrderQuery struct {
+	PageQuery
+	Status     string     `form:"status"`      // 多个状态以逗号分隔
+	OperatorID *uuid.UUID `form:"operator_id"` // 运营商用户忽略该参数
+	DroneID    *uuid.UUID `form:"drone_id"`
+	RuleID     *uuid.UUID `form:"rule_id"`
+}
+
+// WorkOrderResponse 维护工单响应
+type WorkOrderResponse struct {
+	ID                   uuid.UUID                `json:"id"`
+	DroneID              uuid.UUID                `json:"drone_id"`
+	OperatorID           *uuid.UUID               `json:"operator_id"`
+	RuleID               *uuid.UUID               `json:"rule_id"`
+	Title                string                   `json:"title"`
+	Description          *string                  `json:"description"`
+	Status               string                   `json:"status"`
+	OpenedBy             *uuid.UUID               `json:"opened_by"`
+	WorkPerformed        *string                  `json:"work_performed"`
+	SignedOffBy          *uuid.UUID               `json:"signed_off_by"`
+	CompletedAt          *time.Time               `json:"completed_at"`
+	FlightHoursAtSignOff *float64                 `json:"flight_hours_at_sign_off"` // 签核时的累计飞行小时
+	FlightsAtSignOff     *int64                   `json:"flights_at_sign_off"`
+	CancelReason         *string                  `json:"cancel_reason"`
+	CreatedAt            time.Time                `json:"created_at"`
+	UpdatedAt            time.Time                `json:"updated_at"`
+	Drone                *DroneBrief              `json:"drone,omitempty"`
+	Rule                 *MaintenanceRuleResponse `json:"rule,omitempty"`
+}
+
+// ToMaintenanceRuleResponse 转换为维护规则响应
+func ToMaintenanceRuleResponse(rule *models.MaintenanceRule) *MaintenanceRuleResponse {
+	return &MaintenanceRuleResponse{
+		ID:              rule.ID,
+		Model:           rule.Model,
+		Name:            rule.Name,
+		Description:     rule.Description,
+		IntervalHours:   rule.IntervalHours,
+		IntervalFlights: rule.IntervalFlights,
+		IntervalDays:    rule.IntervalDays,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+// ToMaintenanceRuleResponseList 转换为维护规则响应列表
+func ToMaintenanceRuleResponseList(rules []models.MaintenanceRule) []MaintenanceRuleResponse {
+	list := make([]MaintenanceRuleResponse, len(rules))
+	for i := range rules {
+		list[i] = *ToMaintenanceRuleResponse(&rules[i])
+	}
+	return list
+}
+
+// ToWorkOrderResponse 转换为维护工单响应
+func ToWorkOrderResponse(order *models.MaintenanceWorkOrder) *WorkOrderResponse {
+	resp := &WorkOrderResponse{
+		ID:               order.ID,
+		DroneID:          order.DroneID,
+		OperatorID:       order.OperatorID,
+		RuleID:           order.RuleID,
+		Title:            order.Title,
+		Description:      order.Description,
+		Status:           order.Status,
+		OpenedBy:         order.OpenedBy,
+		WorkPerformed:    order.WorkPerformed,
+		SignedOffBy:      order.SignedOffBy,
+		CompletedAt:      order.CompletedAt,
+		FlightsAtSignOff: order.FlightsAtCompletion,
+		CancelReason:     order.CancelReason,
+		CreatedAt:        order.CreatedAt,
+		UpdatedAt:        order.UpdatedAt,
+	}
+	if order.FlightMinutesAtCompletion != nil {
+		hours := float64(*order.FlightMinutesAtCompletion) / 60
+		resp.FlightHoursAtSignOff = &hours
+	}
+	if order.Drone != nil {
+		resp.Drone = &DroneBrief{
+			ID:           order.Drone.ID,
+			SerialNumber: order.Drone.SerialNumber,
+			Name:         order.Drone.Name,
+			Status:       order.Drone.Status,
+		}
+	}
+	if order.Rule != nil {
+		resp.Rule = ToMaintenanceRuleResponse(order.Rule)
+	}
+	return resp
+}
+
+// ToWorkOrderResponseList 转换为维护工单响应列表
+func ToWorkOrderResponseList(orders []models.MaintenanceWorkOrder) []WorkOrderResponse {
+	list := make([]WorkOrderResponse, len(orders))
+	for i := range orders {
+		list[i] = *ToWorkOrderResponse(&orders[i])
+	}
+	return list
+}
@@ -0,0 +1,262 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MissionLocation 任务起降点
+type MissionLocation struct {
+	Lat     float64 `json:"lat" binding:"min=-90,max=90"`
+	Lng     float64 `json:"lng" binding:"min=-180,max=180"`
+	Name    string  `json:"name,omitempty" binding:"max=200"`
+	Address string  `json:"address,omitempty" binding:"max=500"`
+}
+
+// MissionWaypoint 任务航点，高度为相对起飞点高度（米）
+type MissionWaypoint struct {
+	Lat      float64  `json:"lat" binding:"min=-90,max=90"`
+	Lng      float64  `json:"lng" binding:"min=-180,max=180"`
+	Altitude *float64 `json:"altitude,omitempty" binding:"omitempty,min=0"`
+}
+
+// CreateMissionRequest 提交无人机任务请求
+// requires_approval 缺省为 true，仅管理员可以设为 false 豁免审批；flight_area 为 GeoJSON Polygon 或 MultiPolygon
+type CreateMissionRequest struct {
+	DroneID             uuid.UUID         `json:"drone_id" binding:"required"`
+	PilotID             *uuid.UUID        `json:"pilot_id"` // 飞手提交时固定为本人
+	MissionName         string            `json:"mission_name" binding:"required,max=200"`
+	MissionType         string            `json:"mission_type" binding:"required,max=50"`
+	Priority            string            `json:"priority" binding:"omitempty,oneof=low normal high emergency"`
+	PlannedStartTime    time.Time         `json:"planned_start_time" binding:"required"`
+	PlannedEndTime      time.Time         `json:"planned_end_time" binding:"required"`
+	DepartureLocation   MissionLocation   `json:"departure_location" binding:"required"`
+	ArrivalLocation     *MissionLocation  `json:"arrival_location"`
+	Waypoints           []MissionWaypoint `json:"waypoints" binding:"omitempty,max=500,dive"`
+	FlightArea          *geo.Geometry     `json:"flight_area" swaggertype:"object"`
+	PlannedAltitude     *int              `json:"planned_altitude" binding:"omitempty,min=0"`
+	PlannedSpeed        *int              `json:"planned_speed" binding:"omitempty,min=0"`
+	PlannedDistance     *float64          `json:"planned_distance" binding:"omitempty,min=0"`
+	RequiresApproval    *bool             `json:"requires_approval"`
+	Description         *string           `json:"description" binding:"omitempty,max=2000"`
+	Objectives          *string           `json:"objectives" binding:"omitempty,max=2000"`
+	SpecialRequirements *string           `json:"special_requirements" binding:"omitempty,max=2000"`
+	BackupPlan          *string           `json:"backup_plan" binding:"omitempty,max=2000"`
+}
+
+// UpdateMissionRequest 修改无人机任务请求（字段均可选）
+// 仅计划中的任务可以修改，被驳回的任务修改后重新进入待审批
+type UpdateMissionRequest struct {
+	DroneID             *uuid.UUID         `json:"drone_id"`
+	PilotID             *uuid.UUID         `json:"pilot_id"`
+	MissionName         *string            `json:"mission_name" binding:"omitempty,min=1,max=200"`
+	MissionType         *string            `json:"mission_type" binding:"omitempty,min=1,max=50"`
+	Priority            *string            `json:"priority" binding:"omitempty,oneof=low normal high emergency"`
+	PlannedStartTime    *time.Time         `json:"planned_start_time"`
+	PlannedEndTime      *time.Time         `json:"planned_end_time"`
+	DepartureLocation   *MissionLocation   `json:"departure_location"`
+	ArrivalLocation     *MissionLocation   `json:"arrival_location"`
+	Waypoints           *[]MissionWaypoint `json:"waypoints" binding:"omitempty,max=500,dive"`
+	FlightArea          *geo.Geometry      `json:"flight_area" swaggertype:"object"`
+	PlannedAltitude     *int               `json:"planned_altitude" binding:"omitempty,min=0"`
+	PlannedSpeed        *int               `json:"planned_speed" binding:"omitempty,min=0"`
+	PlannedDistance     *float64           `json:"planned_distance" binding:"omitempty,min=0"`
+	Description         *string            `json:"description" binding:"omitempty,max=2000"`
+	Objectives          *string            `json:"objectives" binding:"omitempty,max=2000"`
+	SpecialRequirements *string            `json:"special_requirements" binding:"omitempty,max=2000"`
+	BackupPlan          *string            `json:"backup_plan" binding:"omitempty,max=2000"`
+}
+
+// MissionActionRequest 任务审批与执行操作请求，驳回时 notes 必填
+type MissionActionRequest struct {
+	Notes string `json:"notes" binding:"max=1000"`
+}
+
+// MissionQuery 无人机任务列表查询参数
+type MissionQuery struct {
+	PageQuery
+	Status         string     `form:"status"`          // 多个状态以逗号分隔
+	ApprovalStatus string     `form:"approval_status"` // 多个状态以逗号分隔
+	DroneID        *uuid.UUID `form:"drone_id"`
+	OperatorID     *uuid.UUID `form:"operator_id"` // 运营商和飞手忽略该参数
+	PilotID        *uuid.UUID `form:"pilot_id"`
+	From           *time.Time `form:"from"`
+	To             *time.Time `form:"to"`
+}
+
+// MissionWaypointsGeometry 将航点列表转换为 GeoJSON MultiPoint，空列表返回 nil
+func MissionWaypointsGeometry(waypoints []MissionWaypoint) *geo.Geometry {
+	if len(waypoints) == 0 {
+		return nil
+	}
+	points := make([]geo.Position, len(waypoints))
+	for i, wp := range waypoints {
+		if wp.Altitude != nil {
+			points[i] = geo.NewPosition(wp.Lat, wp.Lng, *wp.Altitude)
+		} else {
+			points[i] = geo.NewPosition(wp.Lat, wp.Lng)
+		}
+	}
+	return geo.NewMultiPoint(points)
+}
+
+// ToMissionWaypoints 将 GeoJSON MultiPoint 转换为航点列表
+func ToMissionWaypoints(geometry *geo.Geometry) []MissionWaypoint {
+	if geometry == nil || geometry.Type != geo.TypeMultiPoint {
+		return nil
+	}
+	waypoints := make([]MissionWaypoint, len(geometry.MultiPoint))
+	for i, p := range geometry.MultiPoint {
+		waypoints[i] = MissionWaypoint{Lat: p.Lat(), Lng: p.Lng()}
+		if alt, ok := p.Altitude(); ok {
+			waypoints[i].Altitude = &alt
+		}
+	}
+	return waypoints
+}
+
+// MissionResponse 无人机任务响应
+type MissionResponse struct {
+	ID                  uuid.UUID         `json:"id"`
+	DroneID             uuid.UUID         `json:"drone_id"`
+	OperatorID          uuid.UUID         `json:"operator_id"`
+	PilotID             *uuid.UUID        `json:"pilot_id"`
+	MissionName         string            `json:"mission_name"`
+	MissionType         string            `json:"mission_type"`
+	MissionStatus       string            `json:"mission_status"`
+	Priority            string            `json:"priority"`
+	PlannedStartTime    time.Time         `json:"planned_start_time"`
+	PlannedEndTime      time.Time         `json:"planned_end_time"`
+	ActualStartTime     *time.Time        `json:"actual_start_time"`
+	ActualEndTime       *time.Time        `json:"actual_end_time"`
+	DepartureLocation   json.RawMessage   `json:"departure_location" swaggertype:"object"`
+	ArrivalLocation     json.RawMessage   `json:"arrival_location,omitempty" swaggertype:"object"`
+	Waypoints           []MissionWaypoint `json:"waypoints,omitempty"`
+	FlightArea          *geo.Geometry     `json:"flight_area,omitempty" swaggertype:"object"`
+	PlannedAltitude     *int              `json:"planned_altitude"`
+	PlannedSpeed        *int              `json:"planned_speed"`
+	PlannedDistance     *float64          `json:"planned_distance"`
+	SubmittedBy         *uuid.UUID        `json:"submitted_by"`
+	RequiresApproval    bool              `json:"requires_approval"`
+	ApprovalStatus      *string           `json:"approval_status"`
+	ApprovedBy          *uuid.UUID        `json:"approved_by"`
+	ApprovalTime        *time.Time        `json:"approval_time"`
+	ApprovalNotes       *string           `json:"approval_notes"`
+	Description         *string           `json:"description"`
+	Objectives          *string           `json:"objectives"`
+	SpecialRequirements *string           `json:"special_requirements"`
+	BackupPlan          *string           `json:"backup_plan"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+	Drone               *DroneBrief       `json:"drone,omitempty"`
+	Conflicts           []ZoneConflict    `json:"conflicts,omitempty"` // 仅提交和修改时返回
+}
+
+// DroneBrief 任务中的无人机摘要
+type DroneBrief struct {
+	ID           uuid.UUID `json:"id"`
+	SerialNumber string    `json:"serial_number"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+}
+
+// MissionLogResponse 无人机任务审计记录响应
+type MissionLogResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	Action         string     `json:"action"`
+	FromStatus     string     `json:"from_status"`
+	ToStatus       string     `json:"to_status"`
+	ApprovalStatus *string    `json:"approval_status"`
+	Notes          string     `json:"notes"`
+	ActorID        *uuid.UUID `json:"actor_id"`
+	ActorName      string     `json:"actor_name"`
+	ActorRole      string     `json:"actor_role"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// ToMissionResponse 转换为无人机任务响应
+func ToMissionResponse(mission *models.DroneMission) *MissionResponse {
+	resp := &MissionResponse{
+		ID:                  mission.ID,
+		DroneID:             mission.DroneID,
+		OperatorID:          mission.OperatorID,
+		PilotID:             mission.PilotID,
+		MissionName:         mission.MissionName,
+		MissionType:         mission.MissionType,
+		MissionStatus:       mission.MissionStatus,
+		Priority:            mission.Priority,
+		PlannedStartTime:    mission.PlannedStartTime,
+		PlannedEndTime:      mission.PlannedEndTime,
+		ActualStartTime:     mission.ActualStartTime,
+		ActualEndTime:       mission.ActualEndTime,
+		DepartureLocation:   rawJSON(&mission.DepartureLocation),
+		ArrivalLocation:     rawJSON(mission.ArrivalLocation),
+		Waypoints:           ToMissionWaypoints(mission.Waypoints),
+		FlightArea:          mission.FlightArea,
+		PlannedAltitude:     mission.PlannedAltitude,
+		PlannedSpeed:        mission.PlannedSpeed,
+		PlannedDistance:     mission.PlannedDistance,
+		SubmittedBy:         mission.SubmittedBy,
+		RequiresApproval:    mission.RequiresApproval,
+		ApprovalStatus:      mission.ApprovalStatus,
+		ApprovedBy:          mission.ApprovedBy,
+		ApprovalTime:        mission.ApprovalTime,
+		ApprovalNotes:       mission.ApprovalNotes,
+		Description:         mission.Description,
+		Objectives:          mission.Objectives,
+		SpecialRequirements: mission.SpecialRequirements,
+		BackupPlan:          mission.BackupPlan,
+		CreatedAt:           mission.CreatedAt,
+		UpdatedAt:           mission.UpdatedAt,
+	}
+	if mission.Drone.ID != uuid.Nil {
+		resp.Drone = &DroneBrief{
+			ID:           mission.Drone.ID,
+			SerialNumber: mission.Drone.SerialNumber,
+			Name:         mission.Drone.Name,
+			Status:       mission.Drone.Status,
+		}
+	}
+	return resp
+}
+
+// ToMissionResponseList 转换为无人机任务响应列表
+func ToMissionResponseList(missions []models.DroneMission) []MissionResponse {
+	list := make([]MissionResponse, len(missions))
+	for i := range missions {
+		list[i] = *ToMissionResponse(&missions[i])
+	}
+	return list
+}
+
+// ToMissionLogResponseList 转换为无人机任务审计记录响应列表
+func ToMissionLogResponseList(logs []models.DroneMissionLog) []MissionLogResponse {
+	list := make([]MissionLogResponse, len(logs))
+	for i, log := range logs {
+		list[i] = MissionLogResponse{
+			ID:             log.ID,
+			Action:         log.Action,
+			FromStatus:     log.FromStatus,
+			ToStatus:       log.ToStatus,
+			ApprovalStatus: log.ApprovalStatus,
+			Notes:          log.Notes,
+			ActorID:        log.ActorID,
+			ActorName:      log.ActorName,
+			ActorRole:      log.ActorRole,
+			CreatedAt:      log.CreatedAt,
+		}
+	}
+	return list
+}
+
+// rawJSON 将 jsonb 字段原样输出，空值返回 nil
+func rawJSON(value *string) json.RawMessage {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return json.RawMessage(*value)
+}
@@ -0,0 +1,300 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"backend/pkg/kml"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 禁飞区冲突来源
+const (
+	ConflictSourcePath = "path" // 航线（起飞点、航点、降落点连线）
+	ConflictSourceArea = "area" // 任务飞行区域
+	// ConflictSourceInvalidGeometry 禁飞区几何数据无效，无法判定是否冲突，按冲突处理
+	ConflictSourceInvalidGeometry = "invalid_geometry"
+)
+
+// ZoneConflict 任务与禁飞区的冲突
+type ZoneConflict struct {
+	ZoneID      uuid.UUID  `json:"zone_id"`
+	ZoneName    string     `json:"zone_name"`
+	ZoneType    string     `json:"zone_type"`
+	Authority   string     `json:"authority"`
+	Reason      string     `json:"reason"`
+	Source      string     `json:"source"`            // path | area | invalid_geometry
+	Segment     *int       `json:"segment,omitempty"` // 冲突航段起点下标，0 为起飞点
+	MinAltitude float64    `json:"min_altitude"`
+	MaxAltitude float64    `json:"max_altitude"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+}
+
+// CreateNoFlyZoneRequest 创建禁飞区请求
+// geometry 为 Polygon、MultiPolygon 或带 properties.radius（米）的 Point；max_altitude 为 0 表示无上限
+// 临时禁飞区必须设置 end_time，到期后由定时任务置为 expired
+type CreateNoFlyZoneRequest struct {
+	Name        string        `json:"name" binding:"required,max=200"`
+	Type        string        `json:"type" binding:"omitempty,oneof=permanent temporary conditional"`
+	Geometry    *geo.Geometry `json:"geometry" binding:"required" swaggertype:"object"`
+	MinAltitude float64       `json:"min_altitude" binding:"omitempty,min=0"`
+	MaxAltitude float64       `json:"max_altitude" binding:"omitempty,min=0"`
+	StartTime   *time.Time    `json:"start_time"`
+	EndTime     *time.Time    `json:"end_time"`
+	Reason      string        `json:"reason" binding:"max=500"`
+	Authority   string        `json:"authority" binding:"max=200"`
+	Description string        `json:"description" binding:"max=2000"`
+}
+
+// UpdateNoFlyZoneRequest 更新禁飞区请求（字段均可选）
+type UpdateNoFlyZoneRequest struct {
+	Name        *string       `json:"name" binding:"omitempty,min=1,max=200"`
+	Type        *string       `json:"type" binding:"omitempty,oneof=permanent temporary conditional"`
+	Geometry    *geo.Geometry `json:"geometry" swaggertype:"object"`
+	MinAltitude *float64      `json:"min_altitude" binding:"omitempty,min=0"`
+	MaxAltitude *float64      `json:"max_altitude" binding:"omitempty,min=0"`
+	StartTime   *time.Time    `json:"start_time"`
+	EndTime     *time.Time    `json:"end_time"`
+	Reason      *string       `json:"reason" binding:"omitempty,max=500"`
+	Authority   *string       `json:"authority" binding:"omitempty,max=200"`
+	Status      *string       `json:"status" binding:"omitempty,oneof=active expired cancelled"`
+	Description *string       `json:"description" binding:"omitempty,max=2000"`
+}
+
+// NoFlyZoneQuery 禁飞区列表查询参数
+type NoFlyZoneQuery struct {
+	PageQuery
+	Status string `form:"status" binding:"omitempty,oneof=active expired cancelled"`
+	Type   string `form:"type" binding:"omitempty,oneof=permanent temporary conditional"`
+	Q      string `form:"q"`
+}
+
+// 禁飞区导出格式
+const (
+	ZoneExportGeoJSON = "geojson"
+	ZoneExportKML     = "kml"
+)
+
+// NoFlyZoneExportQuery 禁飞区导出参数，format 默认为 geojson
+type NoFlyZoneExportQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=active expired cancelled"`
+	Type   string `form:"type" binding:"omitempty,oneof=permanent temporary conditional"`
+	Q      string `form:"q"`
+	Format string `form:"format" binding:"omitempty,oneof=geojson kml"`
+}
+
+// NoFlyZoneProperties 导入导出时 GeoJSON Feature 的 properties
+// 圆形禁飞区的半径（米）放在 radius 中
+type NoFlyZoneProperties struct {
+	Name        string     `json:"name"`
+	Type        string     `json:"type,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	MinAltitude float64    `json:"min_altitude"`
+	MaxAltitude float64    `json:"max_altitude"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	Authority   string     `json:"authority,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Radius      float64    `json:"radius,omitempty"`
+}
+
+// NoFlyZoneImportResult 禁飞区批量导入结果，校验失败的要素不导入
+type NoFlyZoneImportResult struct {
+	Total    int                    `json:"total"`
+	Imported int                    `json:"imported"`
+	Errors   []NoFlyZoneImportError `json:"errors"`
+}
+
+// NoFlyZoneImportError 单个要素的导入错误
+type NoFlyZoneImportError struct {
+	Index int    `json:"index"` // 要素在 features 中的下标
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+// NoFlyZoneResponse 禁飞区响应
+type NoFlyZoneResponse struct {
+	ID          uuid.UUID    `json:"id"`
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	Geometry    geo.Geometry `json:"geometry" swaggertype:"object"`
+	MinAltitude float64      `json:"min_altitude"`
+	MaxAltitude float64      `json:"max_altitude"`
+	StartTime   *time.Time   `json:"start_time"`
+	EndTime     *time.Time   `json:"end_time"`
+	Reason      string       `json:"reason"`
+	Authority   string       `json:"authority"`
+	Status      string       `json:"status"`
+	Description string       `json:"description"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// ToNoFlyZoneResponse 转换为禁飞区响应
+func ToNoFlyZoneResponse(zone *models.NoFlyZone) *NoFlyZoneResponse {
+	return &NoFlyZoneResponse{
+		ID:          zone.ID,
+		Name:        zone.Name,
+		Type:        zone.Type,
+		Geometry:    zone.Geometry,
+		MinAltitude: zone.MinAltitude,
+		MaxAltitude: zone.MaxAltitude,
+		StartTime:   zone.StartTime,
+		EndTime:     zone.EndTime,
+		Reason:      zone.Reason,
+		Authority:   zone.Authority,
+		Status:      zone.Status,
+		Description: zone.Description,
+		CreatedAt:   zone.CreatedAt,
+		UpdatedAt:   zone.UpdatedAt,
+	}
+}
+
+// ToNoFlyZoneResponseList 转换为禁飞区响应列表
+func ToNoFlyZoneResponseList(zones []models.NoFlyZone) []NoFlyZoneResponse {
+	list := make([]NoFlyZoneResponse, len(zones))
+	for i := range zones {
+		list[i] = *ToNoFlyZoneResponse(&zones[i])
+	}
+	return list
+}
+
+// ToNoFlyZoneFeatureCollection 转换为 GeoJSON FeatureCollection，可直接用于批量导入
+func ToNoFlyZoneFeatureCollection(zones []models.NoFlyZone) *geo.FeatureCollection {
+	features := make([]geo.Feature, len(zones))
+	for i := range zones {
+		zone := &zones[i]
+		geometry := zone.Geometry
+		properties := map[string]any{
+			"name":         zone.Name,
+			"type":         zone.Type,
+			"status":       zone.Status,
+			"min_altitude": zone.MinAltitude,
+			"max_altitude": zone.MaxAltitude,
+			"reason":       zone.Reason,
+			"authority":    zone.Authority,
+			"description":  zone.Description,
+		}
+		if zone.StartTime != nil {
+			properties["start_time"] = zone.StartTime.UTC()
+		}
+		if zone.EndTime != nil {
+			properties["end_time"] = zone.EndTime.UTC()
+		}
+		if geometry.IsCircle() {
+			properties["radius"] = geometry.Radius
+		}
+		feature := geo.NewFeature(&geometry, properties)
+		feature.ID = zone.ID
+		features[i] = *feature
+	}
+	return geo.NewFeatureCollection(features...)
+}
+
+// zoneStyles 各类型禁飞区的 KML 样式，颜色格式为 aabbggrr
+var zoneStyles = map[string]kml.Style{
+	models.NoFlyZoneTypePermanent: {
+		ID:        models.NoFlyZoneTypePermanent,
+		LineStyle: &kml.LineStyle{Color: "ff0000ff", Width: 2},
+		PolyStyle: &kml.PolyStyle{Color: "4d0000ff"},
+	},
+	models.NoFlyZoneTypeTemporary: {
+		ID:        models.NoFlyZoneTypeTemporary,
+		LineStyle: &kml.LineStyle{Color: "ff0080ff", Width: 2},
+		PolyStyle: &kml.PolyStyle{Color: "4d0080ff"},
+	},
+	models.NoFlyZoneTypeConditional: {
+		ID:        models.NoFlyZoneTypeConditional,
+		LineStyle: &kml.LineStyle{Color: "ff00ffff", Width: 2},
+		PolyStyle: &kml.PolyStyle{Color: "4d00ffff"},
+	},
+}
+
+// ToNoFlyZoneKML 转换为 KML 文档
+// 圆形禁飞区近似为多边形；设置了最高限制高度时拉伸为立体空域
+func ToNoFlyZoneKML(zones []models.NoFlyZone) *kml.Document {
+	doc := &kml.Document{Name: "no-fly-zones"}
+	for _, zoneType := range []string{models.NoFlyZoneTypePermanent, models.NoFlyZoneTypeTemporary, models.NoFlyZoneTypeConditional} {
+		doc.Styles = append(doc.Styles, zoneStyles[zoneType])
+	}
+
+	for i := range zones {
+		zone := &zones[i]
+		placemark := kml.Placemark{
+			Name:        zone.Name,
+			Description: zone.Reason,
+			ExtendedData: &kml.ExtendedData{Data: []kml.Data{
+				{Name: "id", Value: zone.ID.String()},
+				{Name: "type", Value: zone.Type},
+				{Name: "status", Value: zone.Status},
+				{Name: "authority", Value: zone.Authority},
+				{Name: "min_altitude", Value: strconv.FormatFloat(zone.MinAltitude, 'f', -1, 64)},
+				{Name: "max_altitude", Value: strconv.FormatFloat(zone.MaxAltitude, 'f', -1, 64)},
+			}},
+		}
+		if _, ok := zoneStyles[zone.Type]; ok {
+			placemark.StyleURL = "#" + zone.Type
+		}
+
+		polygons := zonePolygons(zone)
+		switch len(polygons) {
+		case 0:
+			continue
+		case 1:
+			placemark.Polygon = &polygons[0]
+		default:
+			placemark.MultiGeometry = &kml.MultiGeometry{Polygons: polygons}
+		}
+		doc.Placemarks = append(doc.Placemarks, placemark)
+	}
+	return doc
+}
+
+// zonePolygons 将禁飞区几何转换为 KML 多边形
+func zonePolygons(zone *models.NoFlyZone) []kml.Polygon {
+	geometry := zone.Geometry.ToPolygon(geo.DefaultCircleSegments)
+	var rings [][][]geo.Position
+	switch geometry.Type {
+	case geo.TypePolygon:
+		rings = [][][]geo.Position{geometry.Polygon}
+	case geo.TypeMultiPolygon:
+		rings = geometry.MultiPolygon
+	default:
+		return nil
+	}
+
+	altitudeMode := kml.AltitudeClampToGround
+	extrude := 0
+	if zone.MaxAltitude > 0 {
+		altitudeMode = kml.AltitudeRelativeToGround
+		extrude = 1
+	}
+	polygons := make([]kml.Polygon, 0, len(rings))
+	for _, polygon := range rings {
+		if len(polygon) == 0 {
+			continue
+		}
+		out := kml.Polygon{
+			Extrude:         extrude,
+			AltitudeMode:    altitudeMode,
+			OuterBoundaryIs: kmlBoundary(polygon[0], zone.MaxAltitude),
+		}
+		for _, hole := range polygon[1:] {
+			out.InnerBoundaryIs = append(out.InnerBoundaryIs, kmlBoundary(hole, zone.MaxAltitude))
+		}
+		polygons = append(polygons, out)
+	}
+	return polygons
+}
+
+func kmlBoundary(ring []geo.Position, altitude float64) kml.Boundary {
+	coordinates := make(kml.Coordinates, len(ring))
+	for i, p := range ring {
+		coordinates[i] = kml.Coordinate{Lng: p.Lng(), Lat: p.Lat(), Alt: altitude}
+	}
+	return kml.Boundary{LinearRing: kml.LinearRing{Coordinates: coordinates}}
+}
@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"backend/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateOperatorRequest 创建运营商请求
+type CreateOperatorRequest struct {
+	Code             string     `json:"code" binding:"required,max=20,alphanum"`
+	Name             string     `json:"name" binding:"required,max=200"`
+	LicenseNo        string     `json:"license_no" binding:"max=100"`
+	LicenseExpiresAt *time.Time `json:"license_expires_at"`
+	Contact          string     `json:"contact" binding:"max=100"`
+	Phone            string     `json:"phone" binding:"max=50"`
+	Email            string     `json:"email" binding:"omitempty,email"`
+	Address          string     `json:"address" binding:"max=500"`
+	Type             string     `json:"type" binding:"omitempty,oneof=commercial government personal"`
+	Status           string     `json:"status" binding:"omitempty,oneof=active suspended"`
+	Description      string     `json:"description" binding:"max=1000"`
+}
+
+// UpdateOperatorRequest 更新运营商请求（字段均可选）
+type UpdateOperatorRequest struct {
+	Name             *string    `json:"name" binding:"omitempty,min=1,max=200"`
+	LicenseNo        *string    `json:"license_no" binding:"omitempty,max=100"`
+	LicenseExpiresAt *time.Time `json:"license_expires_at"`
+	Contact          *string    `json:"contact" binding:"omitempty,max=100"`
+	Phone            *string    `json:"phone" binding:"omitempty,max=50"`
+	Email            *string    `json:"email" binding:"omitempty,email"`
+	Address          *string    `json:"address" binding:"omitempty,max=500"`
+	Type             *string    `json:"type" binding:"omitempty,oneof=commercial government personal"`
+	Status           *string    `json:"status" binding:"omitempty,oneof=active suspended"`
+	Description      *string    `json:"description" binding:"omitempty,max=1000"`
+}
+
+// OperatorQuery 运营商列表查询参数
+type OperatorQuery struct {
+	PageQuery
+	Type   string `form:"type"`
+	Status string `form:"status"`
+	Q      string `form:"q"`
+}
+
+// BindOperatorUserRequest 绑定运营商用户请求
+type BindOperatorUserRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
+// OperatorResponse 运营商响应
+type OperatorResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Code             string     `json:"code"`
+	Name             string     `json:"name"`
+	LicenseNo        string     `json:"license_no"`
+	LicenseExpiresAt *time.Time `json:"license_expires_at"`
+	Contact          string     `json:"contact"`
+	Phone            string     `json:"phone"`
+	Email            string     `json:"email"`
+	Address          string     `json:"address"`
+	Type             string     `json:"type"`
+	Status           string     `json:"status"`
+	Description      string     `json:"description"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// OperatorBrief 无人机中的运营商摘要
+type OperatorBrief struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Name string    `json:"name"`
+}
+
+// ToOperatorResponse 转换为运营商响应
+func ToOperatorResponse(operator *models.Operator) *OperatorResponse {
+	return &OperatorResponse{
+		ID:               operator.ID,
+		Code:             operator.Code,
+		Name:             operator.Name,
+		LicenseNo:        operator.LicenseNo,
+		LicenseExpiresAt: operator.LicenseExpiresAt,
+		Contact:          operator.Contact,
+		Phone:            operator.Phone,
+		Email:            operator.Email,
+		Address:          operator.Address,
+		Type:             operator.Type,
+		Status:           operator.Status,
+		Description:      operator.Description,
+		CreatedAt:        operator.CreatedAt,
+		UpdatedAt:        operator.UpdatedAt,
+	}
+}
+
+// ToOperatorResponseList 转换为运营商响应列表
+func ToOperatorResponseList(operators []models.Operator) []OperatorResponse {
+	list := make([]OperatorResponse, len(operators))
+	for i := range operators {
+		list[i] = *ToOperatorResponse(&operators[i])
+	}
+	return list
+}
+
+// ToOperatorBrief 转换为运营商摘要
+func ToOperatorBrief(operator *models.Operator) *OperatorBrief {
+	if operator == nil {
+		return nil
+	}
+	return &OperatorBrief{
+		ID:   operator.ID,
+		Code: operator.Code,
+		Name: operator.Name,
+	}
+}
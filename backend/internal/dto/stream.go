@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StreamSubscribeMessage WebSocket 客户端更新订阅的消息
+// 例如 {"types":["flight"],"bbox":[30.5,120.8,31.9,122.2]}，bbox 为空表示不限范围
+type StreamSubscribeMessage struct {
+	Types []string  `json:"types"`
+	BBox  []float64 `json:"bbox"`
+}
+
+// FlightPositionEvent 航班位置实时事件
+type FlightPositionEvent struct {
+	FlightID      uuid.UUID `json:"flight_id"`
+	FlightNumber  string    `json:"flight_number"`
+	Latitude      float64   `json:"latitude"`
+	Longitude     float64   `json:"longitude"`
+	Altitude      *int      `json:"altitude"` // 英尺
+	Speed         *int      `json:"speed"`    // 节
+	Heading       *int      `json:"heading"`
+	VerticalSpeed *int      `json:"vertical_speed"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// DronePositionEvent 无人机位置实时事件
+type DronePositionEvent struct {
+	DroneID        uuid.UUID  `json:"drone_id"`
+	SerialNumber   string     `json:"serial_number"`
+	MissionID      *uuid.UUID `json:"mission_id"`
+	Latitude       float64    `json:"latitude"`
+	Longitude      float64    `json:"longitude"`
+	Altitude       int        `json:"altitude"` // 米（相对地面）
+	Speed          *int       `json:"speed"`    // km/h
+	Heading        *int       `json:"heading"`
+	BatteryLevel   *int       `json:"battery_level"`
+	SignalStrength *int       `json:"signal_strength"`
+	FlightMode     *string    `json:"flight_mode"`
+	Timestamp      time.Time  `json:"timestamp"`
+}
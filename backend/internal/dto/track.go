@@ -0,0 +1,131 @@
+package dto
+
+import (
+	"backend/pkg/geo"
+	"backend/pkg/kml"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 航迹降采样方式
+const (
+	TrackMethodDouglasPeucker = "dp"
+	TrackMethodTimeBucket     = "bucket"
+)
+
+// 航迹输出格式
+const (
+	TrackFormatJSON    = "json"
+	TrackFormatGeoJSON = "geojson"
+	TrackFormatKML     = "kml"
+)
+
+// TrackQuery 航迹回放查询参数
+// 未指定 max_points 时最多返回 1000 个点，method 默认为 dp
+type TrackQuery struct {
+	From      *time.Time `form:"from"`
+	To        *time.Time `form:"to"`
+	MaxPoints int        `form:"max_points" binding:"omitempty,min=2,max=10000"`
+	Method    string     `form:"method" binding:"omitempty,oneof=dp bucket"`
+	Format    string     `form:"format" binding:"omitempty,oneof=json geojson kml"`
+}
+
+// TrackPoint 航迹点
+type TrackPoint struct {
+	Latitude      float64   `json:"latitude"`
+	Longitude     float64   `json:"longitude"`
+	Altitude      *int      `json:"altitude"` // 英尺
+	Speed         *int      `json:"speed"`    // 节
+	Heading       *int      `json:"heading"`
+	VerticalSpeed *int      `json:"vertical_speed"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// TrackResponse 航迹回放响应
+type TrackResponse struct {
+	FlightID     uuid.UUID    `json:"flight_id"`
+	FlightNumber string       `json:"flight_number"`
+	From         *time.Time   `json:"from"`
+	To           *time.Time   `json:"to"`
+	TotalPoints  int          `json:"total_points"` // 降采样前的点数
+	Method       string       `json:"method"`
+	Points       []TrackPoint `json:"points"`
+}
+
+// feetToMeters 英尺转米，GeoJSON 和 KML 的高度单位为米
+const feetToMeters = 0.3048
+
+// ToTrackFeature 转换为 GeoJSON LineString Feature
+// 坐标顺序为 [经度, 纬度, 高度(米)]，各点时间放在 properties.timestamps 中
+func ToTrackFeature(track *TrackResponse) *geo.Feature {
+	coordinates := make([]geo.Position, len(track.Points))
+	timestamps := make([]time.Time, len(track.Points))
+	for i, point := range track.Points {
+		coordinates[i] = geo.NewPosition(point.Latitude, point.Longitude, altitudeMeters(point.Altitude))
+		timestamps[i] = point.Timestamp
+	}
+	return geo.NewFeature(geo.NewLineString(coordinates), map[string]any{
+		"flight_id":     track.FlightID,
+		"flight_number": track.FlightNumber,
+		"total_points":  track.TotalPoints,
+		"method":        track.Method,
+		"timestamps":    timestamps,
+	})
+}
+
+// ToTrackKML 转换为 KML 文档，包含航迹线及起止点
+func ToTrackKML(track *TrackResponse) *kml.Document {
+	doc := &kml.Document{
+		Name: track.FlightNumber,
+		Styles: []kml.Style{{
+			ID:        "track",
+			LineStyle: &kml.LineStyle{Color: "ff0080ff", Width: 3},
+		}},
+	}
+	if len(track.Points) == 0 {
+		return doc
+	}
+
+	coordinates := make(kml.Coordinates, len(track.Points))
+	for i, point := range track.Points {
+		coordinates[i] = kml.Coordinate{Lng: point.Longitude, Lat: point.Latitude, Alt: altitudeMeters(point.Altitude)}
+	}
+	doc.Placemarks = append(doc.Placemarks, kml.Placemark{
+		Name:     track.FlightNumber,
+		StyleURL: "#track",
+		LineString: &kml.LineString{
+			Tessellate:   1,
+			AltitudeMode: kml.AltitudeAbsolute,
+			Coordinates:  coordinates,
+		},
+	})
+
+	first, last := track.Points[0], track.Points[len(track.Points)-1]
+	for _, mark := range []struct {
+		name  string
+		point TrackPoint
+		coord kml.Coordinate
+	}{
+		{"start", first, coordinates[0]},
+		{"end", last, coordinates[len(coordinates)-1]},
+	} {
+		doc.Placemarks = append(doc.Placemarks, kml.Placemark{
+			Name:        mark.name,
+			Description: mark.point.Timestamp.UTC().Format(time.RFC3339),
+			Point: &kml.Point{
+				AltitudeMode: kml.AltitudeAbsolute,
+				Coordinates:  kml.Coordinates{mark.coord},
+			},
+		})
+	}
+	return doc
+}
+
+// altitudeMeters 将英尺高度转换为米，缺失时为 0
+func altitudeMeters(feet *int) float64 {
+	if feet == nil {
+		return 0
+	}
+	return float64(*feet) * feetToMeters
+}
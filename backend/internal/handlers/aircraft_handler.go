@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AircraftHandler 飞机处理器接口
+type AircraftHandler interface {
+	ListAircraft(c *gin.Context)
+	GetAircraft(c *gin.Context)
+	CreateAircraft(c *gin.Context)
+	UpdateAircraft(c *gin.Context)
+	DeleteAircraft(c *gin.Context)
+	SendToMaintenance(c *gin.Context)
+	ReturnToService(c *gin.Context)
+	Retire(c *gin.Context)
+}
+
+type aircraftHandler struct {
+	service services.AircraftService
+}
+
+// NewAircraftHandler 创建飞机处理器实例
+func NewAircraftHandler(service services.AircraftService) AircraftHandler {
+	return &aircraftHandler{
+		service: service,
+	}
+}
+
+// ListAircraft 分页查询飞机
+// @Summary 飞机列表
+// @Tags 飞机
+// @Produce json
+// @Param airline_id query string false "航空公司ID"
+// @Param status query string false "状态 active/maintenance/retired"
+// @Param model query string false "机型"
+// @Param q query string false "搜索关键字"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.AircraftResponse]}
+// @Router /api/aircraft [get]
+func (h *aircraftHandler) ListAircraft(c *gin.Context) {
+	var query dto.AircraftQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AircraftHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListAircraft(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AircraftHandler] 获取飞机列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAircraft 获取飞机详情
+// @Summary 飞机详情
+// @Tags 飞机
+// @Produce json
+// @Param id path string true "飞机ID"
+// @Success 200 {object} response.Response{data=dto.AircraftResponse}
+// @Router /api/aircraft/{id} [get]
+func (h *aircraftHandler) GetAircraft(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	aircraft, err := h.service.GetAircraft(c.Request.Context(), id)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAircraftResponse(aircraft))
+}
+
+// CreateAircraft 创建飞机
+// @Summary 创建飞机
+// @Tags 飞机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateAircraftRequest true "飞机信息"
+// @Success 201 {object} response.Response{data=dto.AircraftResponse}
+// @Router /api/aircraft [post]
+func (h *aircraftHandler) CreateAircraft(c *gin.Context) {
+	var req dto.CreateAircraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AircraftHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	aircraft, err := h.service.CreateAircraft(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[AircraftHandler] 创建飞机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToAircraftResponse(aircraft))
+}
+
+// UpdateAircraft 更新飞机
+// @Summary 更新飞机
+// @Description 更新基础信息，状态需通过 maintenance/activate/retire 接口变更
+// @Tags 飞机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "飞机ID"
+// @Param request body dto.UpdateAircraftRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.AircraftResponse}
+// @Router /api/aircraft/{id} [put]
+func (h *aircraftHandler) UpdateAircraft(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAircraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AircraftHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	aircraft, err := h.service.UpdateAircraft(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[AircraftHandler] 更新飞机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAircraftResponse(aircraft))
+}
+
+// DeleteAircraft 删除飞机
+// @Summary 删除飞机
+// @Tags 飞机
+// @Produce json
+// @Security Bearer
+// @Param id path string true "飞机ID"
+// @Success 200 {object} response.Response
+// @Router /api/aircraft/{id} [delete]
+func (h *aircraftHandler) DeleteAircraft(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAircraft(c.Request.Context(), id); err != nil {
+		logger.Errorf("[AircraftHandler] 删除飞机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "飞机已删除", gin.H{"id": id})
+}
+
+// SendToMaintenance 飞机送修
+// @Summary 飞机送修
+// @Tags 飞机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "飞机ID"
+// @Param request body dto.AircraftStatusRequest false "变更原因"
+// @Success 200 {object} response.Response{data=dto.AircraftResponse}
+// @Router /api/aircraft/{id}/maintenance [post]
+func (h *aircraftHandler) SendToMaintenance(c *gin.Context) {
+	h.changeStatus(c, h.service.SendToMaintenance)
+}
+
+// ReturnToService 飞机恢复运营
+// @Summary 飞机恢复运营
+// @Tags 飞机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "飞机ID"
+// @Param request body dto.AircraftStatusRequest false "变更原因"
+// @Success 200 {object} response.Response{data=dto.AircraftResponse}
+// @Router /api/aircraft/{id}/activate [post]
+func (h *aircraftHandler) ReturnToService(c *gin.Context) {
+	h.changeStatus(c, h.service.ReturnToService)
+}
+
+// Retire 飞机退役
+// @Summary 飞机退役
+// @Tags 飞机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "飞机ID"
+// @Param request body dto.AircraftStatusRequest false "变更原因"
+// @Success 200 {object} response.Response{data=dto.AircraftResponse}
+// @Router /api/aircraft/{id}/retire [post]
+func (h *aircraftHandler) Retire(c *gin.Context) {
+	h.changeStatus(c, h.service.Retire)
+}
+
+// changeStatus 状态迁移接口的公共处理流程
+func (h *aircraftHandler) changeStatus(c *gin.Context, apply func(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error)) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.AircraftStatusRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Warnf("[AircraftHandler] 绑定请求失败: %v", err)
+			response.ValidationError(c, "无效的请求数据")
+			return
+		}
+	}
+
+	aircraft, err := apply(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		logger.Warnf("[AircraftHandler] 飞机状态变更失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAircraftResponse(aircraft))
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AirlineHandler 航空公司处理器接口
+type AirlineHandler interface {
+	ListAirlines(c *gin.Context)
+	GetAirline(c *gin.Context)
+	CreateAirline(c *gin.Context)
+	UpdateAirline(c *gin.Context)
+	DeleteAirline(c *gin.Context)
+	GetFleet(c *gin.Context)
+}
+
+type airlineHandler struct {
+	service services.AirlineService
+}
+
+// NewAirlineHandler 创建航空公司处理器实例
+func NewAirlineHandler(service services.AirlineService) AirlineHandler {
+	return &airlineHandler{
+		service: service,
+	}
+}
+
+// ListAirlines 分页查询航空公司
+// @Summary 航空公司列表
+// @Tags 航空公司
+// @Produce json
+// @Param country query string false "国家"
+// @Param type query string false "类型 passenger/cargo/charter"
+// @Param status query string false "状态"
+// @Param q query string false "搜索关键字"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.AirlineResponse]}
+// @Router /api/airlines [get]
+func (h *airlineHandler) ListAirlines(c *gin.Context) {
+	var query dto.AirlineQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AirlineHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListAirlines(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AirlineHandler] 获取航空公司列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAirline 获取航空公司详情
+// @Summary 航空公司详情
+// @Description 支持通过 UUID 或 IATA 代码查询
+// @Tags 航空公司
+// @Produce json
+// @Param id path string true "航空公司ID或IATA代码"
+// @Success 200 {object} response.Response{data=dto.AirlineResponse}
+// @Router /api/airlines/{id} [get]
+func (h *airlineHandler) GetAirline(c *gin.Context) {
+	airline, err := h.service.GetAirline(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAirlineResponse(airline))
+}
+
+// CreateAirline 创建航空公司
+// @Summary 创建航空公司
+// @Tags 航空公司
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateAirlineRequest true "航空公司信息"
+// @Success 201 {object} response.Response{data=dto.AirlineResponse}
+// @Router /api/airlines [post]
+func (h *airlineHandler) CreateAirline(c *gin.Context) {
+	var req dto.CreateAirlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AirlineHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	airline, err := h.service.CreateAirline(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[AirlineHandler] 创建航空公司失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToAirlineResponse(airline))
+}
+
+// UpdateAirline 更新航空公司
+// @Summary 更新航空公司
+// @Tags 航空公司
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航空公司ID"
+// @Param request body dto.UpdateAirlineRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.AirlineResponse}
+// @Router /api/airlines/{id} [put]
+func (h *airlineHandler) UpdateAirline(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAirlineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AirlineHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	airline, err := h.service.UpdateAirline(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[AirlineHandler] 更新航空公司失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAirlineResponse(airline))
+}
+
+// DeleteAirline 删除航空公司
+// @Summary 删除航空公司
+// @Tags 航空公司
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航空公司ID"
+// @Success 200 {object} response.Response
+// @Router /api/airlines/{id} [delete]
+func (h *airlineHandler) DeleteAirline(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAirline(c.Request.Context(), id); err != nil {
+		logger.Errorf("[AirlineHandler] 删除航空公司失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "航空公司已删除", gin.H{"id": id})
+}
+
+// GetFleet 获取航空公司机队
+// @Summary 航空公司机队
+// @Description 返回机队列表以及按机型、机龄、座位数的汇总
+// @Tags 航空公司
+// @Produce json
+// @Param id path string true "航空公司ID"
+// @Success 200 {object} response.Response{data=dto.FleetResponse}
+// @Router /api/airlines/{id}/fleet [get]
+func (h *airlineHandler) GetFleet(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	fleet, err := h.service.GetFleet(c.Request.Context(), id)
+	if err != nil {
+		logger.Errorf("[AirlineHandler] 获取机队失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, fleet)
+}
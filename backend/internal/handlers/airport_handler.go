@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AirportHandler 机场处理器接口
+type AirportHandler interface {
+	ListAirports(c *gin.Context)
+	GetAirport(c *gin.Context)
+	CreateAirport(c *gin.Context)
+	UpdateAirport(c *gin.Context)
+	DeleteAirport(c *gin.Context)
+	NearbyAirports(c *gin.Context)
+	AirportsInBBox(c *gin.Context)
+}
+
+type airportHandler struct {
+	service services.AirportService
+}
+
+// NewAirportHandler 创建机场处理器实例
+func NewAirportHandler(service services.AirportService) AirportHandler {
+	return &airportHandler{
+		service: service,
+	}
+}
+
+// ListAirports 分页查询机场
+// @Summary 机场列表
+// @Description 按国家/城市/类型/状态过滤，q 参数模糊匹配代码、中文名和英文名
+// @Tags 机场
+// @Produce json
+// @Param country query string false "国家"
+// @Param city query string false "城市"
+// @Param type query string false "类型 civil/military/mixed"
+// @Param status query string false "状态"
+// @Param q query string false "搜索关键字"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.AirportResponse]}
+// @Router /api/airports [get]
+func (h *airportHandler) ListAirports(c *gin.Context) {
+	var query dto.AirportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AirportHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListAirports(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AirportHandler] 获取机场列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAirport 获取机场详情
+// @Summary 机场详情
+// @Description 支持通过 UUID 或 IATA 代码查询
+// @Tags 机场
+// @Produce json
+// @Param id path string true "机场ID或IATA代码"
+// @Success 200 {object} response.Response{data=dto.AirportResponse}
+// @Router /api/airports/{id} [get]
+func (h *airportHandler) GetAirport(c *gin.Context) {
+	airport, err := h.service.GetAirport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAirportResponse(airport))
+}
+
+// CreateAirport 创建机场
+// @Summary 创建机场
+// @Tags 机场
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateAirportRequest true "机场信息"
+// @Success 201 {object} response.Response{data=dto.AirportResponse}
+// @Router /api/airports [post]
+func (h *airportHandler) CreateAirport(c *gin.Context) {
+	var req dto.CreateAirportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AirportHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	airport, err := h.service.CreateAirport(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[AirportHandler] 创建机场失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToAirportResponse(airport))
+}
+
+// UpdateAirport 更新机场
+// @Summary 更新机场
+// @Tags 机场
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "机场ID"
+// @Param request body dto.UpdateAirportRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.AirportResponse}
+// @Router /api/airports/{id} [put]
+func (h *airportHandler) UpdateAirport(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAirportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AirportHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	airport, err := h.service.UpdateAirport(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[AirportHandler] 更新机场失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAirportResponse(airport))
+}
+
+// DeleteAirport 删除机场
+// @Summary 删除机场
+// @Tags 机场
+// @Produce json
+// @Security Bearer
+// @Param id path string true "机场ID"
+// @Success 200 {object} response.Response
+// @Router /api/airports/{id} [delete]
+func (h *airportHandler) DeleteAirport(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAirport(c.Request.Context(), id); err != nil {
+		logger.Errorf("[AirportHandler] 删除机场失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "机场已删除", gin.H{"id": id})
+}
+
+// NearbyAirports 查询附近机场
+// @Summary 附近机场
+// @Description 返回半径范围内的机场，按大圆距离升序排列
+// @Tags 机场
+// @Produce json
+// @Param lat query number true "纬度"
+// @Param lng query number true "经度"
+// @Param radius_km query number false "半径（公里），默认 100"
+// @Param type query string false "类型"
+// @Param status query string false "状态"
+// @Param limit query int false "最大返回条数，默认 20"
+// @Success 200 {object} response.Response{data=[]dto.NearbyAirportResponse}
+// @Router /api/airports/nearby [get]
+func (h *airportHandler) NearbyAirports(c *gin.Context) {
+	var query dto.NearbyAirportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AirportHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	airports, err := h.service.NearbyAirports(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AirportHandler] 查询附近机场失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, airports)
+}
+
+// AirportsInBBox 查询地图视口内的机场
+// @Summary 视口内机场
+// @Description 按经纬度包围盒查询机场，用于地图平移时懒加载
+// @Tags 机场
+// @Produce json
+// @Param minLat query number true "最小纬度"
+// @Param minLng query number true "最小经度"
+// @Param maxLat query number true "最大纬度"
+// @Param maxLng query number true "最大经度"
+// @Param type query string false "类型"
+// @Param status query string false "状态"
+// @Param limit query int false "最大返回条数，默认 500"
+// @Success 200 {object} response.Response{data=[]dto.AirportResponse}
+// @Router /api/airports/bbox [get]
+func (h *airportHandler) AirportsInBBox(c *gin.Context) {
+	var query dto.BBoxAirportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AirportHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	airports, err := h.service.AirportsInBBox(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AirportHandler] 按范围查询机场失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, airports)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AirspaceHandler 空域查询处理器接口
+type AirspaceHandler interface {
+	Check(c *gin.Context)
+}
+
+type airspaceHandler struct {
+	service services.AirspaceService
+}
+
+// NewAirspaceHandler 创建空域查询处理器实例
+func NewAirspaceHandler(service services.AirspaceService) AirspaceHandler {
+	return &airspaceHandler{
+		service: service,
+	}
+}
+
+// Check 检查位置或航线能否飞行
+// @Summary 空域检查
+// @Description 传入单点（point）或航线（path，含高度剖面）及时间，返回生效并限制该位置的禁飞区、到最近禁飞区边界的距离和 go/no_go 结论
+// @Tags 空域
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.AirspaceCheckRequest true "检查位置与时间"
+// @Success 200 {object} response.Response{data=dto.AirspaceCheckResponse}
+// @Router /api/airspace/check [post]
+func (h *airspaceHandler) Check(c *gin.Context) {
+	var req dto.AirspaceCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[AirspaceHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	result, err := h.service.Check(c.Request.Context(), &req)
+	if err != nil {
+		logger.Warnf("[AirspaceHandler] 空域检查失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertHandler 告警处理器接口
+type AlertHandler interface {
+	ListAlerts(c *gin.Context)
+	GetAlert(c *gin.Context)
+	Acknowledge(c *gin.Context)
+	Resolve(c *gin.Context)
+}
+
+type alertHandler struct {
+	service services.AlertService
+}
+
+// NewAlertHandler 创建告警处理器实例
+func NewAlertHandler(service services.AlertService) AlertHandler {
+	return &alertHandler{
+		service: service,
+	}
+}
+
+// ListAlerts 分页查询告警
+// @Summary 告警列表
+// @Tags 告警
+// @Produce json
+// @Security Bearer
+// @Param type query string false "告警类型，多个以逗号分隔"
+// @Param status query string false "状态 open|acknowledged|resolved，多个以逗号分隔"
+// @Param severity query string false "级别 info|warning|critical，多个以逗号分隔"
+// @Param entity_type query string false "对象类型 flight|drone，同时匹配接近告警的关联对象"
+// @Param entity_id query string false "对象ID"
+// @Param from query string false "触发时间下限 RFC3339"
+// @Param to query string false "触发时间上限 RFC3339"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.AlertResponse]}
+// @Router /api/alerts [get]
+func (h *alertHandler) ListAlerts(c *gin.Context) {
+	var query dto.AlertQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AlertHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListAlerts(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AlertHandler] 获取告警列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAlert 获取告警详情
+// @Summary 告警详情
+// @Tags 告警
+// @Produce json
+// @Security Bearer
+// @Param id path string true "告警ID"
+// @Success 200 {object} response.Response{data=dto.AlertResponse}
+// @Router /api/alerts/{id} [get]
+func (h *alertHandler) GetAlert(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	alert, err := h.service.GetAlert(c.Request.Context(), id)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAlertResponse(alert))
+}
+
+// Acknowledge 确认告警
+// @Summary 确认告警
+// @Tags 告警
+// @Produce json
+// @Security Bearer
+// @Param id path string true "告警ID"
+// @Success 200 {object} response.Response{data=dto.AlertResponse}
+// @Router /api/alerts/{id}/acknowledge [post]
+func (h *alertHandler) Acknowledge(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	alert, err := h.service.Acknowledge(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Warnf("[AlertHandler] 确认告警失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAlertResponse(alert))
+}
+
+// Resolve 解除告警
+// @Summary 解除告警
+// @Tags 告警
+// @Produce json
+// @Security Bearer
+// @Param id path string true "告警ID"
+// @Success 200 {object} response.Response{data=dto.AlertResponse}
+// @Router /api/alerts/{id}/resolve [post]
+func (h *alertHandler) Resolve(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	alert, err := h.service.Resolve(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Warnf("[AlertHandler] 解除告警失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToAlertResponse(alert))
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler 统计分析处理器接口
+type AnalyticsHandler interface {
+	OTP(c *gin.Context)
+}
+
+type analyticsHandler struct {
+	service services.AnalyticsService
+}
+
+// NewAnalyticsHandler 创建统计分析处理器实例
+func NewAnalyticsHandler(service services.AnalyticsService) AnalyticsHandler {
+	return &analyticsHandler{
+		service: service,
+	}
+}
+
+// OTP 航班准点率统计
+// @Summary 航班准点率统计
+// @Description 基于航班历史按航空公司、航线或机场统计准点率、平均及分位延误、取消率和备降率，可按日/周/月分桶
+// @Tags 统计分析
+// @Produce json
+// @Security Bearer
+// @Param from query string false "开始日期 2006-01-02，默认 30 天前"
+// @Param to query string false "结束日期 2006-01-02，默认今天"
+// @Param group_by query string false "分组维度 airline|route|airport，默认 airline"
+// @Param interval query string false "时间粒度 day|week|month"
+// @Param airline query string false "航空公司IATA代码"
+// @Param airport query string false "出发或到达机场代码"
+// @Param departure query string false "出发机场代码"
+// @Param arrival query string false "到达机场代码"
+// @Param threshold query int false "准点判定阈值（分钟），默认 15"
+// @Success 200 {object} response.Response{data=dto.OTPResponse}
+// @Router /api/analytics/otp [get]
+func (h *analyticsHandler) OTP(c *gin.Context) {
+	var query dto.OTPQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[AnalyticsHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.OTP(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[AnalyticsHandler] 准点率统计失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
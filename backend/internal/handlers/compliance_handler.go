@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ComplianceHandler 运营商合规统计处理器接口
+type ComplianceHandler interface {
+	OperatorCompliance(c *gin.Context)
+	RankOperators(c *gin.Context)
+}
+
+type complianceHandler struct {
+	service services.ComplianceService
+}
+
+// NewComplianceHandler 创建运营商合规统计处理器实例
+func NewComplianceHandler(service services.ComplianceService) ComplianceHandler {
+	return &complianceHandler{
+		service: service,
+	}
+}
+
+// OperatorCompliance 运营商合规概况
+// @Summary 运营商合规概况
+// @Description 统计期内的飞行审批情况、禁飞区违规、按严重程度的事件分布，以及当前机队和许可证状态，并给出合规评分和扣分项；运营商用户仅能查看所属运营商
+// @Tags 运营商合规
+// @Produce json
+// @Security Bearer
+// @Param id path string true "运营商ID"
+// @Param from query string false "开始日期 2006-01-02，默认 30 天前"
+// @Param to query string false "结束日期 2006-01-02，默认今天"
+// @Success 200 {object} response.Response{data=dto.OperatorCompliance}
+// @Router /api/operators/{id}/compliance [get]
+func (h *complianceHandler) OperatorCompliance(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var query dto.ComplianceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[ComplianceHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.OperatorCompliance(c.Request.Context(), id, &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[ComplianceHandler] 获取运营商合规概况失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RankOperators 运营商合规排名
+// @Summary 运营商合规排名
+// @Description 按合规评分由低到高排名，评分最低的运营商排在第一位
+// @Tags 运营商合规
+// @Produce json
+// @Security Bearer
+// @Param from query string false "开始日期 2006-01-02，默认 30 天前"
+// @Param to query string false "结束日期 2006-01-02，默认今天"
+// @Param type query string false "运营商类型 commercial|government|personal"
+// @Param status query string false "运营商状态 active|suspended"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.OperatorCompliance]}
+// @Router /api/operators/compliance [get]
+func (h *complianceHandler) RankOperators(c *gin.Context) {
+	var query dto.ComplianceRankingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[ComplianceHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.RankOperators(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[ComplianceHandler] 获取运营商合规排名失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
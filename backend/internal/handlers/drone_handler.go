@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DroneHandler 无人机处理器接口
+type DroneHandler interface {
+	ListDrones(c *gin.Context)
+	GetDrone(c *gin.Context)
+	CreateDrone(c *gin.Context)
+	UpdateDrone(c *gin.Context)
+	DeleteDrone(c *gin.Context)
+	ChangeStatus(c *gin.Context)
+}
+
+type droneHandler struct {
+	service services.DroneService
+}
+
+// NewDroneHandler 创建无人机处理器实例
+func NewDroneHandler(service services.DroneService) DroneHandler {
+	return &droneHandler{
+		service: service,
+	}
+}
+
+// ListDrones 分页查询无人机
+// @Summary 无人机列表
+// @Description 运营商用户仅返回所属运营商的无人机
+// @Tags 无人机
+// @Produce json
+// @Security Bearer
+// @Param operator_id query string false "运营商ID（仅管理员有效）"
+// @Param status query string false "状态 idle|flying|maintenance|offline"
+// @Param model query string false "型号"
+// @Param q query string false "关键字（序列号/名称/型号）"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.DroneResponse]}
+// @Router /api/drones [get]
+func (h *droneHandler) ListDrones(c *gin.Context) {
+	var query dto.DroneQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[DroneHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListDrones(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[DroneHandler] 获取无人机列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetDrone 获取无人机详情
+// @Summary 无人机详情
+// @Tags 无人机
+// @Produce json
+// @Security Bearer
+// @Param id path string true "无人机ID"
+// @Success 200 {object} response.Response{data=dto.DroneResponse}
+// @Router /api/drones/{id} [get]
+func (h *droneHandler) GetDrone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	drone, err := h.service.GetDrone(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToDroneResponse(drone))
+}
+
+// CreateDrone 创建无人机
+// @Summary 创建无人机
+// @Description 运营商用户创建的无人机固定归属其运营商
+// @Tags 无人机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateDroneRequest true "无人机信息"
+// @Success 201 {object} response.Response{data=dto.DroneResponse}
+// @Router /api/drones [post]
+func (h *droneHandler) CreateDrone(c *gin.Context) {
+	var req dto.CreateDroneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[DroneHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	drone, err := h.service.CreateDrone(c.Request.Context(), &req, currentActor(c))
+	if err != nil {
+		logger.Errorf("[DroneHandler] 创建无人机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToDroneResponse(drone))
+}
+
+// UpdateDrone 更新无人机
+// @Summary 更新无人机
+// @Description 更新无人机信息，不包含状态；仅管理员可变更所属运营商
+// @Tags 无人机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "无人机ID"
+// @Param request body dto.UpdateDroneRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.DroneResponse}
+// @Router /api/drones/{id} [put]
+func (h *droneHandler) UpdateDrone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateDroneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[DroneHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	drone, err := h.service.UpdateDrone(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Errorf("[DroneHandler] 更新无人机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToDroneResponse(drone))
+}
+
+// DeleteDrone 删除无人机
+// @Summary 删除无人机
+// @Tags 无人机
+// @Produce json
+// @Security Bearer
+// @Param id path string true "无人机ID"
+// @Success 200 {object} response.Response
+// @Router /api/drones/{id} [delete]
+func (h *droneHandler) DeleteDrone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteDrone(c.Request.Context(), id, currentActor(c)); err != nil {
+		logger.Errorf("[DroneHandler] 删除无人机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "无人机已删除", gin.H{"id": id})
+}
+
+// ChangeStatus 变更无人机状态
+// @Summary 变更无人机状态
+// @Description 按状态机校验迁移：idle ⇄ flying，idle/offline ⇄ maintenance，任意状态可转为 offline
+// @Tags 无人机
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "无人机ID"
+// @Param request body dto.DroneStatusRequest true "目标状态"
+// @Success 200 {object} response.Response{data=dto.DroneResponse}
+// @Router /api/drones/{id}/status [post]
+func (h *droneHandler) ChangeStatus(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.DroneStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[DroneHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	drone, err := h.service.ChangeStatus(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[DroneHandler] 无人机状态变更失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToDroneResponse(drone))
+}
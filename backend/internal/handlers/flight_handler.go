@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/repositories"
+	"backend/internal/services"
+	"backend/pkg/apperr"
+	"backend/pkg/kml"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlightHandler 航班处理器接口
+type FlightHandler interface {
+	ListFlights(c *gin.Context)
+	GetFlight(c *gin.Context)
+	CreateFlight(c *gin.Context)
+	UpdateFlight(c *gin.Context)
+	DeleteFlight(c *gin.Context)
+	Departures(c *gin.Context)
+	Arrivals(c *gin.Context)
+	ChangeStatus(c *gin.Context)
+	ListStatusLogs(c *gin.Context)
+	Track(c *gin.Context)
+}
+
+type flightHandler struct {
+	service         services.FlightService
+	positionService services.FlightPositionService
+}
+
+// NewFlightHandler 创建航班处理器实例
+func NewFlightHandler(service services.FlightService, positionService services.FlightPositionService) FlightHandler {
+	return &flightHandler{
+		service:         service,
+		positionService: positionService,
+	}
+}
+
+// ListFlights 分页查询航班
+// @Summary 航班列表
+// @Tags 航班
+// @Produce json
+// @Param flight_number query string false "航班号（前缀匹配）"
+// @Param airline query string false "航空公司ID或IATA代码"
+// @Param status query string false "状态，多个以逗号分隔"
+// @Param date query string false "计划起飞日期 2006-01-02"
+// @Param from query string false "计划起飞时间下限 RFC3339"
+// @Param to query string false "计划起飞时间上限 RFC3339"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.FlightResponse]}
+// @Router /api/flights [get]
+func (h *flightHandler) ListFlights(c *gin.Context) {
+	var query dto.FlightQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[FlightHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListFlights(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[FlightHandler] 获取航班列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetFlight 获取航班详情
+// @Summary 航班详情
+// @Tags 航班
+// @Produce json
+// @Param id path string true "航班ID"
+// @Success 200 {object} response.Response{data=dto.FlightResponse}
+// @Router /api/flights/{id} [get]
+func (h *flightHandler) GetFlight(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	flight, err := h.service.GetFlight(c.Request.Context(), id)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToFlightResponse(flight))
+}
+
+// CreateFlight 创建航班
+// @Summary 创建航班
+// @Tags 航班
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateFlightRequest true "航班信息"
+// @Success 201 {object} response.Response{data=dto.FlightResponse}
+// @Router /api/flights [post]
+func (h *flightHandler) CreateFlight(c *gin.Context) {
+	var req dto.CreateFlightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[FlightHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	flight, err := h.service.CreateFlight(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[FlightHandler] 创建航班失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToFlightResponse(flight))
+}
+
+// UpdateFlight 更新航班
+// @Summary 更新航班
+// @Description 更新航班计划信息，不包含状态
+// @Tags 航班
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Param request body dto.UpdateFlightRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.FlightResponse}
+// @Router /api/flights/{id} [put]
+func (h *flightHandler) UpdateFlight(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateFlightRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[FlightHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	flight, err := h.service.UpdateFlight(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[FlightHandler] 更新航班失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToFlightResponse(flight))
+}
+
+// DeleteFlight 删除航班
+// @Summary 删除航班
+// @Tags 航班
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Success 200 {object} response.Response
+// @Router /api/flights/{id} [delete]
+func (h *flightHandler) DeleteFlight(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteFlight(c.Request.Context(), id); err != nil {
+		logger.Errorf("[FlightHandler] 删除航班失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "航班已删除", gin.H{"id": id})
+}
+
+// ChangeStatus 变更航班状态
+// @Summary 变更航班状态
+// @Description 按状态机校验迁移，记录操作人；到达或取消时写入航班历史
+// @Tags 航班
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Param request body dto.FlightStatusRequest true "目标状态"
+// @Success 200 {object} response.Response{data=dto.FlightResponse}
+// @Router /api/flights/{id}/status [post]
+func (h *flightHandler) ChangeStatus(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.FlightStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[FlightHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	flight, err := h.service.ChangeStatus(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[FlightHandler] 航班状态变更失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToFlightResponse(flight))
+}
+
+// ListStatusLogs 航班状态变更记录
+// @Summary 航班状态变更记录
+// @Tags 航班
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Success 200 {object} response.Response{data=[]dto.FlightStatusLogResponse}
+// @Router /api/flights/{id}/status-logs [get]
+func (h *flightHandler) ListStatusLogs(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	logs, err := h.service.ListStatusLogs(c.Request.Context(), id)
+	if err != nil {
+		logger.Errorf("[FlightHandler] 获取航班状态变更记录失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToFlightStatusLogResponseList(logs))
+}
+
+// Track 航班航迹回放
+// @Summary 航班航迹回放
+// @Description 按时间升序返回航班位置点，超过 max_points 时按 Douglas-Peucker（dp）或时间分桶（bucket）降采样；format 可选 json、geojson、kml
+// @Tags 航班
+// @Produce json
+// @Produce application/geo+json
+// @Produce application/vnd.google-earth.kml+xml
+// @Param id path string true "航班ID"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param max_points query int false "最大点数，默认 1000"
+// @Param method query string false "降采样方式 dp|bucket"
+// @Param format query string false "输出格式 json|geojson|kml"
+// @Success 200 {object} response.Response{data=dto.TrackResponse}
+// @Router /api/flights/{id}/track [get]
+func (h *flightHandler) Track(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var query dto.TrackQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[FlightHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	track, err := h.positionService.Track(c.Request.Context(), id, &query)
+	if err != nil {
+		logger.Errorf("[FlightHandler] 获取航班航迹失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	switch query.Format {
+	case dto.TrackFormatGeoJSON:
+		c.Header("Content-Type", "application/geo+json")
+		c.JSON(http.StatusOK, dto.ToTrackFeature(track))
+	case dto.TrackFormatKML:
+		var buf bytes.Buffer
+		if err := kml.Encode(&buf, dto.ToTrackKML(track)); err != nil {
+			logger.Errorf("[FlightHandler] 生成 KML 失败: %v", err)
+			response.Fail(c, apperr.NewInternalError(err))
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-track.kml"`, track.FlightNumber))
+		c.Data(http.StatusOK, kml.ContentType, buf.Bytes())
+	default:
+		response.Success(c, track)
+	}
+}
+
+// Departures 机场出发航班显示屏
+// @Summary 机场出发航班
+// @Description 默认返回当前时间前 2 小时至后 12 小时内的出发航班，按计划起飞时间排序
+// @Tags 航班
+// @Produce json
+// @Param id path string true "机场ID或IATA代码"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param status query string false "状态，多个以逗号分隔"
+// @Param limit query int false "返回数量上限"
+// @Success 200 {object} response.Response{data=dto.FlightBoardResponse}
+// @Router /api/airports/{id}/departures [get]
+func (h *flightHandler) Departures(c *gin.Context) {
+	h.board(c, repositories.BoardDepartures)
+}
+
+// Arrivals 机场到达航班显示屏
+// @Summary 机场到达航班
+// @Description 默认返回当前时间前 2 小时至后 12 小时内的到达航班，按计划到达时间排序
+// @Tags 航班
+// @Produce json
+// @Param id path string true "机场ID或IATA代码"
+// @Param from query string false "开始时间 RFC3339"
+// @Param to query string false "结束时间 RFC3339"
+// @Param status query string false "状态，多个以逗号分隔"
+// @Param limit query int false "返回数量上限"
+// @Success 200 {object} response.Response{data=dto.FlightBoardResponse}
+// @Router /api/airports/{id}/arrivals [get]
+func (h *flightHandler) Arrivals(c *gin.Context) {
+	h.board(c, repositories.BoardArrivals)
+}
+
+// board 显示屏接口的公共处理流程
+func (h *flightHandler) board(c *gin.Context, direction repositories.BoardDirection) {
+	var query dto.FlightBoardQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[FlightHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	board, err := h.service.Board(c.Request.Context(), c.Param("id"), direction, &query)
+	if err != nil {
+		logger.Errorf("[FlightHandler] 获取机场航班显示数据失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, board)
+}
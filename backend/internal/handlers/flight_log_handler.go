@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlightLogHandler 飞行日志处理器接口
+type FlightLogHandler interface {
+	ListByDrone(c *gin.Context)
+	GetLog(c *gin.Context)
+}
+
+type flightLogHandler struct {
+	service services.FlightLogService
+}
+
+// NewFlightLogHandler 创建飞行日志处理器实例
+func NewFlightLogHandler(service services.FlightLogService) FlightLogHandler {
+	return &flightLogHandler{
+		service: service,
+	}
+}
+
+// ListByDrone 分页查询无人机飞行日志
+// @Summary 无人机飞行日志列表
+// @Description 飞行日志在任务结束或无人机降落时由位置数据自动生成，按降落时间倒序，列表不含轨迹
+// @Tags 飞行日志
+// @Produce json
+// @Security Bearer
+// @Param id path string true "无人机ID"
+// @Param mission_id query string false "任务ID"
+// @Param from query string false "降落时间下限（RFC3339）"
+// @Param to query string false "降落时间上限（RFC3339）"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.FlightLogResponse]}
+// @Router /api/drones/{id}/flight-logs [get]
+func (h *flightLogHandler) ListByDrone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var query dto.FlightLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[FlightLogHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListByDrone(c.Request.Context(), id, &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[FlightLogHandler] 获取飞行日志列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetLog 获取飞行日志详情
+// @Summary 飞行日志详情
+// @Description 包含简化后的飞行轨迹、事件、警告和质量评分
+// @Tags 飞行日志
+// @Produce json
+// @Security Bearer
+// @Param id path string true "飞行日志ID"
+// @Success 200 {object} response.Response{data=dto.FlightLogResponse}
+// @Router /api/flight-logs/{id} [get]
+func (h *flightLogHandler) GetLog(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	log, err := h.service.GetLog(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Errorf("[FlightLogHandler] 获取飞行日志失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToFlightLogResponse(log, true))
+}
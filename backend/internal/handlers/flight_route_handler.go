@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlightRouteHandler 航班计划航线处理器接口
+type FlightRouteHandler interface {
+	GetRoute(c *gin.Context)
+	ReplaceRoute(c *gin.Context)
+	UpdateWaypoint(c *gin.Context)
+	DeleteRoute(c *gin.Context)
+}
+
+type flightRouteHandler struct {
+	service services.FlightRouteService
+}
+
+// NewFlightRouteHandler 创建航班计划航线处理器实例
+func NewFlightRouteHandler(service services.FlightRouteService) FlightRouteHandler {
+	return &flightRouteHandler{
+		service: service,
+	}
+}
+
+// GetRoute 获取航班计划航线
+// @Summary 航班计划航线
+// @Description 按顺序返回航点及实际过点时间；航班有当前位置时附带偏航距离
+// @Tags 航班
+// @Produce json
+// @Param id path string true "航班ID"
+// @Success 200 {object} response.Response{data=dto.FlightRouteResponse}
+// @Router /api/flights/{id}/route [get]
+func (h *flightRouteHandler) GetRoute(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	route, err := h.service.GetRoute(c.Request.Context(), id)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, route)
+}
+
+// ReplaceRoute 设置航班计划航线
+// @Summary 设置航班计划航线
+// @Description 用请求中的航点整体替换原有航线，航点顺序即数组顺序
+// @Tags 航班
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Param request body dto.ReplaceFlightRouteRequest true "航点列表"
+// @Success 200 {object} response.Response{data=dto.FlightRouteResponse}
+// @Router /api/flights/{id}/route [put]
+func (h *flightRouteHandler) ReplaceRoute(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.ReplaceFlightRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[FlightRouteHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	route, err := h.service.ReplaceRoute(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[FlightRouteHandler] 设置航班计划航线失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, route)
+}
+
+// UpdateWaypoint 更新航点
+// @Summary 更新航点
+// @Tags 航班
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Param waypointId path string true "航点ID"
+// @Param request body dto.UpdateFlightWaypointRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.FlightWaypointResponse}
+// @Router /api/flights/{id}/route/waypoints/{waypointId} [put]
+func (h *flightRouteHandler) UpdateWaypoint(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	waypointID, ok := parseUUIDParam(c, "waypointId")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateFlightWaypointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[FlightRouteHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	waypoint, err := h.service.UpdateWaypoint(c.Request.Context(), id, waypointID, &req)
+	if err != nil {
+		logger.Errorf("[FlightRouteHandler] 更新航点失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToFlightWaypointResponse(waypoint))
+}
+
+// DeleteRoute 删除航班计划航线
+// @Summary 删除航班计划航线
+// @Tags 航班
+// @Produce json
+// @Security Bearer
+// @Param id path string true "航班ID"
+// @Success 200 {object} response.Response
+// @Router /api/flights/{id}/route [delete]
+func (h *flightRouteHandler) DeleteRoute(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRoute(c.Request.Context(), id); err != nil {
+		logger.Errorf("[FlightRouteHandler] 删除航班计划航线失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "计划航线已删除", gin.H{"flight_id": id})
+}
@@ -2,8 +2,26 @@ package handlers
 
 // Handlers 聚合所有 HTTP 处理器
 type Handlers struct {
-	Task    TaskHandler
-	User    UserHandler
-	Health  HealthHandler
-	Captcha CaptchaHandler
+	Task        TaskHandler
+	User        UserHandler
+	Health      HealthHandler
+	Captcha     CaptchaHandler
+	Airport     AirportHandler
+	Airline     AirlineHandler
+	Aircraft    AircraftHandler
+	Flight      FlightHandler
+	FlightRoute FlightRouteHandler
+	Ingest      IngestHandler
+	Stream      StreamHandler
+	Alert       AlertHandler
+	Analytics   AnalyticsHandler
+	Operator    OperatorHandler
+	Drone       DroneHandler
+	Mission     MissionHandler
+	NoFlyZone   NoFlyZoneHandler
+	Airspace    AirspaceHandler
+	FlightLog   FlightLogHandler
+	Incident    IncidentHandler
+	Compliance  ComplianceHandler
+	Maintenance MaintenanceHandler
 }
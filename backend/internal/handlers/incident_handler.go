@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IncidentHandler 无人机事件处理器接口
+type IncidentHandler interface {
+	ListIncidents(c *gin.Context)
+	GetIncident(c *gin.Context)
+	FileIncident(c *gin.Context)
+	ConfirmIncident(c *gin.Context)
+	DismissIncident(c *gin.Context)
+	AssignInvestigator(c *gin.Context)
+	StartInvestigation(c *gin.Context)
+	CloseIncident(c *gin.Context)
+}
+
+type incidentHandler struct {
+	service services.IncidentService
+}
+
+// NewIncidentHandler 创建无人机事件处理器实例
+func NewIncidentHandler(service services.IncidentService) IncidentHandler {
+	return &incidentHandler{
+		service: service,
+	}
+}
+
+// ListIncidents 分页查询无人机事件
+// @Summary 无人机事件列表
+// @Description 按严重程度由重到轻、发生时间倒序；open=true 时仅返回未结案事件；运营商用户和飞手仅返回所属运营商的事件
+// @Tags 无人机事件
+// @Produce json
+// @Security Bearer
+// @Param status query string false "调查状态 draft|pending|investigating|closed|dismissed，多个以逗号分隔"
+// @Param open query bool false "仅已确认未结案的事件"
+// @Param severity query string false "严重程度 minor|moderate|serious|critical，多个以逗号分隔"
+// @Param type query string false "事件类型，多个以逗号分隔"
+// @Param operator_id query string false "运营商ID（仅管理员和监管人员有效）"
+// @Param drone_id query string false "无人机ID"
+// @Param mission_id query string false "任务ID"
+// @Param investigator_id query string false "调查人ID"
+// @Param from query string false "发生时间下限 RFC3339"
+// @Param to query string false "发生时间上限 RFC3339"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.IncidentResponse]}
+// @Router /api/incidents [get]
+func (h *incidentHandler) ListIncidents(c *gin.Context) {
+	var query dto.IncidentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[IncidentHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListIncidents(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[IncidentHandler] 获取事件列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetIncident 获取无人机事件详情
+// @Summary 无人机事件详情
+// @Tags 无人机事件
+// @Produce json
+// @Security Bearer
+// @Param id path string true "事件ID"
+// @Success 200 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents/{id} [get]
+func (h *incidentHandler) GetIncident(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	incident, err := h.service.GetIncident(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Errorf("[IncidentHandler] 获取事件失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToIncidentResponse(incident))
+}
+
+// FileIncident 上报无人机事件
+// @Summary 上报无人机事件
+// @Description 可关联无人机、任务或运营商，关联任务时无人机和运营商随任务确定；新事件处于 pending 状态
+// @Tags 无人机事件
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateIncidentRequest true "事件信息"
+// @Success 201 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents [post]
+func (h *incidentHandler) FileIncident(c *gin.Context) {
+	var req dto.CreateIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IncidentHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	incident, err := h.service.FileIncident(c.Request.Context(), &req, currentActor(c))
+	if err != nil {
+		logger.Errorf("[IncidentHandler] 上报事件失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToIncidentResponse(incident))
+}
+
+// ConfirmIncident 确认事件草稿
+// @Summary 确认事件草稿
+// @Description draft -> pending，遥测自动检测的事件经人工确认后进入调查流程，可修正描述；类型和严重程度仅监管人员、管理员可以修正
+// @Tags 无人机事件
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "事件ID"
+// @Param request body dto.ConfirmIncidentRequest true "确认内容"
+// @Success 200 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents/{id}/confirm [post]
+func (h *incidentHandler) ConfirmIncident(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.ConfirmIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IncidentHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	incident, err := h.service.ConfirmIncident(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[IncidentHandler] 确认事件失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToIncidentResponse(incident))
+}
+
+// DismissIncident 驳回事件草稿
+// @Summary 驳回事件草稿
+// @Description draft -> dismissed，自动检测的误报由监管人员或管理员驳回，驳回原因记入调查记录
+// @Tags 无人机事件
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "事件ID"
+// @Param request body dto.DismissIncidentRequest true "驳回原因"
+// @Success 200 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents/{id}/dismiss [post]
+func (h *incidentHandler) DismissIncident(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.DismissIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IncidentHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	incident, err := h.service.DismissIncident(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[IncidentHandler] 驳回事件失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToIncidentResponse(incident))
+}
+
+// AssignInvestigator 指派调查人
+// @Summary 指派调查人
+// @Description 调查人须为监管人员或管理员，已确认且未结案的事件可以更换调查人
+// @Tags 无人机事件
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "事件ID"
+// @Param request body dto.AssignInvestigatorRequest true "调查人"
+// @Success 200 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents/{id}/assign [post]
+func (h *incidentHandler) AssignInvestigator(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.AssignInvestigatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IncidentHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	incident, err := h.service.AssignInvestigator(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[IncidentHandler] 指派调查人失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToIncidentResponse(incident))
+}
+
+// StartInvestigation 开始调查
+// @Summary 开始调查
+// @Description pending -> investigating，需已指派调查人并填写调查计划，仅调查人或管理员可操作
+// @Tags 无人机事件
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "事件ID"
+// @Param request body dto.StartInvestigationRequest true "调查计划"
+// @Success 200 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents/{id}/investigate [post]
+func (h *incidentHandler) StartInvestigation(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.StartInvestigationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IncidentHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	incident, err := h.service.StartInvestigation(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[IncidentHandler] 开始调查失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToIncidentResponse(incident))
+}
+
+// CloseIncident 结案
+// @Summary 事件结案
+// @Description investigating -> closed，需填写根本原因和纠正措施；严重及以上或有人员受伤的事件须已上报主管部门并填写案件编号
+// @Tags 无人机事件
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "事件ID"
+// @Param request body dto.CloseIncidentRequest true "调查结论"
+// @Success 200 {object} response.Response{data=dto.IncidentResponse}
+// @Router /api/incidents/{id}/close [post]
+func (h *incidentHandler) CloseIncident(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CloseIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IncidentHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	incident, err := h.service.CloseIncident(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[IncidentHandler] 结案失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToIncidentResponse(incident))
+}
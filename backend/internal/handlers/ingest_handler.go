@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// telemetryStreamReadLimit 遥测流单条消息大小上限（字节）
+const telemetryStreamReadLimit = 1 << 20
+
+// IngestHandler 数据接入处理器接口
+type IngestHandler interface {
+	IngestFlightPositions(c *gin.Context)
+	IngestDroneTelemetry(c *gin.Context)
+	StreamDroneTelemetry(c *gin.Context)
+}
+
+type ingestHandler struct {
+	flightPositions services.FlightPositionService
+	droneTelemetry  services.DroneTelemetryService
+	upgrader        websocket.Upgrader
+}
+
+// NewIngestHandler 创建数据接入处理器实例
+func NewIngestHandler(flightPositions services.FlightPositionService, droneTelemetry services.DroneTelemetryService) IngestHandler {
+	return &ingestHandler{
+		flightPositions: flightPositions,
+		droneTelemetry:  droneTelemetry,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 1024,
+			CheckOrigin:     checkStreamOrigin,
+		},
+	}
+}
+
+// IngestFlightPositions 批量上报航班位置
+// @Summary 批量上报航班位置
+// @Description 按航班号或 ICAO 地址关联执行中的航班，逐条校验，返回接收与拒绝统计
+// @Tags 数据接入
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.IngestFlightPositionsRequest true "位置报告"
+// @Success 200 {object} response.Response{data=dto.IngestResult}
+// @Router /api/ingest/flight-positions [post]
+func (h *ingestHandler) IngestFlightPositions(c *gin.Context) {
+	var req dto.IngestFlightPositionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IngestHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	result, err := h.flightPositions.IngestPositions(c.Request.Context(), req.Positions)
+	if err != nil {
+		logger.Errorf("[IngestHandler] 航班位置上报失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// IngestDroneTelemetry 批量上报无人机遥测
+// @Summary 批量上报无人机遥测
+// @Description 按无人机 ID 或序列号关联无人机，写入位置并刷新最近位置；新位置点进入有效禁飞区或飞出执行中任务的飞行区域时实时触发告警
+// @Tags 数据接入
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.IngestDroneTelemetryRequest true "遥测报告"
+// @Success 200 {object} response.Response{data=dto.DroneIngestResult}
+// @Router /api/ingest/drone-telemetry [post]
+func (h *ingestHandler) IngestDroneTelemetry(c *gin.Context) {
+	var req dto.IngestDroneTelemetryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[IngestHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	result, err := h.droneTelemetry.Ingest(c.Request.Context(), req.Positions)
+	if err != nil {
+		logger.Errorf("[IngestHandler] 无人机遥测上报失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// StreamDroneTelemetry 通过 WebSocket 持续上报无人机遥测
+// @Summary 无人机遥测流
+// @Description WebSocket 连接，每条消息为单个遥测报告或报告数组（不超过 5000 条），服务端逐条消息回复 dto.DroneIngestResult，出错时回复 {"type":"error","error":"..."}
+// @Tags 数据接入
+// @Security Bearer
+// @Success 101 {object} dto.DroneIngestResult
+// @Router /api/ingest/drone-telemetry/stream [get]
+func (h *ingestHandler) StreamDroneTelemetry(c *gin.Context) {
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Warnf("[IngestHandler] WebSocket 升级失败: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	username := c.GetString("username")
+	logger.Infof("[IngestHandler] 遥测流建立: user=%s", username)
+
+	conn.SetReadLimit(telemetryStreamReadLimit)
+	conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	go pingTelemetryStream(conn, done)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		conn.SetReadDeadline(time.Now().Add(streamPongWait))
+
+		reply := h.ingestTelemetryMessage(c.Request.Context(), data)
+		conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+		if err := conn.WriteJSON(reply); err != nil {
+			break
+		}
+	}
+
+	logger.Infof("[IngestHandler] 遥测流结束: user=%s", username)
+}
+
+// ingestTelemetryMessage 处理遥测流中的一条消息，返回需要回复客户端的内容
+func (h *ingestHandler) ingestTelemetryMessage(ctx context.Context, data []byte) any {
+	var reports []dto.DroneTelemetryReport
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &reports); err != nil {
+			return gin.H{"type": "error", "error": "无效的遥测数据"}
+		}
+	} else {
+		var report dto.DroneTelemetryReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return gin.H{"type": "error", "error": "无效的遥测数据"}
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 || len(reports) > dto.MaxIngestBatchSize {
+		return gin.H{"type": "error", "error": "单条消息的遥测数量应为 1-5000"}
+	}
+
+	result, err := h.droneTelemetry.Ingest(ctx, reports)
+	if err != nil {
+		logger.Errorf("[IngestHandler] 无人机遥测上报失败: %v", err)
+		return gin.H{"type": "error", "error": err.Error()}
+	}
+	return result
+}
+
+// pingTelemetryStream 定期发送 WebSocket 心跳，done 关闭或写入失败时返回
+func pingTelemetryStream(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
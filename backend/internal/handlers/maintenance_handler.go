@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceHandler 无人机维护处理器接口
+type MaintenanceHandler interface {
+	ListRules(c *gin.Context)
+	GetRule(c *gin.Context)
+	CreateRule(c *gin.Context)
+	UpdateRule(c *gin.Context)
+	DeleteRule(c *gin.Context)
+	DroneStatus(c *gin.Context)
+	ListDue(c *gin.Context)
+	ListWorkOrders(c *gin.Context)
+	GetWorkOrder(c *gin.Context)
+	OpenWorkOrder(c *gin.Context)
+	SignOffWorkOrder(c *gin.Context)
+	CancelWorkOrder(c *gin.Context)
+}
+
+type maintenanceHandler struct {
+	service services.MaintenanceService
+}
+
+// NewMaintenanceHandler 创建无人机维护处理器实例
+func NewMaintenanceHandler(service services.MaintenanceService) MaintenanceHandler {
+	return &maintenanceHandler{
+		service: service,
+	}
+}
+
+// ListRules 获取维护规则列表
+// @Summary 维护规则列表
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param model query string false "适用机型"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.MaintenanceRuleResponse]}
+// @Router /api/maintenance/rules [get]
+func (h *maintenanceHandler) ListRules(c *gin.Context) {
+	var query dto.MaintenanceRuleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[MaintenanceHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListRules(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 获取维护规则列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetRule 获取维护规则详情
+// @Summary 维护规则详情
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param id path string true "规则ID"
+// @Success 200 {object} response.Response{data=dto.MaintenanceRuleResponse}
+// @Router /api/maintenance/rules/{id} [get]
+func (h *maintenanceHandler) GetRule(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	rule, err := h.service.GetRule(c.Request.Context(), id)
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 获取维护规则失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToMaintenanceRuleResponse(rule))
+}
+
+// CreateRule 创建维护规则
+// @Summary 创建维护规则
+// @Description 按机型定义维护间隔，飞行小时、飞行次数、日历天数中任一项达到即需维护，至少设置一项
+// @Tags 无人机维护
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateMaintenanceRuleRequest true "规则信息"
+// @Success 201 {object} response.Response{data=dto.MaintenanceRuleResponse}
+// @Router /api/maintenance/rules [post]
+func (h *maintenanceHandler) CreateRule(c *gin.Context) {
+	var req dto.CreateMaintenanceRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MaintenanceHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	rule, err := h.service.CreateRule(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 创建维护规则失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToMaintenanceRuleResponse(rule))
+}
+
+// UpdateRule 更新维护规则
+// @Summary 更新维护规则
+// @Description 未填写的字段保持不变，间隔填 0 表示取消该项
+// @Tags 无人机维护
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "规则ID"
+// @Param request body dto.UpdateMaintenanceRuleRequest true "规则信息"
+// @Success 200 {object} response.Response{data=dto.MaintenanceRuleResponse}
+// @Router /api/maintenance/rules/{id} [put]
+func (h *maintenanceHandler) UpdateRule(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMaintenanceRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MaintenanceHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	rule, err := h.service.UpdateRule(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 更新维护规则失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToMaintenanceRuleResponse(rule))
+}
+
+// DeleteRule 删除维护规则
+// @Summary 删除维护规则
+// @Description 已有工单记录的规则不能删除
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param id path string true "规则ID"
+// @Success 200 {object} response.Response
+// @Router /api/maintenance/rules/{id} [delete]
+func (h *maintenanceHandler) DeleteRule(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRule(c.Request.Context(), id); err != nil {
+		logger.Errorf("[MaintenanceHandler] 删除维护规则失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "维护规则已删除", gin.H{"id": id})
+}
+
+// DroneStatus 获取无人机维护状态
+// @Summary 无人机维护状态
+// @Description 按适用机型的各条规则计算自上次签核以来的飞行小时、飞行次数和天数，使用 90% 为 due，达到间隔为 overdue
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param id path string true "无人机ID"
+// @Success 200 {object} response.Response{data=dto.DroneMaintenanceStatus}
+// @Router /api/drones/{id}/maintenance [get]
+func (h *maintenanceHandler) DroneStatus(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.DroneStatus(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 获取无人机维护状态失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListDue 获取待维护无人机列表
+// @Summary 待维护无人机列表
+// @Description 返回即将到期和已超期的无人机，超期优先；超期的无人机不能通过任务审批或开始执行任务
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param operator_id query string false "运营商ID（运营商用户忽略）"
+// @Param status query string false "due|overdue，默认两者"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.DroneMaintenanceStatus]}
+// @Router /api/maintenance/due [get]
+func (h *maintenanceHandler) ListDue(c *gin.Context) {
+	var query dto.MaintenanceDueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[MaintenanceHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListDue(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 获取待维护无人机失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListWorkOrders 获取维护工单列表
+// @Summary 维护工单列表
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param status query string false "状态，多个以逗号分隔 open|completed|cancelled"
+// @Param operator_id query string false "运营商ID（运营商用户忽略）"
+// @Param drone_id query string false "无人机ID"
+// @Param rule_id query string false "规则ID"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.WorkOrderResponse]}
+// @Router /api/maintenance/work-orders [get]
+func (h *maintenanceHandler) ListWorkOrders(c *gin.Context) {
+	var query dto.WorkOrderQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[MaintenanceHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListWorkOrders(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 获取维护工单列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetWorkOrder 获取维护工单详情
+// @Summary 维护工单详情
+// @Tags 无人机维护
+// @Produce json
+// @Security Bearer
+// @Param id path string true "工单ID"
+// @Success 200 {object} response.Response{data=dto.WorkOrderResponse}
+// @Router /api/maintenance/work-orders/{id} [get]
+func (h *maintenanceHandler) GetWorkOrder(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	order, err := h.service.GetWorkOrder(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 获取维护工单失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToWorkOrderResponse(order))
+}
+
+// OpenWorkOrder 开立维护工单
+// @Summary 开立维护工单
+// @Description 关联规则时规则须适用于该无人机机型，同一无人机同一规则只能有一张未关闭的工单
+// @Tags 无人机维护
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateWorkOrderRequest true "工单信息"
+// @Success 201 {object} response.Response{data=dto.WorkOrderResponse}
+// @Router /api/maintenance/work-orders [post]
+func (h *maintenanceHandler) OpenWorkOrder(c *gin.Context) {
+	var req dto.CreateWorkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MaintenanceHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	order, err := h.service.OpenWorkOrder(c.Request.Context(), &req, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MaintenanceHandler] 开立维护工单失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToWorkOrderResponse(order))
+}
+
+// SignOffWorkOrder 签核维护工单
+// @Summary 签核维护工单
+// @Description open -> completed，须由开单人以外的人员签核；签核后该规则从当前累计飞行时间和次数重新计算周期
+// @Tags 无人机维护
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "工单ID"
+// @Param request body dto.SignOffWorkOrderRequest true "维护内容"
+// @Success 200 {object} response.Response{data=dto.WorkOrderResponse}
+// @Router /api/maintenance/work-orders/{id}/sign-off [post]
+func (h *maintenanceHandler) SignOffWorkOrder(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.SignOffWorkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MaintenanceHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	order, err := h.service.SignOffWorkOrder(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[MaintenanceHandler] 签核维护工单失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToWorkOrderResponse(order))
+}
+
+// CancelWorkOrder 取消维护工单
+// @Summary 取消维护工单
+// @Description open -> cancelled，须说明原因
+// @Tags 无人机维护
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "工单ID"
+// @Param request body dto.CancelWorkOrderRequest true "取消原因"
+// @Success 200 {object} response.Response{data=dto.WorkOrderResponse}
+// @Router /api/maintenance/work-orders/{id}/cancel [post]
+func (h *maintenanceHandler) CancelWorkOrder(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CancelWorkOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MaintenanceHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	order, err := h.service.CancelWorkOrder(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[MaintenanceHandler] 取消维护工单失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToWorkOrderResponse(order))
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MissionHandler 无人机任务处理器接口
+type MissionHandler interface {
+	ListMissions(c *gin.Context)
+	GetMission(c *gin.Context)
+	SubmitMission(c *gin.Context)
+	UpdateMission(c *gin.Context)
+	Approve(c *gin.Context)
+	Reject(c *gin.Context)
+	Start(c *gin.Context)
+	Complete(c *gin.Context)
+	Cancel(c *gin.Context)
+	ListLogs(c *gin.Context)
+	Conflicts(c *gin.Context)
+}
+
+type missionHandler struct {
+	service services.MissionService
+}
+
+// NewMissionHandler 创建无人机任务处理器实例
+func NewMissionHandler(service services.MissionService) MissionHandler {
+	return &missionHandler{
+		service: service,
+	}
+}
+
+// missionAction 任务审批与执行操作的服务方法签名
+type missionAction func(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor services.Actor) (*models.DroneMission, error)
+
+// ListMissions 分页查询无人机任务
+// @Summary 无人机任务列表
+// @Description 运营商用户和飞手仅返回所属运营商的任务
+// @Tags 无人机任务
+// @Produce json
+// @Security Bearer
+// @Param status query string false "任务状态，多个以逗号分隔"
+// @Param approval_status query string false "审批状态 pending|approved|rejected|waived，多个以逗号分隔"
+// @Param drone_id query string false "无人机ID"
+// @Param operator_id query string false "运营商ID（仅管理员和监管人员有效）"
+// @Param pilot_id query string false "飞手ID"
+// @Param from query string false "计划开始时间下限 RFC3339"
+// @Param to query string false "计划开始时间上限 RFC3339"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.MissionResponse]}
+// @Router /api/missions [get]
+func (h *missionHandler) ListMissions(c *gin.Context) {
+	var query dto.MissionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[MissionHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListMissions(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MissionHandler] 获取任务列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetMission 获取无人机任务详情
+// @Summary 无人机任务详情
+// @Tags 无人机任务
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id} [get]
+func (h *missionHandler) GetMission(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	mission, err := h.service.GetMission(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToMissionResponse(mission))
+}
+
+// SubmitMission 提交无人机任务
+// @Summary 提交无人机任务
+// @Description 需要审批的任务（默认）进入待审批状态；飞手提交时飞手固定为本人；响应中的 conflicts 为与禁飞区的冲突
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateMissionRequest true "任务信息"
+// @Success 201 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions [post]
+func (h *missionHandler) SubmitMission(c *gin.Context) {
+	var req dto.CreateMissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MissionHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	mission, conflicts, err := h.service.SubmitMission(c.Request.Context(), &req, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MissionHandler] 提交任务失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	resp := dto.ToMissionResponse(mission)
+	resp.Conflicts = conflicts
+	response.Created(c, resp)
+}
+
+// UpdateMission 修改无人机任务
+// @Summary 修改无人机任务
+// @Description 仅计划中的任务可以修改，被驳回的任务修改后重新进入待审批；响应中的 conflicts 为与禁飞区的冲突
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Param request body dto.UpdateMissionRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id} [put]
+func (h *missionHandler) UpdateMission(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateMissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[MissionHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	mission, conflicts, err := h.service.UpdateMission(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MissionHandler] 修改任务失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	resp := dto.ToMissionResponse(mission)
+	resp.Conflicts = conflicts
+	response.Success(c, resp)
+}
+
+// Approve 审批通过无人机任务
+// @Summary 审批通过任务
+// @Description 监管人员或管理员审批待审批任务，不能审批自己执飞的任务；与禁飞区存在冲突时返回 409
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Param request body dto.MissionActionRequest false "审批意见"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id}/approve [post]
+func (h *missionHandler) Approve(c *gin.Context) {
+	h.action(c, "审批任务", h.service.Approve)
+}
+
+// Reject 驳回无人机任务
+// @Summary 驳回任务
+// @Description 驳回时必须填写审批意见，任务修改后可重新审批
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Param request body dto.MissionActionRequest true "驳回意见"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id}/reject [post]
+func (h *missionHandler) Reject(c *gin.Context) {
+	h.action(c, "驳回任务", h.service.Reject)
+}
+
+// Start 开始执行无人机任务
+// @Summary 开始执行任务
+// @Description 记录实际开始时间并将无人机置为飞行中
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Param request body dto.MissionActionRequest false "备注"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id}/start [post]
+func (h *missionHandler) Start(c *gin.Context) {
+	h.action(c, "开始任务", h.service.Start)
+}
+
+// Complete 完成无人机任务
+// @Summary 完成任务
+// @Description 记录实际结束时间并将无人机恢复为空闲
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Param request body dto.MissionActionRequest false "备注"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id}/complete [post]
+func (h *missionHandler) Complete(c *gin.Context) {
+	h.action(c, "完成任务", h.service.Complete)
+}
+
+// Cancel 取消无人机任务
+// @Summary 取消任务
+// @Tags 无人机任务
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Param request body dto.MissionActionRequest false "取消原因"
+// @Success 200 {object} response.Response{data=dto.MissionResponse}
+// @Router /api/missions/{id}/cancel [post]
+func (h *missionHandler) Cancel(c *gin.Context) {
+	h.action(c, "取消任务", h.service.Cancel)
+}
+
+// ListLogs 无人机任务审计记录
+// @Summary 任务审计记录
+// @Tags 无人机任务
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Success 200 {object} response.Response{data=[]dto.MissionLogResponse}
+// @Router /api/missions/{id}/logs [get]
+func (h *missionHandler) ListLogs(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	logs, err := h.service.ListLogs(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MissionHandler] 获取任务审计记录失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToMissionLogResponseList(logs))
+}
+
+// Conflicts 无人机任务禁飞区冲突
+// @Summary 任务禁飞区冲突
+// @Description 按当前有效禁飞区重新检查任务航线和飞行区域，考虑限制高度和临时禁飞区的生效时间
+// @Tags 无人机任务
+// @Produce json
+// @Security Bearer
+// @Param id path string true "任务ID"
+// @Success 200 {object} response.Response{data=[]dto.ZoneConflict}
+// @Router /api/missions/{id}/conflicts [get]
+func (h *missionHandler) Conflicts(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	conflicts, err := h.service.Conflicts(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		logger.Errorf("[MissionHandler] 检查任务禁飞区冲突失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, conflicts)
+}
+
+// action 审批与执行操作的公共处理流程，请求体可以为空
+func (h *missionHandler) action(c *gin.Context, name string, do missionAction) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.MissionActionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Warnf("[MissionHandler] 绑定请求失败: %v", err)
+			response.ValidationError(c, "无效的请求数据")
+			return
+		}
+	}
+
+	mission, err := do(c.Request.Context(), id, &req, currentActor(c))
+	if err != nil {
+		logger.Warnf("[MissionHandler] %s失败: %v", name, err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToMissionResponse(mission))
+}
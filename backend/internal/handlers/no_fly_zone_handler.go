@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/kml"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxZoneImportSize 禁飞区导入文件大小上限（字节）
+const maxZoneImportSize = 10 << 20
+
+// NoFlyZoneHandler 禁飞区处理器接口
+type NoFlyZoneHandler interface {
+	ListZones(c *gin.Context)
+	GetZone(c *gin.Context)
+	CreateZone(c *gin.Context)
+	UpdateZone(c *gin.Context)
+	DeleteZone(c *gin.Context)
+	ImportZones(c *gin.Context)
+	ExportZones(c *gin.Context)
+}
+
+type noFlyZoneHandler struct {
+	service services.NoFlyZoneService
+}
+
+// NewNoFlyZoneHandler 创建禁飞区处理器实例
+func NewNoFlyZoneHandler(service services.NoFlyZoneService) NoFlyZoneHandler {
+	return &noFlyZoneHandler{
+		service: service,
+	}
+}
+
+// ListZones 分页查询禁飞区
+// @Summary 禁飞区列表
+// @Tags 禁飞区
+// @Produce json
+// @Security Bearer
+// @Param status query string false "状态 active|expired|cancelled"
+// @Param type query string false "类型 permanent|temporary|conditional"
+// @Param q query string false "关键字（名称/发布机构）"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.NoFlyZoneResponse]}
+// @Router /api/no-fly-zones [get]
+func (h *noFlyZoneHandler) ListZones(c *gin.Context) {
+	var query dto.NoFlyZoneQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[NoFlyZoneHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListZones(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[NoFlyZoneHandler] 获取禁飞区列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetZone 获取禁飞区详情
+// @Summary 禁飞区详情
+// @Tags 禁飞区
+// @Produce json
+// @Security Bearer
+// @Param id path string true "禁飞区ID"
+// @Success 200 {object} response.Response{data=dto.NoFlyZoneResponse}
+// @Router /api/no-fly-zones/{id} [get]
+func (h *noFlyZoneHandler) GetZone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	zone, err := h.service.GetZone(c.Request.Context(), id)
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToNoFlyZoneResponse(zone))
+}
+
+// CreateZone 创建禁飞区
+// @Summary 创建禁飞区
+// @Description geometry 为 GeoJSON Polygon、MultiPolygon 或带 properties.radius（米）的 Point
+// @Tags 禁飞区
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateNoFlyZoneRequest true "禁飞区信息"
+// @Success 201 {object} response.Response{data=dto.NoFlyZoneResponse}
+// @Router /api/no-fly-zones [post]
+func (h *noFlyZoneHandler) CreateZone(c *gin.Context) {
+	var req dto.CreateNoFlyZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[NoFlyZoneHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	zone, err := h.service.CreateZone(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[NoFlyZoneHandler] 创建禁飞区失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToNoFlyZoneResponse(zone))
+}
+
+// UpdateZone 更新禁飞区
+// @Summary 更新禁飞区
+// @Tags 禁飞区
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "禁飞区ID"
+// @Param request body dto.UpdateNoFlyZoneRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.NoFlyZoneResponse}
+// @Router /api/no-fly-zones/{id} [put]
+func (h *noFlyZoneHandler) UpdateZone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateNoFlyZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[NoFlyZoneHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	zone, err := h.service.UpdateZone(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[NoFlyZoneHandler] 更新禁飞区失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToNoFlyZoneResponse(zone))
+}
+
+// DeleteZone 删除禁飞区
+// @Summary 删除禁飞区
+// @Tags 禁飞区
+// @Produce json
+// @Security Bearer
+// @Param id path string true "禁飞区ID"
+// @Success 200 {object} response.Response
+// @Router /api/no-fly-zones/{id} [delete]
+func (h *noFlyZoneHandler) DeleteZone(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteZone(c.Request.Context(), id); err != nil {
+		logger.Errorf("[NoFlyZoneHandler] 删除禁飞区失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "禁飞区已删除", gin.H{"id": id})
+}
+
+// ImportZones 从 GeoJSON 批量导入禁飞区
+// @Summary 导入禁飞区
+// @Description 上传 GeoJSON FeatureCollection（multipart 字段 file 或直接作为请求体），属性见 dto.NoFlyZoneProperties；校验失败的要素跳过并在结果中列出
+// @Tags 禁飞区
+// @Accept json,mpfd
+// @Produce json
+// @Security Bearer
+// @Param file formData file false "GeoJSON 文件"
+// @Success 200 {object} response.Response{data=dto.NoFlyZoneImportResult}
+// @Router /api/no-fly-zones/import [post]
+func (h *noFlyZoneHandler) ImportZones(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxZoneImportSize)
+
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			logger.Warnf("[NoFlyZoneHandler] 读取导入文件失败: %v", err)
+			response.ValidationError(c, "缺少导入文件")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			response.Fail(c, apperr.NewInternalError(err))
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	var collection geo.FeatureCollection
+	if err := json.NewDecoder(body).Decode(&collection); err != nil {
+		logger.Warnf("[NoFlyZoneHandler] 解析导入文件失败: %v", err)
+		response.ValidationError(c, "无效的 GeoJSON 文件")
+		return
+	}
+
+	result, err := h.service.ImportZones(c.Request.Context(), &collection)
+	if err != nil {
+		logger.Errorf("[NoFlyZoneHandler] 导入禁飞区失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ExportZones 导出禁飞区
+// @Summary 导出禁飞区
+// @Description 导出为 GeoJSON FeatureCollection（可重新导入）或 KML，圆形禁飞区在 KML 中近似为多边形
+// @Tags 禁飞区
+// @Produce json
+// @Produce application/vnd.google-earth.kml+xml
+// @Security Bearer
+// @Param status query string false "状态 active|expired|cancelled"
+// @Param type query string false "类型 permanent|temporary|conditional"
+// @Param q query string false "关键字（名称/发布机构）"
+// @Param format query string false "导出格式 geojson|kml，默认 geojson"
+// @Success 200 {object} geo.FeatureCollection
+// @Router /api/no-fly-zones/export [get]
+func (h *noFlyZoneHandler) ExportZones(c *gin.Context) {
+	var query dto.NoFlyZoneExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[NoFlyZoneHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	zones, err := h.service.ExportZones(c.Request.Context(), &query)
+	if err != nil {
+		logger.Errorf("[NoFlyZoneHandler] 导出禁飞区失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	switch query.Format {
+	case dto.ZoneExportKML:
+		var buf bytes.Buffer
+		if err := kml.Encode(&buf, dto.ToNoFlyZoneKML(zones)); err != nil {
+			logger.Errorf("[NoFlyZoneHandler] 生成 KML 失败: %v", err)
+			response.Fail(c, apperr.NewInternalError(err))
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="no-fly-zones.kml"`)
+		c.Data(http.StatusOK, kml.ContentType, buf.Bytes())
+	default:
+		c.Header("Content-Type", "application/geo+json")
+		c.Header("Content-Disposition", `attachment; filename="no-fly-zones.geojson"`)
+		c.JSON(http.StatusOK, dto.ToNoFlyZoneFeatureCollection(zones))
+	}
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"backend/internal/dto"
+	"backend/internal/services"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OperatorHandler 运营商处理器接口
+type OperatorHandler interface {
+	ListOperators(c *gin.Context)
+	GetOperator(c *gin.Context)
+	CreateOperator(c *gin.Context)
+	UpdateOperator(c *gin.Context)
+	DeleteOperator(c *gin.Context)
+	ListDrones(c *gin.Context)
+	BindUser(c *gin.Context)
+}
+
+type operatorHandler struct {
+	service      services.OperatorService
+	droneService services.DroneService
+}
+
+// NewOperatorHandler 创建运营商处理器实例
+func NewOperatorHandler(service services.OperatorService, droneService services.DroneService) OperatorHandler {
+	return &operatorHandler{
+		service:      service,
+		droneService: droneService,
+	}
+}
+
+// ListOperators 分页查询运营商
+// @Summary 运营商列表
+// @Description 运营商用户仅返回所属运营商
+// @Tags 运营商
+// @Produce json
+// @Security Bearer
+// @Param type query string false "类型 commercial|government|personal"
+// @Param status query string false "状态 active|suspended"
+// @Param q query string false "关键字（代码/名称）"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.OperatorResponse]}
+// @Router /api/operators [get]
+func (h *operatorHandler) ListOperators(c *gin.Context) {
+	var query dto.OperatorQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[OperatorHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.service.ListOperators(c.Request.Context(), &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[OperatorHandler] 获取运营商列表失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetOperator 获取运营商详情
+// @Summary 运营商详情
+// @Tags 运营商
+// @Produce json
+// @Security Bearer
+// @Param id path string true "运营商ID"
+// @Success 200 {object} response.Response{data=dto.OperatorResponse}
+// @Router /api/operators/{id} [get]
+func (h *operatorHandler) GetOperator(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	operator, err := h.service.GetOperator(c.Request.Context(), id, currentActor(c))
+	if err != nil {
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToOperatorResponse(operator))
+}
+
+// CreateOperator 创建运营商
+// @Summary 创建运营商
+// @Tags 运营商
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateOperatorRequest true "运营商信息"
+// @Success 201 {object} response.Response{data=dto.OperatorResponse}
+// @Router /api/operators [post]
+func (h *operatorHandler) CreateOperator(c *gin.Context) {
+	var req dto.CreateOperatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[OperatorHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	operator, err := h.service.CreateOperator(c.Request.Context(), &req)
+	if err != nil {
+		logger.Errorf("[OperatorHandler] 创建运营商失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Created(c, dto.ToOperatorResponse(operator))
+}
+
+// UpdateOperator 更新运营商
+// @Summary 更新运营商
+// @Tags 运营商
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "运营商ID"
+// @Param request body dto.UpdateOperatorRequest true "更新字段"
+// @Success 200 {object} response.Response{data=dto.OperatorResponse}
+// @Router /api/operators/{id} [put]
+func (h *operatorHandler) UpdateOperator(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateOperatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[OperatorHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	operator, err := h.service.UpdateOperator(c.Request.Context(), id, &req)
+	if err != nil {
+		logger.Errorf("[OperatorHandler] 更新运营商失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, dto.ToOperatorResponse(operator))
+}
+
+// DeleteOperator 删除运营商
+// @Summary 删除运营商
+// @Description 名下仍有无人机时返回 409
+// @Tags 运营商
+// @Produce json
+// @Security Bearer
+// @Param id path string true "运营商ID"
+// @Success 200 {object} response.Response
+// @Router /api/operators/{id} [delete]
+func (h *operatorHandler) DeleteOperator(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOperator(c.Request.Context(), id); err != nil {
+		logger.Errorf("[OperatorHandler] 删除运营商失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "运营商已删除", gin.H{"id": id})
+}
+
+// ListDrones 运营商机队
+// @Summary 运营商机队
+// @Tags 运营商
+// @Produce json
+// @Security Bearer
+// @Param id path string true "运营商ID"
+// @Param status query string false "状态 idle|flying|maintenance|offline"
+// @Param model query string false "型号"
+// @Param q query string false "关键字（序列号/名称/型号）"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} response.Response{data=dto.PageResponse[dto.DroneResponse]}
+// @Router /api/operators/{id}/drones [get]
+func (h *operatorHandler) ListDrones(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var query dto.DroneQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Warnf("[OperatorHandler] 查询参数错误: %v", err)
+		response.ValidationError(c, "无效的查询参数")
+		return
+	}
+
+	result, err := h.droneService.ListOperatorDrones(c.Request.Context(), id, &query, currentActor(c))
+	if err != nil {
+		logger.Errorf("[OperatorHandler] 获取运营商机队失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// BindUser 绑定运营商用户
+// @Summary 绑定运营商用户
+// @Description 绑定后 operator 角色的用户只能访问该运营商的数据
+// @Tags 运营商
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "运营商ID"
+// @Param request body dto.BindOperatorUserRequest true "用户"
+// @Success 200 {object} response.Response
+// @Router /api/operators/{id}/users [post]
+func (h *operatorHandler) BindUser(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.BindOperatorUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Warnf("[OperatorHandler] 绑定请求失败: %v", err)
+		response.ValidationError(c, "无效的请求数据")
+		return
+	}
+
+	if err := h.service.BindUser(c.Request.Context(), id, req.UserID); err != nil {
+		logger.Errorf("[OperatorHandler] 绑定运营商用户失败: %v", err)
+		response.Fail(c, err)
+		return
+	}
+
+	response.SuccessWithMessage(c, "用户已绑定运营商", gin.H{"operator_id": id, "user_id": req.UserID})
+}
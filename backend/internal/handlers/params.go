@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"backend/internal/services"
+	"backend/pkg/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// parseUUIDParam 解析路径中的 UUID 参数
+// 解析失败时直接写入 400 响应并返回 false
+func parseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		response.BadRequest(c, "无效的ID格式")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// currentActor 从认证上下文中提取当前操作人
+func currentActor(c *gin.Context) services.Actor {
+	actor := services.Actor{
+		Username: c.GetString("username"),
+		Role:     c.GetString("role"),
+	}
+	if id, err := uuid.Parse(c.GetString("user_id")); err == nil {
+		actor.UserID = &id
+	}
+	return actor
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"backend/internal/config"
+	"backend/internal/dto"
+	"backend/internal/stream"
+	"backend/pkg/utils/jwt"
+	"backend/pkg/utils/logger"
+	"backend/pkg/utils/response"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// streamPingInterval WebSocket 心跳间隔
+	streamPingInterval = 30 * time.Second
+	// streamPongWait 等待客户端 pong 的最长时间
+	streamPongWait = 2 * streamPingInterval
+	// streamWriteWait 单次写入超时
+	streamWriteWait = 10 * time.Second
+	// sseHeartbeatInterval SSE 注释心跳间隔，防止代理断开空闲连接
+	sseHeartbeatInterval = 15 * time.Second
+)
+
+// StreamHandler 实时推送处理器接口
+type StreamHandler interface {
+	Positions(c *gin.Context)
+}
+
+type streamHandler struct {
+	hub      *stream.Hub
+	upgrader websocket.Upgrader
+}
+
+// NewStreamHandler 创建实时推送处理器实例
+func NewStreamHandler(hub *stream.Hub) StreamHandler {
+	return &streamHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			CheckOrigin:     checkStreamOrigin,
+		},
+	}
+}
+
+// Positions 订阅实时位置推送
+// @Summary 实时位置推送
+// @Description WebSocket 连接可发送 {"types":["flight"],"bbox":[minLat,minLng,maxLat,maxLng]} 更新订阅；非 WebSocket 请求以 SSE 推送
+// @Tags 实时推送
+// @Produce json
+// @Produce text/event-stream
+// @Param token query string false "JWT 令牌（浏览器无法设置请求头时使用）"
+// @Param types query string false "实体类型 flight,drone,alert，默认全部"
+// @Param bbox query string false "范围 minLat,minLng,maxLat,maxLng"
+// @Success 200 {object} stream.Event
+// @Router /api/stream/positions [get]
+func (h *streamHandler) Positions(c *gin.Context) {
+	claims, err := jwt.ValidateToken(streamToken(c), "")
+	if err != nil {
+		logger.Warnf("[StreamHandler] 令牌验证失败: %v", err)
+		response.Unauthorized(c, "无效的认证令牌")
+		return
+	}
+
+	types, err := stream.ParseTypes(c.Query("types"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+	box, err := stream.ParseBBox(c.Query("bbox"))
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	sub := h.hub.Subscribe(stream.Filter{Types: types, BBox: box})
+	defer sub.Close()
+	logger.Infof("[StreamHandler] 订阅建立: user=%s, subscribers=%d", claims.Username, h.hub.Subscribers())
+
+	if websocket.IsWebSocketUpgrade(c.Request) {
+		h.serveWebSocket(c, sub)
+	} else {
+		h.serveSSE(c, sub)
+	}
+
+	logger.Infof("[StreamHandler] 订阅结束: user=%s, dropped=%d", claims.Username, sub.Dropped())
+}
+
+// serveWebSocket 通过 WebSocket 推送事件，并接收客户端的订阅更新
+func (h *streamHandler) serveWebSocket(c *gin.Context, sub *stream.Subscription) {
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logger.Warnf("[StreamHandler] WebSocket 升级失败: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// gorilla/websocket 同一时刻只允许一个写入方，读协程的错误回复交给本循环发送
+	done := make(chan struct{})
+	closing := make(chan struct{})
+	defer close(closing)
+	replies := make(chan gin.H)
+	go func() {
+		defer close(done)
+		h.readSubscriptions(conn, sub, replies, closing)
+	}()
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case reply := <-replies:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if err := conn.WriteJSON(reply); err != nil {
+				return
+			}
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readSubscriptions 读取客户端发送的订阅更新，无效消息的错误回复通过 replies 交给写循环发送
+// 连接关闭或写循环退出（closing 关闭）时返回
+func (h *streamHandler) readSubscriptions(conn *websocket.Conn, sub *stream.Subscription, replies chan<- gin.H, closing <-chan struct{}) {
+	conn.SetReadLimit(4096)
+	conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg dto.StreamSubscribeMessage
+		filter, err := stream.Filter{}, json.Unmarshal(data, &msg)
+		if err == nil {
+			filter, err = subscriptionFilter(msg)
+		}
+		if err != nil {
+			select {
+			case replies <- gin.H{"type": "error", "error": err.Error()}:
+			case <-closing:
+				return
+			}
+			continue
+		}
+		sub.SetFilter(filter)
+	}
+}
+
+// serveSSE 以 Server-Sent Events 推送事件
+func (h *streamHandler) serveSSE(c *gin.Context, sub *stream.Subscription) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-sub.C:
+			if !ok {
+				return false
+			}
+			c.SSEvent(string(event.Type), event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// subscriptionFilter 将 WebSocket 订阅消息转换为过滤条件
+func subscriptionFilter(msg dto.StreamSubscribeMessage) (stream.Filter, error) {
+	types, err := stream.ParseTypes(strings.Join(msg.Types, ","))
+	if err != nil {
+		return stream.Filter{}, err
+	}
+
+	filter := stream.Filter{Types: types}
+	if len(msg.BBox) > 0 {
+		if filter.BBox, err = stream.NewBBox(msg.BBox); err != nil {
+			return stream.Filter{}, err
+		}
+	}
+	return filter, nil
+}
+
+// streamToken 优先从 Authorization 头读取令牌，其次读取 token 查询参数
+func streamToken(c *gin.Context) string {
+	if token, err := jwt.ExtractToken(c); err == nil {
+		return token
+	}
+	return c.Query("token")
+}
+
+// checkStreamOrigin 仅允许 CORS 配置中的来源建立 WebSocket 连接，非浏览器客户端不带 Origin 时放行
+func checkStreamOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range config.AppConfig.CORSOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"backend/pkg/utils/logger"
+	"mime"
 
 	"github.com/gin-gonic/gin"
 )
@@ -49,20 +50,31 @@ func NoCache() gin.HandlerFunc {
 	}
 }
 
+// allowedContentTypes 写请求允许的媒体类型
+// multipart/form-data 用于文件上传（如禁飞区 GeoJSON 导入），application/geo+json 用于直接提交 GeoJSON
+var allowedContentTypes = map[string]bool{
+	"application/json":     true,
+	"application/geo+json": true,
+	"multipart/form-data":  true,
+}
+
 // ContentType 内容类型检查中间件
 func ContentType() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 对于 POST, PUT, PATCH 请求，要求 Content-Type 为 application/json
+		// 对于 POST, PUT, PATCH 请求，要求 Content-Type 为允许的媒体类型（忽略 charset、boundary 等参数）
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "PATCH" {
 			contentType := c.GetHeader("Content-Type")
-			if contentType != "" && contentType != "application/json" {
-				logger.Warnf("[ContentType] 不支持的 Content-Type: %s", contentType)
-				c.JSON(415, gin.H{
-					"success": false,
-					"error":   "不支持的媒体类型",
-				})
-				c.Abort()
-				return
+			if contentType != "" {
+				mediaType, _, err := mime.ParseMediaType(contentType)
+				if err != nil || !allowedContentTypes[mediaType] {
+					logger.Warnf("[ContentType] 不支持的 Content-Type: %s", contentType)
+					c.JSON(415, gin.H{
+						"success": false,
+						"error":   "不支持的媒体类型",
+					})
+					c.Abort()
+					return
+				}
 			}
 		}
 
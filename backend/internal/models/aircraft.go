@@ -6,10 +6,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// 飞机状态
+const (
+	AircraftStatusActive      = "active"
+	AircraftStatusMaintenance = "maintenance"
+	AircraftStatusRetired     = "retired"
+)
+
 // Aircraft 飞机模型
 type Aircraft struct {
 	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	Registration string     `json:"registration" binding:"required" gorm:"type:text;uniqueIndex"` // 注册号
+	ICAOHex      string     `json:"icao_hex" gorm:"type:varchar(6);index"`                        // ICAO 24 位地址（十六进制）
 	AirlineID    *uuid.UUID `json:"airline_id" gorm:"type:uuid;index"`
 	Model        string     `json:"model" gorm:"type:text"`        // 机型，如 Boeing 737-800
 	Manufacturer string     `json:"manufacturer" gorm:"type:text"` // 制造商
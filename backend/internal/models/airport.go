@@ -14,8 +14,8 @@ type Airport struct {
 	NameEn      string    `json:"name_en" gorm:"type:text"`
 	City        string    `json:"city" gorm:"type:text"`
 	Country     string    `json:"country" gorm:"type:text"`
-	Latitude    float64   `json:"latitude" gorm:"type:double precision"`
-	Longitude   float64   `json:"longitude" gorm:"type:double precision"`
+	Latitude    float64   `json:"latitude" gorm:"type:double precision;index:idx_airports_lat_lng"`
+	Longitude   float64   `json:"longitude" gorm:"type:double precision;index:idx_airports_lat_lng"`
 	Altitude    float64   `json:"altitude" gorm:"type:double precision"` // 海拔高度（米）
 	Timezone    string    `json:"timezone" gorm:"type:text"`
 	Type        string    `json:"type" gorm:"type:text;default:'civil'"` // civil, military, mixed
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 告警类型
+const (
+	AlertTypeRouteDeviation   = "route_deviation"
+	AlertTypeNoFlyZoneBreach  = "no_fly_zone_breach" // 无人机进入禁飞区
+	AlertTypeFlightAreaBreach = "flight_area_breach" // 无人机飞出任务批准的飞行区域
+	AlertTypeNearMiss         = "near_miss"          // 无人机与航班间隔不足或预计不足
+)
+
+// 告警级别
+const (
+	AlertSeverityInfo     = "info"
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+)
+
+// 告警状态
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+// 告警关联对象类型
+const (
+	AlertEntityFlight = "flight"
+	AlertEntityDrone  = "drone"
+)
+
+// Alert 告警模型
+// 同一对象同一类型在未解除前只保留一条告警，持续期间刷新触发值和位置
+// 涉及两个对象的告警（如接近告警）以另一方为关联对象，按对象和关联对象分别去重
+type Alert struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Type           string     `json:"type" gorm:"type:varchar(30);not null;index"`
+	Severity       string     `json:"severity" gorm:"type:varchar(20);not null;default:'warning'"`
+	Status         string     `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	EntityType     string     `json:"entity_type" gorm:"type:varchar(20);not null;index:idx_alert_entity"`
+	EntityID       uuid.UUID  `json:"entity_id" gorm:"type:uuid;not null;index:idx_alert_entity"`
+	RelatedType    *string    `json:"related_type" gorm:"type:varchar(20)"`
+	RelatedID      *uuid.UUID `json:"related_id" gorm:"type:uuid;index"`
+	Message        string     `json:"message" gorm:"type:varchar(500)"`
+	Value          float64    `json:"value"`     // 触发值，如偏航距离（米）
+	Threshold      float64    `json:"threshold"` // 告警阈值
+	Latitude       *float64   `json:"latitude" gorm:"type:decimal(10,7)"`
+	Longitude      *float64   `json:"longitude" gorm:"type:decimal(10,7)"`
+	TriggeredAt    time.Time  `json:"triggered_at" gorm:"type:timestamptz;index"`
+	LastSeenAt     time.Time  `json:"last_seen_at" gorm:"type:timestamptz"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by" gorm:"type:uuid"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at" gorm:"type:timestamptz"`
+	ResolvedAt     *time.Time `json:"resolved_at" gorm:"type:timestamptz"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"type:timestamptz;default:now()"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"type:timestamptz;default:now()"`
+}
+
+// TableName 指定表名
+func (Alert) TableName() string {
+	return "alerts"
+}
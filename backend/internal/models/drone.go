@@ -6,6 +6,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// 无人机状态
+const (
+	DroneStatusIdle        = "idle"
+	DroneStatusFlying      = "flying"
+	DroneStatusMaintenance = "maintenance"
+	DroneStatusOffline     = "offline"
+)
+
 // Drone 无人机模型
 type Drone struct {
 	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
@@ -1,13 +1,25 @@
 package models
 
 import (
+	"backend/pkg/geo"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// 飞行事件类型，越界事件沿用告警类型
+const (
+	FlightEventTakeoff         = "takeoff"
+	FlightEventLanding         = "landing"
+	FlightEventModeChange      = "mode_change"
+	FlightEventLowBattery      = "low_battery"
+	FlightEventCriticalBattery = "critical_battery"
+	FlightEventSignalLost      = "signal_lost" // 遥测中断
+)
+
 // DroneFlightLog 无人机飞行日志模型
+// 任务结束或无人机降落时由位置流自动汇总生成
 type DroneFlightLog struct {
 	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	DroneID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"droneId"`
@@ -27,7 +39,7 @@ type DroneFlightLog struct {
 	TotalDistance   *float64 `gorm:"type:decimal(10,2)" json:"totalDistance"` // km
 
 	// 飞行轨迹
-	FlightPath *string `gorm:"type:jsonb" json:"flightPath"` // 完整轨迹点数组
+	FlightPath *geo.Geometry `gorm:"type:jsonb" json:"flightPath"` // 轨迹 LineString，坐标为 [经度, 纬度, 高度(米)]
 
 	// 统计信息
 	BatteryConsumed *int `json:"batteryConsumed"` // 百分比
@@ -7,6 +7,50 @@ import (
 	"gorm.io/gorm"
 )
 
+// 事件类型
+const (
+	IncidentTypeCrash       = "crash"
+	IncidentTypeFlyaway     = "flyaway"
+	IncidentTypeNearMiss    = "near_miss"
+	IncidentTypeViolation   = "violation"
+	IncidentTypeMalfunction = "malfunction"
+)
+
+// 事件严重程度，由轻到重
+const (
+	IncidentSeverityMinor    = "minor"
+	IncidentSeverityModerate = "moderate"
+	IncidentSeveritySerious  = "serious"
+	IncidentSeverityCritical = "critical"
+)
+
+// 调查状态：pending -> investigating -> closed
+// 遥测自动检测的事件以 draft 开立，人工确认后进入 pending，误报则置为 dismissed
+const (
+	InvestigationStatusDraft         = "draft"
+	InvestigationStatusPending       = "pending"
+	InvestigationStatusInvestigating = "investigating"
+	InvestigationStatusClosed        = "closed"
+	InvestigationStatusDismissed     = "dismissed"
+)
+
+// 遥测自动检测规则
+const (
+	IncidentDetectionAltitudeLoss = "altitude_loss" // 高度骤降，疑似坠机
+	IncidentDetectionFlyaway      = "flyaway"       // 持续远离起飞点且不返航
+	IncidentDetectionSignalLost   = "signal_lost"   // 空中失去遥测
+	IncidentDetectionGpsDegraded  = "gps_degraded"  // 定位精度崩溃
+	IncidentDetectionNoFlyZone    = "no_fly_zone"   // 闯入禁飞区
+)
+
+// 无人机损坏程度
+const (
+	DroneDamageNone      = "none"
+	DroneDamageMinor     = "minor"
+	DroneDamageMajor     = "major"
+	DroneDamageTotalLoss = "total_loss"
+)
+
 // DroneIncident 无人机事件/事故模型
 type DroneIncident struct {
 	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -35,8 +79,11 @@ type DroneIncident struct {
 	Injuries                  bool    `gorm:"default:false" json:"injuries"`
 	InjuryDescription         *string `gorm:"type:text" json:"injuryDescription"`
 
+	// 自动检测规则，人工上报的事件为空
+	DetectionRule *string `gorm:"type:varchar(30);index" json:"detectionRule"` // altitude_loss/flyaway/signal_lost/gps_degraded/no_fly_zone
+
 	// 调查信息
-	InvestigationStatus string     `gorm:"type:varchar(20);default:'pending'" json:"investigationStatus"`
+	InvestigationStatus string     `gorm:"type:varchar(20);default:'pending'" json:"investigationStatus"` // draft/pending/investigating/closed/dismissed
 	InvestigatorID      *uuid.UUID `gorm:"type:uuid" json:"investigatorId"`
 	InvestigationNotes  *string    `gorm:"type:text" json:"investigationNotes"`
 	RootCause           *string    `gorm:"type:text" json:"rootCause"`
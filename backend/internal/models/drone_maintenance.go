@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 维护状态，按维护规则间隔的使用比例判断
+const (
+	MaintenanceStatusOK      = "ok"
+	MaintenanceStatusDue     = "due"     // 接近维护间隔
+	MaintenanceStatusOverdue = "overdue" // 已达到维护间隔，完成维护前不能审批或执行任务
+)
+
+// 维护工单状态：open -> completed（签核）/ cancelled
+const (
+	WorkOrderStatusOpen      = "open"
+	WorkOrderStatusCompleted = "completed"
+	WorkOrderStatusCancelled = "cancelled"
+)
+
+// MaintenanceRule 无人机维护规则模型
+// 按机型定义，飞行小时、飞行次数、日历天数中任一项达到间隔即需维护，至少设置一项
+type MaintenanceRule struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Model       string    `gorm:"type:varchar(100);not null;index" json:"model"` // 适用机型，与无人机型号比对时不区分大小写
+	Name        string    `gorm:"type:varchar(200);not null" json:"name"`
+	Description *string   `gorm:"type:text" json:"description"`
+
+	// 维护间隔
+	IntervalHours   *float64 `gorm:"type:decimal(8,2)" json:"intervalHours"` // 飞行小时
+	IntervalFlights *int     `json:"intervalFlights"`                        // 飞行次数
+	IntervalDays    *int     `json:"intervalDays"`                           // 日历天数
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName 指定表名
+func (MaintenanceRule) TableName() string {
+	return "maintenance_rules"
+}
+
+// BeforeCreate GORM钩子：创建前生成UUID
+func (r *MaintenanceRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// MaintenanceWorkOrder 维护工单模型
+// 按规则开立的工单签核后，以签核时的累计飞行时间和次数作为该规则下一周期的起点
+type MaintenanceWorkOrder struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	DroneID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"droneId"`
+	OperatorID *uuid.UUID `gorm:"type:uuid;index" json:"operatorId"`
+	RuleID     *uuid.UUID `gorm:"type:uuid;index" json:"ruleId"` // 临时维修为空
+
+	// 工单信息
+	Title       string     `gorm:"type:varchar(200);not null" json:"title"`
+	Description *string    `gorm:"type:text" json:"description"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'open';index" json:"status"` // open/completed/cancelled
+	OpenedBy    *uuid.UUID `gorm:"type:uuid" json:"openedBy"`
+
+	// 签核信息
+	WorkPerformed             *string    `gorm:"type:text" json:"workPerformed"`
+	SignedOffBy               *uuid.UUID `gorm:"type:uuid" json:"signedOffBy"`
+	CompletedAt               *time.Time `gorm:"index" json:"completedAt"`
+	FlightMinutesAtCompletion *int64     `json:"flightMinutesAtCompletion"` // 签核时的累计飞行时间（分钟）
+	FlightsAtCompletion       *int64     `json:"flightsAtCompletion"`       // 签核时的累计飞行次数
+	CancelReason              *string    `gorm:"type:text" json:"cancelReason"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// 关联
+	Drone *Drone           `gorm:"foreignKey:DroneID" json:"drone,omitempty"`
+	Rule  *MaintenanceRule `gorm:"foreignKey:RuleID" json:"rule,omitempty"`
+}
+
+// TableName 指定表名
+func (MaintenanceWorkOrder) TableName() string {
+	return "maintenance_work_orders"
+}
+
+// BeforeCreate GORM钩子：创建前生成UUID
+func (o *MaintenanceWorkOrder) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
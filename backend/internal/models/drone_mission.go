@@ -1,12 +1,30 @@
 package models
 
 import (
+	"backend/pkg/geo"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// 无人机任务状态
+const (
+	MissionStatusPlanned    = "planned"
+	MissionStatusApproved   = "approved"
+	MissionStatusInProgress = "in_progress"
+	MissionStatusCompleted  = "completed"
+	MissionStatusCancelled  = "cancelled"
+)
+
+// 无人机任务审批状态
+const (
+	ApprovalStatusPending  = "pending"
+	ApprovalStatusApproved = "approved"
+	ApprovalStatusRejected = "rejected"
+	ApprovalStatusWaived   = "waived" // 管理员提交时豁免审批
+)
+
 // DroneMission 无人机飞行任务模型
 type DroneMission struct {
 	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -27,10 +45,10 @@ type DroneMission struct {
 	ActualEndTime    *time.Time `json:"actualEndTime"`
 
 	// 位置信息 (JSON格式)
-	DepartureLocation string  `gorm:"type:jsonb;not null" json:"departureLocation"` // {lat, lng, name, address}
-	ArrivalLocation   *string `gorm:"type:jsonb" json:"arrivalLocation"`
-	Waypoints         *string `gorm:"type:jsonb" json:"waypoints"`  // 航点列表
-	FlightArea        *string `gorm:"type:jsonb" json:"flightArea"` // 飞行区域多边形
+	DepartureLocation string        `gorm:"type:jsonb;not null" json:"departureLocation"` // {lat, lng, name, address}
+	ArrivalLocation   *string       `gorm:"type:jsonb" json:"arrivalLocation"`
+	Waypoints         *geo.Geometry `gorm:"type:jsonb" json:"waypoints"`  // 航点 MultiPoint，坐标为 [经度, 纬度, 高度(米)]
+	FlightArea        *geo.Geometry `gorm:"type:jsonb" json:"flightArea"` // 飞行区域 Polygon/MultiPolygon
 
 	// 飞行参数
 	PlannedAltitude *int     `json:"plannedAltitude"`                           // 米
@@ -38,8 +56,9 @@ type DroneMission struct {
 	PlannedDistance *float64 `gorm:"type:decimal(10,2)" json:"plannedDistance"` // km
 
 	// 审批信息
+	SubmittedBy      *uuid.UUID `gorm:"type:uuid" json:"submittedBy"` // 最近一次提交审批的用户，不能审批该任务
 	RequiresApproval bool       `gorm:"default:false" json:"requiresApproval"`
-	ApprovalStatus   *string    `gorm:"type:varchar(20)" json:"approvalStatus"` // pending/approved/rejected
+	ApprovalStatus   *string    `gorm:"type:varchar(20)" json:"approvalStatus"` // pending/approved/rejected/waived
 	ApprovedBy       *uuid.UUID `gorm:"type:uuid" json:"approvedBy"`
 	ApprovalTime     *time.Time `json:"approvalTime"`
 	ApprovalNotes    *string    `gorm:"type:text" json:"approvalNotes"`
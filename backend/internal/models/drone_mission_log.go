@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 无人机任务操作类型
+const (
+	MissionActionSubmit   = "submit"
+	MissionActionUpdate   = "update"
+	MissionActionApprove  = "approve"
+	MissionActionReject   = "reject"
+	MissionActionStart    = "start"
+	MissionActionComplete = "complete"
+	MissionActionCancel   = "cancel"
+)
+
+// DroneMissionLog 无人机任务审计记录模型
+// 记录任务提交、审批和执行过程中的每一次操作，只追加不修改
+type DroneMissionLog struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	MissionID      uuid.UUID  `json:"mission_id" gorm:"type:uuid;not null;index"`
+	Action         string     `json:"action" gorm:"type:varchar(20);not null"`
+	FromStatus     string     `json:"from_status" gorm:"type:varchar(20)"`
+	ToStatus       string     `json:"to_status" gorm:"type:varchar(20);not null"`
+	ApprovalStatus *string    `json:"approval_status" gorm:"type:varchar(20)"` // 操作后的审批状态
+	Notes          string     `json:"notes" gorm:"type:text"`
+	ActorID        *uuid.UUID `json:"actor_id" gorm:"type:uuid;index"` // 操作人ID
+	ActorName      string     `json:"actor_name" gorm:"type:text"`     // 操作人用户名
+	ActorRole      string     `json:"actor_role" gorm:"type:varchar(20)"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"type:timestamptz;default:now();index"`
+}
+
+// TableName 指定表名
+func (DroneMissionLog) TableName() string {
+	return "drone_mission_logs"
+}
@@ -12,6 +12,7 @@ type FlightHistory struct {
 	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	FlightNumber string     `gorm:"type:varchar(20);not null;index" json:"flightNumber"`
 	FlightDate   time.Time  `gorm:"type:date;not null;index" json:"flightDate"`
+	AirlineCode  string     `gorm:"type:varchar(3);index" json:"airlineCode"` // 航空公司IATA代码
 	AircraftID   *uuid.UUID `gorm:"type:uuid;index" json:"aircraftId"`
 
 	// 机场信息
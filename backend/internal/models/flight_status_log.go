@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightStatusLog 航班状态变更记录模型
+type FlightStatusLog struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	FlightID     uuid.UUID  `json:"flight_id" gorm:"type:uuid;not null;index"`
+	FromStatus   string     `json:"from_status" gorm:"type:varchar(20)"`
+	ToStatus     string     `json:"to_status" gorm:"type:varchar(20);not null"`
+	DelayMinutes int        `json:"delay_minutes" gorm:"type:integer;default:0"` // 变更后的延误分钟数
+	Reason       string     `json:"reason" gorm:"type:text"`
+	ActorID      *uuid.UUID `json:"actor_id" gorm:"type:uuid;index"`     // 操作人ID
+	ActorName    string     `json:"actor_name" gorm:"type:text"`         // 操作人用户名
+	OccurredAt   time.Time  `json:"occurred_at" gorm:"type:timestamptz"` // 状态实际发生时间
+	CreatedAt    time.Time  `json:"created_at" gorm:"type:timestamptz;default:now();index"`
+}
+
+// TableName 指定表名
+func (FlightStatusLog) TableName() string {
+	return "flight_status_logs"
+}
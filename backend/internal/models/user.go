@@ -15,6 +15,9 @@ type User struct {
 	Password string    `json:"-" binding:"required,min=6" gorm:"type:text"`
 	Role     string    `json:"role" gorm:"type:text;default:'user'"`
 
+	// 所属无人机运营商，operator 角色用户只能访问该运营商的机队
+	OperatorID *uuid.UUID `json:"operator_id,omitempty" gorm:"type:uuid;index"`
+
 	// Supabase 扩展字段
 	FullName    *string    `json:"full_name,omitempty" gorm:"type:text;column:full_name"`
 	AvatarURL   *string    `json:"avatar_url,omitempty" gorm:"type:text;column:avatar_url"`
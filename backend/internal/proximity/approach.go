@@ -0,0 +1,82 @@
+package proximity
+
+import (
+	"backend/pkg/geo"
+	"math"
+	"time"
+)
+
+// Minima 最小间隔标准（米），水平和垂直间隔同时小于标准视为间隔不足
+type Minima struct {
+	Horizontal float64
+	Vertical   float64
+}
+
+// Violated 判断间隔是否不足，垂直间隔未知时只按水平间隔判断
+func (m Minima) Violated(horizontal float64, vertical *float64) bool {
+	return horizontal < m.Horizontal && (vertical == nil || *vertical < m.Vertical)
+}
+
+// Approach 两个实体的当前间隔及预测的最近会遇点
+type Approach struct {
+	Horizontal    float64       // 当前水平间隔（米）
+	Vertical      *float64      // 当前垂直间隔（米），任一方高度未知时为 nil
+	TimeToCPA     time.Duration // 距最近会遇点的时间，0 表示正在远离或相对静止
+	CPAHorizontal float64       // 最近会遇点的水平间隔（米）
+	CPAVertical   *float64      // 最近会遇点的垂直间隔（米）
+}
+
+// ClosestApproach 计算 a、b 的间隔，两者先外推到较新的时刻，再在 lookahead 内按匀速运动预测最近会遇点
+// 距离按以 a 为原点的局部平面计算，适用于数十公里以内的间隔
+func ClosestApproach(a, b Track, lookahead time.Duration) Approach {
+	now := a.Timestamp
+	if b.Timestamp.After(now) {
+		now = b.Timestamp
+	}
+	ax, ay, az := a.extrapolate(now, a.Latitude, a.Longitude)
+	bx, by, bz := b.extrapolate(now, a.Latitude, a.Longitude)
+	avx, avy := a.velocity()
+	bvx, bvy := b.velocity()
+
+	rx, ry := bx-ax, by-ay
+	vx, vy := bvx-avx, bvy-avy
+
+	t := 0.0
+	if speed2 := vx*vx + vy*vy; speed2 > 0 {
+		t = math.Max(0, math.Min(lookahead.Seconds(), -(rx*vx+ry*vy)/speed2))
+	}
+
+	approach := Approach{
+		Horizontal:    math.Hypot(rx, ry),
+		TimeToCPA:     time.Duration(t * float64(time.Second)),
+		CPAHorizontal: math.Hypot(rx+vx*t, ry+vy*t),
+	}
+	if az != nil && bz != nil {
+		vertical := math.Abs(*bz - *az)
+		cpa := math.Abs(*bz + b.VerticalSpeed*t - *az - a.VerticalSpeed*t)
+		approach.Vertical, approach.CPAVertical = &vertical, &cpa
+	}
+	return approach
+}
+
+// velocity 水平速度分量（米/秒），x 向东、y 向北
+func (t Track) velocity() (float64, float64) {
+	if t.Heading == nil {
+		return 0, 0
+	}
+	rad := *t.Heading * math.Pi / 180
+	return t.GroundSpeed * math.Sin(rad), t.GroundSpeed * math.Cos(rad)
+}
+
+// extrapolate 将实体外推到 at 时刻，返回以 (originLat, originLng) 为原点的平面坐标和高度
+func (t Track) extrapolate(at time.Time, originLat, originLng float64) (float64, float64, *float64) {
+	dt := at.Sub(t.Timestamp).Seconds()
+	vx, vy := t.velocity()
+	x := (geo.NormalizeLng(t.Longitude-originLng))*math.Pi/180*geo.EarthRadius*math.Cos(originLat*math.Pi/180) + vx*dt
+	y := (t.Latitude-originLat)*math.Pi/180*geo.EarthRadius + vy*dt
+	if t.Altitude == nil {
+		return x, y, nil
+	}
+	z := *t.Altitude + t.VerticalSpeed*dt
+	return x, y, &z
+}
@@ -0,0 +1,51 @@
+package proximity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestClosestApproachHeadOn(t *testing.T) {
+	// 无人机悬停，航班自南 3 公里处以 100 m/s 向北飞来并保持 120 米高度差
+	drone := newTrack(KindDrone, 31.2, 121.4, base)
+	drone.Altitude = float(100)
+	flight := newTrack(KindFlight, 31.2-3000/111195.0, 121.4, base)
+	flight.Altitude, flight.Heading, flight.GroundSpeed = float(220), float(0), 100
+
+	approach := ClosestApproach(drone, flight, time.Minute)
+	assert.InDelta(t, 3000, approach.Horizontal, 5)
+	assert.InDelta(t, 30, approach.TimeToCPA.Seconds(), 0.5)
+	assert.InDelta(t, 0, approach.CPAHorizontal, 5)
+	require.NotNil(t, approach.CPAVertical)
+	assert.InDelta(t, 120, *approach.CPAVertical, 0.01)
+
+	minima := Minima{Horizontal: 500, Vertical: 150}
+	assert.False(t, minima.Violated(approach.Horizontal, approach.Vertical))
+	assert.True(t, minima.Violated(approach.CPAHorizontal, approach.CPAVertical))
+
+	// 预测窗口不足时取窗口末端
+	approach = ClosestApproach(drone, flight, 10*time.Second)
+	assert.Equal(t, 10*time.Second, approach.TimeToCPA)
+	assert.InDelta(t, 2000, approach.CPAHorizontal, 5)
+}
+
+func TestClosestApproachDivergingAndExtrapolated(t *testing.T) {
+	drone := newTrack(KindDrone, 31.2, 121.4, base)
+	flight := newTrack(KindFlight, 31.2+1000/111195.0, 121.4, base.Add(-5*time.Second))
+	flight.Heading, flight.GroundSpeed = float(0), 100
+
+	// 航班外推 5 秒后距无人机 1500 米并继续远离
+	approach := ClosestApproach(drone, flight, time.Minute)
+	assert.InDelta(t, 1500, approach.Horizontal, 5)
+	assert.Zero(t, approach.TimeToCPA)
+	assert.Equal(t, approach.Horizontal, approach.CPAHorizontal)
+	assert.Nil(t, approach.Vertical)
+	assert.True(t, Minima{Horizontal: 2000, Vertical: 150}.Violated(approach.Horizontal, approach.Vertical))
+}
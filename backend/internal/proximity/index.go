@@ -0,0 +1,198 @@
+// Package proximity 提供航空器之间的间隔计算和进程内空间索引
+//
+// Index 以经纬度网格保存每个实体的最新状态，支持按半径查询周边实体；
+// ClosestApproach 按航向和速度匀速外推，预测两者的最近会遇点（CPA）。
+package proximity
+
+import (
+	"backend/pkg/geo"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind 实体类型
+type Kind string
+
+const (
+	// KindFlight 有人驾驶航班
+	KindFlight Kind = "flight"
+	// KindDrone 无人机
+	KindDrone Kind = "drone"
+)
+
+// Key 实体标识
+type Key struct {
+	Kind Kind
+	ID   uuid.UUID
+}
+
+// Track 实体的最新状态，长度单位统一为米、速度为米/秒
+type Track struct {
+	Key
+	Label         string // 航班号或无人机序列号
+	Latitude      float64
+	Longitude     float64
+	Altitude      *float64 // 高度未知时为 nil
+	GroundSpeed   float64
+	Heading       *float64 // 航向（度），未知时不做水平外推
+	VerticalSpeed float64
+	Timestamp     time.Time
+}
+
+type cell struct {
+	lat, lng int
+}
+
+// Index 网格空间索引，可在多个 goroutine 中并发使用
+// 超过 maxAge 未更新的实体视为失效，查询时忽略并定期清理
+type Index struct {
+	mu        sync.RWMutex
+	cellSize  float64
+	maxAge    time.Duration
+	tracks    map[Key]*Track
+	cells     map[cell]map[Key]*Track
+	lastPrune time.Time
+}
+
+// NewIndex 创建空间索引，cellSize 为网格边长（度）
+func NewIndex(cellSize float64, maxAge time.Duration) *Index {
+	return &Index{
+		cellSize: cellSize,
+		maxAge:   maxAge,
+		tracks:   make(map[Key]*Track),
+		cells:    make(map[cell]map[Key]*Track),
+	}
+}
+
+// Upsert 写入实体最新状态，早于已有状态的数据被忽略，返回是否写入
+func (i *Index) Upsert(track Track) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if current, ok := i.tracks[track.Key]; ok {
+		if track.Timestamp.Before(current.Timestamp) {
+			return false
+		}
+		i.remove(current)
+	}
+	stored := track
+	i.tracks[track.Key] = &stored
+	c := i.cellOf(track.Latitude, track.Longitude)
+	if i.cells[c] == nil {
+		i.cells[c] = make(map[Key]*Track)
+	}
+	i.cells[c][track.Key] = &stored
+
+	if track.Timestamp.Sub(i.lastPrune) > i.maxAge {
+		i.prune(track.Timestamp)
+		i.lastPrune = track.Timestamp
+	}
+	return true
+}
+
+// Remove 删除实体
+func (i *Index) Remove(key Key) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if current, ok := i.tracks[key]; ok {
+		i.remove(current)
+	}
+}
+
+// Get 返回实体的最新状态
+func (i *Index) Get(key Key) (Track, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	track, ok := i.tracks[key]
+	if !ok {
+		return Track{}, false
+	}
+	return *track, true
+}
+
+// Nearby 返回距给定点 radius 米以内、在 at 时刻仍有效的实体
+func (i *Index) Nearby(lat, lng, radius float64, at time.Time) []Track {
+	box := geo.BBoxAround(lat, lng, radius)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var result []Track
+	collect := func(tracks map[Key]*Track) {
+		for _, track := range tracks {
+			if at.Sub(track.Timestamp) > i.maxAge || !box.Contains(track.Latitude, track.Longitude) {
+				continue
+			}
+			if geo.Haversine(lat, lng, track.Latitude, track.Longitude) <= radius {
+				result = append(result, *track)
+			}
+		}
+	}
+
+	// 需扫描的网格数多于已占用网格时直接遍历已占用网格
+	rows := int(math.Floor(box.MaxLat/i.cellSize)) - int(math.Floor(box.MinLat/i.cellSize)) + 1
+	cols := int(math.Ceil(lngSpan(box)/i.cellSize)) + 1
+	if rows*cols > len(i.cells) {
+		for _, tracks := range i.cells {
+			collect(tracks)
+		}
+		return result
+	}
+
+	minRow := int(math.Floor(box.MinLat / i.cellSize))
+	minCol := int(math.Floor(box.MinLng / i.cellSize))
+	wrap := int(math.Round(360 / i.cellSize))
+	for row := minRow; row < minRow+rows; row++ {
+		for col := minCol; col < minCol+cols; col++ {
+			c := cell{lat: row, lng: col}
+			// 跨越 180° 经线时列号回绕到 -180° 一侧
+			if float64(col)*i.cellSize >= 180 {
+				c.lng = col - wrap
+			}
+			collect(i.cells[c])
+		}
+	}
+	return result
+}
+
+// Len 返回索引中的实体数量（含尚未清理的失效实体）
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.tracks)
+}
+
+func (i *Index) cellOf(lat, lng float64) cell {
+	return cell{lat: int(math.Floor(lat / i.cellSize)), lng: int(math.Floor(lng / i.cellSize))}
+}
+
+func (i *Index) remove(track *Track) {
+	delete(i.tracks, track.Key)
+	c := i.cellOf(track.Latitude, track.Longitude)
+	delete(i.cells[c], track.Key)
+	if len(i.cells[c]) == 0 {
+		delete(i.cells, c)
+	}
+}
+
+// prune 清理 at 时刻已失效的实体
+func (i *Index) prune(at time.Time) {
+	for _, track := range i.tracks {
+		if at.Sub(track.Timestamp) > i.maxAge {
+			i.remove(track)
+		}
+	}
+}
+
+// lngSpan 包围盒的经度跨度（度）
+func lngSpan(box geo.BBox) float64 {
+	if box.CrossesAntimeridian() {
+		return 360 - box.MinLng + box.MaxLng
+	}
+	return box.MaxLng - box.MinLng
+}
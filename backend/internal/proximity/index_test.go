@@ -0,0 +1,83 @@
+package proximity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var base = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newTrack(kind Kind, lat, lng float64, at time.Time) Track {
+	return Track{Key: Key{Kind: kind, ID: uuid.New()}, Latitude: lat, Longitude: lng, Timestamp: at}
+}
+
+func keys(tracks []Track) []Key {
+	result := make([]Key, len(tracks))
+	for i, track := range tracks {
+		result[i] = track.Key
+	}
+	return result
+}
+
+func TestIndexNearbyAndMove(t *testing.T) {
+	index := NewIndex(0.1, time.Minute)
+	drone := newTrack(KindDrone, 31.2, 121.4, base)
+	flight := newTrack(KindFlight, 31.21, 121.41, base)
+	far := newTrack(KindFlight, 31.5, 121.4, base)
+	for _, track := range []Track{drone, flight, far} {
+		require.True(t, index.Upsert(track))
+	}
+
+	nearby := index.Nearby(31.2, 121.4, 2000, base)
+	assert.ElementsMatch(t, []Key{drone.Key, flight.Key}, keys(nearby))
+
+	// 移动到另一个网格
+	flight.Latitude, flight.Timestamp = 31.45, base.Add(time.Second)
+	require.True(t, index.Upsert(flight))
+	assert.ElementsMatch(t, []Key{drone.Key}, keys(index.Nearby(31.2, 121.4, 2000, base)))
+	assert.ElementsMatch(t, []Key{flight.Key, far.Key}, keys(index.Nearby(31.48, 121.4, 10000, base)))
+
+	// 乱序的旧数据不覆盖
+	flight.Latitude, flight.Timestamp = 31.2, base
+	assert.False(t, index.Upsert(flight))
+	stored, ok := index.Get(flight.Key)
+	require.True(t, ok)
+	assert.Equal(t, 31.45, stored.Latitude)
+
+	index.Remove(drone.Key)
+	assert.Empty(t, index.Nearby(31.2, 121.4, 2000, base))
+	assert.Equal(t, 2, index.Len())
+}
+
+func TestIndexExpiresStaleTracks(t *testing.T) {
+	index := NewIndex(0.1, time.Minute)
+	old := newTrack(KindDrone, 31.2, 121.4, base)
+	index.Upsert(old)
+
+	assert.Len(t, index.Nearby(31.2, 121.4, 1000, base.Add(30*time.Second)), 1)
+	assert.Empty(t, index.Nearby(31.2, 121.4, 1000, base.Add(2*time.Minute)))
+
+	// 新数据写入时清理失效实体
+	index.Upsert(newTrack(KindFlight, 31.2, 121.4, base.Add(2*time.Minute)))
+	_, ok := index.Get(old.Key)
+	assert.False(t, ok)
+	assert.Equal(t, 1, index.Len())
+}
+
+func TestIndexNearbyAcrossAntimeridian(t *testing.T) {
+	index := NewIndex(0.1, time.Minute)
+	east := newTrack(KindDrone, -17.0, 179.99, base)
+	west := newTrack(KindFlight, -17.0, -179.99, base)
+	index.Upsert(east)
+	index.Upsert(west)
+	// 填充已占用网格，确保走逐格扫描
+	for i := 0; i < 100; i++ {
+		index.Upsert(newTrack(KindFlight, float64(i%80), float64(i), base))
+	}
+
+	assert.ElementsMatch(t, []Key{east.Key, west.Key}, keys(index.Nearby(-17.0, 179.995, 5000, base)))
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AircraftFilter 飞机列表过滤条件
+type AircraftFilter struct {
+	AirlineID *uuid.UUID
+	Status    string
+	Model     string
+	Keyword   string // 模糊匹配 registration/model/manufacturer
+	Offset    int
+	Limit     int
+}
+
+// AircraftRepository 飞机仓储接口
+type AircraftRepository interface {
+	Create(ctx context.Context, aircraft *models.Aircraft) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Aircraft, error)
+	FindByRegistration(ctx context.Context, registration string) (*models.Aircraft, error)
+	FindByICAOHex(ctx context.Context, icaoHex string) (*models.Aircraft, error)
+	Update(ctx context.Context, aircraft *models.Aircraft) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter AircraftFilter) ([]models.Aircraft, int64, error)
+	ListByAirline(ctx context.Context, airlineID uuid.UUID) ([]models.Aircraft, error)
+	CountByAirline(ctx context.Context, airlineID uuid.UUID) (int64, error)
+}
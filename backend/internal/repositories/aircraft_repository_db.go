@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBAircraftRepository 数据库飞机仓储实现
+type DBAircraftRepository struct {
+	db *gorm.DB
+}
+
+// NewDBAircraftRepository 创建数据库飞机仓储实例
+func NewDBAircraftRepository(db *gorm.DB) AircraftRepository {
+	return &DBAircraftRepository{
+		db: db,
+	}
+}
+
+// Create 创建飞机
+func (r *DBAircraftRepository) Create(ctx context.Context, aircraft *models.Aircraft) error {
+	if aircraft.ID == uuid.Nil {
+		aircraft.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Omit("Airline").Create(aircraft).Error; err != nil {
+		logger.Errorf("创建飞机失败: %v", err)
+		return errors.New("创建飞机失败: " + err.Error())
+	}
+
+	logger.Infof("飞机创建成功: ID=%s, Registration=%s", aircraft.ID.String(), aircraft.Registration)
+	return nil
+}
+
+// FindByID 根据ID查找飞机，预加载所属航空公司
+func (r *DBAircraftRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+	var aircraft models.Aircraft
+	if err := r.db.WithContext(ctx).Preload("Airline").First(&aircraft, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找飞机失败: %v", err)
+		return nil, err
+	}
+	return &aircraft, nil
+}
+
+// FindByRegistration 根据注册号查找飞机
+func (r *DBAircraftRepository) FindByRegistration(ctx context.Context, registration string) (*models.Aircraft, error) {
+	var aircraft models.Aircraft
+	if err := r.db.WithContext(ctx).Where("registration = ?", strings.ToUpper(registration)).First(&aircraft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据注册号查找飞机失败: %v", err)
+		return nil, err
+	}
+	return &aircraft, nil
+}
+
+// FindByICAOHex 根据 ICAO 24 位地址查找飞机
+func (r *DBAircraftRepository) FindByICAOHex(ctx context.Context, icaoHex string) (*models.Aircraft, error) {
+	var aircraft models.Aircraft
+	if err := r.db.WithContext(ctx).Where("icao_hex = ?", strings.ToUpper(icaoHex)).First(&aircraft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ICAO地址查找飞机失败: %v", err)
+		return nil, err
+	}
+	return &aircraft, nil
+}
+
+// Update 更新飞机
+func (r *DBAircraftRepository) Update(ctx context.Context, aircraft *models.Aircraft) error {
+	if err := r.db.WithContext(ctx).Omit("Airline").Save(aircraft).Error; err != nil {
+		logger.Errorf("更新飞机失败: %v", err)
+		return errors.New("更新飞机失败: " + err.Error())
+	}
+
+	logger.Infof("飞机更新成功: ID=%s", aircraft.ID.String())
+	return nil
+}
+
+// Delete 删除飞机
+func (r *DBAircraftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Aircraft{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除飞机失败: %v", result.Error)
+		return errors.New("删除飞机失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("飞机删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询飞机
+func (r *DBAircraftRepository) List(ctx context.Context, filter AircraftFilter) ([]models.Aircraft, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Aircraft{})
+
+	if filter.AirlineID != nil {
+		query = query.Where("airline_id = ?", *filter.AirlineID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Model != "" {
+		query = query.Where("model = ?", filter.Model)
+	}
+	if filter.Keyword != "" {
+		like := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(registration) LIKE ? OR LOWER(model) LIKE ? OR LOWER(manufacturer) LIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计飞机数量失败: %v", err)
+		return nil, 0, errors.New("获取飞机列表失败: " + err.Error())
+	}
+
+	var aircraft []models.Aircraft
+	if err := query.Preload("Airline").Order("registration ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&aircraft).Error; err != nil {
+		logger.Errorf("获取飞机列表失败: %v", err)
+		return nil, 0, errors.New("获取飞机列表失败: " + err.Error())
+	}
+
+	return aircraft, total, nil
+}
+
+// ListByAirline 获取航空公司的全部机队
+func (r *DBAircraftRepository) ListByAirline(ctx context.Context, airlineID uuid.UUID) ([]models.Aircraft, error) {
+	var aircraft []models.Aircraft
+	if err := r.db.WithContext(ctx).Where("airline_id = ?", airlineID).Order("registration ASC").Find(&aircraft).Error; err != nil {
+		logger.Errorf("获取航空公司机队失败: %v", err)
+		return nil, errors.New("获取航空公司机队失败: " + err.Error())
+	}
+	return aircraft, nil
+}
+
+// CountByAirline 统计航空公司名下飞机数量
+func (r *DBAircraftRepository) CountByAirline(ctx context.Context, airlineID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Aircraft{}).Where("airline_id = ?", airlineID).Count(&count).Error; err != nil {
+		logger.Errorf("统计航空公司飞机数量失败: %v", err)
+		return 0, errors.New("统计航空公司飞机数量失败: " + err.Error())
+	}
+	return count, nil
+}
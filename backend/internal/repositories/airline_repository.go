@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AirlineFilter 航空公司列表过滤条件
+type AirlineFilter struct {
+	Country string
+	Type    string
+	Status  string
+	Keyword string // 模糊匹配 code/name/name_en/callsign
+	Offset  int
+	Limit   int
+}
+
+// AirlineRepository 航空公司仓储接口
+type AirlineRepository interface {
+	Create(ctx context.Context, airline *models.Airline) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Airline, error)
+	FindByCode(ctx context.Context, code string) (*models.Airline, error)
+	Update(ctx context.Context, airline *models.Airline) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter AirlineFilter) ([]models.Airline, int64, error)
+}
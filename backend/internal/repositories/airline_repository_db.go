@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBAirlineRepository 数据库航空公司仓储实现
+type DBAirlineRepository struct {
+	db *gorm.DB
+}
+
+// NewDBAirlineRepository 创建数据库航空公司仓储实例
+func NewDBAirlineRepository(db *gorm.DB) AirlineRepository {
+	return &DBAirlineRepository{
+		db: db,
+	}
+}
+
+// Create 创建航空公司
+func (r *DBAirlineRepository) Create(ctx context.Context, airline *models.Airline) error {
+	if airline.ID == uuid.Nil {
+		airline.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Create(airline).Error; err != nil {
+		logger.Errorf("创建航空公司失败: %v", err)
+		return errors.New("创建航空公司失败: " + err.Error())
+	}
+
+	logger.Infof("航空公司创建成功: ID=%s, Code=%s", airline.ID.String(), airline.Code)
+	return nil
+}
+
+// FindByID 根据ID查找航空公司
+func (r *DBAirlineRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Airline, error) {
+	var airline models.Airline
+	if err := r.db.WithContext(ctx).First(&airline, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找航空公司失败: %v", err)
+		return nil, err
+	}
+	return &airline, nil
+}
+
+// FindByCode 根据IATA代码查找航空公司
+func (r *DBAirlineRepository) FindByCode(ctx context.Context, code string) (*models.Airline, error) {
+	var airline models.Airline
+	if err := r.db.WithContext(ctx).Where("code = ?", strings.ToUpper(code)).First(&airline).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据代码查找航空公司失败: %v", err)
+		return nil, err
+	}
+	return &airline, nil
+}
+
+// Update 更新航空公司
+func (r *DBAirlineRepository) Update(ctx context.Context, airline *models.Airline) error {
+	if err := r.db.WithContext(ctx).Save(airline).Error; err != nil {
+		logger.Errorf("更新航空公司失败: %v", err)
+		return errors.New("更新航空公司失败: " + err.Error())
+	}
+
+	logger.Infof("航空公司更新成功: ID=%s", airline.ID.String())
+	return nil
+}
+
+// Delete 删除航空公司
+func (r *DBAirlineRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Airline{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除航空公司失败: %v", result.Error)
+		return errors.New("删除航空公司失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("航空公司删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询航空公司
+func (r *DBAirlineRepository) List(ctx context.Context, filter AirlineFilter) ([]models.Airline, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Airline{})
+
+	if filter.Country != "" {
+		query = query.Where("country = ?", filter.Country)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Keyword != "" {
+		like := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(code) LIKE ? OR LOWER(name) LIKE ? OR LOWER(name_en) LIKE ? OR LOWER(callsign) LIKE ?",
+			like, like, like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计航空公司数量失败: %v", err)
+		return nil, 0, errors.New("获取航空公司列表失败: " + err.Error())
+	}
+
+	var airlines []models.Airline
+	if err := query.Order("code ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&airlines).Error; err != nil {
+		logger.Errorf("获取航空公司列表失败: %v", err)
+		return nil, 0, errors.New("获取航空公司列表失败: " + err.Error())
+	}
+
+	return airlines, total, nil
+}
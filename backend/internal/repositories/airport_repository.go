@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AirportFilter 机场列表过滤条件
+type AirportFilter struct {
+	Country string
+	City    string
+	Type    string
+	Status  string
+	Keyword string // 模糊匹配 code/name/name_en
+	Offset  int
+	Limit   int
+}
+
+// AirportRepository 机场仓储接口
+type AirportRepository interface {
+	Create(ctx context.Context, airport *models.Airport) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Airport, error)
+	FindByCode(ctx context.Context, code string) (*models.Airport, error)
+	Update(ctx context.Context, airport *models.Airport) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter AirportFilter) ([]models.Airport, int64, error)
+	// FindInBBox 查询包围盒内的机场，仅使用 filter 中的 Type/Status/Limit
+	FindInBBox(ctx context.Context, box geo.BBox, filter AirportFilter) ([]models.Airport, error)
+}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBAirportRepository 数据库机场仓储实现
+// 使用GORM与MySQL/PostgreSQL交互
+type DBAirportRepository struct {
+	db *gorm.DB
+}
+
+// NewDBAirportRepository 创建数据库机场仓储实例
+func NewDBAirportRepository(db *gorm.DB) AirportRepository {
+	return &DBAirportRepository{
+		db: db,
+	}
+}
+
+// Create 创建机场
+func (r *DBAirportRepository) Create(ctx context.Context, airport *models.Airport) error {
+	if airport.ID == uuid.Nil {
+		airport.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Create(airport).Error; err != nil {
+		logger.Errorf("创建机场失败: %v", err)
+		return errors.New("创建机场失败: " + err.Error())
+	}
+
+	logger.Infof("机场创建成功: ID=%s, Code=%s", airport.ID.String(), airport.Code)
+	return nil
+}
+
+// FindByID 根据ID查找机场
+func (r *DBAirportRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Airport, error) {
+	var airport models.Airport
+	if err := r.db.WithContext(ctx).First(&airport, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找机场失败: %v", err)
+		return nil, err
+	}
+	return &airport, nil
+}
+
+// FindByCode 根据IATA代码查找机场
+func (r *DBAirportRepository) FindByCode(ctx context.Context, code string) (*models.Airport, error) {
+	var airport models.Airport
+	if err := r.db.WithContext(ctx).Where("code = ?", strings.ToUpper(code)).First(&airport).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据代码查找机场失败: %v", err)
+		return nil, err
+	}
+	return &airport, nil
+}
+
+// Update 更新机场
+func (r *DBAirportRepository) Update(ctx context.Context, airport *models.Airport) error {
+	if err := r.db.WithContext(ctx).Save(airport).Error; err != nil {
+		logger.Errorf("更新机场失败: %v", err)
+		return errors.New("更新机场失败: " + err.Error())
+	}
+
+	logger.Infof("机场更新成功: ID=%s", airport.ID.String())
+	return nil
+}
+
+// Delete 删除机场
+func (r *DBAirportRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Airport{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除机场失败: %v", result.Error)
+		return errors.New("删除机场失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("机场删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询机场
+// 返回当前页数据和满足条件的总数
+func (r *DBAirportRepository) List(ctx context.Context, filter AirportFilter) ([]models.Airport, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Airport{})
+
+	if filter.Country != "" {
+		query = query.Where("country = ?", filter.Country)
+	}
+	if filter.City != "" {
+		query = query.Where("city = ?", filter.City)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Keyword != "" {
+		// 使用 LOWER 保证 MySQL 与 PostgreSQL 下均不区分大小写
+		like := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(code) LIKE ? OR LOWER(name) LIKE ? OR LOWER(name_en) LIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计机场数量失败: %v", err)
+		return nil, 0, errors.New("获取机场列表失败: " + err.Error())
+	}
+
+	var airports []models.Airport
+	if err := query.Order("code ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&airports).Error; err != nil {
+		logger.Errorf("获取机场列表失败: %v", err)
+		return nil, 0, errors.New("获取机场列表失败: " + err.Error())
+	}
+
+	return airports, total, nil
+}
+
+// FindInBBox 查询包围盒内的机场
+// 仅使用标准比较运算，MySQL 与 PostgreSQL 通用
+func (r *DBAirportRepository) FindInBBox(ctx context.Context, box geo.BBox, filter AirportFilter) ([]models.Airport, error) {
+	query := r.db.WithContext(ctx).
+		Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+
+	if box.CrossesAntimeridian() {
+		query = query.Where("longitude >= ? OR longitude <= ?", box.MinLng, box.MaxLng)
+	} else {
+		query = query.Where("longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var airports []models.Airport
+	if err := query.Order("code ASC").Find(&airports).Error; err != nil {
+		logger.Errorf("按范围查询机场失败: %v", err)
+		return nil, errors.New("按范围查询机场失败: " + err.Error())
+	}
+	return airports, nil
+}
@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// airportDocument 机场在MongoDB中的文档结构
+// 使用字符串形式的UUID作为 _id，字段名与JSON保持一致
+type airportDocument struct {
+	ID          string    `bson:"_id"`
+	Code        string    `bson:"code"`
+	Name        string    `bson:"name"`
+	NameEn      string    `bson:"name_en"`
+	City        string    `bson:"city"`
+	Country     string    `bson:"country"`
+	Latitude    float64   `bson:"latitude"`
+	Longitude   float64   `bson:"longitude"`
+	Altitude    float64   `bson:"altitude"`
+	Timezone    string    `bson:"timezone"`
+	Type        string    `bson:"type"`
+	Status      string    `bson:"status"`
+	Description string    `bson:"description"`
+	CreatedAt   time.Time `bson:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+func newAirportDocument(a *models.Airport) *airportDocument {
+	return &airportDocument{
+		ID:          a.ID.String(),
+		Code:        a.Code,
+		Name:        a.Name,
+		NameEn:      a.NameEn,
+		City:        a.City,
+		Country:     a.Country,
+		Latitude:    a.Latitude,
+		Longitude:   a.Longitude,
+		Altitude:    a.Altitude,
+		Timezone:    a.Timezone,
+		Type:        a.Type,
+		Status:      a.Status,
+		Description: a.Description,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
+
+func (d *airportDocument) toModel() models.Airport {
+	id, _ := uuid.Parse(d.ID)
+	return models.Airport{
+		ID:          id,
+		Code:        d.Code,
+		Name:        d.Name,
+		NameEn:      d.NameEn,
+		City:        d.City,
+		Country:     d.Country,
+		Latitude:    d.Latitude,
+		Longitude:   d.Longitude,
+		Altitude:    d.Altitude,
+		Timezone:    d.Timezone,
+		Type:        d.Type,
+		Status:      d.Status,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+// MongoAirportRepository MongoDB机场仓储实现
+type MongoAirportRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAirportRepository 创建MongoDB机场仓储实例
+func NewMongoAirportRepository(db *mongo.Database) AirportRepository {
+	return &MongoAirportRepository{
+		collection: db.Collection("airports"),
+	}
+}
+
+// Create 创建机场
+func (r *MongoAirportRepository) Create(ctx context.Context, airport *models.Airport) error {
+	if airport.ID == uuid.Nil {
+		airport.ID = uuid.New()
+	}
+	now := time.Now()
+	airport.CreatedAt = now
+	airport.UpdatedAt = now
+
+	if _, err := r.collection.InsertOne(ctx, newAirportDocument(airport)); err != nil {
+		logger.Errorf("创建机场失败: %v", err)
+		return errors.New("创建机场失败: " + err.Error())
+	}
+
+	logger.Infof("机场创建成功: ID=%s, Code=%s", airport.ID.String(), airport.Code)
+	return nil
+}
+
+// FindByID 根据ID查找机场
+func (r *MongoAirportRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Airport, error) {
+	return r.findOne(ctx, bson.M{"_id": id.String()})
+}
+
+// FindByCode 根据IATA代码查找机场
+func (r *MongoAirportRepository) FindByCode(ctx context.Context, code string) (*models.Airport, error) {
+	return r.findOne(ctx, bson.M{"code": strings.ToUpper(code)})
+}
+
+func (r *MongoAirportRepository) findOne(ctx context.Context, filter bson.M) (*models.Airport, error) {
+	var doc airportDocument
+	if err := r.collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("查找机场失败: %v", err)
+		return nil, err
+	}
+	airport := doc.toModel()
+	return &airport, nil
+}
+
+// Update 更新机场
+func (r *MongoAirportRepository) Update(ctx context.Context, airport *models.Airport) error {
+	airport.UpdatedAt = time.Now()
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": airport.ID.String()}, newAirportDocument(airport))
+	if err != nil {
+		logger.Errorf("更新机场失败: %v", err)
+		return errors.New("更新机场失败: " + err.Error())
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("机场更新成功: ID=%s", airport.ID.String())
+	return nil
+}
+
+// Delete 删除机场
+func (r *MongoAirportRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.String()})
+	if err != nil {
+		logger.Errorf("删除机场失败: %v", err)
+		return errors.New("删除机场失败: " + err.Error())
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("机场删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询机场
+func (r *MongoAirportRepository) List(ctx context.Context, filter AirportFilter) ([]models.Airport, int64, error) {
+	query := bson.M{}
+	if filter.Country != "" {
+		query["country"] = filter.Country
+	}
+	if filter.City != "" {
+		query["city"] = filter.City
+	}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Keyword != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Keyword), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"code": pattern},
+			bson.M{"name": pattern},
+			bson.M{"name_en": pattern},
+		}
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		logger.Errorf("统计机场数量失败: %v", err)
+		return nil, 0, errors.New("获取机场列表失败: " + err.Error())
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "code", Value: 1}}).
+		SetSkip(int64(filter.Offset))
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	airports, err := r.find(ctx, query, opts)
+	if err != nil {
+		logger.Errorf("获取机场列表失败: %v", err)
+		return nil, 0, errors.New("获取机场列表失败: " + err.Error())
+	}
+	return airports, total, nil
+}
+
+// FindInBBox 查询包围盒内的机场
+func (r *MongoAirportRepository) FindInBBox(ctx context.Context, box geo.BBox, filter AirportFilter) ([]models.Airport, error) {
+	query := bson.M{
+		"latitude": bson.M{"$gte": box.MinLat, "$lte": box.MaxLat},
+	}
+	if box.CrossesAntimeridian() {
+		query["$or"] = bson.A{
+			bson.M{"longitude": bson.M{"$gte": box.MinLng}},
+			bson.M{"longitude": bson.M{"$lte": box.MaxLng}},
+		}
+	} else {
+		query["longitude"] = bson.M{"$gte": box.MinLng, "$lte": box.MaxLng}
+	}
+	if filter.Type != "" {
+		query["type"] = filter.Type
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+
+	airports, err := r.find(ctx, query, opts)
+	if err != nil {
+		logger.Errorf("按范围查询机场失败: %v", err)
+		return nil, errors.New("按范围查询机场失败: " + err.Error())
+	}
+	return airports, nil
+}
+
+func (r *MongoAirportRepository) find(ctx context.Context, query bson.M, opts *options.FindOptions) ([]models.Airport, error) {
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []airportDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	airports := make([]models.Airport, len(docs))
+	for i := range docs {
+		airports[i] = docs[i].toModel()
+	}
+	return airports, nil
+}
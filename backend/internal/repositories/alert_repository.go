@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertFilter 告警列表过滤条件
+type AlertFilter struct {
+	Types      []string
+	Statuses   []string
+	Severities []string
+	EntityType string     // 同时匹配关联对象
+	EntityID   *uuid.UUID // 同时匹配关联对象
+	From       *time.Time // 触发时间下限
+	To         *time.Time // 触发时间上限
+	Offset     int
+	Limit      int
+}
+
+// AlertRepository 告警仓储接口
+type AlertRepository interface {
+	Create(ctx context.Context, alert *models.Alert) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Alert, error)
+	Update(ctx context.Context, alert *models.Alert) error
+	List(ctx context.Context, filter AlertFilter) ([]models.Alert, int64, error)
+	// FindActive 查找对象指定类型的未解除告警，relatedID 为 nil 时只匹配没有关联对象的告警，不存在时返回 ErrNotFound
+	FindActive(ctx context.Context, alertType, entityType string, entityID uuid.UUID, relatedID *uuid.UUID) (*models.Alert, error)
+	// ListActive 查找指定类型的全部未解除告警
+	ListActive(ctx context.Context, alertType string) ([]models.Alert, error)
+	// CountDroneAlertsByOperator 按无人机所属运营商统计触发时间在 [from, to) 内的指定类型告警，operatorID 为 nil 时统计全部运营商
+	CountDroneAlertsByOperator(ctx context.Context, alertType string, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]int64, error)
+}
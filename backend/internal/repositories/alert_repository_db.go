@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBAlertRepository 数据库告警仓储实现
+type DBAlertRepository struct {
+	db *gorm.DB
+}
+
+// NewDBAlertRepository 创建数据库告警仓储实例
+func NewDBAlertRepository(db *gorm.DB) AlertRepository {
+	return &DBAlertRepository{
+		db: db,
+	}
+}
+
+// Create 创建告警
+func (r *DBAlertRepository) Create(ctx context.Context, alert *models.Alert) error {
+	if alert.ID == uuid.Nil {
+		alert.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Create(alert).Error; err != nil {
+		logger.Errorf("创建告警失败: %v", err)
+		return errors.New("创建告警失败: " + err.Error())
+	}
+
+	logger.Infof("告警已创建: ID=%s, Type=%s, Entity=%s/%s", alert.ID.String(), alert.Type, alert.EntityType, alert.EntityID.String())
+	return nil
+}
+
+// FindByID 根据ID查找告警
+func (r *DBAlertRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Alert, error) {
+	var alert models.Alert
+	if err := r.db.WithContext(ctx).First(&alert, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找告警失败: %v", err)
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// Update 更新告警
+func (r *DBAlertRepository) Update(ctx context.Context, alert *models.Alert) error {
+	if err := r.db.WithContext(ctx).Save(alert).Error; err != nil {
+		logger.Errorf("更新告警失败: %v", err)
+		return errors.New("更新告警失败: " + err.Error())
+	}
+	return nil
+}
+
+// List 分页查询告警，按触发时间倒序
+func (r *DBAlertRepository) List(ctx context.Context, filter AlertFilter) ([]models.Alert, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Alert{})
+
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.Severities) > 0 {
+		query = query.Where("severity IN ?", filter.Severities)
+	}
+	// 对象条件同时匹配告警对象和关联对象
+	if filter.EntityType != "" || filter.EntityID != nil {
+		var own, related []string
+		var ownArgs, relatedArgs []any
+		if filter.EntityType != "" {
+			own, related = append(own, "entity_type = ?"), append(related, "related_type = ?")
+			ownArgs, relatedArgs = append(ownArgs, filter.EntityType), append(relatedArgs, filter.EntityType)
+		}
+		if filter.EntityID != nil {
+			own, related = append(own, "entity_id = ?"), append(related, "related_id = ?")
+			ownArgs, relatedArgs = append(ownArgs, *filter.EntityID), append(relatedArgs, *filter.EntityID)
+		}
+		query = query.Where("(("+strings.Join(own, " AND ")+") OR ("+strings.Join(related, " AND ")+"))", append(ownArgs, relatedArgs...)...)
+	}
+	if filter.From != nil {
+		query = query.Where("triggered_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("triggered_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计告警数量失败: %v", err)
+		return nil, 0, errors.New("获取告警列表失败: " + err.Error())
+	}
+
+	var alerts []models.Alert
+	if err := query.Order("triggered_at DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&alerts).Error; err != nil {
+		logger.Errorf("获取告警列表失败: %v", err)
+		return nil, 0, errors.New("获取告警列表失败: " + err.Error())
+	}
+
+	return alerts, total, nil
+}
+
+// FindActive 查找对象指定类型的未解除告警
+func (r *DBAlertRepository) FindActive(ctx context.Context, alertType, entityType string, entityID uuid.UUID, relatedID *uuid.UUID) (*models.Alert, error) {
+	query := r.db.WithContext(ctx).
+		Where("type = ? AND entity_type = ? AND entity_id = ? AND status <> ?", alertType, entityType, entityID, models.AlertStatusResolved)
+	if relatedID != nil {
+		query = query.Where("related_id = ?", *relatedID)
+	} else {
+		query = query.Where("related_id IS NULL")
+	}
+
+	var alert models.Alert
+	err := query.
+		Order("triggered_at DESC").
+		First(&alert).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("查找未解除告警失败: %v", err)
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// ListActive 查找指定类型的全部未解除告警
+func (r *DBAlertRepository) ListActive(ctx context.Context, alertType string) ([]models.Alert, error) {
+	var alerts []models.Alert
+	if err := r.db.WithContext(ctx).
+		Where("type = ? AND status <> ?", alertType, models.AlertStatusResolved).
+		Order("triggered_at").
+		Find(&alerts).Error; err != nil {
+		logger.Errorf("查找未解除告警失败: %v", err)
+		return nil, errors.New("查找未解除告警失败: " + err.Error())
+	}
+	return alerts, nil
+}
+
+// CountDroneAlertsByOperator 按运营商统计无人机告警数量
+func (r *DBAlertRepository) CountDroneAlertsByOperator(ctx context.Context, alertType string, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Alert{}).
+		Select("drones.operator_id, COUNT(*) AS count").
+		Joins("JOIN drones ON drones.id = alerts.entity_id").
+		Where("alerts.type = ? AND alerts.entity_type = ? AND drones.operator_id IS NOT NULL", alertType, models.AlertEntityDrone).
+		Where("alerts.triggered_at >= ? AND alerts.triggered_at < ?", from, to)
+	if operatorID != nil {
+		query = query.Where("drones.operator_id = ?", *operatorID)
+	}
+
+	var rows []struct {
+		OperatorID uuid.UUID
+		Count      int64
+	}
+	if err := query.Group("drones.operator_id").Scan(&rows).Error; err != nil {
+		logger.Errorf("统计运营商告警数量失败: %v", err)
+		return nil, errors.New("统计运营商告警数量失败: " + err.Error())
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.OperatorID] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// 少数日期时间表达式在 PostgreSQL 与 MySQL 上写法不同，按连接的数据库方言生成
+
+// isMySQL 当前连接是否为 MySQL
+func isMySQL(db *gorm.DB) bool {
+	return db.Dialector.Name() == "mysql"
+}
+
+// sqlAddMinutes 时间列加上分钟数（分钟数可以是列或表达式）
+func sqlAddMinutes(db *gorm.DB, column, minutes string) string {
+	if isMySQL(db) {
+		return "DATE_ADD(" + column + ", INTERVAL " + minutes + " MINUTE)"
+	}
+	return "(" + column + " + " + minutes + " * INTERVAL '1 minute')"
+}
+
+// sqlMinutesBetween 两个时间列相差的整分钟数（end - start）
+func sqlMinutesBetween(db *gorm.DB, start, end string) string {
+	if isMySQL(db) {
+		return "TIMESTAMPDIFF(MINUTE, " + start + ", " + end + ")"
+	}
+	return "FLOOR(EXTRACT(EPOCH FROM " + end + " - " + start + ") / 60)"
+}
+
+// sqlWeekStart 日期列所在周（周一开始）的第一天
+func sqlWeekStart(db *gorm.DB, column string) string {
+	if isMySQL(db) {
+		return "DATE_SUB(" + column + ", INTERVAL WEEKDAY(" + column + ") DAY)"
+	}
+	return "CAST(DATE_TRUNC('week', CAST(" + column + " AS timestamp)) AS date)"
+}
+
+// sqlMonthStart 日期列所在月的第一天
+func sqlMonthStart(db *gorm.DB, column string) string {
+	if isMySQL(db) {
+		return "DATE_SUB(" + column + ", INTERVAL DAYOFMONTH(" + column + ") - 1 DAY)"
+	}
+	return "CAST(DATE_TRUNC('month', CAST(" + column + " AS timestamp)) AS date)"
+}
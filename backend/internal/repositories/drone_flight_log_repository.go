@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DroneFlightLogFilter 飞行日志列表过滤条件
+type DroneFlightLogFilter struct {
+	DroneID   *uuid.UUID
+	MissionID *uuid.UUID
+	From      *time.Time // 降落时间下限
+	To        *time.Time // 降落时间上限
+	Offset    int
+	Limit     int
+}
+
+// FlightTotals 无人机累计飞行时间和次数
+type FlightTotals struct {
+	Minutes int64 // 累计飞行时间（分钟）
+	Flights int64 // 累计飞行次数
+}
+
+// DroneFlightLogRepository 无人机飞行日志仓储接口
+type DroneFlightLogRepository interface {
+	Create(ctx context.Context, log *models.DroneFlightLog) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.DroneFlightLog, error)
+	// List 分页查询飞行日志，按降落时间倒序
+	List(ctx context.Context, filter DroneFlightLogFilter) ([]models.DroneFlightLog, int64, error)
+	// CountUnplannedByOperator 按无人机所属运营商统计降落时间在 [from, to) 内、未关联任务的飞行次数，operatorID 为 nil 时统计全部运营商
+	CountUnplannedByOperator(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]int64, error)
+	// TotalsByDrone 按飞行日志汇总各无人机的累计飞行时间和次数
+	TotalsByDrone(ctx context.Context, droneIDs []uuid.UUID) (map[uuid.UUID]FlightTotals, error)
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DBDroneFlightLogRepository 数据库飞行日志仓储实现
+type DBDroneFlightLogRepository struct {
+	db *gorm.DB
+}
+
+// NewDBDroneFlightLogRepository 创建数据库飞行日志仓储实例
+func NewDBDroneFlightLogRepository(db *gorm.DB) DroneFlightLogRepository {
+	return &DBDroneFlightLogRepository{
+		db: db,
+	}
+}
+
+// Create 创建飞行日志
+func (r *DBDroneFlightLogRepository) Create(ctx context.Context, log *models.DroneFlightLog) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(log).Error; err != nil {
+		logger.Errorf("创建飞行日志失败: %v", err)
+		return errors.New("创建飞行日志失败: " + err.Error())
+	}
+	return nil
+}
+
+// FindByID 根据ID查找飞行日志
+func (r *DBDroneFlightLogRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.DroneFlightLog, error) {
+	var log models.DroneFlightLog
+	if err := r.db.WithContext(ctx).First(&log, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找飞行日志失败: %v", err)
+		return nil, err
+	}
+	return &log, nil
+}
+
+// List 分页查询飞行日志，按降落时间倒序
+func (r *DBDroneFlightLogRepository) List(ctx context.Context, filter DroneFlightLogFilter) ([]models.DroneFlightLog, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.DroneFlightLog{})
+
+	if filter.DroneID != nil {
+		query = query.Where("drone_id = ?", *filter.DroneID)
+	}
+	if filter.MissionID != nil {
+		query = query.Where("mission_id = ?", *filter.MissionID)
+	}
+	if filter.From != nil {
+		query = query.Where("landing_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("landing_time < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计飞行日志数量失败: %v", err)
+		return nil, 0, errors.New("获取飞行日志列表失败: " + err.Error())
+	}
+
+	var logs []models.DroneFlightLog
+	if err := query.Order("landing_time DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&logs).Error; err != nil {
+		logger.Errorf("获取飞行日志列表失败: %v", err)
+		return nil, 0, errors.New("获取飞行日志列表失败: " + err.Error())
+	}
+
+	return logs, total, nil
+}
+
+// CountUnplannedByOperator 按运营商统计未关联任务的飞行次数
+func (r *DBDroneFlightLogRepository) CountUnplannedByOperator(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.DroneFlightLog{}).
+		Select("drones.operator_id, COUNT(*) AS count").
+		Joins("JOIN drones ON drones.id = drone_flight_logs.drone_id").
+		Where("drone_flight_logs.mission_id IS NULL AND drones.operator_id IS NOT NULL").
+		Where("drone_flight_logs.landing_time >= ? AND drone_flight_logs.landing_time < ?", from, to)
+	if operatorID != nil {
+		query = query.Where("drones.operator_id = ?", *operatorID)
+	}
+
+	var rows []struct {
+		OperatorID uuid.UUID
+		Count      int64
+	}
+	if err := query.Group("drones.operator_id").Scan(&rows).Error; err != nil {
+		logger.Errorf("统计无任务飞行次数失败: %v", err)
+		return nil, errors.New("统计无任务飞行次数失败: " + err.Error())
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.OperatorID] = row.Count
+	}
+	return counts, nil
+}
+
+// TotalsByDrone 汇总各无人机的累计飞行时间和次数
+func (r *DBDroneFlightLogRepository) TotalsByDrone(ctx context.Context, droneIDs []uuid.UUID) (map[uuid.UUID]FlightTotals, error) {
+	totals := make(map[uuid.UUID]FlightTotals, len(droneIDs))
+	if len(droneIDs) == 0 {
+		return totals, nil
+	}
+
+	var rows []struct {
+		DroneID uuid.UUID
+		FlightTotals
+	}
+	err := r.db.WithContext(ctx).Model(&models.DroneFlightLog{}).
+		Select("drone_id, COALESCE(SUM(flight_duration), 0) AS minutes, COUNT(*) AS flights").
+		Where("drone_id IN ?", droneIDs).
+		Group("drone_id").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Errorf("汇总无人机飞行时间失败: %v", err)
+		return nil, errors.New("汇总无人机飞行时间失败: " + err.Error())
+	}
+
+	for _, row := range rows {
+		totals[row.DroneID] = row.FlightTotals
+	}
+	return totals, nil
+}
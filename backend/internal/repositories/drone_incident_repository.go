@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DroneIncidentFilter 无人机事件列表过滤条件
+type DroneIncidentFilter struct {
+	OperatorID     *uuid.UUID
+	DroneID        *uuid.UUID
+	MissionID      *uuid.UUID
+	InvestigatorID *uuid.UUID
+	Types          []string
+	Severities     []string
+	Statuses       []string
+	DetectionRule  string
+	From           *time.Time // 发生时间下限
+	To             *time.Time // 发生时间上限
+	Offset         int
+	Limit          int
+}
+
+// DroneIncidentRepository 无人机事件仓储接口
+type DroneIncidentRepository interface {
+	Create(ctx context.Context, incident *models.DroneIncident) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.DroneIncident, error)
+	// UpdateInvestigation 以当前调查状态作为更新条件保存调查字段，状态已变更时返回 ErrStaleState
+	UpdateInvestigation(ctx context.Context, incident *models.DroneIncident, fromStatus string) error
+	// ConfirmDraft 保存人工确认后的事件内容，仅草稿状态的事件可以确认，状态已变更时返回 ErrStaleState
+	ConfirmDraft(ctx context.Context, incident *models.DroneIncident) error
+	// List 分页查询事件，按严重程度由重到轻、发生时间倒序
+	List(ctx context.Context, filter DroneIncidentFilter) ([]models.DroneIncident, int64, error)
+	// CountBySeverity 按运营商和严重程度统计发生时间在 [from, to) 内的已确认事件（不含草稿和已驳回），operatorID 为 nil 时统计全部运营商
+	CountBySeverity(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]map[string]int64, error)
+}
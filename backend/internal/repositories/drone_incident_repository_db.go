@@ -0,0 +1,205 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// incidentOrder 按严重程度由重到轻、发生时间倒序排序
+var incidentOrder = clause.OrderBy{
+	Expression: clause.Expr{
+		SQL: "CASE severity WHEN ? THEN 4 WHEN ? THEN 3 WHEN ? THEN 2 WHEN ? THEN 1 ELSE 0 END DESC, incident_date DESC",
+		Vars: []any{
+			models.IncidentSeverityCritical, models.IncidentSeveritySerious,
+			models.IncidentSeverityModerate, models.IncidentSeverityMinor,
+		},
+	},
+}
+
+// DBDroneIncidentRepository 数据库无人机事件仓储实现
+type DBDroneIncidentRepository struct {
+	db *gorm.DB
+}
+
+// NewDBDroneIncidentRepository 创建数据库无人机事件仓储实例
+func NewDBDroneIncidentRepository(db *gorm.DB) DroneIncidentRepository {
+	return &DBDroneIncidentRepository{
+		db: db,
+	}
+}
+
+// Create 创建事件
+func (r *DBDroneIncidentRepository) Create(ctx context.Context, incident *models.DroneIncident) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(incident).Error; err != nil {
+		logger.Errorf("创建无人机事件失败: %v", err)
+		return errors.New("创建无人机事件失败: " + err.Error())
+	}
+
+	logger.Infof("无人机事件创建成功: ID=%s, type=%s, severity=%s", incident.ID.String(), incident.IncidentType, incident.Severity)
+	return nil
+}
+
+// FindByID 根据ID查找事件，预加载无人机和调查人
+func (r *DBDroneIncidentRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.DroneIncident, error) {
+	var incident models.DroneIncident
+	err := r.db.WithContext(ctx).
+		Preload("Drone").
+		Preload("Investigator", func(db *gorm.DB) *gorm.DB { return db.Select("id", "username", "full_name", "role") }).
+		First(&incident, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找无人机事件失败: %v", err)
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// UpdateInvestigation 以当前调查状态作为更新条件保存调查字段
+func (r *DBDroneIncidentRepository) UpdateInvestigation(ctx context.Context, incident *models.DroneIncident, fromStatus string) error {
+	result := r.db.WithContext(ctx).Model(&models.DroneIncident{}).
+		Where("id = ? AND investigation_status = ?", incident.ID, fromStatus).
+		Updates(map[string]any{
+			"investigation_status":  incident.InvestigationStatus,
+			"investigator_id":       incident.InvestigatorID,
+			"investigation_notes":   incident.InvestigationNotes,
+			"cause":                 incident.Cause,
+			"root_cause":            incident.RootCause,
+			"corrective_actions":    incident.CorrectiveActions,
+			"preventive_measures":   incident.PreventiveMeasures,
+			"reported_to_authority": incident.ReportedToAuthority,
+			"authority_case_number": incident.AuthorityCaseNumber,
+			"updated_at":            time.Now(),
+		})
+	if result.Error != nil {
+		logger.Errorf("更新无人机事件调查信息失败: %v", result.Error)
+		return errors.New("更新无人机事件调查信息失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleState
+	}
+
+	logger.Infof("无人机事件调查信息更新成功: ID=%s, %s -> %s", incident.ID.String(), fromStatus, incident.InvestigationStatus)
+	return nil
+}
+
+// ConfirmDraft 以草稿状态作为更新条件保存人工确认的事件内容
+func (r *DBDroneIncidentRepository) ConfirmDraft(ctx context.Context, incident *models.DroneIncident) error {
+	result := r.db.WithContext(ctx).Model(&models.DroneIncident{}).
+		Where("id = ? AND investigation_status = ?", incident.ID, models.InvestigationStatusDraft).
+		Updates(map[string]any{
+			"incident_type":               incident.IncidentType,
+			"severity":                    incident.Severity,
+			"description":                 incident.Description,
+			"cause":                       incident.Cause,
+			"drone_damage":                incident.DroneDamage,
+			"property_damage":             incident.PropertyDamage,
+			"property_damage_description": incident.PropertyDamageDescription,
+			"injuries":                    incident.Injuries,
+			"injury_description":          incident.InjuryDescription,
+			"investigation_status":        incident.InvestigationStatus,
+			"updated_at":                  time.Now(),
+		})
+	if result.Error != nil {
+		logger.Errorf("确认无人机事件失败: %v", result.Error)
+		return errors.New("确认无人机事件失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleState
+	}
+
+	logger.Infof("无人机事件确认成功: ID=%s, type=%s, severity=%s", incident.ID.String(), incident.IncidentType, incident.Severity)
+	return nil
+}
+
+// List 分页查询事件，按严重程度由重到轻、发生时间倒序
+func (r *DBDroneIncidentRepository) List(ctx context.Context, filter DroneIncidentFilter) ([]models.DroneIncident, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.DroneIncident{})
+
+	if filter.OperatorID != nil {
+		query = query.Where("operator_id = ?", *filter.OperatorID)
+	}
+	if filter.DroneID != nil {
+		query = query.Where("drone_id = ?", *filter.DroneID)
+	}
+	if filter.MissionID != nil {
+		query = query.Where("mission_id = ?", *filter.MissionID)
+	}
+	if filter.InvestigatorID != nil {
+		query = query.Where("investigator_id = ?", *filter.InvestigatorID)
+	}
+	if len(filter.Types) > 0 {
+		query = query.Where("incident_type IN ?", filter.Types)
+	}
+	if len(filter.Severities) > 0 {
+		query = query.Where("severity IN ?", filter.Severities)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("investigation_status IN ?", filter.Statuses)
+	}
+	if filter.DetectionRule != "" {
+		query = query.Where("detection_rule = ?", filter.DetectionRule)
+	}
+	if filter.From != nil {
+		query = query.Where("incident_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("incident_date <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计无人机事件数量失败: %v", err)
+		return nil, 0, errors.New("获取无人机事件列表失败: " + err.Error())
+	}
+
+	var incidents []models.DroneIncident
+	err := query.Preload("Drone").
+		Order(incidentOrder).
+		Offset(filter.Offset).Limit(filter.Limit).
+		Find(&incidents).Error
+	if err != nil {
+		logger.Errorf("获取无人机事件列表失败: %v", err)
+		return nil, 0, errors.New("获取无人机事件列表失败: " + err.Error())
+	}
+
+	return incidents, total, nil
+}
+
+// CountBySeverity 按运营商和严重程度统计已确认事件
+func (r *DBDroneIncidentRepository) CountBySeverity(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]map[string]int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.DroneIncident{}).
+		Select("operator_id, severity, COUNT(*) AS count").
+		Where("operator_id IS NOT NULL AND investigation_status NOT IN ?", []string{models.InvestigationStatusDraft, models.InvestigationStatusDismissed}).
+		Where("incident_date >= ? AND incident_date < ?", from, to)
+	if operatorID != nil {
+		query = query.Where("operator_id = ?", *operatorID)
+	}
+
+	var rows []struct {
+		OperatorID uuid.UUID
+		Severity   string
+		Count      int64
+	}
+	if err := query.Group("operator_id, severity").Scan(&rows).Error; err != nil {
+		logger.Errorf("统计运营商事件数量失败: %v", err)
+		return nil, errors.New("统计运营商事件数量失败: " + err.Error())
+	}
+
+	counts := make(map[uuid.UUID]map[string]int64)
+	for _, row := range rows {
+		if counts[row.OperatorID] == nil {
+			counts[row.OperatorID] = make(map[string]int64)
+		}
+		counts[row.OperatorID][row.Severity] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DroneMissionFilter 无人机任务列表过滤条件
+type DroneMissionFilter struct {
+	OperatorID       *uuid.UUID
+	DroneID          *uuid.UUID
+	PilotID          *uuid.UUID
+	Statuses         []string
+	ApprovalStatuses []string
+	From             *time.Time // 计划开始时间下限
+	To               *time.Time // 计划开始时间上限
+	Offset           int
+	Limit            int
+}
+
+// MissionApprovalCount 运营商实际起飞任务按是否需要审批和审批状态分组的数量
+type MissionApprovalCount struct {
+	RequiresApproval bool
+	ApprovalStatus   *string
+	Count            int64
+}
+
+// DroneMissionRepository 无人机任务仓储接口
+// 任务的每次变更与审计记录在同一事务中写入
+type DroneMissionRepository interface {
+	Create(ctx context.Context, mission *models.DroneMission, log *models.DroneMissionLog) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.DroneMission, error)
+	// FindActiveByDrone 查找无人机正在执行的任务，不存在时返回 ErrNotFound
+	FindActiveByDrone(ctx context.Context, droneID uuid.UUID) (*models.DroneMission, error)
+	// Update 以任务当前状态和审批状态作为更新条件保存任务，条件不满足时返回 ErrStaleState
+	Update(ctx context.Context, mission *models.DroneMission, fromStatus string, fromApproval *string, log *models.DroneMissionLog) error
+	// ChangeStatus 以任务当前状态和审批状态作为更新条件变更任务，droneStatus 非空时同时更新无人机状态
+	// 无人机状态以 mission.Drone.Status 作为更新条件，任一条件不满足时返回 ErrStaleState
+	ChangeStatus(ctx context.Context, mission *models.DroneMission, fromStatus string, fromApproval *string, log *models.DroneMissionLog, droneStatus string) error
+	List(ctx context.Context, filter DroneMissionFilter) ([]models.DroneMission, int64, error)
+	ListLogs(ctx context.Context, missionID uuid.UUID) ([]models.DroneMissionLog, error)
+	// ApprovalCountsByOperator 按运营商统计实际起飞时间在 [from, to) 内的任务审批情况，operatorID 为 nil 时统计全部运营商
+	ApprovalCountsByOperator(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID][]MissionApprovalCount, error)
+}
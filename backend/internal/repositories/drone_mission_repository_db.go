@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DBDroneMissionRepository 数据库无人机任务仓储实现
+type DBDroneMissionRepository struct {
+	db *gorm.DB
+}
+
+// NewDBDroneMissionRepository 创建数据库无人机任务仓储实例
+func NewDBDroneMissionRepository(db *gorm.DB) DroneMissionRepository {
+	return &DBDroneMissionRepository{
+		db: db,
+	}
+}
+
+// Create 在事务中创建任务并写入提交记录
+func (r *DBDroneMissionRepository) Create(ctx context.Context, mission *models.DroneMission, log *models.DroneMissionLog) error {
+	if mission.ID == uuid.Nil {
+		mission.ID = uuid.New()
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(mission).Error; err != nil {
+			return err
+		}
+		return createMissionLog(tx, mission.ID, log)
+	})
+	if err != nil {
+		logger.Errorf("创建无人机任务失败: %v", err)
+		return errors.New("创建无人机任务失败: " + err.Error())
+	}
+
+	logger.Infof("无人机任务创建成功: ID=%s, Name=%s", mission.ID.String(), mission.MissionName)
+	return nil
+}
+
+// FindByID 根据ID查找任务，预加载无人机
+func (r *DBDroneMissionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.DroneMission, error) {
+	var mission models.DroneMission
+	if err := r.db.WithContext(ctx).Preload("Drone").First(&mission, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找无人机任务失败: %v", err)
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// FindActiveByDrone 查找无人机正在执行的任务，存在多个时取计划开始时间最晚的一个
+func (r *DBDroneMissionRepository) FindActiveByDrone(ctx context.Context, droneID uuid.UUID) (*models.DroneMission, error) {
+	var mission models.DroneMission
+	err := r.db.WithContext(ctx).
+		Where("drone_id = ? AND mission_status = ?", droneID, models.MissionStatusInProgress).
+		Order("planned_start_time DESC").
+		First(&mission).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("查找执行中的无人机任务失败: %v", err)
+		return nil, err
+	}
+	return &mission, nil
+}
+
+// Update 在事务中保存任务并写入审计记录
+// 以任务当前状态和审批状态作为更新条件，避免覆盖并发的审批结果
+func (r *DBDroneMissionRepository) Update(ctx context.Context, mission *models.DroneMission, fromStatus string, fromApproval *string, log *models.DroneMissionLog) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(mission).Where("mission_status = ?", fromStatus)
+		if fromApproval == nil {
+			query = query.Where("approval_status IS NULL")
+		} else {
+			query = query.Where("approval_status = ?", *fromApproval)
+		}
+
+		result := query.Select("*").Omit("id", "created_at", clause.Associations).Updates(mission)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleState
+		}
+		return createMissionLog(tx, mission.ID, log)
+	})
+	if err != nil {
+		if errors.Is(err, ErrStaleState) {
+			return err
+		}
+		logger.Errorf("更新无人机任务失败: %v", err)
+		return errors.New("更新无人机任务失败: " + err.Error())
+	}
+
+	logger.Infof("无人机任务更新成功: ID=%s", mission.ID.String())
+	return nil
+}
+
+// ChangeStatus 在事务中变更任务状态、写入审计记录，并按需同步无人机状态
+// 以任务和无人机的当前状态作为更新条件，避免并发审批或重复启动
+func (r *DBDroneMissionRepository) ChangeStatus(ctx context.Context, mission *models.DroneMission, fromStatus string, fromApproval *string, log *models.DroneMissionLog, droneStatus string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.DroneMission{}).Where("id = ? AND mission_status = ?", mission.ID, fromStatus)
+		if fromApproval == nil {
+			query = query.Where("approval_status IS NULL")
+		} else {
+			query = query.Where("approval_status = ?", *fromApproval)
+		}
+
+		result := query.Updates(map[string]any{
+			"mission_status":    mission.MissionStatus,
+			"approval_status":   mission.ApprovalStatus,
+			"approved_by":       mission.ApprovedBy,
+			"approval_time":     mission.ApprovalTime,
+			"approval_notes":    mission.ApprovalNotes,
+			"actual_start_time": mission.ActualStartTime,
+			"actual_end_time":   mission.ActualEndTime,
+			"updated_at":        time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleState
+		}
+
+		// 无人机状态以读取任务时的状态作为更新条件，避免同一无人机的两个任务同时开始
+		if droneStatus != "" {
+			result := tx.Model(&models.Drone{}).Where("id = ? AND status = ?", mission.DroneID, mission.Drone.Status).
+				Updates(map[string]any{"status": droneStatus, "updated_at": time.Now()})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrStaleState
+			}
+		}
+		return createMissionLog(tx, mission.ID, log)
+	})
+	if err != nil {
+		if errors.Is(err, ErrStaleState) {
+			return err
+		}
+		logger.Errorf("更新无人机任务状态失败: %v", err)
+		return errors.New("更新无人机任务状态失败: " + err.Error())
+	}
+
+	logger.Infof("无人机任务状态更新成功: ID=%s, action=%s, %s -> %s", mission.ID.String(), log.Action, fromStatus, mission.MissionStatus)
+	return nil
+}
+
+// List 按条件分页查询任务，按计划开始时间倒序
+func (r *DBDroneMissionRepository) List(ctx context.Context, filter DroneMissionFilter) ([]models.DroneMission, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.DroneMission{})
+
+	if filter.OperatorID != nil {
+		query = query.Where("operator_id = ?", *filter.OperatorID)
+	}
+	if filter.DroneID != nil {
+		query = query.Where("drone_id = ?", *filter.DroneID)
+	}
+	if filter.PilotID != nil {
+		query = query.Where("pilot_id = ?", *filter.PilotID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("mission_status IN ?", filter.Statuses)
+	}
+	if len(filter.ApprovalStatuses) > 0 {
+		query = query.Where("approval_status IN ?", filter.ApprovalStatuses)
+	}
+	if filter.From != nil {
+		query = query.Where("planned_start_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("planned_start_time <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计无人机任务数量失败: %v", err)
+		return nil, 0, errors.New("获取无人机任务列表失败: " + err.Error())
+	}
+
+	var missions []models.DroneMission
+	if err := query.Preload("Drone").Order("planned_start_time DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&missions).Error; err != nil {
+		logger.Errorf("获取无人机任务列表失败: %v", err)
+		return nil, 0, errors.New("获取无人机任务列表失败: " + err.Error())
+	}
+
+	return missions, total, nil
+}
+
+// ListLogs 查询任务审计记录，按时间升序
+func (r *DBDroneMissionRepository) ListLogs(ctx context.Context, missionID uuid.UUID) ([]models.DroneMissionLog, error) {
+	var logs []models.DroneMissionLog
+	if err := r.db.WithContext(ctx).Where("mission_id = ?", missionID).Order("created_at ASC").Find(&logs).Error; err != nil {
+		logger.Errorf("获取无人机任务审计记录失败: %v", err)
+		return nil, errors.New("获取无人机任务审计记录失败: " + err.Error())
+	}
+	return logs, nil
+}
+
+// createMissionLog 写入任务审计记录
+func createMissionLog(tx *gorm.DB, missionID uuid.UUID, log *models.DroneMissionLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	log.MissionID = missionID
+	return tx.Create(log).Error
+}
+
+// ApprovalCountsByOperator 按运营商、是否需要审批和审批状态分组统计实际起飞的任务
+func (r *DBDroneMissionRepository) ApprovalCountsByOperator(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID][]MissionApprovalCount, error) {
+	query := r.db.WithContext(ctx).Model(&models.DroneMission{}).
+		Select("operator_id, requires_approval, approval_status, COUNT(*) AS count").
+		Where("actual_start_time >= ? AND actual_start_time < ?", from, to)
+	if operatorID != nil {
+		query = query.Where("operator_id = ?", *operatorID)
+	}
+
+	var rows []struct {
+		OperatorID uuid.UUID
+		MissionApprovalCount
+	}
+	if err := query.Group("operator_id, requires_approval, approval_status").Scan(&rows).Error; err != nil {
+		logger.Errorf("统计任务审批情况失败: %v", err)
+		return nil, errors.New("统计任务审批情况失败: " + err.Error())
+	}
+
+	counts := make(map[uuid.UUID][]MissionApprovalCount)
+	for _, row := range rows {
+		counts[row.OperatorID] = append(counts[row.OperatorID], row.MissionApprovalCount)
+	}
+	return counts, nil
+}
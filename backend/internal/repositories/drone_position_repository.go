@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DronePositionFilter 无人机位置查询条件
+type DronePositionFilter struct {
+	DroneID   *uuid.UUID
+	MissionID *uuid.UUID
+	From      *time.Time // 上报时间下限（不含）
+	To        *time.Time // 上报时间上限（含）
+}
+
+// DronePositionRepository 无人机位置仓储接口
+type DronePositionRepository interface {
+	// BulkCreate 批量写入位置点
+	BulkCreate(ctx context.Context, positions []models.DronePosition) error
+	// LatestTimestamps 查询各无人机已入库的最新位置时间
+	LatestTimestamps(ctx context.Context, droneIDs []uuid.UUID) (map[uuid.UUID]time.Time, error)
+	// List 按上报时间升序查询位置点
+	List(ctx context.Context, filter DronePositionFilter) ([]models.DronePosition, error)
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// dronePositionBatchSize 批量写入时每批的记录数
+const dronePositionBatchSize = 500
+
+// DBDronePositionRepository 数据库无人机位置仓储实现
+type DBDronePositionRepository struct {
+	db *gorm.DB
+}
+
+// NewDBDronePositionRepository 创建数据库无人机位置仓储实例
+func NewDBDronePositionRepository(db *gorm.DB) DronePositionRepository {
+	return &DBDronePositionRepository{
+		db: db,
+	}
+}
+
+// BulkCreate 批量写入位置点
+func (r *DBDronePositionRepository) BulkCreate(ctx context.Context, positions []models.DronePosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Omit("Drone", "Mission").CreateInBatches(positions, dronePositionBatchSize).Error; err != nil {
+		logger.Errorf("批量写入无人机位置失败: %v", err)
+		return errors.New("批量写入无人机位置失败: " + err.Error())
+	}
+	return nil
+}
+
+// LatestTimestamps 查询各无人机已入库的最新位置时间
+func (r *DBDronePositionRepository) LatestTimestamps(ctx context.Context, droneIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	latest := make(map[uuid.UUID]time.Time, len(droneIDs))
+	if len(droneIDs) == 0 {
+		return latest, nil
+	}
+
+	var rows []struct {
+		DroneID uuid.UUID
+		Latest  time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&models.DronePosition{}).
+		Select("drone_id, MAX(timestamp) AS latest").
+		Where("drone_id IN ?", droneIDs).
+		Group("drone_id").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Errorf("查询无人机最新位置时间失败: %v", err)
+		return nil, errors.New("查询无人机最新位置时间失败: " + err.Error())
+	}
+
+	for _, row := range rows {
+		latest[row.DroneID] = row.Latest
+	}
+	return latest, nil
+}
+
+// List 按上报时间升序查询位置点
+func (r *DBDronePositionRepository) List(ctx context.Context, filter DronePositionFilter) ([]models.DronePosition, error) {
+	query := r.db.WithContext(ctx).Model(&models.DronePosition{})
+
+	if filter.DroneID != nil {
+		query = query.Where("drone_id = ?", *filter.DroneID)
+	}
+	if filter.MissionID != nil {
+		query = query.Where("mission_id = ?", *filter.MissionID)
+	}
+	if filter.From != nil {
+		query = query.Where("timestamp > ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("timestamp <= ?", *filter.To)
+	}
+
+	var positions []models.DronePosition
+	if err := query.Order("timestamp ASC").Find(&positions).Error; err != nil {
+		logger.Errorf("查询无人机位置失败: %v", err)
+		return nil, errors.New("查询无人机位置失败: " + err.Error())
+	}
+	return positions, nil
+}
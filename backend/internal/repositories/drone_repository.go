@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DroneFilter 无人机列表过滤条件
+type DroneFilter struct {
+	OperatorID *uuid.UUID
+	Status     string
+	Model      string
+	Keyword    string // 模糊匹配 serial_number/name/model
+	Offset     int
+	Limit      int
+}
+
+// DroneRepository 无人机仓储接口
+type DroneRepository interface {
+	Create(ctx context.Context, drone *models.Drone) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Drone, error)
+	FindBySerialNumber(ctx context.Context, serialNumber string) (*models.Drone, error)
+	Update(ctx context.Context, drone *models.Drone) error
+	// UpdateLastPosition 更新无人机最近一次上报的位置和时间
+	UpdateLastPosition(ctx context.Context, id uuid.UUID, latitude, longitude, altitude float64, at time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter DroneFilter) ([]models.Drone, int64, error)
+	// ListSilentAirborne 查询最近一次上报时仍在空中（高度不低于 minAltitude）、且上报时间落在 [from, to) 内的无人机
+	ListSilentAirborne(ctx context.Context, minAltitude float64, from, to time.Time) ([]models.Drone, error)
+	CountByOperator(ctx context.Context, operatorID uuid.UUID) (int64, error)
+	// CountByOperatorAndStatus 按运营商和状态统计无人机数量，operatorID 为 nil 时统计全部运营商
+	CountByOperatorAndStatus(ctx context.Context, operatorID *uuid.UUID) (map[uuid.UUID]map[string]int64, error)
+}
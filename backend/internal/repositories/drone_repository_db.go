@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBDroneRepository 数据库无人机仓储实现
+type DBDroneRepository struct {
+	db *gorm.DB
+}
+
+// NewDBDroneRepository 创建数据库无人机仓储实例
+func NewDBDroneRepository(db *gorm.DB) DroneRepository {
+	return &DBDroneRepository{
+		db: db,
+	}
+}
+
+// Create 创建无人机
+func (r *DBDroneRepository) Create(ctx context.Context, drone *models.Drone) error {
+	if drone.ID == uuid.Nil {
+		drone.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Omit("Operator").Create(drone).Error; err != nil {
+		logger.Errorf("创建无人机失败: %v", err)
+		return errors.New("创建无人机失败: " + err.Error())
+	}
+
+	logger.Infof("无人机创建成功: ID=%s, SerialNumber=%s", drone.ID.String(), drone.SerialNumber)
+	return nil
+}
+
+// FindByID 根据ID查找无人机，预加载所属运营商
+func (r *DBDroneRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Drone, error) {
+	var drone models.Drone
+	if err := r.db.WithContext(ctx).Preload("Operator").First(&drone, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找无人机失败: %v", err)
+		return nil, err
+	}
+	return &drone, nil
+}
+
+// FindBySerialNumber 根据序列号查找无人机
+func (r *DBDroneRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (*models.Drone, error) {
+	var drone models.Drone
+	if err := r.db.WithContext(ctx).Where("serial_number = ?", strings.ToUpper(serialNumber)).First(&drone).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据序列号查找无人机失败: %v", err)
+		return nil, err
+	}
+	return &drone, nil
+}
+
+// Update 更新无人机
+func (r *DBDroneRepository) Update(ctx context.Context, drone *models.Drone) error {
+	if err := r.db.WithContext(ctx).Omit("Operator").Save(drone).Error; err != nil {
+		logger.Errorf("更新无人机失败: %v", err)
+		return errors.New("更新无人机失败: " + err.Error())
+	}
+
+	logger.Infof("无人机更新成功: ID=%s", drone.ID.String())
+	return nil
+}
+
+// UpdateLastPosition 更新无人机最近一次上报的位置和时间
+func (r *DBDroneRepository) UpdateLastPosition(ctx context.Context, id uuid.UUID, latitude, longitude, altitude float64, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.Drone{}).Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"last_latitude":    latitude,
+			"last_longitude":   longitude,
+			"last_altitude":    altitude,
+			"last_update_time": at,
+			"updated_at":       time.Now(),
+		}).Error
+	if err != nil {
+		logger.Errorf("更新无人机位置失败: %v", err)
+		return errors.New("更新无人机位置失败: " + err.Error())
+	}
+	return nil
+}
+
+// Delete 删除无人机
+func (r *DBDroneRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Drone{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除无人机失败: %v", result.Error)
+		return errors.New("删除无人机失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("无人机删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询无人机
+func (r *DBDroneRepository) List(ctx context.Context, filter DroneFilter) ([]models.Drone, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Drone{})
+
+	if filter.OperatorID != nil {
+		query = query.Where("operator_id = ?", *filter.OperatorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Model != "" {
+		query = query.Where("model = ?", filter.Model)
+	}
+	if filter.Keyword != "" {
+		like := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(serial_number) LIKE ? OR LOWER(name) LIKE ? OR LOWER(model) LIKE ?", like, like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计无人机数量失败: %v", err)
+		return nil, 0, errors.New("获取无人机列表失败: " + err.Error())
+	}
+
+	var drones []models.Drone
+	if err := query.Preload("Operator").Order("serial_number ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&drones).Error; err != nil {
+		logger.Errorf("获取无人机列表失败: %v", err)
+		return nil, 0, errors.New("获取无人机列表失败: " + err.Error())
+	}
+
+	return drones, total, nil
+}
+
+// CountByOperator 统计运营商的无人机数量
+func (r *DBDroneRepository) CountByOperator(ctx context.Context, operatorID uuid.UUID) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Drone{}).Where("operator_id = ?", operatorID).Count(&count).Error; err != nil {
+		logger.Errorf("统计运营商无人机数量失败: %v", err)
+		return 0, errors.New("统计运营商无人机数量失败: " + err.Error())
+	}
+	return count, nil
+}
+
+// CountByOperatorAndStatus 按运营商和状态统计无人机数量
+func (r *DBDroneRepository) CountByOperatorAndStatus(ctx context.Context, operatorID *uuid.UUID) (map[uuid.UUID]map[string]int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Drone{}).
+		Select("operator_id, status, COUNT(*) AS count").
+		Where("operator_id IS NOT NULL")
+	if operatorID != nil {
+		query = query.Where("operator_id = ?", *operatorID)
+	}
+
+	var rows []struct {
+		OperatorID uuid.UUID
+		Status     string
+		Count      int64
+	}
+	if err := query.Group("operator_id, status").Scan(&rows).Error; err != nil {
+		logger.Errorf("统计运营商无人机状态失败: %v", err)
+		return nil, errors.New("统计运营商无人机状态失败: " + err.Error())
+	}
+
+	counts := make(map[uuid.UUID]map[string]int64)
+	for _, row := range rows {
+		if counts[row.OperatorID] == nil {
+			counts[row.OperatorID] = make(map[string]int64)
+		}
+		counts[row.OperatorID][row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// ListSilentAirborne 查询在空中停止上报的无人机
+func (r *DBDroneRepository) ListSilentAirborne(ctx context.Context, minAltitude float64, from, to time.Time) ([]models.Drone, error) {
+	var drones []models.Drone
+	err := r.db.WithContext(ctx).
+		Where("last_altitude >= ? AND last_update_time >= ? AND last_update_time < ?", minAltitude, from, to).
+		Order("last_update_time ASC").
+		Find(&drones).Error
+	if err != nil {
+		logger.Errorf("查询停止上报的无人机失败: %v", err)
+		return nil, errors.New("查询停止上报的无人机失败: " + err.Error())
+	}
+	return drones, nil
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "errors"
+
+// ErrNotFound 记录不存在
+// 仓储层查询不到记录时返回，服务层据此转换为 404
+var ErrNotFound = errors.New("记录不存在")
+
+// ErrStaleState 记录状态已被并发修改
+// 条件更新未命中时返回，服务层据此转换为 409
+var ErrStaleState = errors.New("记录状态已变更")
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// 航班历史聚合分组维度
+const (
+	FlightHistoryGroupAirline = "airline"
+	FlightHistoryGroupRoute   = "route"
+	FlightHistoryGroupAirport = "airport"
+)
+
+// 航班历史聚合时间粒度
+const (
+	FlightHistoryIntervalDay   = "day"
+	FlightHistoryIntervalWeek  = "week"
+	FlightHistoryIntervalMonth = "month"
+)
+
+// FlightHistoryFilter 航班历史查询条件
+type FlightHistoryFilter struct {
+	From        time.Time // 航班日期下限（含）
+	To          time.Time // 航班日期上限（不含）
+	AirlineCode string    // 航空公司IATA代码，旧记录按航班号前缀匹配
+	Airport     string    // 出发或到达机场代码
+	Departure   string
+	Arrival     string
+}
+
+// FlightHistoryGrouping 航班历史聚合维度
+type FlightHistoryGrouping struct {
+	GroupBy  string // airline/route/airport，为空时不分组；按机场分组时航班同时计入出发和到达机场
+	Interval string // day/week/month，为空时不分桶；周从周一开始
+}
+
+// FlightHistoryCount 按分组、时间桶、状态和到达延误聚合的航班数量
+type FlightHistoryCount struct {
+	Key    string    `gorm:"column:group_key"` // 不分组时为空
+	Bucket time.Time // 时间桶起始日期，不分桶时为零值
+	Status string
+	Delay  int // 到达延误分钟数，仅已到达航班有值，提前到达计为 0
+	Count  int
+}
+
+// FlightHistoryRepository 航班历史仓储接口
+type FlightHistoryRepository interface {
+	// CountByDelay 在数据库中按分组、时间桶、状态和到达延误分钟数统计航班数量
+	CountByDelay(ctx context.Context, filter FlightHistoryFilter, grouping FlightHistoryGrouping) ([]FlightHistoryCount, error)
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// DBFlightHistoryRepository 数据库航班历史仓储实现
+type DBFlightHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewDBFlightHistoryRepository 创建数据库航班历史仓储实例
+func NewDBFlightHistoryRepository(db *gorm.DB) FlightHistoryRepository {
+	return &DBFlightHistoryRepository{
+		db: db,
+	}
+}
+
+// CountByDelay 按分组、时间桶、状态和到达延误分钟数统计航班数量
+// 内层查询逐条计算分组键和延误，外层聚合，只返回聚合后的行
+func (r *DBFlightHistoryRepository) CountByDelay(ctx context.Context, filter FlightHistoryFilter, grouping FlightHistoryGrouping) ([]FlightHistoryCount, error) {
+	var rows *gorm.DB
+	if grouping.GroupBy == FlightHistoryGroupAirport {
+		departures := r.filtered(ctx, filter).Select(r.rowColumns("departure_airport"))
+		arrivals := r.filtered(ctx, filter).Select(r.rowColumns("arrival_airport")).
+			Where("arrival_airport <> departure_airport")
+		rows = r.db.WithContext(ctx).Table("(? UNION ALL ?) AS h", departures, arrivals)
+	} else {
+		rows = r.db.WithContext(ctx).Table("(?) AS h", r.filtered(ctx, filter).Select(r.rowColumns(flightHistoryKeySQL(grouping.GroupBy))))
+	}
+
+	columns := []string{"group_key", "status", "delay"}
+	if bucket := r.bucketSQL(grouping.Interval); bucket != "" {
+		rows = rows.Select(strings.Join(columns, ", ") + ", " + bucket + " AS bucket, COUNT(*) AS count")
+		columns = append(columns, "bucket")
+	} else {
+		rows = rows.Select(strings.Join(columns, ", ") + ", COUNT(*) AS count")
+	}
+
+	var counts []FlightHistoryCount
+	if err := rows.Group(strings.Join(columns, ", ")).Scan(&counts).Error; err != nil {
+		logger.Errorf("统计航班历史失败: %v", err)
+		return nil, errors.New("统计航班历史失败: " + err.Error())
+	}
+	return counts, nil
+}
+
+// filtered 按查询条件过滤航班历史
+func (r *DBFlightHistoryRepository) filtered(ctx context.Context, filter FlightHistoryFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&models.FlightHistory{}).
+		Where("flight_date >= ? AND flight_date < ?", filter.From, filter.To)
+
+	if code := strings.ToUpper(filter.AirlineCode); code != "" {
+		query = query.Where("(airline_code = ? OR ((airline_code IS NULL OR airline_code = '') AND flight_number LIKE ?))", code, code+"%")
+	}
+	if filter.Airport != "" {
+		airport := strings.ToUpper(filter.Airport)
+		query = query.Where("(departure_airport = ? OR arrival_airport = ?)", airport, airport)
+	}
+	if filter.Departure != "" {
+		query = query.Where("departure_airport = ?", strings.ToUpper(filter.Departure))
+	}
+	if filter.Arrival != "" {
+		query = query.Where("arrival_airport = ?", strings.ToUpper(filter.Arrival))
+	}
+	return query
+}
+
+// rowColumns 内层查询字段：分组键、航班日期、状态和到达延误
+func (r *DBFlightHistoryRepository) rowColumns(key string) string {
+	return key + " AS group_key, flight_date, status, " + r.delaySQL() + " AS delay"
+}
+
+// delaySQL 到达延误分钟数，优先使用实际与计划到达时间，提前到达计为 0
+func (r *DBFlightHistoryRepository) delaySQL() string {
+	return "CASE" +
+		" WHEN status <> '" + models.FlightStatusArrived + "' THEN 0" +
+		" WHEN scheduled_arrival IS NOT NULL AND actual_arrival IS NOT NULL" +
+		" THEN GREATEST(" + sqlMinutesBetween(r.db, "scheduled_arrival", "actual_arrival") + ", 0)" +
+		" WHEN delay_minutes > 0 THEN delay_minutes" +
+		" ELSE 0 END"
+}
+
+// flightHistoryKeySQL 分组键表达式，早期记录没有航空公司代码时取航班号前两位
+func flightHistoryKeySQL(groupBy string) string {
+	switch groupBy {
+	case FlightHistoryGroupAirline:
+		return "COALESCE(NULLIF(airline_code, ''), UPPER(LEFT(flight_number, 2)))"
+	case FlightHistoryGroupRoute:
+		return "CONCAT(departure_airport, '-', arrival_airport)"
+	default:
+		return "''"
+	}
+}
+
+// bucketSQL 时间桶起始日期表达式，不分桶时返回空
+func (r *DBFlightHistoryRepository) bucketSQL(interval string) string {
+	switch interval {
+	case FlightHistoryIntervalDay:
+		return "flight_date"
+	case FlightHistoryIntervalWeek:
+		return sqlWeekStart(r.db, "flight_date")
+	case FlightHistoryIntervalMonth:
+		return sqlMonthStart(r.db, "flight_date")
+	default:
+		return ""
+	}
+}
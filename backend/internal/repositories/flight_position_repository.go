@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightPositionRepository 航班位置仓储接口
+type FlightPositionRepository interface {
+	// BulkCreate 批量写入位置点
+	BulkCreate(ctx context.Context, positions []models.FlightPosition) error
+	// LatestTimestamps 查询各航班已入库的最新位置时间
+	LatestTimestamps(ctx context.Context, flightIDs []uuid.UUID) (map[uuid.UUID]time.Time, error)
+	// ListByFlight 按时间升序查询航班在时间窗口内的位置点，from/to 为空表示不限
+	ListByFlight(ctx context.Context, flightID uuid.UUID, from, to *time.Time) ([]models.FlightPosition, error)
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// flightPositionBatchSize 批量写入时每批的记录数
+const flightPositionBatchSize = 500
+
+// DBFlightPositionRepository 数据库航班位置仓储实现
+type DBFlightPositionRepository struct {
+	db *gorm.DB
+}
+
+// NewDBFlightPositionRepository 创建数据库航班位置仓储实例
+func NewDBFlightPositionRepository(db *gorm.DB) FlightPositionRepository {
+	return &DBFlightPositionRepository{
+		db: db,
+	}
+}
+
+// BulkCreate 批量写入位置点
+func (r *DBFlightPositionRepository) BulkCreate(ctx context.Context, positions []models.FlightPosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Omit("Flight").CreateInBatches(positions, flightPositionBatchSize).Error; err != nil {
+		logger.Errorf("批量写入航班位置失败: %v", err)
+		return errors.New("批量写入航班位置失败: " + err.Error())
+	}
+	return nil
+}
+
+// LatestTimestamps 查询各航班已入库的最新位置时间
+func (r *DBFlightPositionRepository) LatestTimestamps(ctx context.Context, flightIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	latest := make(map[uuid.UUID]time.Time, len(flightIDs))
+	if len(flightIDs) == 0 {
+		return latest, nil
+	}
+
+	var rows []struct {
+		FlightID uuid.UUID
+		Latest   time.Time
+	}
+	err := r.db.WithContext(ctx).Model(&models.FlightPosition{}).
+		Select("flight_id, MAX(timestamp) AS latest").
+		Where("flight_id IN ?", flightIDs).
+		Group("flight_id").
+		Scan(&rows).Error
+	if err != nil {
+		logger.Errorf("查询航班最新位置时间失败: %v", err)
+		return nil, errors.New("查询航班最新位置时间失败: " + err.Error())
+	}
+
+	for _, row := range rows {
+		latest[row.FlightID] = row.Latest
+	}
+	return latest, nil
+}
+
+// ListByFlight 按时间升序查询航班在时间窗口内的位置点
+func (r *DBFlightPositionRepository) ListByFlight(ctx context.Context, flightID uuid.UUID, from, to *time.Time) ([]models.FlightPosition, error) {
+	query := r.db.WithContext(ctx).Where("flight_id = ?", flightID)
+	if from != nil {
+		query = query.Where("timestamp >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("timestamp <= ?", *to)
+	}
+
+	var positions []models.FlightPosition
+	if err := query.Order("timestamp ASC").Find(&positions).Error; err != nil {
+		logger.Errorf("查询航班航迹失败: %v", err)
+		return nil, errors.New("查询航班航迹失败: " + err.Error())
+	}
+	return positions, nil
+}
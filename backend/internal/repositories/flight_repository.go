@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightFilter 航班列表过滤条件
+type FlightFilter struct {
+	FlightNumber string // 前缀匹配
+	AirlineID    *uuid.UUID
+	DepartureID  *uuid.UUID
+	ArrivalID    *uuid.UUID
+	Statuses     []string
+	From         *time.Time // 计划起飞时间下限
+	To           *time.Time // 计划起飞时间上限
+	Offset       int
+	Limit        int
+}
+
+// BoardDirection 航班显示屏方向
+type BoardDirection string
+
+const (
+	// BoardDepartures 出发航班
+	BoardDepartures BoardDirection = "departures"
+	// BoardArrivals 到达航班
+	BoardArrivals BoardDirection = "arrivals"
+)
+
+// FlightRepository 航班仓储接口
+type FlightRepository interface {
+	Create(ctx context.Context, flight *models.Flight) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	Update(ctx context.Context, flight *models.Flight) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter FlightFilter) ([]models.Flight, int64, error)
+	// Board 查询机场出发/到达航班，包含预计时间落入窗口的延误航班，按预计时间升序
+	Board(ctx context.Context, airportID uuid.UUID, direction BoardDirection, from, to time.Time, statuses []string, limit int) ([]models.Flight, error)
+	// ChangeStatus 在事务中更新航班状态并写入变更记录，history 非空时同时写入历史记录
+	// 航班当前状态与 fromStatus 不一致时返回 ErrStaleState
+	ChangeStatus(ctx context.Context, flight *models.Flight, fromStatus string, log *models.FlightStatusLog, history *models.FlightHistory) error
+	ListStatusLogs(ctx context.Context, flightID uuid.UUID) ([]models.FlightStatusLog, error)
+	// FindActiveByNumber 查找指定时刻正在执行的航班（未到达、未取消且时间窗口覆盖 at）
+	FindActiveByNumber(ctx context.Context, flightNumber string, at time.Time) (*models.Flight, error)
+	// FindActiveByAircraft 查找指定飞机在指定时刻正在执行的航班
+	FindActiveByAircraft(ctx context.Context, aircraftID uuid.UUID, at time.Time) (*models.Flight, error)
+	// UpdatePosition 更新航班当前位置（高度米、速度 km/h），不修改其他字段
+	UpdatePosition(ctx context.Context, flightID uuid.UUID, latitude, longitude, altitude, speed float64) error
+}
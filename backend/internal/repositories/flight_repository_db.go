@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBFlightRepository 数据库航班仓储实现
+type DBFlightRepository struct {
+	db *gorm.DB
+}
+
+// NewDBFlightRepository 创建数据库航班仓储实例
+func NewDBFlightRepository(db *gorm.DB) FlightRepository {
+	return &DBFlightRepository{
+		db: db,
+	}
+}
+
+// preloadFlight 预加载航班的航空公司、飞机和起降机场
+func preloadFlight(db *gorm.DB) *gorm.DB {
+	return db.Preload("Airline").Preload("Aircraft").Preload("Departure").Preload("Arrival")
+}
+
+// Create 创建航班
+func (r *DBFlightRepository) Create(ctx context.Context, flight *models.Flight) error {
+	if flight.ID == uuid.Nil {
+		flight.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Omit(flightAssociations...).Create(flight).Error; err != nil {
+		logger.Errorf("创建航班失败: %v", err)
+		return errors.New("创建航班失败: " + err.Error())
+	}
+
+	logger.Infof("航班创建成功: ID=%s, FlightNumber=%s", flight.ID.String(), flight.FlightNumber)
+	return nil
+}
+
+// FindByID 根据ID查找航班
+func (r *DBFlightRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	var flight models.Flight
+	if err := preloadFlight(r.db.WithContext(ctx)).First(&flight, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找航班失败: %v", err)
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// Update 更新航班计划信息（不级联更新关联对象，不修改状态相关字段）
+// 状态只能通过 ChangeStatus 变更
+func (r *DBFlightRepository) Update(ctx context.Context, flight *models.Flight) error {
+	omitted := append(append([]string{}, flightAssociations...), flightStatusFields...)
+	if err := r.db.WithContext(ctx).Omit(omitted...).Save(flight).Error; err != nil {
+		logger.Errorf("更新航班失败: %v", err)
+		return errors.New("更新航班失败: " + err.Error())
+	}
+
+	logger.Infof("航班更新成功: ID=%s", flight.ID.String())
+	return nil
+}
+
+// Delete 删除航班
+func (r *DBFlightRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Flight{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除航班失败: %v", result.Error)
+		return errors.New("删除航班失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("航班删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询航班，按计划起飞时间升序
+func (r *DBFlightRepository) List(ctx context.Context, filter FlightFilter) ([]models.Flight, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Flight{})
+
+	if filter.FlightNumber != "" {
+		query = query.Where("flight_number LIKE ?", strings.ToUpper(filter.FlightNumber)+"%")
+	}
+	if filter.AirlineID != nil {
+		query = query.Where("airline_id = ?", *filter.AirlineID)
+	}
+	if filter.DepartureID != nil {
+		query = query.Where("departure_id = ?", *filter.DepartureID)
+	}
+	if filter.ArrivalID != nil {
+		query = query.Where("arrival_id = ?", *filter.ArrivalID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.From != nil {
+		query = query.Where("departure_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("departure_time < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计航班数量失败: %v", err)
+		return nil, 0, errors.New("获取航班列表失败: " + err.Error())
+	}
+
+	var flights []models.Flight
+	if err := preloadFlight(query).Order("departure_time ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&flights).Error; err != nil {
+		logger.Errorf("获取航班列表失败: %v", err)
+		return nil, 0, errors.New("获取航班列表失败: " + err.Error())
+	}
+
+	return flights, total, nil
+}
+
+// Board 查询机场出发/到达航班
+// 出发按 departure_time、到达按 arrival_time 计算；计划时间在窗口内，或未结束航班的预计时间（计划时间加延误）在窗口内的均显示
+func (r *DBFlightRepository) Board(ctx context.Context, airportID uuid.UUID, direction BoardDirection, from, to time.Time, statuses []string, limit int) ([]models.Flight, error) {
+	airportColumn, timeColumn := "departure_id", "departure_time"
+	if direction == BoardArrivals {
+		airportColumn, timeColumn = "arrival_id", "arrival_time"
+	}
+	estimated := sqlAddMinutes(r.db, timeColumn, "delay_minutes")
+
+	query := preloadFlight(r.db.WithContext(ctx)).
+		Where(airportColumn+" = ?", airportID).
+		Where(
+			r.db.Where(timeColumn+" >= ? AND "+timeColumn+" < ?", from, to).
+				Or("status NOT IN ? AND "+estimated+" >= ? AND "+estimated+" < ?",
+					[]string{models.FlightStatusArrived, models.FlightStatusCancelled}, from, to),
+		)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var flights []models.Flight
+	if err := query.Order(estimated + " ASC, " + timeColumn + " ASC").Find(&flights).Error; err != nil {
+		logger.Errorf("获取机场航班显示数据失败: %v", err)
+		return nil, errors.New("获取机场航班显示数据失败: " + err.Error())
+	}
+	return flights, nil
+}
+
+// ChangeStatus 在事务中更新航班状态、写入状态变更记录和历史记录
+// 以当前状态作为更新条件，避免并发变更覆盖
+func (r *DBFlightRepository) ChangeStatus(ctx context.Context, flight *models.Flight, fromStatus string, log *models.FlightStatusLog, history *models.FlightHistory) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Flight{}).
+			Where("id = ? AND status = ?", flight.ID, fromStatus).
+			Updates(map[string]any{
+				"status":                flight.Status,
+				"delay_minutes":         flight.DelayMinutes,
+				"actual_departure_time": flight.ActualDepartureTime,
+				"actual_arrival_time":   flight.ActualArrivalTime,
+				"updated_at":            time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleState
+		}
+
+		if log.ID == uuid.Nil {
+			log.ID = uuid.New()
+		}
+		if err := tx.Create(log).Error; err != nil {
+			return err
+		}
+
+		if history != nil {
+			if err := tx.Omit("Aircraft").Create(history).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrStaleState) {
+			return err
+		}
+		logger.Errorf("更新航班状态失败: %v", err)
+		return errors.New("更新航班状态失败: " + err.Error())
+	}
+
+	logger.Infof("航班状态更新成功: ID=%s, %s -> %s", flight.ID.String(), fromStatus, flight.Status)
+	return nil
+}
+
+// ListStatusLogs 查询航班状态变更记录，按时间升序
+func (r *DBFlightRepository) ListStatusLogs(ctx context.Context, flightID uuid.UUID) ([]models.FlightStatusLog, error) {
+	var logs []models.FlightStatusLog
+	if err := r.db.WithContext(ctx).Where("flight_id = ?", flightID).Order("created_at ASC").Find(&logs).Error; err != nil {
+		logger.Errorf("获取航班状态变更记录失败: %v", err)
+		return nil, errors.New("获取航班状态变更记录失败: " + err.Error())
+	}
+	return logs, nil
+}
+
+// activeFlightWindow 判定航班是否正在执行时，计划时间前后允许的偏差
+const activeFlightWindow = 12 * time.Hour
+
+// FindActiveByNumber 查找指定时刻正在执行的航班
+func (r *DBFlightRepository) FindActiveByNumber(ctx context.Context, flightNumber string, at time.Time) (*models.Flight, error) {
+	return r.findActive(ctx, r.db.WithContext(ctx).Where("flight_number = ?", strings.ToUpper(flightNumber)), at)
+}
+
+// FindActiveByAircraft 查找指定飞机在指定时刻正在执行的航班
+func (r *DBFlightRepository) FindActiveByAircraft(ctx context.Context, aircraftID uuid.UUID, at time.Time) (*models.Flight, error) {
+	return r.findActive(ctx, r.db.WithContext(ctx).Where("aircraft_id = ?", aircraftID), at)
+}
+
+// findActive 在计划时间窗口内取最近起飞的未结束航班
+func (r *DBFlightRepository) findActive(ctx context.Context, query *gorm.DB, at time.Time) (*models.Flight, error) {
+	var flight models.Flight
+	err := query.
+		Where("status NOT IN ?", []string{models.FlightStatusArrived, models.FlightStatusCancelled}).
+		Where("departure_time <= ? AND arrival_time >= ?", at.Add(activeFlightWindow), at.Add(-activeFlightWindow)).
+		Order("departure_time DESC").
+		First(&flight).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("查找执行中航班失败: %v", err)
+		return nil, err
+	}
+	return &flight, nil
+}
+
+// UpdatePosition 更新航班当前位置
+func (r *DBFlightRepository) UpdatePosition(ctx context.Context, flightID uuid.UUID, latitude, longitude, altitude, speed float64) error {
+	err := r.db.WithContext(ctx).Model(&models.Flight{}).Where("id = ?", flightID).
+		UpdateColumns(map[string]any{
+			"latitude":   latitude,
+			"longitude":  longitude,
+			"altitude":   altitude,
+			"speed":      speed,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		logger.Errorf("更新航班位置失败: %v", err)
+		return errors.New("更新航班位置失败: " + err.Error())
+	}
+	return nil
+}
+
+// flightAssociations 写入航班时忽略的关联字段
+var flightAssociations = []string{"Airline", "Aircraft", "Departure", "Arrival"}
+
+// flightStatusFields 由状态机维护的字段，普通更新时忽略
+var flightStatusFields = []string{"Status", "DelayMinutes", "ActualDepartureTime", "ActualArrivalTime"}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightRouteRepository 航班计划航线仓储接口
+type FlightRouteRepository interface {
+	// ListByFlight 按航点顺序查询航班的计划航线
+	ListByFlight(ctx context.Context, flightID uuid.UUID) ([]models.FlightRoute, error)
+	// ListByFlights 批量查询多个航班的计划航线，按航班分组并按航点顺序排列
+	ListByFlights(ctx context.Context, flightIDs []uuid.UUID) (map[uuid.UUID][]models.FlightRoute, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.FlightRoute, error)
+	// Replace 在事务中用新航点整体替换航班的计划航线
+	Replace(ctx context.Context, flightID uuid.UUID, waypoints []models.FlightRoute) error
+	Update(ctx context.Context, waypoint *models.FlightRoute) error
+	// DeleteByFlight 删除航班的全部航点，返回删除数量
+	DeleteByFlight(ctx context.Context, flightID uuid.UUID) (int64, error)
+	// MarkPassed 为尚未记录实际过点时间的航点写入过点时间
+	MarkPassed(ctx context.Context, ids []uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBFlightRouteRepository 数据库航班计划航线仓储实现
+type DBFlightRouteRepository struct {
+	db *gorm.DB
+}
+
+// NewDBFlightRouteRepository 创建数据库航班计划航线仓储实例
+func NewDBFlightRouteRepository(db *gorm.DB) FlightRouteRepository {
+	return &DBFlightRouteRepository{
+		db: db,
+	}
+}
+
+// ListByFlight 按航点顺序查询航班的计划航线
+func (r *DBFlightRouteRepository) ListByFlight(ctx context.Context, flightID uuid.UUID) ([]models.FlightRoute, error) {
+	var waypoints []models.FlightRoute
+	if err := r.db.WithContext(ctx).Where("flight_id = ?", flightID).Order("sequence ASC").Find(&waypoints).Error; err != nil {
+		logger.Errorf("查询航班计划航线失败: %v", err)
+		return nil, errors.New("查询航班计划航线失败: " + err.Error())
+	}
+	return waypoints, nil
+}
+
+// ListByFlights 批量查询多个航班的计划航线
+func (r *DBFlightRouteRepository) ListByFlights(ctx context.Context, flightIDs []uuid.UUID) (map[uuid.UUID][]models.FlightRoute, error) {
+	routes := make(map[uuid.UUID][]models.FlightRoute, len(flightIDs))
+	if len(flightIDs) == 0 {
+		return routes, nil
+	}
+
+	var waypoints []models.FlightRoute
+	if err := r.db.WithContext(ctx).Where("flight_id IN ?", flightIDs).Order("flight_id, sequence ASC").Find(&waypoints).Error; err != nil {
+		logger.Errorf("批量查询航班计划航线失败: %v", err)
+		return nil, errors.New("批量查询航班计划航线失败: " + err.Error())
+	}
+
+	for _, waypoint := range waypoints {
+		routes[waypoint.FlightID] = append(routes[waypoint.FlightID], waypoint)
+	}
+	return routes, nil
+}
+
+// FindByID 根据ID查找航点
+func (r *DBFlightRouteRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.FlightRoute, error) {
+	var waypoint models.FlightRoute
+	if err := r.db.WithContext(ctx).First(&waypoint, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找航点失败: %v", err)
+		return nil, err
+	}
+	return &waypoint, nil
+}
+
+// Replace 在事务中整体替换航班的计划航线
+func (r *DBFlightRouteRepository) Replace(ctx context.Context, flightID uuid.UUID, waypoints []models.FlightRoute) error {
+	for i := range waypoints {
+		waypoints[i].FlightID = flightID
+		if waypoints[i].ID == uuid.Nil {
+			waypoints[i].ID = uuid.New()
+		}
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("flight_id = ?", flightID).Delete(&models.FlightRoute{}).Error; err != nil {
+			return err
+		}
+		if len(waypoints) == 0 {
+			return nil
+		}
+		return tx.Omit("Flight").Create(&waypoints).Error
+	})
+	if err != nil {
+		logger.Errorf("保存航班计划航线失败: %v", err)
+		return errors.New("保存航班计划航线失败: " + err.Error())
+	}
+
+	logger.Infof("航班计划航线已更新: FlightID=%s, Waypoints=%d", flightID.String(), len(waypoints))
+	return nil
+}
+
+// Update 更新航点
+func (r *DBFlightRouteRepository) Update(ctx context.Context, waypoint *models.FlightRoute) error {
+	if err := r.db.WithContext(ctx).Omit("Flight").Save(waypoint).Error; err != nil {
+		logger.Errorf("更新航点失败: %v", err)
+		return errors.New("更新航点失败: " + err.Error())
+	}
+	return nil
+}
+
+// DeleteByFlight 删除航班的全部航点
+func (r *DBFlightRouteRepository) DeleteByFlight(ctx context.Context, flightID uuid.UUID) (int64, error) {
+	result := r.db.WithContext(ctx).Where("flight_id = ?", flightID).Delete(&models.FlightRoute{})
+	if result.Error != nil {
+		logger.Errorf("删除航班计划航线失败: %v", result.Error)
+		return 0, errors.New("删除航班计划航线失败: " + result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
+
+// MarkPassed 为尚未记录实际过点时间的航点写入过点时间
+// 已有实际时间的航点保持不变，避免重复上报覆盖首次过点时间
+func (r *DBFlightRouteRepository) MarkPassed(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := r.db.WithContext(ctx).Model(&models.FlightRoute{}).
+		Where("id IN ? AND actual_time IS NULL", ids).
+		Update("actual_time", at).Error
+	if err != nil {
+		logger.Errorf("更新航点过点时间失败: %v", err)
+		return errors.New("更新航点过点时间失败: " + err.Error())
+	}
+	return nil
+}
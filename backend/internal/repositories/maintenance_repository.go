@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceRuleFilter 维护规则列表过滤条件
+type MaintenanceRuleFilter struct {
+	Model  string // 适用机型，不区分大小写
+	Offset int
+	Limit  int
+}
+
+// MaintenanceWorkOrderFilter 维护工单列表过滤条件
+type MaintenanceWorkOrderFilter struct {
+	OperatorID *uuid.UUID
+	DroneID    *uuid.UUID
+	RuleID     *uuid.UUID
+	Statuses   []string
+	Offset     int
+	Limit      int
+}
+
+// MaintenanceRepository 无人机维护仓储接口，管理维护规则和维护工单
+type MaintenanceRepository interface {
+	CreateRule(ctx context.Context, rule *models.MaintenanceRule) error
+	FindRuleByID(ctx context.Context, id uuid.UUID) (*models.MaintenanceRule, error)
+	UpdateRule(ctx context.Context, rule *models.MaintenanceRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	// ListRules 分页查询维护规则，按机型和名称排序
+	ListRules(ctx context.Context, filter MaintenanceRuleFilter) ([]models.MaintenanceRule, int64, error)
+	// ListRulesByModels 查询适用于指定机型的全部规则，机型不区分大小写
+	ListRulesByModels(ctx context.Context, droneModels []string) ([]models.MaintenanceRule, error)
+
+	CreateWorkOrder(ctx context.Context, order *models.MaintenanceWorkOrder) error
+	FindWorkOrderByID(ctx context.Context, id uuid.UUID) (*models.MaintenanceWorkOrder, error)
+	// CloseWorkOrder 以 open 状态作为更新条件保存签核或取消结果，状态已变更时返回 ErrStaleState
+	CloseWorkOrder(ctx context.Context, order *models.MaintenanceWorkOrder) error
+	// ListWorkOrders 分页查询工单，按创建时间倒序
+	ListWorkOrders(ctx context.Context, filter MaintenanceWorkOrderFilter) ([]models.MaintenanceWorkOrder, int64, error)
+	// LatestCompleted 查询各无人机每条规则最近一次签核的工单
+	LatestCompleted(ctx context.Context, droneIDs []uuid.UUID) ([]models.MaintenanceWorkOrder, error)
+}
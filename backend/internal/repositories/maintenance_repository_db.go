@@ -0,0 +1,233 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DBMaintenanceRepository 数据库无人机维护仓储实现
+type DBMaintenanceRepository struct {
+	db *gorm.DB
+}
+
+// NewDBMaintenanceRepository 创建数据库无人机维护仓储实例
+func NewDBMaintenanceRepository(db *gorm.DB) MaintenanceRepository {
+	return &DBMaintenanceRepository{
+		db: db,
+	}
+}
+
+// CreateRule 创建维护规则
+func (r *DBMaintenanceRepository) CreateRule(ctx context.Context, rule *models.MaintenanceRule) error {
+	if err := r.db.WithContext(ctx).Create(rule).Error; err != nil {
+		logger.Errorf("创建维护规则失败: %v", err)
+		return errors.New("创建维护规则失败: " + err.Error())
+	}
+
+	logger.Infof("维护规则创建成功: ID=%s, model=%s, name=%s", rule.ID.String(), rule.Model, rule.Name)
+	return nil
+}
+
+// FindRuleByID 根据ID查找维护规则
+func (r *DBMaintenanceRepository) FindRuleByID(ctx context.Context, id uuid.UUID) (*models.MaintenanceRule, error) {
+	var rule models.MaintenanceRule
+	if err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找维护规则失败: %v", err)
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// UpdateRule 更新维护规则
+func (r *DBMaintenanceRepository) UpdateRule(ctx context.Context, rule *models.MaintenanceRule) error {
+	if err := r.db.WithContext(ctx).Save(rule).Error; err != nil {
+		logger.Errorf("更新维护规则失败: %v", err)
+		return errors.New("更新维护规则失败: " + err.Error())
+	}
+
+	logger.Infof("维护规则更新成功: ID=%s", rule.ID.String())
+	return nil
+}
+
+// DeleteRule 删除维护规则
+func (r *DBMaintenanceRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.MaintenanceRule{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除维护规则失败: %v", result.Error)
+		return errors.New("删除维护规则失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("维护规则删除成功: ID=%s", id.String())
+	return nil
+}
+
+// ListRules 分页查询维护规则
+func (r *DBMaintenanceRepository) ListRules(ctx context.Context, filter MaintenanceRuleFilter) ([]models.MaintenanceRule, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.MaintenanceRule{})
+
+	if filter.Model != "" {
+		query = query.Where("LOWER(model) = ?", strings.ToLower(filter.Model))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计维护规则数量失败: %v", err)
+		return nil, 0, errors.New("获取维护规则列表失败: " + err.Error())
+	}
+
+	var rules []models.MaintenanceRule
+	if err := query.Order("model ASC, name ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&rules).Error; err != nil {
+		logger.Errorf("获取维护规则列表失败: %v", err)
+		return nil, 0, errors.New("获取维护规则列表失败: " + err.Error())
+	}
+
+	return rules, total, nil
+}
+
+// ListRulesByModels 查询适用于指定机型的规则
+func (r *DBMaintenanceRepository) ListRulesByModels(ctx context.Context, droneModels []string) ([]models.MaintenanceRule, error) {
+	if len(droneModels) == 0 {
+		return nil, nil
+	}
+	lowered := make([]string, len(droneModels))
+	for i, model := range droneModels {
+		lowered[i] = strings.ToLower(model)
+	}
+
+	var rules []models.MaintenanceRule
+	if err := r.db.WithContext(ctx).Where("LOWER(model) IN ?", lowered).Order("name ASC").Find(&rules).Error; err != nil {
+		logger.Errorf("查询机型维护规则失败: %v", err)
+		return nil, errors.New("查询机型维护规则失败: " + err.Error())
+	}
+	return rules, nil
+}
+
+// CreateWorkOrder 创建维护工单
+func (r *DBMaintenanceRepository) CreateWorkOrder(ctx context.Context, order *models.MaintenanceWorkOrder) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(order).Error; err != nil {
+		logger.Errorf("创建维护工单失败: %v", err)
+		return errors.New("创建维护工单失败: " + err.Error())
+	}
+
+	logger.Infof("维护工单创建成功: ID=%s, drone=%s", order.ID.String(), order.DroneID.String())
+	return nil
+}
+
+// FindWorkOrderByID 根据ID查找工单，预加载无人机和维护规则
+func (r *DBMaintenanceRepository) FindWorkOrderByID(ctx context.Context, id uuid.UUID) (*models.MaintenanceWorkOrder, error) {
+	var order models.MaintenanceWorkOrder
+	if err := r.db.WithContext(ctx).Preload("Drone").Preload("Rule").First(&order, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找维护工单失败: %v", err)
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CloseWorkOrder 以 open 状态作为更新条件保存签核或取消结果
+func (r *DBMaintenanceRepository) CloseWorkOrder(ctx context.Context, order *models.MaintenanceWorkOrder) error {
+	result := r.db.WithContext(ctx).Model(&models.MaintenanceWorkOrder{}).
+		Where("id = ? AND status = ?", order.ID, models.WorkOrderStatusOpen).
+		Updates(map[string]any{
+			"status":                       order.Status,
+			"work_performed":               order.WorkPerformed,
+			"signed_off_by":                order.SignedOffBy,
+			"completed_at":                 order.CompletedAt,
+			"flight_minutes_at_completion": order.FlightMinutesAtCompletion,
+			"flights_at_completion":        order.FlightsAtCompletion,
+			"cancel_reason":                order.CancelReason,
+			"updated_at":                   time.Now(),
+		})
+	if result.Error != nil {
+		logger.Errorf("关闭维护工单失败: %v", result.Error)
+		return errors.New("关闭维护工单失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleState
+	}
+
+	logger.Infof("维护工单关闭成功: ID=%s, status=%s", order.ID.String(), order.Status)
+	return nil
+}
+
+// ListWorkOrders 分页查询工单，按创建时间倒序
+func (r *DBMaintenanceRepository) ListWorkOrders(ctx context.Context, filter MaintenanceWorkOrderFilter) ([]models.MaintenanceWorkOrder, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.MaintenanceWorkOrder{})
+
+	if filter.OperatorID != nil {
+		query = query.Where("operator_id = ?", *filter.OperatorID)
+	}
+	if filter.DroneID != nil {
+		query = query.Where("drone_id = ?", *filter.DroneID)
+	}
+	if filter.RuleID != nil {
+		query = query.Where("rule_id = ?", *filter.RuleID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计维护工单数量失败: %v", err)
+		return nil, 0, errors.New("获取维护工单列表失败: " + err.Error())
+	}
+
+	var orders []models.MaintenanceWorkOrder
+	err := query.Preload("Drone").Preload("Rule").
+		Order("created_at DESC").
+		Offset(filter.Offset).Limit(filter.Limit).
+		Find(&orders).Error
+	if err != nil {
+		logger.Errorf("获取维护工单列表失败: %v", err)
+		return nil, 0, errors.New("获取维护工单列表失败: " + err.Error())
+	}
+
+	return orders, total, nil
+}
+
+// LatestCompleted 查询各无人机每条规则最近一次签核的工单
+func (r *DBMaintenanceRepository) LatestCompleted(ctx context.Context, droneIDs []uuid.UUID) ([]models.MaintenanceWorkOrder, error) {
+	if len(droneIDs) == 0 {
+		return nil, nil
+	}
+
+	var orders []models.MaintenanceWorkOrder
+	err := r.db.WithContext(ctx).
+		Where("drone_id IN ? AND rule_id IS NOT NULL AND status = ?", droneIDs, models.WorkOrderStatusCompleted).
+		Order("completed_at DESC").
+		Find(&orders).Error
+	if err != nil {
+		logger.Errorf("查询最近签核的维护工单失败: %v", err)
+		return nil, errors.New("查询最近签核的维护工单失败: " + err.Error())
+	}
+
+	type key struct{ droneID, ruleID uuid.UUID }
+	seen := make(map[key]bool, len(orders))
+	latest := make([]models.MaintenanceWorkOrder, 0, len(orders))
+	for _, order := range orders {
+		k := key{order.DroneID, *order.RuleID}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		latest = append(latest, order)
+	}
+	return latest, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NoFlyZoneFilter 禁飞区列表过滤条件，Limit 为 0 表示不分页
+type NoFlyZoneFilter struct {
+	Status  string
+	Type    string
+	Keyword string // 模糊匹配 name/authority
+	Offset  int
+	Limit   int
+}
+
+// NoFlyZoneRepository 禁飞区仓储接口
+type NoFlyZoneRepository interface {
+	Create(ctx context.Context, zone *models.NoFlyZone) error
+	// CreateBatch 在同一事务中批量创建禁飞区
+	CreateBatch(ctx context.Context, zones []models.NoFlyZone) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.NoFlyZone, error)
+	Update(ctx context.Context, zone *models.NoFlyZone) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter NoFlyZoneFilter) ([]models.NoFlyZone, int64, error)
+	// ListActive 查询生效时间与 [from, to] 有交集的有效禁飞区，未设置起止时间视为长期有效
+	ListActive(ctx context.Context, from, to time.Time) ([]models.NoFlyZone, error)
+	// ExpireEnded 将结束时间早于 now 的有效临时禁飞区置为过期，返回更新数量
+	ExpireEnded(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBNoFlyZoneRepository 数据库禁飞区仓储实现
+type DBNoFlyZoneRepository struct {
+	db *gorm.DB
+}
+
+// NewDBNoFlyZoneRepository 创建数据库禁飞区仓储实例
+func NewDBNoFlyZoneRepository(db *gorm.DB) NoFlyZoneRepository {
+	return &DBNoFlyZoneRepository{
+		db: db,
+	}
+}
+
+// Create 创建禁飞区
+func (r *DBNoFlyZoneRepository) Create(ctx context.Context, zone *models.NoFlyZone) error {
+	if zone.ID == uuid.Nil {
+		zone.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Create(zone).Error; err != nil {
+		logger.Errorf("创建禁飞区失败: %v", err)
+		return errors.New("创建禁飞区失败: " + err.Error())
+	}
+
+	logger.Infof("禁飞区创建成功: ID=%s, Name=%s", zone.ID.String(), zone.Name)
+	return nil
+}
+
+// CreateBatch 批量创建禁飞区，任一失败则全部回滚
+func (r *DBNoFlyZoneRepository) CreateBatch(ctx context.Context, zones []models.NoFlyZone) error {
+	if len(zones) == 0 {
+		return nil
+	}
+	for i := range zones {
+		if zones[i].ID == uuid.Nil {
+			zones[i].ID = uuid.New()
+		}
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(zones, 100).Error
+	})
+	if err != nil {
+		logger.Errorf("批量创建禁飞区失败: %v", err)
+		return errors.New("批量创建禁飞区失败: " + err.Error())
+	}
+
+	logger.Infof("禁飞区批量创建成功: Count=%d", len(zones))
+	return nil
+}
+
+// FindByID 根据ID查找禁飞区
+func (r *DBNoFlyZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.NoFlyZone, error) {
+	var zone models.NoFlyZone
+	if err := r.db.WithContext(ctx).First(&zone, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找禁飞区失败: %v", err)
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// Update 更新禁飞区
+func (r *DBNoFlyZoneRepository) Update(ctx context.Context, zone *models.NoFlyZone) error {
+	if err := r.db.WithContext(ctx).Save(zone).Error; err != nil {
+		logger.Errorf("更新禁飞区失败: %v", err)
+		return errors.New("更新禁飞区失败: " + err.Error())
+	}
+
+	logger.Infof("禁飞区更新成功: ID=%s", zone.ID.String())
+	return nil
+}
+
+// Delete 删除禁飞区
+func (r *DBNoFlyZoneRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.NoFlyZone{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除禁飞区失败: %v", result.Error)
+		return errors.New("删除禁飞区失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("禁飞区删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件查询禁飞区
+func (r *DBNoFlyZoneRepository) List(ctx context.Context, filter NoFlyZoneFilter) ([]models.NoFlyZone, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.NoFlyZone{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Keyword != "" {
+		like := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(authority) LIKE ?", like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计禁飞区数量失败: %v", err)
+		return nil, 0, errors.New("获取禁飞区列表失败: " + err.Error())
+	}
+
+	query = query.Order("name ASC")
+	if filter.Limit > 0 {
+		query = query.Offset(filter.Offset).Limit(filter.Limit)
+	}
+	var zones []models.NoFlyZone
+	if err := query.Find(&zones).Error; err != nil {
+		logger.Errorf("获取禁飞区列表失败: %v", err)
+		return nil, 0, errors.New("获取禁飞区列表失败: " + err.Error())
+	}
+
+	return zones, total, nil
+}
+
+// ListActive 查询时间窗口内有效的禁飞区
+func (r *DBNoFlyZoneRepository) ListActive(ctx context.Context, from, to time.Time) ([]models.NoFlyZone, error) {
+	var zones []models.NoFlyZone
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.NoFlyZoneStatusActive).
+		Where("start_time IS NULL OR start_time <= ?", to).
+		Where("end_time IS NULL OR end_time >= ?", from).
+		Order("name ASC").
+		Find(&zones).Error
+	if err != nil {
+		logger.Errorf("获取有效禁飞区失败: %v", err)
+		return nil, errors.New("获取有效禁飞区失败: " + err.Error())
+	}
+	return zones, nil
+}
+
+// ExpireEnded 将已结束的临时禁飞区置为过期
+func (r *DBNoFlyZoneRepository) ExpireEnded(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.NoFlyZone{}).
+		Where("status = ? AND type = ?", models.NoFlyZoneStatusActive, models.NoFlyZoneTypeTemporary).
+		Where("end_time IS NOT NULL AND end_time < ?", now).
+		Updates(map[string]any{"status": models.NoFlyZoneStatusExpired, "updated_at": now})
+	if result.Error != nil {
+		logger.Errorf("更新过期禁飞区失败: %v", result.Error)
+		return 0, errors.New("更新过期禁飞区失败: " + result.Error.Error())
+	}
+	if result.RowsAffected > 0 {
+		logger.Infof("临时禁飞区已过期: Count=%d", result.RowsAffected)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OperatorFilter 运营商列表过滤条件
+type OperatorFilter struct {
+	ID      *uuid.UUID // 限定单个运营商（运营商用户的访问范围）
+	Type    string
+	Status  string
+	Keyword string // 模糊匹配 code/name
+	Offset  int
+	Limit   int
+}
+
+// OperatorRepository 无人机运营商仓储接口
+type OperatorRepository interface {
+	Create(ctx context.Context, operator *models.Operator) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Operator, error)
+	FindByCode(ctx context.Context, code string) (*models.Operator, error)
+	Update(ctx context.Context, operator *models.Operator) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter OperatorFilter) ([]models.Operator, int64, error)
+}
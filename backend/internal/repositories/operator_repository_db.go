@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"backend/internal/models"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DBOperatorRepository 数据库运营商仓储实现
+type DBOperatorRepository struct {
+	db *gorm.DB
+}
+
+// NewDBOperatorRepository 创建数据库运营商仓储实例
+func NewDBOperatorRepository(db *gorm.DB) OperatorRepository {
+	return &DBOperatorRepository{
+		db: db,
+	}
+}
+
+// Create 创建运营商
+func (r *DBOperatorRepository) Create(ctx context.Context, operator *models.Operator) error {
+	if operator.ID == uuid.Nil {
+		operator.ID = uuid.New()
+	}
+
+	if err := r.db.WithContext(ctx).Create(operator).Error; err != nil {
+		logger.Errorf("创建运营商失败: %v", err)
+		return errors.New("创建运营商失败: " + err.Error())
+	}
+
+	logger.Infof("运营商创建成功: ID=%s, Code=%s", operator.ID.String(), operator.Code)
+	return nil
+}
+
+// FindByID 根据ID查找运营商
+func (r *DBOperatorRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Operator, error) {
+	var operator models.Operator
+	if err := r.db.WithContext(ctx).First(&operator, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据ID查找运营商失败: %v", err)
+		return nil, err
+	}
+	return &operator, nil
+}
+
+// FindByCode 根据代码查找运营商
+func (r *DBOperatorRepository) FindByCode(ctx context.Context, code string) (*models.Operator, error) {
+	var operator models.Operator
+	if err := r.db.WithContext(ctx).Where("code = ?", strings.ToUpper(code)).First(&operator).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		logger.Errorf("根据代码查找运营商失败: %v", err)
+		return nil, err
+	}
+	return &operator, nil
+}
+
+// Update 更新运营商
+func (r *DBOperatorRepository) Update(ctx context.Context, operator *models.Operator) error {
+	if err := r.db.WithContext(ctx).Save(operator).Error; err != nil {
+		logger.Errorf("更新运营商失败: %v", err)
+		return errors.New("更新运营商失败: " + err.Error())
+	}
+
+	logger.Infof("运营商更新成功: ID=%s", operator.ID.String())
+	return nil
+}
+
+// Delete 删除运营商
+func (r *DBOperatorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Operator{}, "id = ?", id)
+	if result.Error != nil {
+		logger.Errorf("删除运营商失败: %v", result.Error)
+		return errors.New("删除运营商失败: " + result.Error.Error())
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	logger.Infof("运营商删除成功: ID=%s", id.String())
+	return nil
+}
+
+// List 按条件分页查询运营商
+func (r *DBOperatorRepository) List(ctx context.Context, filter OperatorFilter) ([]models.Operator, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Operator{})
+
+	if filter.ID != nil {
+		query = query.Where("id = ?", *filter.ID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Keyword != "" {
+		like := "%" + strings.ToLower(filter.Keyword) + "%"
+		query = query.Where("LOWER(code) LIKE ? OR LOWER(name) LIKE ?", like, like)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		logger.Errorf("统计运营商数量失败: %v", err)
+		return nil, 0, errors.New("获取运营商列表失败: " + err.Error())
+	}
+
+	var operators []models.Operator
+	if err := query.Order("code ASC").Offset(filter.Offset).Limit(filter.Limit).Find(&operators).Error; err != nil {
+		logger.Errorf("获取运营商列表失败: %v", err)
+		return nil, 0, errors.New("获取运营商列表失败: " + err.Error())
+	}
+
+	return operators, total, nil
+}
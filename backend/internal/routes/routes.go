@@ -4,6 +4,7 @@ import (
 	"backend/internal/config"
 	"backend/internal/handlers"
 	"backend/internal/middlewares"
+	"backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -98,6 +99,329 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 			tasks.PATCH("/:id/toggle", r.handlers.Task.ToggleTask)
 		}
 
+		// 机场路由（查询公开访问，写操作需要管理员权限）
+		airports := api.Group("/airports")
+		{
+			airports.GET("", r.handlers.Airport.ListAirports)
+			airports.GET("/nearby", r.handlers.Airport.NearbyAirports)
+			airports.GET("/bbox", r.handlers.Airport.AirportsInBBox)
+			airports.GET("/:id", r.handlers.Airport.GetAirport)
+			airports.GET("/:id/departures", r.handlers.Flight.Departures)
+			airports.GET("/:id/arrivals", r.handlers.Flight.Arrivals)
+		}
+		airportsAdmin := api.Group("/airports")
+		airportsAdmin.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			airportsAdmin.POST("", r.handlers.Airport.CreateAirport)
+			airportsAdmin.PUT("/:id", r.handlers.Airport.UpdateAirport)
+			airportsAdmin.DELETE("/:id", r.handlers.Airport.DeleteAirport)
+		}
+
+		// 航空公司路由（查询公开访问，写操作需要管理员权限）
+		airlines := api.Group("/airlines")
+		{
+			airlines.GET("", r.handlers.Airline.ListAirlines)
+			airlines.GET("/:id", r.handlers.Airline.GetAirline)
+			airlines.GET("/:id/fleet", r.handlers.Airline.GetFleet)
+		}
+		airlinesAdmin := api.Group("/airlines")
+		airlinesAdmin.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			airlinesAdmin.POST("", r.handlers.Airline.CreateAirline)
+			airlinesAdmin.PUT("/:id", r.handlers.Airline.UpdateAirline)
+			airlinesAdmin.DELETE("/:id", r.handlers.Airline.DeleteAirline)
+		}
+
+		// 飞机路由（查询公开访问，写操作和状态变更需要管理员权限）
+		aircraft := api.Group("/aircraft")
+		{
+			aircraft.GET("", r.handlers.Aircraft.ListAircraft)
+			aircraft.GET("/:id", r.handlers.Aircraft.GetAircraft)
+		}
+		aircraftAdmin := api.Group("/aircraft")
+		aircraftAdmin.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			aircraftAdmin.POST("", r.handlers.Aircraft.CreateAircraft)
+			aircraftAdmin.PUT("/:id", r.handlers.Aircraft.UpdateAircraft)
+			aircraftAdmin.DELETE("/:id", r.handlers.Aircraft.DeleteAircraft)
+			aircraftAdmin.POST("/:id/maintenance", r.handlers.Aircraft.SendToMaintenance)
+			aircraftAdmin.POST("/:id/activate", r.handlers.Aircraft.ReturnToService)
+			aircraftAdmin.POST("/:id/retire", r.handlers.Aircraft.Retire)
+		}
+
+		// 航班路由（查询公开访问，写操作需要管理员权限）
+		flights := api.Group("/flights")
+		{
+			flights.GET("", r.handlers.Flight.ListFlights)
+			flights.GET("/:id", r.handlers.Flight.GetFlight)
+			flights.GET("/:id/track", r.handlers.Flight.Track)
+			flights.GET("/:id/route", r.handlers.FlightRoute.GetRoute)
+		}
+		flightsAdmin := api.Group("/flights")
+		flightsAdmin.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			flightsAdmin.POST("", r.handlers.Flight.CreateFlight)
+			flightsAdmin.PUT("/:id", r.handlers.Flight.UpdateFlight)
+			flightsAdmin.DELETE("/:id", r.handlers.Flight.DeleteFlight)
+			flightsAdmin.POST("/:id/status", r.handlers.Flight.ChangeStatus)
+			flightsAdmin.GET("/:id/status-logs", r.handlers.Flight.ListStatusLogs)
+			flightsAdmin.PUT("/:id/route", r.handlers.FlightRoute.ReplaceRoute)
+			flightsAdmin.PUT("/:id/route/waypoints/:waypointId", r.handlers.FlightRoute.UpdateWaypoint)
+			flightsAdmin.DELETE("/:id/route", r.handlers.FlightRoute.DeleteRoute)
+		}
+
+		// 告警路由（需要管理员权限）
+		alerts := api.Group("/alerts")
+		alerts.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			alerts.GET("", r.handlers.Alert.ListAlerts)
+			alerts.GET("/:id", r.handlers.Alert.GetAlert)
+			alerts.POST("/:id/acknowledge", r.handlers.Alert.Acknowledge)
+			alerts.POST("/:id/resolve", r.handlers.Alert.Resolve)
+		}
+
+		// 运营商路由（管理员或运营商用户，运营商用户仅能访问所属运营商）
+		operators := api.Group("/operators")
+		operators.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleOperator}),
+		)
+		{
+			operators.GET("", r.handlers.Operator.ListOperators)
+			operators.GET("/:id", r.handlers.Operator.GetOperator)
+			operators.GET("/:id/drones", r.handlers.Operator.ListDrones)
+		}
+		operatorsAdmin := api.Group("/operators")
+		operatorsAdmin.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			operatorsAdmin.POST("", r.handlers.Operator.CreateOperator)
+			operatorsAdmin.PUT("/:id", r.handlers.Operator.UpdateOperator)
+			operatorsAdmin.DELETE("/:id", r.handlers.Operator.DeleteOperator)
+			operatorsAdmin.POST("/:id/users", r.handlers.Operator.BindUser)
+		}
+		// 运营商合规统计（管理员、监管人员查看全部，运营商用户仅能查看所属运营商）
+		operatorsCompliance := api.Group("/operators")
+		operatorsCompliance.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator}),
+		)
+		{
+			operatorsCompliance.GET("/compliance", r.handlers.Compliance.RankOperators)
+			operatorsCompliance.GET("/:id/compliance", r.handlers.Compliance.OperatorCompliance)
+		}
+
+		// 无人机路由（管理员或运营商用户，运营商用户仅能管理所属机队）
+		drones := api.Group("/drones")
+		drones.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleOperator}),
+		)
+		{
+			drones.GET("", r.handlers.Drone.ListDrones)
+			drones.GET("/:id", r.handlers.Drone.GetDrone)
+			drones.POST("", r.handlers.Drone.CreateDrone)
+			drones.PUT("/:id", r.handlers.Drone.UpdateDrone)
+			drones.DELETE("/:id", r.handlers.Drone.DeleteDrone)
+			drones.POST("/:id/status", r.handlers.Drone.ChangeStatus)
+		}
+
+		// 飞行日志路由（查询面向所有无人机相关角色，按运营商范围过滤）
+		droneLogs := api.Group("/drones")
+		droneLogs.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			droneLogs.GET("/:id/flight-logs", r.handlers.FlightLog.ListByDrone)
+			droneLogs.GET("/:id/maintenance", r.handlers.Maintenance.DroneStatus)
+		}
+		flightLogs := api.Group("/flight-logs")
+		flightLogs.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			flightLogs.GET("/:id", r.handlers.FlightLog.GetLog)
+		}
+
+		// 无人机维护规则（查询面向所有无人机相关角色，维护由管理员负责）
+		maintenanceRules := api.Group("/maintenance/rules")
+		maintenanceRules.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			maintenanceRules.GET("", r.handlers.Maintenance.ListRules)
+			maintenanceRules.GET("/:id", r.handlers.Maintenance.GetRule)
+		}
+		maintenanceRulesAdmin := api.Group("/maintenance/rules")
+		maintenanceRulesAdmin.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
+		)
+		{
+			maintenanceRulesAdmin.POST("", r.handlers.Maintenance.CreateRule)
+			maintenanceRulesAdmin.PUT("/:id", r.handlers.Maintenance.UpdateRule)
+			maintenanceRulesAdmin.DELETE("/:id", r.handlers.Maintenance.DeleteRule)
+		}
+		// 待维护无人机与维护工单查询（管理员、监管人员查看全部，运营商用户仅能查看所属机队）
+		maintenance := api.Group("/maintenance")
+		maintenance.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator}),
+		)
+		{
+			maintenance.GET("/due", r.handlers.Maintenance.ListDue)
+			maintenance.GET("/work-orders", r.handlers.Maintenance.ListWorkOrders)
+			maintenance.GET("/work-orders/:id", r.handlers.Maintenance.GetWorkOrder)
+		}
+		// 维护工单开立、签核与取消（管理员、运营商）
+		maintenanceWork := api.Group("/maintenance/work-orders")
+		maintenanceWork.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleOperator}),
+		)
+		{
+			maintenanceWork.POST("", r.handlers.Maintenance.OpenWorkOrder)
+			maintenanceWork.POST("/:id/sign-off", r.handlers.Maintenance.SignOffWorkOrder)
+			maintenanceWork.POST("/:id/cancel", r.handlers.Maintenance.CancelWorkOrder)
+		}
+
+		// 无人机任务路由（查询面向所有无人机相关角色，按运营商范围过滤）
+		missions := api.Group("/missions")
+		missions.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			missions.GET("", r.handlers.Mission.ListMissions)
+			missions.GET("/:id", r.handlers.Mission.GetMission)
+			missions.GET("/:id/logs", r.handlers.Mission.ListLogs)
+			missions.GET("/:id/conflicts", r.handlers.Mission.Conflicts)
+		}
+		// 任务提交与执行（管理员、运营商、飞手）
+		missionsPlan := api.Group("/missions")
+		missionsPlan.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			missionsPlan.POST("", r.handlers.Mission.SubmitMission)
+			missionsPlan.PUT("/:id", r.handlers.Mission.UpdateMission)
+			missionsPlan.POST("/:id/start", r.handlers.Mission.Start)
+			missionsPlan.POST("/:id/complete", r.handlers.Mission.Complete)
+			missionsPlan.POST("/:id/cancel", r.handlers.Mission.Cancel)
+		}
+		// 任务审批（管理员、监管人员）
+		missionsReview := api.Group("/missions")
+		missionsReview.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator}),
+		)
+		{
+			missionsReview.POST("/:id/approve", r.handlers.Mission.Approve)
+			missionsReview.POST("/:id/reject", r.handlers.Mission.Reject)
+		}
+
+		// 无人机事件路由（上报与查询面向所有无人机相关角色，按运营商范围过滤）
+		incidents := api.Group("/incidents")
+		incidents.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			incidents.GET("", r.handlers.Incident.ListIncidents)
+			incidents.GET("/:id", r.handlers.Incident.GetIncident)
+			incidents.POST("", r.handlers.Incident.FileIncident)
+			incidents.POST("/:id/confirm", r.handlers.Incident.ConfirmIncident)
+		}
+		// 事件调查（管理员、监管人员）
+		incidentsInvestigate := api.Group("/incidents")
+		incidentsInvestigate.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator}),
+		)
+		{
+			incidentsInvestigate.POST("/:id/dismiss", r.handlers.Incident.DismissIncident)
+			incidentsInvestigate.POST("/:id/assign", r.handlers.Incident.AssignInvestigator)
+			incidentsInvestigate.POST("/:id/investigate", r.handlers.Incident.StartInvestigation)
+			incidentsInvestigate.POST("/:id/close", r.handlers.Incident.CloseIncident)
+		}
+
+		// 禁飞区路由（查询与导出面向所有无人机相关角色）
+		zones := api.Group("/no-fly-zones")
+		zones.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			zones.GET("", r.handlers.NoFlyZone.ListZones)
+			zones.GET("/export", r.handlers.NoFlyZone.ExportZones)
+			zones.GET("/:id", r.handlers.NoFlyZone.GetZone)
+		}
+		// 禁飞区维护与导入（管理员、监管人员）
+		zonesManage := api.Group("/no-fly-zones")
+		zonesManage.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator}),
+		)
+		{
+			zonesManage.POST("", r.handlers.NoFlyZone.CreateZone)
+			zonesManage.POST("/import", r.handlers.NoFlyZone.ImportZones)
+			zonesManage.PUT("/:id", r.handlers.NoFlyZone.UpdateZone)
+			zonesManage.DELETE("/:id", r.handlers.NoFlyZone.DeleteZone)
+		}
+
+		// 空域检查路由（无人机相关角色起飞前查询）
+		airspace := api.Group("/airspace")
+		airspace.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleRegulator, services.RoleOperator, services.RolePilot}),
+		)
+		{
+			airspace.POST("/check", r.handlers.Airspace.Check)
+		}
+
+		// 统计分析路由（需要登录）
+		analytics := api.Group("/analytics")
+		analytics.Use(middlewares.AuthMiddleware())
+		{
+			analytics.GET("/otp", r.handlers.Analytics.OTP)
+		}
+
+		// 数据接入路由（需要管理员或接收站 feeder 角色）
+		ingest := api.Group("/ingest")
+		ingest.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin, services.RoleFeeder}),
+		)
+		{
+			ingest.POST("/flight-positions", r.handlers.Ingest.IngestFlightPositions)
+			ingest.POST("/drone-telemetry", r.handlers.Ingest.IngestDroneTelemetry)
+			ingest.GET("/drone-telemetry/stream", r.handlers.Ingest.StreamDroneTelemetry)
+		}
+
+		// 实时推送路由（WebSocket/SSE，处理器内部校验 JWT，支持 token 查询参数）
+		api.GET("/stream/positions", r.handlers.Stream.Positions)
+
 		// 需要认证的路由
 		user := api.Group("/user")
 		user.Use(middlewares.AuthMiddleware())
@@ -109,7 +433,7 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 		admin := api.Group("/admin")
 		admin.Use(
 			middlewares.AuthMiddleware(),
-			middlewares.RoleBasedAuth([]string{"admin"}),
+			middlewares.RoleBasedAuth([]string{services.RoleAdmin}),
 		)
 		{
 			admin.GET("/users", r.handlers.User.ListUsers)
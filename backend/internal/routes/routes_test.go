@@ -0,0 +1,138 @@
+package routes
+
+import (
+	"backend/internal/config"
+	"backend/internal/dto"
+	"backend/internal/handlers"
+	"backend/internal/services"
+	"backend/pkg/geo"
+	"backend/pkg/utils/jwt"
+	"backend/pkg/utils/logger"
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const importGeoJSON = `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"name":"机场净空区","type":"permanent"},"geometry":{"type":"Polygon","coordinates":[[[116.0,39.0],[116.1,39.0],[116.1,39.1],[116.0,39.0]]]}}]}`
+
+// MockNoFlyZoneService 模拟禁飞区服务，仅实现导入
+type MockNoFlyZoneService struct {
+	services.NoFlyZoneService
+	mock.Mock
+}
+
+func (m *MockNoFlyZoneService) ImportZones(ctx context.Context, collection *geo.FeatureCollection) (*dto.NoFlyZoneImportResult, error) {
+	args := m.Called(ctx, collection)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.NoFlyZoneImportResult), args.Error(1)
+}
+
+// setupEngine 按生产配置注册全部中间件与路由
+func setupEngine(t *testing.T, zoneService services.NoFlyZoneService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	discard := log.New(io.Discard, "", 0)
+	logger.InfoLogger, logger.ErrorLogger, logger.DebugLogger, logger.WarnLogger = discard, discard, discard, discard
+
+	previous := config.AppConfig
+	config.AppConfig = &config.Config{CORSOrigins: []string{"http://localhost:5173"}}
+	t.Cleanup(func() { config.AppConfig = previous })
+
+	h := &handlers.Handlers{
+		Task:        handlers.NewTaskHandler(nil),
+		User:        handlers.NewUserHandler(nil),
+		Health:      handlers.NewHealthHandler(nil),
+		Captcha:     handlers.NewCaptchaHandler(),
+		Airport:     handlers.NewAirportHandler(nil),
+		Airline:     handlers.NewAirlineHandler(nil),
+		Aircraft:    handlers.NewAircraftHandler(nil),
+		Flight:      handlers.NewFlightHandler(nil, nil),
+		FlightRoute: handlers.NewFlightRouteHandler(nil),
+		Alert:       handlers.NewAlertHandler(nil),
+		Analytics:   handlers.NewAnalyticsHandler(nil),
+		Operator:    handlers.NewOperatorHandler(nil, nil),
+		Drone:       handlers.NewDroneHandler(nil),
+		Mission:     handlers.NewMissionHandler(nil),
+		NoFlyZone:   handlers.NewNoFlyZoneHandler(zoneService),
+		Airspace:    handlers.NewAirspaceHandler(nil),
+		Ingest:      handlers.NewIngestHandler(nil, nil),
+		Stream:      handlers.NewStreamHandler(nil),
+		FlightLog:   handlers.NewFlightLogHandler(nil),
+		Incident:    handlers.NewIncidentHandler(nil),
+		Compliance:  handlers.NewComplianceHandler(nil),
+		Maintenance: handlers.NewMaintenanceHandler(nil),
+	}
+	engine := gin.New()
+	NewRouter(h).SetupRoutes(engine)
+	return engine
+}
+
+func adminToken(t *testing.T) string {
+	token, err := jwt.GenerateToken(uuid.New().String(), "admin", "admin", "", 0)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestImportZones_MultipartThroughMiddlewares(t *testing.T) {
+	zoneService := new(MockNoFlyZoneService)
+	zoneService.On("ImportZones", mock.Anything, mock.MatchedBy(func(fc *geo.FeatureCollection) bool {
+		return len(fc.Features) == 1
+	})).Return(&dto.NoFlyZoneImportResult{Total: 1, Imported: 1}, nil)
+	engine := setupEngine(t, zoneService)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "zones.geojson")
+	assert.NoError(t, err)
+	_, _ = part.Write([]byte(importGeoJSON))
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/no-fly-zones/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+adminToken(t))
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	zoneService.AssertExpectations(t)
+}
+
+func TestImportZones_GeoJSONBodyThroughMiddlewares(t *testing.T) {
+	zoneService := new(MockNoFlyZoneService)
+	zoneService.On("ImportZones", mock.Anything, mock.Anything).
+		Return(&dto.NoFlyZoneImportResult{Total: 1, Imported: 1}, nil)
+	engine := setupEngine(t, zoneService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/no-fly-zones/import", strings.NewReader(importGeoJSON))
+	req.Header.Set("Content-Type", "application/geo+json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+adminToken(t))
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	zoneService.AssertExpectations(t)
+}
+
+func TestContentType_RejectsUnsupportedMediaType(t *testing.T) {
+	engine := setupEngine(t, new(MockNoFlyZoneService))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/no-fly-zones/import", strings.NewReader(importGeoJSON))
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Authorization", "Bearer "+adminToken(t))
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
@@ -0,0 +1,31 @@
+package services
+
+import "github.com/google/uuid"
+
+// 系统角色
+const (
+	RoleAdmin     = "admin"
+	RoleOperator  = "operator"
+	RolePilot     = "pilot"
+	RoleRegulator = "regulator"
+	// RoleFeeder 数据接入角色，仅用于 ADS-B/MAVLink 接收站调用 /ingest 接口上报位置
+	// 注册接口不会分配该角色，账号和长期令牌通过 scripts/create_feeder.go 创建
+	RoleFeeder = "feeder"
+)
+
+// Actor 操作人信息，用于记录状态变更和审计
+type Actor struct {
+	UserID   *uuid.UUID
+	Username string
+	Role     string
+}
+
+// HasRole 是否为指定角色之一
+func (a Actor) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if a.Role == role {
+			return true
+		}
+	}
+	return false
+}
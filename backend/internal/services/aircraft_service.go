@@ -0,0 +1,278 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AircraftService 飞机服务接口
+type AircraftService interface {
+	ListAircraft(ctx context.Context, query *dto.AircraftQuery) (*dto.PageResponse[dto.AircraftResponse], error)
+	GetAircraft(ctx context.Context, id uuid.UUID) (*models.Aircraft, error)
+	CreateAircraft(ctx context.Context, req *dto.CreateAircraftRequest) (*models.Aircraft, error)
+	UpdateAircraft(ctx context.Context, id uuid.UUID, req *dto.UpdateAircraftRequest) (*models.Aircraft, error)
+	DeleteAircraft(ctx context.Context, id uuid.UUID) error
+	SendToMaintenance(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error)
+	ReturnToService(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error)
+	Retire(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error)
+}
+
+// aircraftTransitions 允许的飞机状态迁移
+// retired 为终态，不允许再迁出
+var aircraftTransitions = map[string][]string{
+	models.AircraftStatusActive:      {models.AircraftStatusMaintenance, models.AircraftStatusRetired},
+	models.AircraftStatusMaintenance: {models.AircraftStatusActive, models.AircraftStatusRetired},
+}
+
+type aircraftService struct {
+	repo        repositories.AircraftRepository
+	airlineRepo repositories.AirlineRepository
+}
+
+// NewAircraftService 创建飞机服务实例
+func NewAircraftService(repo repositories.AircraftRepository, airlineRepo repositories.AirlineRepository) AircraftService {
+	return &aircraftService{
+		repo:        repo,
+		airlineRepo: airlineRepo,
+	}
+}
+
+// ListAircraft 分页查询飞机
+func (s *aircraftService) ListAircraft(ctx context.Context, query *dto.AircraftQuery) (*dto.PageResponse[dto.AircraftResponse], error) {
+	query.Normalize()
+
+	filter := repositories.AircraftFilter{
+		Status:  query.Status,
+		Model:   query.Model,
+		Keyword: strings.TrimSpace(query.Q),
+		Offset:  query.Offset(),
+		Limit:   query.PageSize,
+	}
+	if query.AirlineID != "" {
+		airlineID, err := uuid.Parse(query.AirlineID)
+		if err != nil {
+			return nil, apperr.NewBadRequest("无效的航空公司ID")
+		}
+		filter.AirlineID = &airlineID
+	}
+
+	aircraft, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToAircraftResponseList(aircraft), total, query.PageQuery), nil
+}
+
+// GetAircraft 获取飞机详情
+func (s *aircraftService) GetAircraft(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+	aircraft, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("飞机不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return aircraft, nil
+}
+
+// CreateAircraft 创建飞机，注册号全局唯一
+func (s *aircraftService) CreateAircraft(ctx context.Context, req *dto.CreateAircraftRequest) (*models.Aircraft, error) {
+	registration := strings.ToUpper(strings.TrimSpace(req.Registration))
+	if registration == "" {
+		return nil, apperr.NewBadRequest("注册号不能为空")
+	}
+
+	if _, err := s.repo.FindByRegistration(ctx, registration); err == nil {
+		return nil, apperr.NewConflict("飞机注册号已存在")
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	icaoHex := strings.ToUpper(req.ICAOHex)
+	if err := s.ensureICAOHexUnique(ctx, icaoHex, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureAirline(ctx, req.AirlineID); err != nil {
+		return nil, err
+	}
+
+	aircraft := &models.Aircraft{
+		Registration: registration,
+		ICAOHex:      icaoHex,
+		AirlineID:    req.AirlineID,
+		Model:        req.Model,
+		Manufacturer: req.Manufacturer,
+		SerialNumber: req.SerialNumber,
+		YearBuilt:    req.YearBuilt,
+		Capacity:     req.Capacity,
+		MaxRange:     req.MaxRange,
+		CruiseSpeed:  req.CruiseSpeed,
+		EngineType:   req.EngineType,
+		EngineCount:  req.EngineCount,
+		Status:       models.AircraftStatusActive,
+		Description:  req.Description,
+	}
+
+	if err := s.repo.Create(ctx, aircraft); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return aircraft, nil
+}
+
+// UpdateAircraft 更新飞机基础信息，状态只能通过状态迁移接口修改
+func (s *aircraftService) UpdateAircraft(ctx context.Context, id uuid.UUID, req *dto.UpdateAircraftRequest) (*models.Aircraft, error) {
+	aircraft, err := s.GetAircraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AirlineID != nil {
+		if err := s.ensureAirline(ctx, req.AirlineID); err != nil {
+			return nil, err
+		}
+		aircraft.AirlineID = req.AirlineID
+		aircraft.Airline = nil
+	}
+	if req.ICAOHex != nil {
+		icaoHex := strings.ToUpper(*req.ICAOHex)
+		if err := s.ensureICAOHexUnique(ctx, icaoHex, aircraft.ID); err != nil {
+			return nil, err
+		}
+		aircraft.ICAOHex = icaoHex
+	}
+	if req.Model != nil {
+		aircraft.Model = *req.Model
+	}
+	if req.Manufacturer != nil {
+		aircraft.Manufacturer = *req.Manufacturer
+	}
+	if req.SerialNumber != nil {
+		aircraft.SerialNumber = *req.SerialNumber
+	}
+	if req.YearBuilt != nil {
+		aircraft.YearBuilt = *req.YearBuilt
+	}
+	if req.Capacity != nil {
+		aircraft.Capacity = *req.Capacity
+	}
+	if req.MaxRange != nil {
+		aircraft.MaxRange = *req.MaxRange
+	}
+	if req.CruiseSpeed != nil {
+		aircraft.CruiseSpeed = *req.CruiseSpeed
+	}
+	if req.EngineType != nil {
+		aircraft.EngineType = *req.EngineType
+	}
+	if req.EngineCount != nil {
+		aircraft.EngineCount = *req.EngineCount
+	}
+	if req.Description != nil {
+		aircraft.Description = *req.Description
+	}
+
+	if err := s.repo.Update(ctx, aircraft); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return aircraft, nil
+}
+
+// DeleteAircraft 删除飞机
+func (s *aircraftService) DeleteAircraft(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("飞机不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// SendToMaintenance 飞机送修
+func (s *aircraftService) SendToMaintenance(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error) {
+	return s.transition(ctx, id, models.AircraftStatusMaintenance, reason)
+}
+
+// ReturnToService 维修完成，恢复运营
+func (s *aircraftService) ReturnToService(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error) {
+	return s.transition(ctx, id, models.AircraftStatusActive, reason)
+}
+
+// Retire 飞机退役
+func (s *aircraftService) Retire(ctx context.Context, id uuid.UUID, reason string) (*models.Aircraft, error) {
+	return s.transition(ctx, id, models.AircraftStatusRetired, reason)
+}
+
+// transition 执行状态迁移并校验迁移是否合法
+func (s *aircraftService) transition(ctx context.Context, id uuid.UUID, target, reason string) (*models.Aircraft, error) {
+	aircraft, err := s.GetAircraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canTransitAircraft(aircraft.Status, target) {
+		return nil, apperr.NewConflict(fmt.Sprintf("飞机状态不允许从 %s 变更为 %s", aircraft.Status, target))
+	}
+
+	from := aircraft.Status
+	aircraft.Status = target
+	if err := s.repo.Update(ctx, aircraft); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	logger.Infof("[AircraftService] 飞机状态变更: registration=%s, %s -> %s, reason=%s",
+		aircraft.Registration, from, target, reason)
+	return aircraft, nil
+}
+
+// ensureAirline 校验航空公司存在
+func (s *aircraftService) ensureAirline(ctx context.Context, airlineID *uuid.UUID) error {
+	if airlineID == nil {
+		return nil
+	}
+	if _, err := s.airlineRepo.FindByID(ctx, *airlineID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewBadRequest("航空公司不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// ensureICAOHexUnique 校验 ICAO 地址未被其他飞机占用，空值不校验
+func (s *aircraftService) ensureICAOHexUnique(ctx context.Context, icaoHex string, selfID uuid.UUID) error {
+	if icaoHex == "" {
+		return nil
+	}
+	existing, err := s.repo.FindByICAOHex(ctx, icaoHex)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil
+		}
+		return apperr.NewInternalError(err)
+	}
+	if existing.ID != selfID {
+		return apperr.NewConflict("ICAO 地址已被其他飞机使用")
+	}
+	return nil
+}
+
+func canTransitAircraft(from, to string) bool {
+	for _, allowed := range aircraftTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
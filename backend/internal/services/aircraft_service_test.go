@@ -0,0 +1,130 @@
+package services
+
+import (
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAircraftRepository 模拟飞机仓储
+type MockAircraftRepository struct {
+	mock.Mock
+}
+
+func (m *MockAircraftRepository) Create(ctx context.Context, aircraft *models.Aircraft) error {
+	return m.Called(ctx, aircraft).Error(0)
+}
+
+func (m *MockAircraftRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Aircraft, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Aircraft), args.Error(1)
+}
+
+func (m *MockAircraftRepository) FindByRegistration(ctx context.Context, registration string) (*models.Aircraft, error) {
+	args := m.Called(ctx, registration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Aircraft), args.Error(1)
+}
+
+func (m *MockAircraftRepository) FindByICAOHex(ctx context.Context, icaoHex string) (*models.Aircraft, error) {
+	args := m.Called(ctx, icaoHex)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Aircraft), args.Error(1)
+}
+
+func (m *MockAircraftRepository) Update(ctx context.Context, aircraft *models.Aircraft) error {
+	return m.Called(ctx, aircraft).Error(0)
+}
+
+func (m *MockAircraftRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAircraftRepository) List(ctx context.Context, filter repositories.AircraftFilter) ([]models.Aircraft, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Aircraft), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAircraftRepository) ListByAirline(ctx context.Context, airlineID uuid.UUID) ([]models.Aircraft, error) {
+	args := m.Called(ctx, airlineID)
+	return args.Get(0).([]models.Aircraft), args.Error(1)
+}
+
+func (m *MockAircraftRepository) CountByAirline(ctx context.Context, airlineID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, airlineID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestAircraftStatusTransitions(t *testing.T) {
+	discardLogs()
+	ctx := context.Background()
+	repo := new(MockAircraftRepository)
+	service := NewAircraftService(repo, nil)
+
+	aircraft := &models.Aircraft{ID: uuid.New(), Registration: "B-1234", Status: models.AircraftStatusActive}
+	repo.On("FindByID", ctx, aircraft.ID).Return(aircraft, nil)
+	repo.On("Update", ctx, aircraft).Return(nil)
+
+	// active -> maintenance -> active
+	result, err := service.SendToMaintenance(ctx, aircraft.ID, "A 检")
+	require.NoError(t, err)
+	assert.Equal(t, models.AircraftStatusMaintenance, result.Status)
+
+	_, err = service.SendToMaintenance(ctx, aircraft.ID, "重复送修")
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	result, err = service.ReturnToService(ctx, aircraft.ID, "A 检完成")
+	require.NoError(t, err)
+	assert.Equal(t, models.AircraftStatusActive, result.Status)
+
+	_, err = service.ReturnToService(ctx, aircraft.ID, "已在运营")
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	// maintenance -> retired，退役为终态
+	aircraft.Status = models.AircraftStatusMaintenance
+	result, err = service.Retire(ctx, aircraft.ID, "机龄到限")
+	require.NoError(t, err)
+	assert.Equal(t, models.AircraftStatusRetired, result.Status)
+
+	_, err = service.ReturnToService(ctx, aircraft.ID, "")
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	_, err = service.SendToMaintenance(ctx, aircraft.ID, "")
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	_, err = service.Retire(ctx, aircraft.ID, "")
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	assert.Equal(t, models.AircraftStatusRetired, aircraft.Status)
+	repo.AssertNumberOfCalls(t, "Update", 3)
+}
+
+func TestAircraftRetireFromActiveAndNotFound(t *testing.T) {
+	discardLogs()
+	ctx := context.Background()
+	repo := new(MockAircraftRepository)
+	service := NewAircraftService(repo, nil)
+
+	aircraft := &models.Aircraft{ID: uuid.New(), Registration: "B-5678", Status: models.AircraftStatusActive}
+	repo.On("FindByID", ctx, aircraft.ID).Return(aircraft, nil)
+	repo.On("Update", ctx, aircraft).Return(nil)
+	result, err := service.Retire(ctx, aircraft.ID, "出售")
+	require.NoError(t, err)
+	assert.Equal(t, models.AircraftStatusRetired, result.Status)
+
+	missing := uuid.New()
+	repo.On("FindByID", ctx, missing).Return(nil, repositories.ErrNotFound)
+	_, err = service.SendToMaintenance(ctx, missing, "")
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AirlineService 航空公司服务接口
+type AirlineService interface {
+	ListAirlines(ctx context.Context, query *dto.AirlineQuery) (*dto.PageResponse[dto.AirlineResponse], error)
+	GetAirline(ctx context.Context, idOrCode string) (*models.Airline, error)
+	CreateAirline(ctx context.Context, req *dto.CreateAirlineRequest) (*models.Airline, error)
+	UpdateAirline(ctx context.Context, id uuid.UUID, req *dto.UpdateAirlineRequest) (*models.Airline, error)
+	DeleteAirline(ctx context.Context, id uuid.UUID) error
+	GetFleet(ctx context.Context, id uuid.UUID) (*dto.FleetResponse, error)
+}
+
+type airlineService struct {
+	repo         repositories.AirlineRepository
+	aircraftRepo repositories.AircraftRepository
+}
+
+// NewAirlineService 创建航空公司服务实例
+func NewAirlineService(repo repositories.AirlineRepository, aircraftRepo repositories.AircraftRepository) AirlineService {
+	return &airlineService{
+		repo:         repo,
+		aircraftRepo: aircraftRepo,
+	}
+}
+
+// ListAirlines 分页查询航空公司
+func (s *airlineService) ListAirlines(ctx context.Context, query *dto.AirlineQuery) (*dto.PageResponse[dto.AirlineResponse], error) {
+	query.Normalize()
+
+	airlines, total, err := s.repo.List(ctx, repositories.AirlineFilter{
+		Country: query.Country,
+		Type:    query.Type,
+		Status:  query.Status,
+		Keyword: strings.TrimSpace(query.Q),
+		Offset:  query.Offset(),
+		Limit:   query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToAirlineResponseList(airlines), total, query.PageQuery), nil
+}
+
+// GetAirline 根据ID或IATA代码获取航空公司
+func (s *airlineService) GetAirline(ctx context.Context, idOrCode string) (*models.Airline, error) {
+	var (
+		airline *models.Airline
+		err     error
+	)
+	if id, parseErr := uuid.Parse(idOrCode); parseErr == nil {
+		airline, err = s.repo.FindByID(ctx, id)
+	} else {
+		airline, err = s.repo.FindByCode(ctx, idOrCode)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("航空公司不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return airline, nil
+}
+
+// CreateAirline 创建航空公司，代码全局唯一
+func (s *airlineService) CreateAirline(ctx context.Context, req *dto.CreateAirlineRequest) (*models.Airline, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+
+	if _, err := s.repo.FindByCode(ctx, code); err == nil {
+		return nil, apperr.NewConflict("航空公司代码已存在")
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	airline := &models.Airline{
+		Code:        code,
+		Name:        req.Name,
+		NameEn:      req.NameEn,
+		Country:     req.Country,
+		Logo:        req.Logo,
+		Website:     req.Website,
+		Callsign:    req.Callsign,
+		Type:        defaultString(req.Type, "passenger"),
+		Status:      defaultString(req.Status, "active"),
+		Description: req.Description,
+	}
+
+	if err := s.repo.Create(ctx, airline); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return airline, nil
+}
+
+// UpdateAirline 更新航空公司
+func (s *airlineService) UpdateAirline(ctx context.Context, id uuid.UUID, req *dto.UpdateAirlineRequest) (*models.Airline, error) {
+	airline, err := s.findByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		airline.Name = *req.Name
+	}
+	if req.NameEn != nil {
+		airline.NameEn = *req.NameEn
+	}
+	if req.Country != nil {
+		airline.Country = *req.Country
+	}
+	if req.Logo != nil {
+		airline.Logo = *req.Logo
+	}
+	if req.Website != nil {
+		airline.Website = *req.Website
+	}
+	if req.Callsign != nil {
+		airline.Callsign = *req.Callsign
+	}
+	if req.Type != nil {
+		airline.Type = *req.Type
+	}
+	if req.Status != nil {
+		airline.Status = *req.Status
+	}
+	if req.Description != nil {
+		airline.Description = *req.Description
+	}
+
+	if err := s.repo.Update(ctx, airline); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return airline, nil
+}
+
+// DeleteAirline 删除航空公司，名下仍有飞机时拒绝删除
+func (s *airlineService) DeleteAirline(ctx context.Context, id uuid.UUID) error {
+	count, err := s.aircraftRepo.CountByAirline(ctx, id)
+	if err != nil {
+		return apperr.NewInternalError(err)
+	}
+	if count > 0 {
+		return apperr.NewConflict("航空公司名下仍有飞机，无法删除")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("航空公司不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// GetFleet 获取航空公司机队及按机型、机龄、座位数的汇总
+func (s *airlineService) GetFleet(ctx context.Context, id uuid.UUID) (*dto.FleetResponse, error) {
+	airline, err := s.findByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	aircraft, err := s.aircraftRepo.ListByAirline(ctx, id)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return &dto.FleetResponse{
+		Airline:  *dto.ToAirlineResponse(airline),
+		Summary:  summarizeFleet(aircraft, time.Now().Year()),
+		Aircraft: dto.ToAircraftResponseList(aircraft),
+	}, nil
+}
+
+func (s *airlineService) findByID(ctx context.Context, id uuid.UUID) (*models.Airline, error) {
+	airline, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("航空公司不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return airline, nil
+}
+
+// summarizeFleet 汇总机队统计
+// 已退役飞机计入状态分布，但不计入座位数和机龄统计
+func summarizeFleet(aircraft []models.Aircraft, currentYear int) dto.FleetSummary {
+	summary := dto.FleetSummary{
+		TotalAircraft: len(aircraft),
+		ByStatus:      make(map[string]int),
+		ByModel:       []dto.FleetModelSummary{},
+	}
+
+	type modelAgg struct {
+		dto.FleetModelSummary
+		ageSum   int
+		ageCount int
+	}
+	byModel := make(map[string]*modelAgg)
+	ageSum, ageCount := 0, 0
+
+	for _, a := range aircraft {
+		summary.ByStatus[a.Status]++
+		if a.Status == models.AircraftStatusRetired {
+			continue
+		}
+
+		agg, ok := byModel[a.Model]
+		if !ok {
+			agg = &modelAgg{FleetModelSummary: dto.FleetModelSummary{Model: a.Model, Manufacturer: a.Manufacturer}}
+			byModel[a.Model] = agg
+		}
+		agg.Count++
+		agg.TotalCapacity += a.Capacity
+		summary.TotalCapacity += a.Capacity
+
+		if a.YearBuilt > 0 {
+			age := currentYear - a.YearBuilt
+			agg.ageSum += age
+			agg.ageCount++
+			ageSum += age
+			ageCount++
+			if summary.OldestYear == 0 || a.YearBuilt < summary.OldestYear {
+				summary.OldestYear = a.YearBuilt
+			}
+			if a.YearBuilt > summary.NewestYear {
+				summary.NewestYear = a.YearBuilt
+			}
+		}
+	}
+
+	if ageCount > 0 {
+		summary.AverageAge = roundTo(float64(ageSum)/float64(ageCount), 1)
+	}
+	for _, agg := range byModel {
+		if agg.ageCount > 0 {
+			agg.AverageAge = roundTo(float64(agg.ageSum)/float64(agg.ageCount), 1)
+		}
+		summary.ByModel = append(summary.ByModel, agg.FleetModelSummary)
+	}
+	sort.Slice(summary.ByModel, func(i, j int) bool {
+		if summary.ByModel[i].Count != summary.ByModel[j].Count {
+			return summary.ByModel[i].Count > summary.ByModel[j].Count
+		}
+		return summary.ByModel[i].Model < summary.ByModel[j].Model
+	})
+
+	return summary
+}
+
+// roundTo 保留指定位数的小数
+func roundTo(value float64, digits int) float64 {
+	pow := math.Pow(10, float64(digits))
+	return math.Round(value*pow) / pow
+}
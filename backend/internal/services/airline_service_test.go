@@ -0,0 +1,46 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeFleet(t *testing.T) {
+	aircraft := []models.Aircraft{
+		{Model: "A320neo", Manufacturer: "Airbus", Capacity: 186, YearBuilt: 2018, Status: models.AircraftStatusActive},
+		{Model: "A320neo", Manufacturer: "Airbus", Capacity: 186, YearBuilt: 2021, Status: models.AircraftStatusMaintenance},
+		{Model: "A320neo", Manufacturer: "Airbus", Capacity: 174, Status: models.AircraftStatusActive}, // 出厂年份未知，不参与机龄统计
+		{Model: "B737-800", Manufacturer: "Boeing", Capacity: 164, YearBuilt: 2010, Status: models.AircraftStatusActive},
+		{Model: "B737-800", Manufacturer: "Boeing", Capacity: 164, YearBuilt: 1999, Status: models.AircraftStatusRetired}, // 退役飞机只计入状态分布
+	}
+
+	summary := summarizeFleet(aircraft, 2024)
+	assert.Equal(t, 5, summary.TotalAircraft)
+	assert.Equal(t, 186+186+174+164, summary.TotalCapacity)
+	// (6 + 3 + 14) / 3
+	assert.Equal(t, 7.7, summary.AverageAge)
+	assert.Equal(t, 2010, summary.OldestYear)
+	assert.Equal(t, 2021, summary.NewestYear)
+	assert.Equal(t, map[string]int{
+		models.AircraftStatusActive:      3,
+		models.AircraftStatusMaintenance: 1,
+		models.AircraftStatusRetired:     1,
+	}, summary.ByStatus)
+	// 按在役数量降序
+	assert.Equal(t, []dto.FleetModelSummary{
+		{Model: "A320neo", Manufacturer: "Airbus", Count: 3, TotalCapacity: 546, AverageAge: 4.5},
+		{Model: "B737-800", Manufacturer: "Boeing", Count: 1, TotalCapacity: 164, AverageAge: 14},
+	}, summary.ByModel)
+}
+
+func TestSummarizeFleetEmpty(t *testing.T) {
+	summary := summarizeFleet(nil, 2024)
+	assert.Equal(t, 0, summary.TotalAircraft)
+	assert.Zero(t, summary.AverageAge)
+	assert.Zero(t, summary.OldestYear)
+	assert.Empty(t, summary.ByStatus)
+	assert.Empty(t, summary.ByModel)
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// AirportService 机场服务接口
+type AirportService interface {
+	ListAirports(ctx context.Context, query *dto.AirportQuery) (*dto.PageResponse[dto.AirportResponse], error)
+	GetAirport(ctx context.Context, idOrCode string) (*models.Airport, error)
+	CreateAirport(ctx context.Context, req *dto.CreateAirportRequest) (*models.Airport, error)
+	UpdateAirport(ctx context.Context, id uuid.UUID, req *dto.UpdateAirportRequest) (*models.Airport, error)
+	DeleteAirport(ctx context.Context, id uuid.UUID) error
+	NearbyAirports(ctx context.Context, query *dto.NearbyAirportQuery) ([]dto.NearbyAirportResponse, error)
+	AirportsInBBox(ctx context.Context, query *dto.BBoxAirportQuery) ([]dto.AirportResponse, error)
+}
+
+const (
+	defaultNearbyRadiusKm = 100
+	defaultNearbyLimit    = 20
+	defaultBBoxLimit      = 500
+)
+
+type airportService struct {
+	repo repositories.AirportRepository
+}
+
+// NewAirportService 创建机场服务实例
+func NewAirportService(repo repositories.AirportRepository) AirportService {
+	return &airportService{
+		repo: repo,
+	}
+}
+
+// ListAirports 分页查询机场
+func (s *airportService) ListAirports(ctx context.Context, query *dto.AirportQuery) (*dto.PageResponse[dto.AirportResponse], error) {
+	query.Normalize()
+
+	airports, total, err := s.repo.List(ctx, repositories.AirportFilter{
+		Country: query.Country,
+		City:    query.City,
+		Type:    query.Type,
+		Status:  query.Status,
+		Keyword: strings.TrimSpace(query.Q),
+		Offset:  query.Offset(),
+		Limit:   query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToAirportResponseList(airports), total, query.PageQuery), nil
+}
+
+// GetAirport 根据ID或IATA代码获取机场
+func (s *airportService) GetAirport(ctx context.Context, idOrCode string) (*models.Airport, error) {
+	var (
+		airport *models.Airport
+		err     error
+	)
+	if id, parseErr := uuid.Parse(idOrCode); parseErr == nil {
+		airport, err = s.repo.FindByID(ctx, id)
+	} else {
+		airport, err = s.repo.FindByCode(ctx, idOrCode)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("机场不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return airport, nil
+}
+
+// CreateAirport 创建机场，代码全局唯一
+func (s *airportService) CreateAirport(ctx context.Context, req *dto.CreateAirportRequest) (*models.Airport, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if code == "" {
+		return nil, apperr.NewBadRequest("机场代码不能为空")
+	}
+
+	if _, err := s.repo.FindByCode(ctx, code); err == nil {
+		return nil, apperr.NewConflict("机场代码已存在")
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	airport := &models.Airport{
+		Code:        code,
+		Name:        req.Name,
+		NameEn:      req.NameEn,
+		City:        req.City,
+		Country:     req.Country,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Altitude:    req.Altitude,
+		Timezone:    req.Timezone,
+		Type:        defaultString(req.Type, "civil"),
+		Status:      defaultString(req.Status, "active"),
+		Description: req.Description,
+	}
+
+	if err := s.repo.Create(ctx, airport); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return airport, nil
+}
+
+// UpdateAirport 更新机场，仅修改请求中提供的字段
+func (s *airportService) UpdateAirport(ctx context.Context, id uuid.UUID, req *dto.UpdateAirportRequest) (*models.Airport, error) {
+	airport, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("机场不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+
+	if req.Name != nil {
+		airport.Name = *req.Name
+	}
+	if req.NameEn != nil {
+		airport.NameEn = *req.NameEn
+	}
+	if req.City != nil {
+		airport.City = *req.City
+	}
+	if req.Country != nil {
+		airport.Country = *req.Country
+	}
+	if req.Latitude != nil {
+		airport.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		airport.Longitude = *req.Longitude
+	}
+	if req.Altitude != nil {
+		airport.Altitude = *req.Altitude
+	}
+	if req.Timezone != nil {
+		airport.Timezone = *req.Timezone
+	}
+	if req.Type != nil {
+		airport.Type = *req.Type
+	}
+	if req.Status != nil {
+		airport.Status = *req.Status
+	}
+	if req.Description != nil {
+		airport.Description = *req.Description
+	}
+
+	if err := s.repo.Update(ctx, airport); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return airport, nil
+}
+
+// DeleteAirport 删除机场
+func (s *airportService) DeleteAirport(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("机场不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// NearbyAirports 查询附近机场，按大圆距离升序返回
+// 先用外接包围盒在数据库中预过滤，再用 Haversine 精确计算距离，三种数据库通用
+func (s *airportService) NearbyAirports(ctx context.Context, query *dto.NearbyAirportQuery) ([]dto.NearbyAirportResponse, error) {
+	lat, lng := *query.Lat, *query.Lng
+	radiusKm := query.RadiusKm
+	if radiusKm <= 0 {
+		radiusKm = defaultNearbyRadiusKm
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultNearbyLimit
+	}
+
+	box := geo.BBoxAround(lat, lng, radiusKm*1000)
+	candidates, err := s.repo.FindInBBox(ctx, box, repositories.AirportFilter{
+		Type:   query.Type,
+		Status: query.Status,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	results := make([]dto.NearbyAirportResponse, 0, len(candidates))
+	for i := range candidates {
+		distanceKm := geo.Haversine(lat, lng, candidates[i].Latitude, candidates[i].Longitude) / 1000
+		if distanceKm > radiusKm {
+			continue
+		}
+		results = append(results, dto.NearbyAirportResponse{
+			AirportResponse: *dto.ToAirportResponse(&candidates[i]),
+			DistanceKm:      roundTo(distanceKm, 2),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].DistanceKm < results[j].DistanceKm
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// AirportsInBBox 查询地图视口内的机场
+func (s *airportService) AirportsInBBox(ctx context.Context, query *dto.BBoxAirportQuery) ([]dto.AirportResponse, error) {
+	box := geo.BBox{
+		MinLat: *query.MinLat,
+		MinLng: *query.MinLng,
+		MaxLat: *query.MaxLat,
+		MaxLng: *query.MaxLng,
+	}
+	if !box.Valid() {
+		return nil, apperr.NewBadRequest("无效的经纬度范围")
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultBBoxLimit
+	}
+
+	airports, err := s.repo.FindInBBox(ctx, box, repositories.AirportFilter{
+		Type:   query.Type,
+		Status: query.Status,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return dto.ToAirportResponseList(airports), nil
+}
+
+// defaultString 值为空时返回默认值
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAirportNormalizesCodeAndDefaults(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAirportRepository)
+	service := NewAirportService(repo)
+
+	repo.On("FindByCode", ctx, "PEK").Return(nil, repositories.ErrNotFound)
+	repo.On("Create", ctx, mock.AnythingOfType("*models.Airport")).Return(nil)
+
+	airport, err := service.CreateAirport(ctx, &dto.CreateAirportRequest{Code: " pek ", Name: "北京首都国际机场"})
+	require.NoError(t, err)
+	assert.Equal(t, "PEK", airport.Code)
+	assert.Equal(t, "civil", airport.Type)
+	assert.Equal(t, "active", airport.Status)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateAirportRejectsDuplicateAndBlankCode(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAirportRepository)
+	service := NewAirportService(repo)
+
+	repo.On("FindByCode", ctx, "SHA").Return(&models.Airport{ID: uuid.New(), Code: "SHA"}, nil)
+	_, err := service.CreateAirport(ctx, &dto.CreateAirportRequest{Code: "sha", Name: "上海虹桥"})
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	_, err = service.CreateAirport(ctx, &dto.CreateAirportRequest{Code: "   ", Name: "无代码"})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	repo.On("FindByCode", ctx, "CAN").Return(nil, errors.New("connection reset"))
+	_, err = service.CreateAirport(ctx, &dto.CreateAirportRequest{Code: "CAN", Name: "广州白云"})
+	assertAppErrorCode(t, err, apperr.ErrCodeInternalServerError)
+
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAirportKeepsCodeAndAppliesProvidedFields(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAirportRepository)
+	service := NewAirportService(repo)
+
+	existing := &models.Airport{ID: uuid.New(), Code: "PVG", Name: "浦东", City: "上海", Type: "civil", Status: "active"}
+	repo.On("FindByID", ctx, existing.ID).Return(existing, nil)
+	repo.On("Update", ctx, existing).Return(nil)
+
+	status := "closed"
+	name := "上海浦东国际机场"
+	airport, err := service.UpdateAirport(ctx, existing.ID, &dto.UpdateAirportRequest{Name: &name, Status: &status})
+	require.NoError(t, err)
+	assert.Equal(t, "PVG", airport.Code)
+	assert.Equal(t, name, airport.Name)
+	assert.Equal(t, "closed", airport.Status)
+	assert.Equal(t, "上海", airport.City)
+	// 代码创建后不可修改，更新时无需再做唯一性检查
+	repo.AssertNotCalled(t, "FindByCode", mock.Anything, mock.Anything)
+}
+
+func TestAirportNotFoundMapping(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAirportRepository)
+	service := NewAirportService(repo)
+
+	missing := uuid.New()
+	repo.On("FindByID", ctx, missing).Return(nil, repositories.ErrNotFound)
+	repo.On("FindByCode", ctx, "XXX").Return(nil, repositories.ErrNotFound)
+	repo.On("Delete", ctx, missing).Return(repositories.ErrNotFound)
+
+	_, err := service.GetAirport(ctx, missing.String())
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+	_, err = service.GetAirport(ctx, "XXX")
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+	_, err = service.UpdateAirport(ctx, missing, &dto.UpdateAirportRequest{})
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+	assertAppErrorCode(t, service.DeleteAirport(ctx, missing), apperr.ErrCodeNotFound)
+
+	broken := uuid.New()
+	repo.On("FindByID", ctx, broken).Return(nil, errors.New("connection reset"))
+	_, err = service.GetAirport(ctx, broken.String())
+	assertAppErrorCode(t, err, apperr.ErrCodeInternalServerError)
+}
+
+func TestListAirportsNormalizesQuery(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAirportRepository)
+	service := NewAirportService(repo)
+
+	repo.On("List", ctx, repositories.AirportFilter{
+		Country: "中国",
+		Status:  "active",
+		Keyword: "首都",
+		Offset:  0,
+		Limit:   dto.DefaultPageSize,
+	}).Return([]models.Airport{{ID: uuid.New(), Code: "PEK"}}, int64(1), nil).Once()
+
+	page, err := service.ListAirports(ctx, &dto.AirportQuery{Country: "中国", Status: "active", Q: "  首都 "})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, 1, page.Page)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "PEK", page.Items[0].Code)
+
+	repo.On("List", ctx, repositories.AirportFilter{
+		Offset: 2 * dto.MaxPageSize,
+		Limit:  dto.MaxPageSize,
+	}).Return([]models.Airport{}, int64(0), nil).Once()
+
+	query := &dto.AirportQuery{PageQuery: dto.PageQuery{Page: 3, PageSize: 1000}}
+	page, err = service.ListAirports(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, dto.MaxPageSize, page.PageSize)
+	assert.Empty(t, page.Items)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"math"
+	"time"
+)
+
+// AirspaceService 空域查询服务接口
+type AirspaceService interface {
+	// Check 检查位置或航线在指定时间是否受有效禁飞区限制
+	Check(ctx context.Context, req *dto.AirspaceCheckRequest) (*dto.AirspaceCheckResponse, error)
+}
+
+type airspaceService struct {
+	zoneRepo repositories.NoFlyZoneRepository
+}
+
+// NewAirspaceService 创建空域查询服务实例
+func NewAirspaceService(zoneRepo repositories.NoFlyZoneRepository) AirspaceService {
+	return &airspaceService{
+		zoneRepo: zoneRepo,
+	}
+}
+
+// Check 检查位置或航线与有效禁飞区的关系
+// 水平范围相交且高度区间重叠的禁飞区视为限制；几何无效的禁飞区无法判定，作为警告返回，不影响结论
+func (s *airspaceService) Check(ctx context.Context, req *dto.AirspaceCheckRequest) (*dto.AirspaceCheckResponse, error) {
+	path, err := airspacePath(req)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := airspaceWindow(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := s.zoneRepo.ListActive(ctx, from, to)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	resp := &dto.AirspaceCheckResponse{
+		Verdict: dto.AirspaceVerdictGo,
+		From:    from,
+		To:      to,
+		Zones:   []dto.AirspaceZone{},
+	}
+	for i := range zones {
+		zone := &zones[i]
+		if !zoneActiveDuring(zone, from, to) {
+			continue
+		}
+		shape, err := zoneGeometry(zone)
+		if err != nil {
+			logger.Warnf("[AirspaceService] 禁飞区几何数据无效，无法判定: zone=%s, err=%v", zone.ID.String(), err)
+			result := newAirspaceZone(zone, 0)
+			result.InvalidGeometry = true
+			resp.Warnings = append(resp.Warnings, result)
+			continue
+		}
+
+		result := newAirspaceZone(zone, pathBoundaryDistance(shape, path))
+		if resp.Nearest == nil || result.BoundaryDistance < resp.Nearest.BoundaryDistance {
+			nearest := result
+			resp.Nearest = &nearest
+		}
+		if segment, ok := pathConflict(shape, zoneAltitudeBand(zone), path, nil); ok {
+			if len(path) > 1 {
+				result.Segment = &segment
+			}
+			resp.Zones = append(resp.Zones, result)
+		}
+	}
+	if len(resp.Zones) > 0 {
+		resp.Verdict = dto.AirspaceVerdictNoGo
+	}
+	return resp, nil
+}
+
+// airspacePath 将请求转换为航线点序列，单点检查返回一个点
+func airspacePath(req *dto.AirspaceCheckRequest) ([]missionPoint, error) {
+	if (req.Point == nil) == (len(req.Path) == 0) {
+		return nil, apperr.NewBadRequest("point 和 path 必须且只能指定一个")
+	}
+	points := req.Path
+	if req.Point != nil {
+		points = []dto.AirspacePoint{*req.Point}
+	}
+	path := make([]missionPoint, len(points))
+	for i, p := range points {
+		path[i] = missionPoint{LatLng: geo.LatLng{Lat: p.Lat, Lng: p.Lng}, Altitude: p.Altitude}
+	}
+	return path, nil
+}
+
+// airspaceWindow 计算检查的时间窗口，未指定时间时为当前时刻
+func airspaceWindow(req *dto.AirspaceCheckRequest, now time.Time) (time.Time, time.Time, error) {
+	from := now
+	if req.Time != nil {
+		from = *req.Time
+	}
+	to := from
+	if req.EndTime != nil {
+		if req.EndTime.Before(from) {
+			return from, to, apperr.NewBadRequest("结束时间不能早于开始时间")
+		}
+		to = *req.EndTime
+	}
+	return from, to, nil
+}
+
+// pathBoundaryDistance 计算航线（或单点）到禁飞区边界的最短距离（米）
+func pathBoundaryDistance(shape *geo.Geometry, path []missionPoint) float64 {
+	if len(path) == 1 {
+		return shape.BoundaryDistance(path[0].LatLng)
+	}
+	best := math.Inf(1)
+	for i := 0; i < len(path)-1; i++ {
+		best = math.Min(best, shape.SegmentBoundaryDistance(path[i].LatLng, path[i+1].LatLng))
+	}
+	return best
+}
+
+func newAirspaceZone(zone *models.NoFlyZone, distance float64) dto.AirspaceZone {
+	return dto.AirspaceZone{
+		ZoneID:           zone.ID,
+		ZoneName:         zone.Name,
+		ZoneType:         zone.Type,
+		Authority:        zone.Authority,
+		Reason:           zone.Reason,
+		MinAltitude:      zone.MinAltitude,
+		MaxAltitude:      zone.MaxAltitude,
+		StartTime:        zone.StartTime,
+		EndTime:          zone.EndTime,
+		BoundaryDistance: roundTo(distance, 1),
+	}
+}
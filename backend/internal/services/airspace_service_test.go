@@ -0,0 +1,139 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/pkg/apperr"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestAirspaceCheckPoint(t *testing.T) {
+	service := NewAirspaceService(newZoneRepo(
+		newZone("走廊", corridorZone, 0, 500),
+		newZone("高空管制区", corridorZone, 300, 1000),
+	))
+
+	// 走廊中心，低于高空管制区下限
+	resp, err := service.Check(context.Background(), &dto.AirspaceCheckRequest{
+		Point: &dto.AirspacePoint{Lat: 31.20, Lng: 121.45, Altitude: floatPtr(120)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictNoGo, resp.Verdict)
+	if assert.Len(t, resp.Zones, 1) {
+		assert.Equal(t, "走廊", resp.Zones[0].ZoneName)
+		assert.Nil(t, resp.Zones[0].Segment)
+		// 距东西两侧边界各约 950 米
+		assert.InDelta(t, 950, resp.Zones[0].BoundaryDistance, 20)
+	}
+
+	// 走廊以东约 4.8 公里
+	resp, err = service.Check(context.Background(), &dto.AirspaceCheckRequest{
+		Point: &dto.AirspacePoint{Lat: 31.20, Lng: 121.51, Altitude: floatPtr(120)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictGo, resp.Verdict)
+	assert.Empty(t, resp.Zones)
+	if assert.NotNil(t, resp.Nearest) {
+		assert.InDelta(t, 4760, resp.Nearest.BoundaryDistance, 30)
+	}
+}
+
+func TestAirspaceCheckInvalidZoneGeometryIsWarning(t *testing.T) {
+	discardLogs()
+	broken := newZone("数据损坏", invalidZone, 0, 500)
+	service := NewAirspaceService(newZoneRepo(broken, newZone("走廊", corridorZone, 0, 500)))
+
+	// 无效禁飞区不决定结论，作为警告返回
+	resp, err := service.Check(context.Background(), &dto.AirspaceCheckRequest{
+		Point: &dto.AirspacePoint{Lat: 31.20, Lng: 121.51, Altitude: floatPtr(120)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictGo, resp.Verdict)
+	assert.Empty(t, resp.Zones)
+	if assert.Len(t, resp.Warnings, 1) {
+		assert.Equal(t, broken.ID, resp.Warnings[0].ZoneID)
+		assert.True(t, resp.Warnings[0].InvalidGeometry)
+	}
+	assert.NotNil(t, resp.Nearest)
+
+	// 有效禁飞区仍按几何判定
+	resp, err = service.Check(context.Background(), &dto.AirspaceCheckRequest{
+		Point: &dto.AirspacePoint{Lat: 31.20, Lng: 121.45, Altitude: floatPtr(120)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictNoGo, resp.Verdict)
+	if assert.Len(t, resp.Zones, 1) {
+		assert.Equal(t, "走廊", resp.Zones[0].ZoneName)
+	}
+	assert.Len(t, resp.Warnings, 1)
+}
+
+func TestAirspaceCheckPathAltitudeProfile(t *testing.T) {
+	service := NewAirspaceService(newZoneRepo(newZone("高空管制区", corridorZone, 300, 1000)))
+
+	// 低空穿越走廊
+	path := []dto.AirspacePoint{
+		{Lat: 31.20, Lng: 121.40, Altitude: floatPtr(100)},
+		{Lat: 31.20, Lng: 121.50, Altitude: floatPtr(150)},
+	}
+	resp, err := service.Check(context.Background(), &dto.AirspaceCheckRequest{Path: path})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictGo, resp.Verdict)
+	if assert.NotNil(t, resp.Nearest) {
+		assert.Equal(t, 0.0, resp.Nearest.BoundaryDistance)
+	}
+
+	// 第二段爬升到管制高度
+	path = append(path, dto.AirspacePoint{Lat: 31.20, Lng: 121.40, Altitude: floatPtr(400)})
+	resp, err = service.Check(context.Background(), &dto.AirspaceCheckRequest{Path: path})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictNoGo, resp.Verdict)
+	if assert.Len(t, resp.Zones, 1) && assert.NotNil(t, resp.Zones[0].Segment) {
+		assert.Equal(t, 1, *resp.Zones[0].Segment)
+	}
+}
+
+func TestAirspaceCheckTimeWindow(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	zone := newZone("临时管制", corridorZone, 0, 0)
+	zone.Type = models.NoFlyZoneTypeTemporary
+	zone.StartTime = &start
+	service := NewAirspaceService(newZoneRepo(zone))
+
+	point := &dto.AirspacePoint{Lat: 31.20, Lng: 121.45}
+	before := start.Add(-2 * time.Hour)
+	resp, err := service.Check(context.Background(), &dto.AirspaceCheckRequest{Point: point, Time: &before})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictGo, resp.Verdict)
+	assert.Nil(t, resp.Nearest)
+
+	end := start.Add(time.Hour)
+	resp, err = service.Check(context.Background(), &dto.AirspaceCheckRequest{Point: point, Time: &before, EndTime: &end})
+	require.NoError(t, err)
+	assert.Equal(t, dto.AirspaceVerdictNoGo, resp.Verdict)
+
+	_, err = service.Check(context.Background(), &dto.AirspaceCheckRequest{Point: point, Time: &end, EndTime: &before})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+}
+
+func TestAirspaceCheckRequiresPointOrPath(t *testing.T) {
+	service := NewAirspaceService(newZoneRepo())
+
+	_, err := service.Check(context.Background(), &dto.AirspaceCheckRequest{})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	_, err = service.Check(context.Background(), &dto.AirspaceCheckRequest{
+		Point: &dto.AirspacePoint{Lat: 31.2, Lng: 121.45},
+		Path:  []dto.AirspacePoint{{Lat: 31.2, Lng: 121.4}, {Lat: 31.2, Lng: 121.5}},
+	})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+}
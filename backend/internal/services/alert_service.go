@@ -0,0 +1,221 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/internal/stream"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertService 告警服务接口
+type AlertService interface {
+	ListAlerts(ctx context.Context, query *dto.AlertQuery) (*dto.PageResponse[dto.AlertResponse], error)
+	GetAlert(ctx context.Context, id uuid.UUID) (*models.Alert, error)
+	Acknowledge(ctx context.Context, id uuid.UUID, actor Actor) (*models.Alert, error)
+	Resolve(ctx context.Context, id uuid.UUID, actor Actor) (*models.Alert, error)
+
+	// Raise 触发告警；对象已有同类型未解除告警时仅刷新触发值、级别和位置，不重复创建
+	Raise(ctx context.Context, alert *models.Alert) (*models.Alert, error)
+	// Clear 解除对象指定类型的未解除告警，不存在时忽略
+	Clear(ctx context.Context, alertType, entityType string, entityID uuid.UUID, at time.Time) error
+	// ClearRelated 解除对象与关联对象之间指定类型的未解除告警，不存在时忽略
+	ClearRelated(ctx context.Context, alertType, entityType string, entityID, relatedID uuid.UUID, at time.Time) error
+	// ListActive 查询指定类型的全部未解除告警
+	ListActive(ctx context.Context, alertType string) ([]models.Alert, error)
+}
+
+// alertSeverityRank 告警级别排序，用于判断是否升级
+var alertSeverityRank = map[string]int{
+	models.AlertSeverityInfo:     0,
+	models.AlertSeverityWarning:  1,
+	models.AlertSeverityCritical: 2,
+}
+
+type alertService struct {
+	repo repositories.AlertRepository
+	hub  *stream.Hub
+}
+
+// NewAlertService 创建告警服务实例
+func NewAlertService(repo repositories.AlertRepository, hub *stream.Hub) AlertService {
+	return &alertService{
+		repo: repo,
+		hub:  hub,
+	}
+}
+
+// ListAlerts 分页查询告警
+func (s *alertService) ListAlerts(ctx context.Context, query *dto.AlertQuery) (*dto.PageResponse[dto.AlertResponse], error) {
+	query.Normalize()
+
+	alerts, total, err := s.repo.List(ctx, repositories.AlertFilter{
+		Types:      splitStatuses(query.Type),
+		Statuses:   splitStatuses(query.Status),
+		Severities: splitStatuses(query.Severity),
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		From:       query.From,
+		To:         query.To,
+		Offset:     query.Offset(),
+		Limit:      query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToAlertResponseList(alerts), total, query.PageQuery), nil
+}
+
+// GetAlert 获取告警详情
+func (s *alertService) GetAlert(ctx context.Context, id uuid.UUID) (*models.Alert, error) {
+	alert, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("告警不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return alert, nil
+}
+
+// Acknowledge 确认告警，仅未确认的告警可以确认
+func (s *alertService) Acknowledge(ctx context.Context, id uuid.UUID, actor Actor) (*models.Alert, error) {
+	alert, err := s.GetAlert(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Status != models.AlertStatusOpen {
+		return nil, apperr.NewConflict("告警已确认或已解除")
+	}
+
+	now := time.Now()
+	alert.Status = models.AlertStatusAcknowledged
+	alert.AcknowledgedBy = actor.UserID
+	alert.AcknowledgedAt = &now
+	if err := s.repo.Update(ctx, alert); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	s.publish(alert)
+	return alert, nil
+}
+
+// Resolve 手动解除告警
+func (s *alertService) Resolve(ctx context.Context, id uuid.UUID, actor Actor) (*models.Alert, error) {
+	alert, err := s.GetAlert(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Status == models.AlertStatusResolved {
+		return nil, apperr.NewConflict("告警已解除")
+	}
+
+	now := time.Now()
+	if alert.AcknowledgedAt == nil {
+		alert.AcknowledgedBy = actor.UserID
+		alert.AcknowledgedAt = &now
+	}
+	alert.Status = models.AlertStatusResolved
+	alert.ResolvedAt = &now
+	if err := s.repo.Update(ctx, alert); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	s.publish(alert)
+	return alert, nil
+}
+
+// Raise 触发告警，新建或级别升级时推送实时事件
+func (s *alertService) Raise(ctx context.Context, alert *models.Alert) (*models.Alert, error) {
+	if alert.TriggeredAt.IsZero() {
+		alert.TriggeredAt = time.Now()
+	}
+
+	active, err := s.repo.FindActive(ctx, alert.Type, alert.EntityType, alert.EntityID, alert.RelatedID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	if active == nil {
+		alert.Status = models.AlertStatusOpen
+		alert.LastSeenAt = alert.TriggeredAt
+		if err := s.repo.Create(ctx, alert); err != nil {
+			return nil, apperr.NewInternalError(err)
+		}
+		s.publish(alert)
+		return alert, nil
+	}
+
+	escalated := alertSeverityRank[alert.Severity] > alertSeverityRank[active.Severity]
+	if escalated {
+		active.Severity = alert.Severity
+	}
+	active.Message = alert.Message
+	active.Value = alert.Value
+	active.Threshold = alert.Threshold
+	active.Latitude, active.Longitude = alert.Latitude, alert.Longitude
+	if alert.TriggeredAt.After(active.LastSeenAt) {
+		active.LastSeenAt = alert.TriggeredAt
+	}
+	if err := s.repo.Update(ctx, active); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	if escalated {
+		s.publish(active)
+	}
+	return active, nil
+}
+
+// Clear 自动解除告警并推送实时事件
+func (s *alertService) Clear(ctx context.Context, alertType, entityType string, entityID uuid.UUID, at time.Time) error {
+	return s.clear(ctx, alertType, entityType, entityID, nil, at)
+}
+
+// ClearRelated 自动解除两个对象之间的告警并推送实时事件
+func (s *alertService) ClearRelated(ctx context.Context, alertType, entityType string, entityID, relatedID uuid.UUID, at time.Time) error {
+	return s.clear(ctx, alertType, entityType, entityID, &relatedID, at)
+}
+
+// ListActive 查询指定类型的全部未解除告警，供检测服务重启后恢复状态
+func (s *alertService) ListActive(ctx context.Context, alertType string) ([]models.Alert, error) {
+	alerts, err := s.repo.ListActive(ctx, alertType)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return alerts, nil
+}
+
+func (s *alertService) clear(ctx context.Context, alertType, entityType string, entityID uuid.UUID, relatedID *uuid.UUID, at time.Time) error {
+	active, err := s.repo.FindActive(ctx, alertType, entityType, entityID, relatedID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil
+		}
+		return apperr.NewInternalError(err)
+	}
+
+	active.Status = models.AlertStatusResolved
+	active.ResolvedAt = &at
+	if err := s.repo.Update(ctx, active); err != nil {
+		return apperr.NewInternalError(err)
+	}
+	s.publish(active)
+	return nil
+}
+
+// publish 推送告警实时事件，无坐标的告警以 (0,0) 发布，仅对不限范围的订阅可见
+func (s *alertService) publish(alert *models.Alert) {
+	event := stream.Event{
+		Type:      stream.EntityAlert,
+		Timestamp: time.Now(),
+		Data:      dto.ToAlertResponse(alert),
+	}
+	if alert.Latitude != nil && alert.Longitude != nil {
+		event.Latitude, event.Longitude = *alert.Latitude, *alert.Longitude
+	}
+	s.hub.Publish(event)
+}
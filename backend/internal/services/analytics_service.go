@@ -0,0 +1,263 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultOTPThreshold 默认准点判定阈值（分钟），到达延误不超过该值视为准点
+	defaultOTPThreshold = 15
+	// defaultStatsRangeDays 未指定日期范围时统计的天数
+	defaultStatsRangeDays = 30
+	// maxStatsRangeDays 单次统计的最大天数
+	maxStatsRangeDays = 366
+
+	// flightHistoryStatusDiverted 航班历史中的备降状态
+	flightHistoryStatusDiverted = "diverted"
+)
+
+// AnalyticsService 航班统计分析服务接口
+type AnalyticsService interface {
+	// OTP 按航空公司、航线或机场统计准点率、延误分布、取消率和备降率
+	OTP(ctx context.Context, query *dto.OTPQuery) (*dto.OTPResponse, error)
+}
+
+type analyticsService struct {
+	historyRepo repositories.FlightHistoryRepository
+}
+
+// NewAnalyticsService 创建航班统计分析服务实例
+func NewAnalyticsService(historyRepo repositories.FlightHistoryRepository) AnalyticsService {
+	return &analyticsService{
+		historyRepo: historyRepo,
+	}
+}
+
+// OTP 准点率统计
+// 按机场分组时，航班同时计入出发机场和到达机场
+func (s *analyticsService) OTP(ctx context.Context, query *dto.OTPQuery) (*dto.OTPResponse, error) {
+	from, to, err := otpDateRange(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	groupBy := defaultString(query.GroupBy, dto.OTPGroupAirline)
+	threshold := defaultOTPThreshold
+	if query.Threshold != nil {
+		threshold = *query.Threshold
+	}
+
+	filter := repositories.FlightHistoryFilter{
+		From:        from,
+		To:          to.AddDate(0, 0, 1),
+		AirlineCode: strings.TrimSpace(query.Airline),
+		Airport:     strings.TrimSpace(query.Airport),
+		Departure:   strings.TrimSpace(query.Departure),
+		Arrival:     strings.TrimSpace(query.Arrival),
+	}
+	// 按机场分组时航班计入两个分组，总体指标需单独统计
+	overall, err := s.historyRepo.CountByDelay(ctx, filter, repositories.FlightHistoryGrouping{})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	grouped, err := s.historyRepo.CountByDelay(ctx, filter, repositories.FlightHistoryGrouping{
+		GroupBy:  groupBy,
+		Interval: query.Interval,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	resp := &dto.OTPResponse{
+		From:             from,
+		To:               to,
+		GroupBy:          groupBy,
+		Interval:         query.Interval,
+		ThresholdMinutes: threshold,
+	}
+	resp.Overall, resp.Groups = aggregateOTP(overall, grouped, threshold)
+	return resp, nil
+}
+
+// otpDateRange 解析准点率统计日期范围
+func otpDateRange(query *dto.OTPQuery, now time.Time) (time.Time, time.Time, error) {
+	return statsDateRange(query.From, query.To, now)
+}
+
+// statsDateRange 解析统计日期范围（UTC 日期，闭区间），未指定时截至今天
+func statsDateRange(fromDate, toDate *time.Time, now time.Time) (time.Time, time.Time, error) {
+	to := truncateDay(now)
+	if toDate != nil {
+		to = truncateDay(*toDate)
+	}
+	from := to.AddDate(0, 0, -(defaultStatsRangeDays - 1))
+	if fromDate != nil {
+		from = truncateDay(*fromDate)
+	}
+
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, apperr.NewBadRequest("结束日期不能早于开始日期")
+	}
+	if to.Sub(from) >= maxStatsRangeDays*24*time.Hour {
+		return time.Time{}, time.Time{}, apperr.NewBadRequest("统计范围不能超过 366 天")
+	}
+	return from, to, nil
+}
+
+// aggregateOTP 汇总数据库聚合结果，返回总体指标和按航班量降序排列的分组指标
+func aggregateOTP(overall, grouped []repositories.FlightHistoryCount, threshold int) (dto.OTPStats, []dto.OTPGroup) {
+	var total otpAccumulator
+	for _, count := range overall {
+		total.add(count, threshold)
+	}
+
+	groups := make(map[string]*otpGroupAccumulator)
+	for _, count := range grouped {
+		group, ok := groups[count.Key]
+		if !ok {
+			group = &otpGroupAccumulator{buckets: make(map[time.Time]*otpAccumulator)}
+			groups[count.Key] = group
+		}
+		group.add(count, threshold)
+
+		if !count.Bucket.IsZero() {
+			start := truncateDay(count.Bucket)
+			bucket, ok := group.buckets[start]
+			if !ok {
+				bucket = &otpAccumulator{}
+				group.buckets[start] = bucket
+			}
+			bucket.add(count, threshold)
+		}
+	}
+
+	result := make([]dto.OTPGroup, 0, len(groups))
+	for key, group := range groups {
+		item := dto.OTPGroup{Key: key, OTPStats: group.stats()}
+		for start, bucket := range group.buckets {
+			item.Buckets = append(item.Buckets, dto.OTPBucket{Start: start, OTPStats: bucket.stats()})
+		}
+		sort.Slice(item.Buckets, func(i, j int) bool { return item.Buckets[i].Start.Before(item.Buckets[j].Start) })
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return result[i].Key < result[j].Key
+	})
+	return total.stats(), result
+}
+
+// truncateDay 截取 UTC 日期
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// otpAccumulator 准点率指标累加器，已到达航班按延误分钟数计数，用于计算百分位
+type otpAccumulator struct {
+	total, arrived, onTime, cancelled, diverted int
+	delaySum                                    int
+	delays                                      map[int]int
+}
+
+type otpGroupAccumulator struct {
+	otpAccumulator
+	buckets map[time.Time]*otpAccumulator
+}
+
+func (a *otpAccumulator) add(count repositories.FlightHistoryCount, threshold int) {
+	a.total += count.Count
+	switch count.Status {
+	case models.FlightStatusCancelled:
+		a.cancelled += count.Count
+	case flightHistoryStatusDiverted:
+		a.diverted += count.Count
+	case models.FlightStatusArrived:
+		a.arrived += count.Count
+		if count.Delay <= threshold {
+			a.onTime += count.Count
+		}
+		a.delaySum += count.Delay * count.Count
+		if a.delays == nil {
+			a.delays = make(map[int]int)
+		}
+		a.delays[count.Delay] += count.Count
+	}
+}
+
+func (a *otpAccumulator) stats() dto.OTPStats {
+	stats := dto.OTPStats{
+		Total:            a.total,
+		Arrived:          a.arrived,
+		OnTime:           a.onTime,
+		Cancelled:        a.cancelled,
+		Diverted:         a.diverted,
+		OnTimeRate:       percentage(a.onTime, a.arrived),
+		CancellationRate: percentage(a.cancelled, a.total),
+		DiversionRate:    percentage(a.diverted, a.total),
+	}
+	if a.arrived == 0 {
+		return stats
+	}
+
+	histogram := make([]delayCount, 0, len(a.delays))
+	for minutes, count := range a.delays {
+		histogram = append(histogram, delayCount{minutes: minutes, count: count})
+	}
+	sort.Slice(histogram, func(i, j int) bool { return histogram[i].minutes < histogram[j].minutes })
+
+	stats.AvgDelayMinutes = roundTo(float64(a.delaySum)/float64(a.arrived), 1)
+	stats.P50DelayMinutes = roundTo(percentile(histogram, 50), 1)
+	stats.P90DelayMinutes = roundTo(percentile(histogram, 90), 1)
+	stats.P95DelayMinutes = roundTo(percentile(histogram, 95), 1)
+	return stats
+}
+
+// delayCount 某一延误分钟数的航班数量
+type delayCount struct {
+	minutes, count int
+}
+
+// percentile 计算按延误升序排列的计数直方图的百分位数（线性插值）
+func percentile(histogram []delayCount, p float64) float64 {
+	n := 0
+	for _, bin := range histogram {
+		n += bin.count
+	}
+	if n == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(n-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	low, high := float64(nthDelay(histogram, lower)), float64(nthDelay(histogram, upper))
+	return low + (high-low)*(rank-float64(lower))
+}
+
+// nthDelay 返回直方图中第 i 个（从 0 开始）航班的延误分钟数
+func nthDelay(histogram []delayCount, i int) int {
+	for _, bin := range histogram {
+		if i < bin.count {
+			return bin.minutes
+		}
+		i -= bin.count
+	}
+	return histogram[len(histogram)-1].minutes
+}
+
+// percentage 计算百分比，保留两位小数
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundTo(float64(part)*100/float64(total), 2)
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateOTP(t *testing.T) {
+	monday := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	overall := []repositories.FlightHistoryCount{
+		{Status: models.FlightStatusArrived, Delay: 5, Count: 1},
+		{Status: models.FlightStatusArrived, Delay: 40, Count: 1},
+		{Status: models.FlightStatusArrived, Delay: 0, Count: 1},
+		{Status: models.FlightStatusCancelled, Count: 1},
+		{Status: flightHistoryStatusDiverted, Count: 1},
+	}
+	grouped := []repositories.FlightHistoryCount{
+		{Key: "MU", Bucket: monday, Status: models.FlightStatusArrived, Delay: 5, Count: 1},
+		{Key: "MU", Bucket: monday, Status: models.FlightStatusArrived, Delay: 40, Count: 1},
+		{Key: "MU", Bucket: monday.AddDate(0, 0, 7), Status: models.FlightStatusCancelled, Count: 1},
+		{Key: "MU", Bucket: monday.AddDate(0, 0, 7), Status: models.FlightStatusArrived, Delay: 0, Count: 1},
+		{Key: "CA", Bucket: monday, Status: flightHistoryStatusDiverted, Count: 1},
+	}
+
+	stats, groups := aggregateOTP(overall, grouped, 15)
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 3, stats.Arrived)
+	assert.Equal(t, 2, stats.OnTime)
+	assert.Equal(t, 66.67, stats.OnTimeRate)
+	assert.Equal(t, 20.0, stats.CancellationRate)
+	assert.Equal(t, 20.0, stats.DiversionRate)
+	assert.Equal(t, 15.0, stats.AvgDelayMinutes)
+	assert.Equal(t, 5.0, stats.P50DelayMinutes)
+
+	require.Len(t, groups, 2)
+	assert.Equal(t, "MU", groups[0].Key)
+	assert.Equal(t, 4, groups[0].Total)
+	assert.Equal(t, 15.0, groups[0].AvgDelayMinutes)
+	require.Len(t, groups[0].Buckets, 2)
+	assert.Equal(t, monday, groups[0].Buckets[0].Start)
+	assert.Equal(t, 2, groups[0].Buckets[0].Total)
+	assert.Equal(t, 22.5, groups[0].Buckets[0].AvgDelayMinutes)
+	assert.Equal(t, monday.AddDate(0, 0, 7), groups[0].Buckets[1].Start)
+	assert.Equal(t, "CA", groups[1].Key)
+	assert.Equal(t, 1, groups[1].Diverted)
+
+	// 不分桶时没有时间桶
+	_, groups = aggregateOTP(overall, []repositories.FlightHistoryCount{
+		{Key: "PEK", Status: models.FlightStatusArrived, Delay: 5, Count: 3},
+		{Key: "SHA", Status: models.FlightStatusArrived, Delay: 5, Count: 2},
+	}, 15)
+	require.Len(t, groups, 2)
+	assert.Equal(t, 3, groups[0].Total)
+	assert.Nil(t, groups[0].Buckets)
+}
+
+func TestPercentile(t *testing.T) {
+	histogram := []delayCount{{0, 1}, {10, 1}, {20, 1}, {30, 1}, {40, 1}}
+	assert.Equal(t, 20.0, percentile(histogram, 50))
+	assert.Equal(t, 36.0, percentile(histogram, 90))
+	assert.Equal(t, 0.0, percentile(nil, 50))
+
+	// 重复延误按数量展开：0,0,0,10
+	histogram = []delayCount{{0, 3}, {10, 1}}
+	assert.Equal(t, 0.0, percentile(histogram, 50))
+	assert.InDelta(t, 7.0, percentile(histogram, 90), 1e-9)
+}
+
+func TestOTPDateRange(t *testing.T) {
+	now := time.Date(2024, 5, 31, 18, 0, 0, 0, time.UTC)
+	from, to, err := otpDateRange(&dto.OTPQuery{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), to)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, err = otpDateRange(&dto.OTPQuery{From: &start}, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// licenseExpiryWarningDays 许可证到期前该天数内提示即将到期
+	licenseExpiryWarningDays = 30
+
+	// 合规评分扣分标准
+	penaltyUnapprovedMission = 20 // 每次未经审批起飞
+	penaltyWaivedMission     = 5  // 每次豁免审批起飞
+	penaltyUnplannedFlight   = 10 // 每次无任务飞行
+	penaltyZoneViolation     = 15 // 每次闯入禁飞区
+	penaltyOverdueDrone      = 10 // 每架维护超期的无人机
+	penaltyLicenseInvalid    = 30 // 许可证缺失或已过期
+	penaltyLicenseExpiring   = 5  // 许可证即将到期
+)
+
+// incidentPenalties 已确认事件按严重程度由重到轻的扣分标准
+var incidentPenalties = []struct {
+	severity string
+	label    string
+	penalty  int
+}{
+	{models.IncidentSeverityCritical, "特别严重", 20},
+	{models.IncidentSeveritySerious, "严重", 10},
+	{models.IncidentSeverityModerate, "一般", 5},
+	{models.IncidentSeverityMinor, "轻微", 2},
+}
+
+// ComplianceService 运营商合规统计服务接口
+// 按统计期汇总运营商的飞行审批、禁飞区违规、事件、机队维护和许可证情况并评分，运营商用户只能查看所属运营商
+type ComplianceService interface {
+	// OperatorCompliance 统计单个运营商的合规概况
+	OperatorCompliance(ctx context.Context, id uuid.UUID, query *dto.ComplianceQuery, actor Actor) (*dto.OperatorCompliance, error)
+	// RankOperators 统计各运营商的合规概况，按评分由低到高排名后分页
+	RankOperators(ctx context.Context, query *dto.ComplianceRankingQuery, actor Actor) (*dto.PageResponse[dto.OperatorCompliance], error)
+}
+
+type complianceService struct {
+	operatorRepo  repositories.OperatorRepository
+	missionRepo   repositories.DroneMissionRepository
+	flightLogRepo repositories.DroneFlightLogRepository
+	alertRepo     repositories.AlertRepository
+	incidentRepo  repositories.DroneIncidentRepository
+	droneRepo     repositories.DroneRepository
+	userRepo      repositories.UserRepository
+	maintenance   MaintenanceChecker
+}
+
+// NewComplianceService 创建运营商合规统计服务实例
+// maintenance 为空时不统计维护超期的无人机
+func NewComplianceService(
+	operatorRepo repositories.OperatorRepository,
+	missionRepo repositories.DroneMissionRepository,
+	flightLogRepo repositories.DroneFlightLogRepository,
+	alertRepo repositories.AlertRepository,
+	incidentRepo repositories.DroneIncidentRepository,
+	droneRepo repositories.DroneRepository,
+	userRepo repositories.UserRepository,
+	maintenance MaintenanceChecker,
+) ComplianceService {
+	return &complianceService{
+		operatorRepo:  operatorRepo,
+		missionRepo:   missionRepo,
+		flightLogRepo: flightLogRepo,
+		alertRepo:     alertRepo,
+		incidentRepo:  incidentRepo,
+		droneRepo:     droneRepo,
+		userRepo:      userRepo,
+		maintenance:   maintenance,
+	}
+}
+
+// complianceStats 统计期内按运营商汇总的合规原始数据
+type complianceStats struct {
+	missions   map[uuid.UUID][]repositories.MissionApprovalCount
+	unplanned  map[uuid.UUID]int64
+	violations map[uuid.UUID]int64
+	incidents  map[uuid.UUID]map[string]int64
+	fleet      map[uuid.UUID]map[string]int64
+	overdue    map[uuid.UUID]int64 // 当前维护超期的无人机数量
+}
+
+// OperatorCompliance 范围外的运营商视为不存在
+func (s *complianceService) OperatorCompliance(ctx context.Context, id uuid.UUID, query *dto.ComplianceQuery, actor Actor) (*dto.OperatorCompliance, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Allows(&id) {
+		return nil, apperr.NewNotFound("运营商不存在")
+	}
+	now := time.Now()
+	from, to, err := statsDateRange(query.From, query.To, now)
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := s.operatorRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("运营商不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+
+	stats, err := s.loadStats(ctx, &id, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	compliance := buildCompliance(operator, stats, from, to, now)
+	return &compliance, nil
+}
+
+// RankOperators 评分相同时按运营商代码排序
+func (s *complianceService) RankOperators(ctx context.Context, query *dto.ComplianceRankingQuery, actor Actor) (*dto.PageResponse[dto.OperatorCompliance], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.Normalize()
+	now := time.Now()
+	from, to, err := statsDateRange(query.From, query.To, now)
+	if err != nil {
+		return nil, err
+	}
+
+	operators, _, err := s.operatorRepo.List(ctx, repositories.OperatorFilter{
+		ID:     scope.Filter(nil),
+		Type:   strings.TrimSpace(query.Type),
+		Status: strings.TrimSpace(query.Status),
+		Limit:  -1,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	stats, err := s.loadStats(ctx, scope.Filter(nil), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	ranked := rankCompliance(operators, stats, from, to, now)
+	start := min(query.Offset(), len(ranked))
+	end := min(start+query.PageSize, len(ranked))
+	return dto.NewPageResponse(ranked[start:end], int64(len(ranked)), query.PageQuery), nil
+}
+
+// loadStats 加载 [from, to) 内的统计数据，operatorID 为 nil 时加载全部运营商
+func (s *complianceService) loadStats(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (*complianceStats, error) {
+	var (
+		stats complianceStats
+		err   error
+	)
+	if stats.missions, err = s.missionRepo.ApprovalCountsByOperator(ctx, operatorID, from, to); err != nil {
+		return nil, err
+	}
+	if stats.unplanned, err = s.flightLogRepo.CountUnplannedByOperator(ctx, operatorID, from, to); err != nil {
+		return nil, err
+	}
+	if stats.violations, err = s.alertRepo.CountDroneAlertsByOperator(ctx, models.AlertTypeNoFlyZoneBreach, operatorID, from, to); err != nil {
+		return nil, err
+	}
+	if stats.incidents, err = s.incidentRepo.CountBySeverity(ctx, operatorID, from, to); err != nil {
+		return nil, err
+	}
+	if stats.fleet, err = s.droneRepo.CountByOperatorAndStatus(ctx, operatorID); err != nil {
+		return nil, err
+	}
+	if s.maintenance != nil {
+		if stats.overdue, err = s.maintenance.CountOverdueByOperator(ctx, operatorID); err != nil {
+			return nil, err
+		}
+	}
+	return &stats, nil
+}
+
+// rankCompliance 计算各运营商的合规概况并按评分由低到高排名
+func rankCompliance(operators []models.Operator, stats *complianceStats, from, to, now time.Time) []dto.OperatorCompliance {
+	ranked := make([]dto.OperatorCompliance, len(operators))
+	for i := range operators {
+		ranked[i] = buildCompliance(&operators[i], stats, from, to, now)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score < ranked[j].Score
+		}
+		return ranked[i].Operator.Code < ranked[j].Operator.Code
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	return ranked
+}
+
+// tallyMissionApprovals 汇总实际起飞任务的审批情况
+// 审批通过的计入 approved；无需审批（管理员豁免或历史数据）的计入 waived；其余均视为未经审批起飞
+func tallyMissionApprovals(counts []repositories.MissionApprovalCount) dto.MissionCompliance {
+	var missions dto.MissionCompliance
+	for _, count := range counts {
+		missions.Flown += count.Count
+		switch {
+		case count.ApprovalStatus != nil && *count.ApprovalStatus == models.ApprovalStatusApproved:
+			missions.Approved += count.Count
+		case !count.RequiresApproval:
+			missions.Waived += count.Count
+		default:
+			missions.Unapproved += count.Count
+		}
+	}
+	return missions
+}
+
+// buildCompliance 汇总单个运营商的合规数据并评分，评分最低为 0
+func buildCompliance(operator *models.Operator, stats *complianceStats, from, to, now time.Time) dto.OperatorCompliance {
+	incidents := stats.incidents[operator.ID]
+	fleet := stats.fleet[operator.ID]
+
+	compliance := dto.OperatorCompliance{
+		Operator:       dto.ToOperatorBrief(operator),
+		OperatorStatus: operator.Status,
+		From:           from,
+		To:             to,
+		Missions:       tallyMissionApprovals(stats.missions[operator.ID]),
+		ZoneViolations: stats.violations[operator.ID],
+		Incidents: dto.IncidentCompliance{
+			Minor:    incidents[models.IncidentSeverityMinor],
+			Moderate: incidents[models.IncidentSeverityModerate],
+			Serious:  incidents[models.IncidentSeveritySerious],
+			Critical: incidents[models.IncidentSeverityCritical],
+		},
+		Fleet: dto.FleetCompliance{
+			InMaintenance:      fleet[models.DroneStatusMaintenance],
+			OverdueMaintenance: stats.overdue[operator.ID],
+		},
+		License:  licenseCompliance(operator, now),
+		Findings: []string{},
+	}
+	compliance.Missions.Unplanned = stats.unplanned[operator.ID]
+	for _, count := range incidents {
+		compliance.Incidents.Total += count
+	}
+	for _, count := range fleet {
+		compliance.Fleet.Total += count
+	}
+
+	penalty := 0
+	deduct := func(count int64, points int, format string, args ...any) {
+		if count <= 0 {
+			return
+		}
+		penalty += int(count) * points
+		compliance.Findings = append(compliance.Findings, fmt.Sprintf(format, args...))
+	}
+	deduct(compliance.Missions.Unapproved, penaltyUnapprovedMission, "%d 次任务未经审批起飞", compliance.Missions.Unapproved)
+	deduct(compliance.Missions.Waived, penaltyWaivedMission, "%d 次任务豁免审批起飞", compliance.Missions.Waived)
+	deduct(compliance.Missions.Unplanned, penaltyUnplannedFlight, "%d 次飞行未关联任务", compliance.Missions.Unplanned)
+	deduct(compliance.ZoneViolations, penaltyZoneViolation, "%d 次闯入禁飞区", compliance.ZoneViolations)
+	deduct(compliance.Fleet.OverdueMaintenance, penaltyOverdueDrone, "%d 架无人机维护超期", compliance.Fleet.OverdueMaintenance)
+	for _, rule := range incidentPenalties {
+		deduct(incidents[rule.severity], rule.penalty, "%d 起%s事件", incidents[rule.severity], rule.label)
+	}
+	switch compliance.License.Status {
+	case dto.LicenseStatusMissing:
+		deduct(1, penaltyLicenseInvalid, "未登记运营许可证")
+	case dto.LicenseStatusExpired:
+		deduct(1, penaltyLicenseInvalid, "运营许可证已过期")
+	case dto.LicenseStatusExpiring:
+		deduct(1, penaltyLicenseExpiring, "运营许可证将于 %d 天内到期", *compliance.License.DaysRemaining)
+	}
+
+	compliance.Score = max(0, 100-penalty)
+	return compliance
+}
+
+// licenseCompliance 判断许可证状态，未设置有效期的许可证视为长期有效
+func licenseCompliance(operator *models.Operator, now time.Time) dto.LicenseCompliance {
+	license := dto.LicenseCompliance{
+		Number:    operator.LicenseNo,
+		ExpiresAt: operator.LicenseExpiresAt,
+		Status:    dto.LicenseStatusValid,
+	}
+	if strings.TrimSpace(operator.LicenseNo) == "" {
+		license.Status = dto.LicenseStatusMissing
+		return license
+	}
+	if operator.LicenseExpiresAt == nil {
+		return license
+	}
+
+	days := int(math.Ceil(operator.LicenseExpiresAt.Sub(now).Hours() / 24))
+	license.DaysRemaining = &days
+	switch {
+	case !operator.LicenseExpiresAt.After(now):
+		license.Status = dto.LicenseStatusExpired
+	case days <= licenseExpiryWarningDays:
+		license.Status = dto.LicenseStatusExpiring
+	}
+	return license
+}
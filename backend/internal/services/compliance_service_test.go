@@ -0,0 +1,168 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildCompliance(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	from, to := now.AddDate(0, 0, -29), now
+	expires := now.AddDate(0, 0, 10)
+	operator := &models.Operator{ID: uuid.New(), Code: "SKY", LicenseNo: "UAS-2024-001", LicenseExpiresAt: &expires, Status: models.OperatorStatusActive}
+	stats := &complianceStats{
+		missions: map[uuid.UUID][]repositories.MissionApprovalCount{operator.ID: {
+			{RequiresApproval: true, ApprovalStatus: stringPtr(models.ApprovalStatusApproved), Count: 6},
+			{RequiresApproval: false, ApprovalStatus: stringPtr(models.ApprovalStatusWaived), Count: 1},
+			{RequiresApproval: true, ApprovalStatus: stringPtr(models.ApprovalStatusPending), Count: 1},
+		}},
+		unplanned:  map[uuid.UUID]int64{operator.ID: 2},
+		violations: map[uuid.UUID]int64{operator.ID: 1},
+		incidents: map[uuid.UUID]map[string]int64{operator.ID: {
+			models.IncidentSeverityMinor:   3,
+			models.IncidentSeveritySerious: 1,
+		}},
+		fleet: map[uuid.UUID]map[string]int64{operator.ID: {
+			models.DroneStatusIdle:        4,
+			models.DroneStatusMaintenance: 1,
+		}},
+		overdue: map[uuid.UUID]int64{operator.ID: 1},
+	}
+
+	compliance := buildCompliance(operator, stats, from, to, now)
+	assert.Equal(t, dto.MissionCompliance{Flown: 8, Approved: 6, Waived: 1, Unapproved: 1, Unplanned: 2}, compliance.Missions)
+	assert.Equal(t, int64(1), compliance.ZoneViolations)
+	assert.Equal(t, dto.IncidentCompliance{Total: 4, Minor: 3, Serious: 1}, compliance.Incidents)
+	assert.Equal(t, dto.FleetCompliance{Total: 5, InMaintenance: 1, OverdueMaintenance: 1}, compliance.Fleet)
+	assert.Equal(t, dto.LicenseStatusExpiring, compliance.License.Status)
+	assert.Equal(t, 10, *compliance.License.DaysRemaining)
+	// 100 - 20 - 5 - 2*10 - 15 - 10 - 10 - 3*2 - 5
+	assert.Equal(t, 9, compliance.Score)
+	assert.Equal(t, []string{
+		"1 次任务未经审批起飞",
+		"1 次任务豁免审批起飞",
+		"2 次飞行未关联任务",
+		"1 次闯入禁飞区",
+		"1 架无人机维护超期",
+		"1 起严重事件",
+		"3 起轻微事件",
+		"运营许可证将于 10 天内到期",
+	}, compliance.Findings)
+
+	// 没有任何记录的运营商满分，评分不低于 0
+	clean := buildCompliance(&models.Operator{ID: uuid.New(), LicenseNo: "UAS-2024-002"}, &complianceStats{}, from, to, now)
+	assert.Equal(t, 100, clean.Score)
+	assert.Empty(t, clean.Findings)
+	stats.violations[operator.ID] = 10
+	assert.Equal(t, 0, buildCompliance(operator, stats, from, to, now).Score)
+}
+
+func TestTallyMissionApprovalsFromMissionFlow(t *testing.T) {
+	ctx := context.Background()
+	operatorID := uuid.New()
+	drone := &models.Drone{ID: uuid.New(), OperatorID: &operatorID, Status: models.DroneStatusIdle}
+	pilotID := uuid.New()
+
+	droneRepo := new(MockDroneRepository)
+	droneRepo.On("FindByID", ctx, drone.ID).Return(drone, nil)
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByID", ctx, pilotID).Return(&models.User{ID: pilotID, OperatorID: &operatorID}, nil)
+	repo := new(MockDroneMissionRepository)
+	repo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.DroneMission).ID = uuid.New()
+	})
+	repo.On("ChangeStatus", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	service := NewMissionService(repo, droneRepo, userRepo, NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	waive := false
+	submit := func(actor Actor) *models.DroneMission {
+		mission, _, err := service.SubmitMission(ctx, &dto.CreateMissionRequest{
+			DroneID:           drone.ID,
+			PilotID:           &pilotID,
+			MissionName:       "电力巡检",
+			MissionType:       "inspection",
+			PlannedStartTime:  start,
+			PlannedEndTime:    start.Add(time.Hour),
+			DepartureLocation: dto.MissionLocation{Lat: 31.20, Lng: 121.40},
+			RequiresApproval:  &waive,
+		}, actor)
+		require.NoError(t, err)
+		repo.On("FindByID", ctx, mission.ID).Return(mission, nil)
+		return mission
+	}
+	fly := func(mission *models.DroneMission) {
+		mission.Drone.Status = models.DroneStatusIdle
+		_, err := service.Start(ctx, mission.ID, &dto.MissionActionRequest{}, newAdminActor())
+		require.NoError(t, err)
+	}
+
+	// 飞手提交后经审批执行；管理员豁免审批后直接执行
+	approved := submit(Actor{UserID: &pilotID, Role: RolePilot})
+	_, err := service.Approve(ctx, approved.ID, &dto.MissionActionRequest{}, newReviewer())
+	require.NoError(t, err)
+	fly(approved)
+	waived := submit(newAdminActor())
+	fly(waived)
+
+	// 按仓储的分组方式汇总实际起飞的任务
+	var counts []repositories.MissionApprovalCount
+	for _, mission := range []*models.DroneMission{approved, waived} {
+		require.NotNil(t, mission.ActualStartTime)
+		counts = append(counts, repositories.MissionApprovalCount{
+			RequiresApproval: mission.RequiresApproval,
+			ApprovalStatus:   mission.ApprovalStatus,
+			Count:            1,
+		})
+	}
+	assert.Equal(t, dto.MissionCompliance{Flown: 2, Approved: 1, Waived: 1}, tallyMissionApprovals(counts))
+}
+
+func TestLicenseCompliance(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	expired, distant := now.AddDate(0, 0, -1), now.AddDate(1, 0, 0)
+
+	assert.Equal(t, dto.LicenseStatusMissing, licenseCompliance(&models.Operator{LicenseNo: "  "}, now).Status)
+	assert.Equal(t, dto.LicenseStatusValid, licenseCompliance(&models.Operator{LicenseNo: "UAS-1"}, now).Status)
+	assert.Equal(t, dto.LicenseStatusValid, licenseCompliance(&models.Operator{LicenseNo: "UAS-1", LicenseExpiresAt: &distant}, now).Status)
+
+	license := licenseCompliance(&models.Operator{LicenseNo: "UAS-1", LicenseExpiresAt: &expired}, now)
+	assert.Equal(t, dto.LicenseStatusExpired, license.Status)
+	assert.Equal(t, -1, *license.DaysRemaining)
+}
+
+func TestRankCompliance(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	operators := []models.Operator{
+		{ID: uuid.New(), Code: "AAA", LicenseNo: "UAS-1"},
+		{ID: uuid.New(), Code: "BBB", LicenseNo: "UAS-2"},
+		{ID: uuid.New(), Code: "CCC"},
+	}
+	stats := &complianceStats{violations: map[uuid.UUID]int64{operators[1].ID: 1}}
+
+	ranked := rankCompliance(operators, stats, now, now, now)
+	require.Len(t, ranked, 3)
+	assert.Equal(t, []string{"CCC", "BBB", "AAA"}, []string{ranked[0].Operator.Code, ranked[1].Operator.Code, ranked[2].Operator.Code})
+	assert.Equal(t, []int{70, 85, 100}, []int{ranked[0].Score, ranked[1].Score, ranked[2].Score})
+	assert.Equal(t, 1, ranked[0].Rank)
+	assert.Equal(t, 3, ranked[2].Rank)
+}
+
+func TestOperatorComplianceOutOfScope(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	service := NewComplianceService(nil, nil, nil, nil, nil, nil, userRepo, nil)
+	actor := newOperatorActor(userRepo, uuid.New())
+
+	_, err := service.OperatorCompliance(context.Background(), uuid.New(), &dto.ComplianceQuery{}, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+}
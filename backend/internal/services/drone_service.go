@@ -0,0 +1,291 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DroneService 无人机服务接口
+// 所有操作按操作人的运营商范围校验，运营商用户只能访问所属运营商的机队
+type DroneService interface {
+	ListDrones(ctx context.Context, query *dto.DroneQuery, actor Actor) (*dto.PageResponse[dto.DroneResponse], error)
+	ListOperatorDrones(ctx context.Context, operatorID uuid.UUID, query *dto.DroneQuery, actor Actor) (*dto.PageResponse[dto.DroneResponse], error)
+	GetDrone(ctx context.Context, id uuid.UUID, actor Actor) (*models.Drone, error)
+	CreateDrone(ctx context.Context, req *dto.CreateDroneRequest, actor Actor) (*models.Drone, error)
+	UpdateDrone(ctx context.Context, id uuid.UUID, req *dto.UpdateDroneRequest, actor Actor) (*models.Drone, error)
+	DeleteDrone(ctx context.Context, id uuid.UUID, actor Actor) error
+	ChangeStatus(ctx context.Context, id uuid.UUID, req *dto.DroneStatusRequest, actor Actor) (*models.Drone, error)
+}
+
+// droneTransitions 允许的无人机状态迁移
+// 飞行中的无人机需先降落（idle）才能进入维护
+var droneTransitions = map[string][]string{
+	models.DroneStatusIdle:        {models.DroneStatusFlying, models.DroneStatusMaintenance, models.DroneStatusOffline},
+	models.DroneStatusFlying:      {models.DroneStatusIdle, models.DroneStatusOffline},
+	models.DroneStatusMaintenance: {models.DroneStatusIdle, models.DroneStatusOffline},
+	models.DroneStatusOffline:     {models.DroneStatusIdle, models.DroneStatusMaintenance},
+}
+
+type droneService struct {
+	repo         repositories.DroneRepository
+	operatorRepo repositories.OperatorRepository
+	userRepo     repositories.UserRepository
+}
+
+// NewDroneService 创建无人机服务实例
+func NewDroneService(repo repositories.DroneRepository, operatorRepo repositories.OperatorRepository, userRepo repositories.UserRepository) DroneService {
+	return &droneService{
+		repo:         repo,
+		operatorRepo: operatorRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// ListDrones 分页查询无人机，运营商用户的 operator_id 参数被忽略
+func (s *droneService) ListDrones(ctx context.Context, query *dto.DroneQuery, actor Actor) (*dto.PageResponse[dto.DroneResponse], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, scope.Filter(query.OperatorID), query)
+}
+
+// ListOperatorDrones 查询指定运营商的机队，范围外的运营商视为不存在
+func (s *droneService) ListOperatorDrones(ctx context.Context, operatorID uuid.UUID, query *dto.DroneQuery, actor Actor) (*dto.PageResponse[dto.DroneResponse], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Allows(&operatorID) {
+		return nil, apperr.NewNotFound("运营商不存在")
+	}
+	if err := s.ensureOperator(ctx, &operatorID); err != nil {
+		return nil, apperr.NewNotFound("运营商不存在")
+	}
+	return s.list(ctx, &operatorID, query)
+}
+
+// GetDrone 获取无人机详情，范围外的无人机视为不存在
+func (s *droneService) GetDrone(ctx context.Context, id uuid.UUID, actor Actor) (*models.Drone, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.findDrone(ctx, id, scope)
+}
+
+// CreateDrone 创建无人机，序列号全局唯一
+func (s *droneService) CreateDrone(ctx context.Context, req *dto.CreateDroneRequest, actor Actor) (*models.Drone, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorID := req.OperatorID
+	if !scope.All {
+		operatorID = scope.Filter(nil)
+	}
+	if err := s.ensureOperator(ctx, operatorID); err != nil {
+		return nil, err
+	}
+
+	serial := strings.ToUpper(strings.TrimSpace(req.SerialNumber))
+	if _, err := s.repo.FindBySerialNumber(ctx, serial); err == nil {
+		return nil, apperr.NewConflict("无人机序列号已存在")
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	drone := &models.Drone{
+		SerialNumber: serial,
+		Name:         req.Name,
+		OperatorID:   operatorID,
+		Model:        req.Model,
+		Manufacturer: req.Manufacturer,
+		MaxAltitude:  req.MaxAltitude,
+		MaxSpeed:     req.MaxSpeed,
+		MaxRange:     req.MaxRange,
+		BatteryLife:  req.BatteryLife,
+		Weight:       req.Weight,
+		CameraModel:  req.CameraModel,
+		Status:       models.DroneStatusIdle,
+		Description:  req.Description,
+	}
+
+	if err := s.repo.Create(ctx, drone); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return drone, nil
+}
+
+// UpdateDrone 更新无人机，仅修改请求中提供的字段，状态通过 ChangeStatus 变更
+func (s *droneService) UpdateDrone(ctx context.Context, id uuid.UUID, req *dto.UpdateDroneRequest, actor Actor) (*models.Drone, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	drone, err := s.findDrone(ctx, id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OperatorID != nil {
+		if !scope.All {
+			return nil, apperr.NewForbidden("只有管理员可以变更无人机所属运营商")
+		}
+		if err := s.ensureOperator(ctx, req.OperatorID); err != nil {
+			return nil, err
+		}
+		drone.OperatorID = req.OperatorID
+		drone.Operator = nil
+	}
+	if req.Name != nil {
+		drone.Name = *req.Name
+	}
+	if req.Model != nil {
+		drone.Model = *req.Model
+	}
+	if req.Manufacturer != nil {
+		drone.Manufacturer = *req.Manufacturer
+	}
+	if req.MaxAltitude != nil {
+		drone.MaxAltitude = *req.MaxAltitude
+	}
+	if req.MaxSpeed != nil {
+		drone.MaxSpeed = *req.MaxSpeed
+	}
+	if req.MaxRange != nil {
+		drone.MaxRange = *req.MaxRange
+	}
+	if req.BatteryLife != nil {
+		drone.BatteryLife = *req.BatteryLife
+	}
+	if req.Weight != nil {
+		drone.Weight = *req.Weight
+	}
+	if req.CameraModel != nil {
+		drone.CameraModel = *req.CameraModel
+	}
+	if req.Description != nil {
+		drone.Description = *req.Description
+	}
+
+	if err := s.repo.Update(ctx, drone); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return drone, nil
+}
+
+// DeleteDrone 删除无人机，飞行中的无人机不允许删除
+func (s *droneService) DeleteDrone(ctx context.Context, id uuid.UUID, actor Actor) error {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return err
+	}
+	drone, err := s.findDrone(ctx, id, scope)
+	if err != nil {
+		return err
+	}
+	if drone.Status == models.DroneStatusFlying {
+		return apperr.NewConflict("无人机正在飞行，无法删除")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("无人机不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// ChangeStatus 变更无人机状态，按状态机校验迁移是否合法
+func (s *droneService) ChangeStatus(ctx context.Context, id uuid.UUID, req *dto.DroneStatusRequest, actor Actor) (*models.Drone, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	drone, err := s.findDrone(ctx, id, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canTransitDrone(drone.Status, req.Status) {
+		return nil, apperr.NewConflict(fmt.Sprintf("无人机状态不允许从 %s 变更为 %s", drone.Status, req.Status))
+	}
+
+	from := drone.Status
+	drone.Status = req.Status
+	if err := s.repo.Update(ctx, drone); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	logger.Infof("[DroneService] 无人机状态变更: serial=%s, %s -> %s, operator=%s, reason=%s",
+		drone.SerialNumber, from, req.Status, actor.Username, req.Reason)
+	return drone, nil
+}
+
+func (s *droneService) list(ctx context.Context, operatorID *uuid.UUID, query *dto.DroneQuery) (*dto.PageResponse[dto.DroneResponse], error) {
+	query.Normalize()
+
+	drones, total, err := s.repo.List(ctx, repositories.DroneFilter{
+		OperatorID: operatorID,
+		Status:     query.Status,
+		Model:      query.Model,
+		Keyword:    strings.TrimSpace(query.Q),
+		Offset:     query.Offset(),
+		Limit:      query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToDroneResponseList(drones), total, query.PageQuery), nil
+}
+
+// findDrone 查询无人机并校验运营商范围
+func (s *droneService) findDrone(ctx context.Context, id uuid.UUID, scope OperatorScope) (*models.Drone, error) {
+	drone, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("无人机不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(drone.OperatorID) {
+		return nil, apperr.NewNotFound("无人机不存在")
+	}
+	return drone, nil
+}
+
+// ensureOperator 校验运营商存在
+func (s *droneService) ensureOperator(ctx context.Context, operatorID *uuid.UUID) error {
+	if operatorID == nil {
+		return nil
+	}
+	if _, err := s.operatorRepo.FindByID(ctx, *operatorID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewBadRequest("运营商不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+func canTransitDrone(from, to string) bool {
+	for _, allowed := range droneTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
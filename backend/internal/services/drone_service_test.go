@@ -0,0 +1,233 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDroneRepository 模拟无人机仓储
+type MockDroneRepository struct {
+	mock.Mock
+}
+
+func (m *MockDroneRepository) Create(ctx context.Context, drone *models.Drone) error {
+	args := m.Called(ctx, drone)
+	return args.Error(0)
+}
+
+func (m *MockDroneRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Drone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Drone), args.Error(1)
+}
+
+func (m *MockDroneRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (*models.Drone, error) {
+	args := m.Called(ctx, serialNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Drone), args.Error(1)
+}
+
+func (m *MockDroneRepository) Update(ctx context.Context, drone *models.Drone) error {
+	args := m.Called(ctx, drone)
+	return args.Error(0)
+}
+
+func (m *MockDroneRepository) UpdateLastPosition(ctx context.Context, id uuid.UUID, latitude, longitude, altitude float64, at time.Time) error {
+	args := m.Called(ctx, id, latitude, longitude, altitude, at)
+	return args.Error(0)
+}
+
+func (m *MockDroneRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDroneRepository) List(ctx context.Context, filter repositories.DroneFilter) ([]models.Drone, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Drone), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDroneRepository) CountByOperator(ctx context.Context, operatorID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, operatorID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDroneRepository) ListSilentAirborne(ctx context.Context, minAltitude float64, from, to time.Time) ([]models.Drone, error) {
+	args := m.Called(ctx, minAltitude, from, to)
+	return args.Get(0).([]models.Drone), args.Error(1)
+}
+
+func (m *MockDroneRepository) CountByOperatorAndStatus(ctx context.Context, operatorID *uuid.UUID) (map[uuid.UUID]map[string]int64, error) {
+	args := m.Called(ctx, operatorID)
+	return args.Get(0).(map[uuid.UUID]map[string]int64), args.Error(1)
+}
+
+// MockUserRepository 模拟用户仓储
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	return user, args.Error(0)
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	args := m.Called(ctx, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	return user, args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) List(ctx context.Context) ([]*models.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+// newOperatorActor 创建绑定到指定运营商的操作人
+func newOperatorActor(userRepo *MockUserRepository, operatorID uuid.UUID) Actor {
+	userID := uuid.New()
+	userRepo.On("FindByID", mock.Anything, userID).Return(&models.User{ID: userID, Role: RoleOperator, OperatorID: &operatorID}, nil)
+	return Actor{UserID: &userID, Username: "pilot", Role: RoleOperator}
+}
+
+// assertAppErrorCode 断言错误为指定错误码的 AppError
+func assertAppErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var appErr *apperr.AppError
+	if assert.True(t, errors.As(err, &appErr), "expected AppError, got %v", err) {
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+func TestCanTransitDrone(t *testing.T) {
+	assert.True(t, canTransitDrone(models.DroneStatusIdle, models.DroneStatusFlying))
+	assert.True(t, canTransitDrone(models.DroneStatusFlying, models.DroneStatusIdle))
+	assert.True(t, canTransitDrone(models.DroneStatusOffline, models.DroneStatusMaintenance))
+	assert.False(t, canTransitDrone(models.DroneStatusFlying, models.DroneStatusMaintenance))
+	assert.False(t, canTransitDrone(models.DroneStatusMaintenance, models.DroneStatusFlying))
+	assert.False(t, canTransitDrone(models.DroneStatusIdle, models.DroneStatusIdle))
+}
+
+func TestResolveOperatorScope(t *testing.T) {
+	ctx := context.Background()
+	userRepo := new(MockUserRepository)
+
+	scope, err := resolveOperatorScope(ctx, userRepo, Actor{Role: RoleAdmin})
+	assert.NoError(t, err)
+	assert.True(t, scope.All)
+
+	_, err = resolveOperatorScope(ctx, userRepo, Actor{Role: "user"})
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+
+	unboundID := uuid.New()
+	userRepo.On("FindByID", mock.Anything, unboundID).Return(&models.User{ID: unboundID, Role: RoleOperator}, nil)
+	_, err = resolveOperatorScope(ctx, userRepo, Actor{UserID: &unboundID, Role: RoleOperator})
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+
+	operatorID := uuid.New()
+	scope, err = resolveOperatorScope(ctx, userRepo, newOperatorActor(userRepo, operatorID))
+	assert.NoError(t, err)
+	assert.True(t, scope.Allows(&operatorID))
+	other := uuid.New()
+	assert.False(t, scope.Allows(&other))
+	assert.False(t, scope.Allows(nil))
+	assert.Equal(t, operatorID, *scope.Filter(&other))
+}
+
+func TestDroneServiceHidesOtherOperatorsFleet(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneRepository)
+	userRepo := new(MockUserRepository)
+	service := NewDroneService(repo, nil, userRepo)
+
+	own, foreign := uuid.New(), uuid.New()
+	actor := newOperatorActor(userRepo, own)
+
+	droneID := uuid.New()
+	repo.On("FindByID", mock.Anything, droneID).Return(&models.Drone{ID: droneID, OperatorID: &foreign, Status: models.DroneStatusIdle}, nil)
+
+	_, err := service.GetDrone(ctx, droneID, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+
+	_, err = service.ChangeStatus(ctx, droneID, &dto.DroneStatusRequest{Status: models.DroneStatusFlying}, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	_, err = service.ListOperatorDrones(ctx, foreign, &dto.DroneQuery{}, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+}
+
+func TestDroneServiceListForcesOperatorFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneRepository)
+	userRepo := new(MockUserRepository)
+	service := NewDroneService(repo, nil, userRepo)
+
+	own, foreign := uuid.New(), uuid.New()
+	actor := newOperatorActor(userRepo, own)
+
+	repo.On("List", mock.Anything, mock.MatchedBy(func(f repositories.DroneFilter) bool {
+		return f.OperatorID != nil && *f.OperatorID == own
+	})).Return([]models.Drone{}, int64(0), nil)
+
+	_, err := service.ListDrones(ctx, &dto.DroneQuery{OperatorID: &foreign}, actor)
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDroneServiceCreateRejectsDuplicateSerial(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneRepository)
+	service := NewDroneService(repo, nil, new(MockUserRepository))
+
+	repo.On("FindBySerialNumber", mock.Anything, "DJI-001").Return(&models.Drone{}, nil)
+	_, err := service.CreateDrone(ctx, &dto.CreateDroneRequest{SerialNumber: " dji-001 ", Name: "M300"}, Actor{Role: RoleAdmin})
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	repo.On("FindBySerialNumber", mock.Anything, "DJI-002").Return(nil, repositories.ErrNotFound)
+	repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+	_, err = service.CreateDrone(ctx, &dto.CreateDroneRequest{SerialNumber: "dji-002", Name: "M300"}, Actor{Role: RoleAdmin})
+	assertAppErrorCode(t, err, apperr.ErrCodeInternalServerError)
+}
@@ -0,0 +1,530 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/internal/stream"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DroneTelemetryService 无人机遥测服务接口
+type DroneTelemetryService interface {
+	// Ingest 批量接收遥测，逐条校验，无效或无法关联无人机的报告会被跳过；
+	// 新位置点逐个与有效禁飞区和执行中任务的飞行区域比对，越界时触发告警，恢复后解除；
+	// 检测到无人机从飞行转为落地时汇总本次飞行生成飞行日志；
+	// 高度骤降、飞走、遥测中断、定位崩溃和闯入禁飞区开立事件草稿待人工确认；
+	// 空中无人机的最新位置与周边航班比对间隔，间隔不足或预计不足时触发接近告警
+	Ingest(ctx context.Context, reports []dto.DroneTelemetryReport) (*dto.DroneIngestResult, error)
+}
+
+type droneTelemetryService struct {
+	repo        repositories.DronePositionRepository
+	droneRepo   repositories.DroneRepository
+	missionRepo repositories.DroneMissionRepository
+	zoneRepo    repositories.NoFlyZoneRepository
+	alerts      AlertService
+	flightLogs  FlightLogService
+	incidents   IncidentDetectionService
+	proximity   ProximityService
+	hub         *stream.Hub
+
+	mu           sync.Mutex
+	invalidZones map[uuid.UUID]time.Time // 已记录几何无效的禁飞区及其记录时的更新时间
+}
+
+// NewDroneTelemetryService 创建无人机遥测服务实例
+func NewDroneTelemetryService(
+	repo repositories.DronePositionRepository,
+	droneRepo repositories.DroneRepository,
+	missionRepo repositories.DroneMissionRepository,
+	zoneRepo repositories.NoFlyZoneRepository,
+	alerts AlertService,
+	flightLogs FlightLogService,
+	incidents IncidentDetectionService,
+	proximity ProximityService,
+	hub *stream.Hub,
+) DroneTelemetryService {
+	return &droneTelemetryService{
+		repo:        repo,
+		droneRepo:   droneRepo,
+		missionRepo: missionRepo,
+		zoneRepo:    zoneRepo,
+		alerts:      alerts,
+		flightLogs:  flightLogs,
+		incidents:   incidents,
+		proximity:   proximity,
+		hub:         hub,
+	}
+}
+
+// telemetryTarget 遥测关联的无人机及其执行中的任务，violation 为本批次首个闯入禁飞区的位置点
+type telemetryTarget struct {
+	drone     *models.Drone
+	mission   *models.DroneMission
+	violation *zoneViolation
+}
+
+// zoneViolation 闯入禁飞区的位置点及所在禁飞区
+type zoneViolation struct {
+	position *models.DronePosition
+	hits     []zoneHit
+}
+
+// Ingest 批量接收遥测
+// 所有有效位置点批量写入 drone_positions，每架无人机仅用比已入库数据更新的点刷新最近位置、推送实时事件并做越界检测
+func (s *droneTelemetryService) Ingest(ctx context.Context, reports []dto.DroneTelemetryReport) (*dto.DroneIngestResult, error) {
+	result := &dto.DroneIngestResult{Received: len(reports), Drones: []uuid.UUID{}}
+	now := time.Now()
+
+	resolved := make(map[string]*telemetryTarget)
+	targets := make(map[uuid.UUID]*telemetryTarget)
+	positions := make([]models.DronePosition, 0, len(reports))
+
+	for i := range reports {
+		report := &reports[i]
+		if reason := validateTelemetryReport(report, now); reason != "" {
+			result.Errors = append(result.Errors, dto.IngestError{Index: i, Reason: reason})
+			continue
+		}
+
+		target, err := s.resolveTarget(ctx, resolved, report)
+		if err != nil {
+			return nil, apperr.NewInternalError(err)
+		}
+		if target == nil {
+			result.Errors = append(result.Errors, dto.IngestError{Index: i, Reason: "未找到无人机"})
+			continue
+		}
+		targets[target.drone.ID] = target
+
+		positions = append(positions, toDronePosition(report, target, now))
+	}
+
+	result.Accepted = len(positions)
+	result.Rejected = result.Received - result.Accepted
+	if len(positions) == 0 {
+		return result, nil
+	}
+
+	droneIDs := make([]uuid.UUID, 0, len(targets))
+	for id := range targets {
+		droneIDs = append(droneIDs, id)
+	}
+	stored, err := s.repo.LatestTimestamps(ctx, droneIDs)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	if err := s.repo.BulkCreate(ctx, positions); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	// 只有比已入库数据更新的位置点参与当前位置刷新和越界检测，按时间排序保证告警顺序
+	tracks := make(map[uuid.UUID][]models.DronePosition, len(targets))
+	for _, position := range positions {
+		if last, ok := stored[position.DroneID]; ok && !position.Timestamp.After(last) {
+			continue
+		}
+		tracks[position.DroneID] = append(tracks[position.DroneID], position)
+	}
+
+	events := make([]stream.Event, 0, len(tracks))
+	for _, id := range droneIDs {
+		track := tracks[id]
+		if len(track) == 0 {
+			continue
+		}
+		sort.Slice(track, func(i, j int) bool { return track[i].Timestamp.Before(track[j].Timestamp) })
+
+		latest := &track[len(track)-1]
+		if err := s.droneRepo.UpdateLastPosition(ctx, id, latest.Latitude, latest.Longitude, float64(latest.Altitude), latest.Timestamp); err != nil {
+			return nil, apperr.NewInternalError(err)
+		}
+		result.Drones = append(result.Drones, id)
+		events = append(events, toDronePositionEvent(targets[id].drone, latest))
+	}
+	s.hub.Publish(events...)
+
+	// 越界检测失败不影响本次上报结果
+	breaches, err := s.checkBreaches(ctx, targets, tracks)
+	if err != nil {
+		logger.Errorf("[DroneTelemetryService] 越界检测失败: %v", err)
+	}
+	result.Breaches = breaches
+
+	// 飞行日志生成失败不影响本次上报结果
+	for id, track := range tracks {
+		for _, landedAt := range detectLandings(targets[id].drone, track) {
+			if _, err := s.flightLogs.GenerateForLanding(ctx, targets[id].drone, landedAt); err != nil {
+				logger.Errorf("[DroneTelemetryService] 生成飞行日志失败: drone=%s, err=%v", id, err)
+			}
+		}
+	}
+
+	// 事件检测失败不影响本次上报结果
+	for id, track := range tracks {
+		target := targets[id]
+		if _, err := s.incidents.DetectTelemetry(ctx, target.drone, target.mission, track); err != nil {
+			logger.Errorf("[DroneTelemetryService] 遥测异常事件检测失败: drone=%s, err=%v", id, err)
+		}
+		if target.violation != nil {
+			if _, err := s.incidents.ReportZoneViolation(ctx, target.drone, target.violation.position, zoneNames(target.violation.hits)); err != nil {
+				logger.Errorf("[DroneTelemetryService] 开立禁飞区违规事件失败: drone=%s, err=%v", id, err)
+			}
+		}
+	}
+
+	// 接近检测失败不影响本次上报结果
+	for id, track := range tracks {
+		if err := s.proximity.UpdateDrone(ctx, targets[id].drone, &track[len(track)-1]); err != nil {
+			logger.Errorf("[DroneTelemetryService] 接近检测失败: drone=%s, err=%v", id, err)
+		}
+	}
+
+	logger.Infof("[DroneTelemetryService] 遥测上报: received=%d, accepted=%d, drones=%d, breaches=%d",
+		result.Received, result.Accepted, len(result.Drones), result.Breaches)
+	return result, nil
+}
+
+// resolveTarget 根据无人机 ID 或序列号关联无人机及其执行中的任务，同一批次内缓存结果
+func (s *droneTelemetryService) resolveTarget(ctx context.Context, cache map[string]*telemetryTarget, report *dto.DroneTelemetryReport) (*telemetryTarget, error) {
+	serialNumber := strings.TrimSpace(report.SerialNumber)
+	key := "S:" + serialNumber
+	if report.DroneID != nil {
+		key = "I:" + report.DroneID.String()
+	}
+	if target, ok := cache[key]; ok {
+		return target, nil
+	}
+
+	var (
+		drone *models.Drone
+		err   error
+	)
+	if report.DroneID != nil {
+		drone, err = s.droneRepo.FindByID(ctx, *report.DroneID)
+	} else {
+		drone, err = s.droneRepo.FindBySerialNumber(ctx, serialNumber)
+	}
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			cache[key] = nil
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	mission, err := s.missionRepo.FindActiveByDrone(ctx, drone.ID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return nil, err
+	}
+
+	target := &telemetryTarget{drone: drone, mission: mission}
+	cache[key] = target
+	return target, nil
+}
+
+// checkBreaches 逐点检测禁飞区和飞行区域越界，返回越界的位置点数
+// 越界的点触发（或刷新）告警；批次内从越界恢复或最后一个点未越界时解除告警
+func (s *droneTelemetryService) checkBreaches(ctx context.Context, targets map[uuid.UUID]*telemetryTarget, tracks map[uuid.UUID][]models.DronePosition) (int, error) {
+	if len(tracks) == 0 {
+		return 0, nil
+	}
+
+	var from, to time.Time
+	for _, track := range tracks {
+		if from.IsZero() || track[0].Timestamp.Before(from) {
+			from = track[0].Timestamp
+		}
+		if last := track[len(track)-1].Timestamp; last.After(to) {
+			to = last
+		}
+	}
+	zones, err := s.zoneRepo.ListActive(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	zones = s.validZones(zones)
+
+	breaches := 0
+	for id, track := range tracks {
+		target := targets[id]
+		inZone, outsideArea := false, false
+		for i := range track {
+			position := &track[i]
+			last := i == len(track)-1
+
+			hits := zonesContaining(zones, position)
+			if len(hits) > 0 {
+				if err := s.raiseZoneBreach(ctx, target.drone, hits, position); err != nil {
+					return breaches, err
+				}
+				if target.violation == nil {
+					target.violation = &zoneViolation{position: position, hits: hits}
+				}
+			} else if inZone || last {
+				if err := s.alerts.Clear(ctx, models.AlertTypeNoFlyZoneBreach, models.AlertEntityDrone, id, position.Timestamp); err != nil {
+					return breaches, err
+				}
+			}
+			inZone = len(hits) > 0
+
+			outside, distance := outsideFlightArea(target.mission, position)
+			if outside {
+				if err := s.raiseAreaBreach(ctx, target, distance, position); err != nil {
+					return breaches, err
+				}
+			} else if outsideArea || last {
+				if err := s.alerts.Clear(ctx, models.AlertTypeFlightAreaBreach, models.AlertEntityDrone, id, position.Timestamp); err != nil {
+					return breaches, err
+				}
+			}
+			outsideArea = outside
+
+			if inZone || outside {
+				breaches++
+			}
+		}
+	}
+	return breaches, nil
+}
+
+// raiseZoneBreach 触发禁飞区闯入告警，告警值为进入最深的禁飞区内距边界的距离（米）
+func (s *droneTelemetryService) raiseZoneBreach(ctx context.Context, drone *models.Drone, hits []zoneHit, position *models.DronePosition) error {
+	depth := 0.0
+	for _, hit := range hits {
+		if hit.depth > depth {
+			depth = hit.depth
+		}
+	}
+
+	latitude, longitude := position.Latitude, position.Longitude
+	_, err := s.alerts.Raise(ctx, &models.Alert{
+		Type:        models.AlertTypeNoFlyZoneBreach,
+		Severity:    models.AlertSeverityCritical,
+		EntityType:  models.AlertEntityDrone,
+		EntityID:    drone.ID,
+		Message:     fmt.Sprintf("无人机 %s 进入禁飞区 %s，高度 %d 米", drone.SerialNumber, strings.Join(zoneNames(hits), "、"), position.Altitude),
+		Value:       roundTo(depth, 1),
+		Latitude:    &latitude,
+		Longitude:   &longitude,
+		TriggeredAt: position.Timestamp,
+	})
+	return err
+}
+
+// raiseAreaBreach 触发飞出任务飞行区域告警，告警值为距飞行区域边界的距离（米）
+func (s *droneTelemetryService) raiseAreaBreach(ctx context.Context, target *telemetryTarget, distance float64, position *models.DronePosition) error {
+	latitude, longitude := position.Latitude, position.Longitude
+	_, err := s.alerts.Raise(ctx, &models.Alert{
+		Type:        models.AlertTypeFlightAreaBreach,
+		Severity:    models.AlertSeverityWarning,
+		EntityType:  models.AlertEntityDrone,
+		EntityID:    target.drone.ID,
+		Message:     fmt.Sprintf("无人机 %s 飞出任务 %s 批准的飞行区域 %.0f 米", target.drone.SerialNumber, target.mission.MissionName, distance),
+		Value:       roundTo(distance, 1),
+		Latitude:    &latitude,
+		Longitude:   &longitude,
+		TriggeredAt: position.Timestamp,
+	})
+	return err
+}
+
+// detectLandings 返回轨迹中由飞行转为落地的时刻，初始状态取无人机上一次记录的高度
+func detectLandings(drone *models.Drone, track []models.DronePosition) []time.Time {
+	airborne := drone.LastAltitude != nil && *drone.LastAltitude >= airborneAltitude
+
+	var landings []time.Time
+	for i := range track {
+		position := &track[i]
+		switch {
+		case isAirborne(position):
+			airborne = true
+		case airborne && isLanded(position):
+			landings = append(landings, position.Timestamp)
+			airborne = false
+		}
+	}
+	return landings
+}
+
+// validZones 过滤几何无效的禁飞区，无法判定水平范围的禁飞区不参与逐点越界检测
+// 无效禁飞区属于数据质量问题，每个禁飞区只记录一次，修改后仍无效时再次记录
+func (s *droneTelemetryService) validZones(zones []models.NoFlyZone) []models.NoFlyZone {
+	valid := zones[:0:0]
+	for i := range zones {
+		zone := &zones[i]
+		if _, err := zoneGeometry(zone); err != nil {
+			s.mu.Lock()
+			if s.invalidZones == nil {
+				s.invalidZones = make(map[uuid.UUID]time.Time)
+			}
+			reported, ok := s.invalidZones[zone.ID]
+			if !ok || !reported.Equal(zone.UpdatedAt) {
+				s.invalidZones[zone.ID] = zone.UpdatedAt
+				logger.Errorf("[DroneTelemetryService] 禁飞区几何数据无效，已跳过越界检测: zone=%s, name=%s, err=%v", zone.ID.String(), zone.Name, err)
+			}
+			s.mu.Unlock()
+			continue
+		}
+		valid = append(valid, *zone)
+	}
+	return valid
+}
+
+// zoneHit 位置点所在的禁飞区及距其边界的距离（米）
+type zoneHit struct {
+	zone  *models.NoFlyZone
+	depth float64
+}
+
+func zoneNames(hits []zoneHit) []string {
+	names := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		names = append(names, hit.zone.Name)
+	}
+	return names
+}
+
+// zonesContaining 返回位置点在上报时刻所处的禁飞区，需水平范围包含且高度在限制区间内，几何无效的禁飞区跳过
+func zonesContaining(zones []models.NoFlyZone, position *models.DronePosition) []zoneHit {
+	point := geo.LatLng{Lat: position.Latitude, Lng: position.Longitude}
+	altitude := float64(position.Altitude)
+
+	var hits []zoneHit
+	for i := range zones {
+		zone := &zones[i]
+		if !zoneActiveDuring(zone, position.Timestamp, position.Timestamp) {
+			continue
+		}
+		band := zoneAltitudeBand(zone)
+		if altitude < band.Min || altitude > band.Max {
+			continue
+		}
+		shape, err := zoneGeometry(zone)
+		if err != nil || !shape.ContainsPoint(point) {
+			continue
+		}
+		hits = append(hits, zoneHit{zone: zone, depth: shape.BoundaryDistance(point)})
+	}
+	return hits
+}
+
+// outsideFlightArea 判断位置点是否飞出任务批准的飞行区域，返回距区域边界的距离（米）
+// 没有执行中任务或任务未设置飞行区域时不检测
+func outsideFlightArea(mission *models.DroneMission, position *models.DronePosition) (bool, float64) {
+	if mission == nil || mission.FlightArea == nil || !mission.FlightArea.IsArea() {
+		return false, 0
+	}
+	point := geo.LatLng{Lat: position.Latitude, Lng: position.Longitude}
+	if mission.FlightArea.ContainsPoint(point) {
+		return false, 0
+	}
+	return true, mission.FlightArea.BoundaryDistance(point)
+}
+
+// toDronePosition 将遥测报告转换为位置记录
+func toDronePosition(report *dto.DroneTelemetryReport, target *telemetryTarget, now time.Time) models.DronePosition {
+	timestamp := now
+	if report.Timestamp != nil {
+		timestamp = *report.Timestamp
+	}
+	var missionID *uuid.UUID
+	if target.mission != nil {
+		id := target.mission.ID
+		missionID = &id
+	}
+	var flightMode *string
+	if mode := strings.ToLower(strings.TrimSpace(report.FlightMode)); mode != "" {
+		flightMode = &mode
+	}
+
+	return models.DronePosition{
+		DroneID:        target.drone.ID,
+		MissionID:      missionID,
+		Latitude:       *report.Latitude,
+		Longitude:      *report.Longitude,
+		Altitude:       *report.Altitude,
+		AltitudeMSL:    report.AltitudeMSL,
+		Speed:          report.Speed,
+		Heading:        report.Heading,
+		VerticalSpeed:  report.VerticalSpeed,
+		BatteryLevel:   report.BatteryLevel,
+		SignalStrength: report.SignalStrength,
+		GpsSatellites:  report.GpsSatellites,
+		GpsAccuracy:    report.GpsAccuracy,
+		FlightMode:     flightMode,
+		Temperature:    report.Temperature,
+		Humidity:       report.Humidity,
+		AirPressure:    report.AirPressure,
+		Timestamp:      timestamp,
+	}
+}
+
+// toDronePositionEvent 转换为实时推送事件
+func toDronePositionEvent(drone *models.Drone, position *models.DronePosition) stream.Event {
+	return stream.Event{
+		Type:      stream.EntityDrone,
+		Latitude:  position.Latitude,
+		Longitude: position.Longitude,
+		Timestamp: position.Timestamp,
+		Data: dto.DronePositionEvent{
+			DroneID:        drone.ID,
+			SerialNumber:   drone.SerialNumber,
+			MissionID:      position.MissionID,
+			Latitude:       position.Latitude,
+			Longitude:      position.Longitude,
+			Altitude:       position.Altitude,
+			Speed:          position.Speed,
+			Heading:        position.Heading,
+			BatteryLevel:   position.BatteryLevel,
+			SignalStrength: position.SignalStrength,
+			FlightMode:     position.FlightMode,
+			Timestamp:      position.Timestamp,
+		},
+	}
+}
+
+// validateTelemetryReport 校验遥测报告，返回拒绝原因，合法时返回空字符串
+func validateTelemetryReport(report *dto.DroneTelemetryReport, now time.Time) string {
+	if report.DroneID == nil && strings.TrimSpace(report.SerialNumber) == "" {
+		return "drone_id 与 serial_number 至少提供一个"
+	}
+	if report.Latitude == nil || report.Longitude == nil {
+		return "缺少经纬度"
+	}
+	if *report.Latitude < -90 || *report.Latitude > 90 {
+		return fmt.Sprintf("纬度超出范围: %v", *report.Latitude)
+	}
+	if *report.Longitude < -180 || *report.Longitude > 180 {
+		return fmt.Sprintf("经度超出范围: %v", *report.Longitude)
+	}
+	if report.Altitude == nil {
+		return "缺少相对高度"
+	}
+	if report.Heading != nil && (*report.Heading < 0 || *report.Heading > 360) {
+		return fmt.Sprintf("航向超出范围: %d", *report.Heading)
+	}
+	if report.Speed != nil && *report.Speed < 0 {
+		return fmt.Sprintf("速度不能为负数: %d", *report.Speed)
+	}
+	if report.BatteryLevel != nil && (*report.BatteryLevel < 0 || *report.BatteryLevel > 100) {
+		return fmt.Sprintf("电量超出范围: %d", *report.BatteryLevel)
+	}
+	if report.Timestamp != nil && report.Timestamp.After(now.Add(maxReportClockSkew)) {
+		return "上报时间晚于服务器当前时间"
+	}
+	return ""
+}
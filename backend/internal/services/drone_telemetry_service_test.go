@@ -0,0 +1,143 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTelemetryTrack 生成一架无人机按分钟上报的位置点，坐标为 [纬度, 经度]
+func newTelemetryTrack(droneID uuid.UUID, altitude int, coords ...[2]float64) []models.DronePosition {
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	track := make([]models.DronePosition, len(coords))
+	for i, c := range coords {
+		track[i] = models.DronePosition{
+			DroneID:   droneID,
+			Latitude:  c[0],
+			Longitude: c[1],
+			Altitude:  altitude,
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return track
+}
+
+func TestValidateTelemetryReport(t *testing.T) {
+	now := time.Now()
+	id := uuid.New()
+	lat, lng, badLat := 31.2, 121.4, 91.0
+	altitude, battery, badBattery, badHeading := 120, 80, 101, 361
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name   string
+		report dto.DroneTelemetryReport
+		valid  bool
+	}{
+		{"无人机ID", dto.DroneTelemetryReport{DroneID: &id, Latitude: &lat, Longitude: &lng, Altitude: &altitude, BatteryLevel: &battery}, true},
+		{"序列号", dto.DroneTelemetryReport{SerialNumber: "DJI-001", Latitude: &lat, Longitude: &lng, Altitude: &altitude}, true},
+		{"缺少标识", dto.DroneTelemetryReport{Latitude: &lat, Longitude: &lng, Altitude: &altitude}, false},
+		{"缺少高度", dto.DroneTelemetryReport{DroneID: &id, Latitude: &lat, Longitude: &lng}, false},
+		{"纬度越界", dto.DroneTelemetryReport{DroneID: &id, Latitude: &badLat, Longitude: &lng, Altitude: &altitude}, false},
+		{"电量越界", dto.DroneTelemetryReport{DroneID: &id, Latitude: &lat, Longitude: &lng, Altitude: &altitude, BatteryLevel: &badBattery}, false},
+		{"航向越界", dto.DroneTelemetryReport{DroneID: &id, Latitude: &lat, Longitude: &lng, Altitude: &altitude, Heading: &badHeading}, false},
+		{"未来时间", dto.DroneTelemetryReport{DroneID: &id, Latitude: &lat, Longitude: &lng, Altitude: &altitude, Timestamp: &future}, false},
+	}
+
+	for _, tc := range cases {
+		reason := validateTelemetryReport(&tc.report, now)
+		assert.Equal(t, tc.valid, reason == "", "%s: %s", tc.name, reason)
+	}
+}
+
+func TestCheckBreachesRaisesAndClearsZoneAlert(t *testing.T) {
+	ctx := context.Background()
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-001"}
+	// 由西向东穿过走廊禁飞区：区外、区内、区外
+	track := newTelemetryTrack(drone.ID, 120, [2]float64{31.2, 121.43}, [2]float64{31.2, 121.45}, [2]float64{31.2, 121.47})
+
+	alerts := new(MockAlertService)
+	alerts.On("Raise", ctx, mock.MatchedBy(func(alert *models.Alert) bool {
+		return alert.Type == models.AlertTypeNoFlyZoneBreach &&
+			alert.Severity == models.AlertSeverityCritical &&
+			alert.EntityID == drone.ID &&
+			alert.TriggeredAt.Equal(track[1].Timestamp) &&
+			alert.Value > 900 && alert.Value < 1000
+	})).Return(nil).Once()
+	alerts.On("Clear", ctx, models.AlertTypeNoFlyZoneBreach, models.AlertEntityDrone, drone.ID, track[2].Timestamp).Return(nil).Once()
+	alerts.On("Clear", ctx, models.AlertTypeFlightAreaBreach, models.AlertEntityDrone, drone.ID, track[2].Timestamp).Return(nil).Once()
+
+	service := &droneTelemetryService{
+		zoneRepo: newZoneRepo(newZone("走廊", corridorZone, 0, 500), newZone("高空管制区", corridorZone, 300, 1000)),
+		alerts:   alerts,
+	}
+	breaches, err := service.checkBreaches(ctx,
+		map[uuid.UUID]*telemetryTarget{drone.ID: {drone: drone}},
+		map[uuid.UUID][]models.DronePosition{drone.ID: track},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, breaches)
+	alerts.AssertExpectations(t)
+}
+
+func TestCheckBreachesSkipsInvalidZoneGeometry(t *testing.T) {
+	discardLogs()
+	ctx := context.Background()
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-003"}
+	track := newTelemetryTrack(drone.ID, 120, [2]float64{31.2, 121.51})
+
+	// 几何无效的禁飞区不参与逐点检测，不触发闯入告警
+	alerts := new(MockAlertService)
+	alerts.On("Clear", ctx, models.AlertTypeNoFlyZoneBreach, models.AlertEntityDrone, drone.ID, track[0].Timestamp).Return(nil).Twice()
+	alerts.On("Clear", ctx, models.AlertTypeFlightAreaBreach, models.AlertEntityDrone, drone.ID, track[0].Timestamp).Return(nil).Twice()
+
+	broken := newZone("数据损坏", invalidZone, 0, 300)
+	service := &droneTelemetryService{zoneRepo: newZoneRepo(broken), alerts: alerts}
+	for i := 0; i < 2; i++ {
+		breaches, err := service.checkBreaches(ctx,
+			map[uuid.UUID]*telemetryTarget{drone.ID: {drone: drone}},
+			map[uuid.UUID][]models.DronePosition{drone.ID: track},
+		)
+		require.NoError(t, err)
+		assert.Zero(t, breaches)
+	}
+	alerts.AssertExpectations(t)
+	alerts.AssertNotCalled(t, "Raise", mock.Anything, mock.Anything)
+
+	// 每个无效禁飞区只记录一次
+	assert.Len(t, service.invalidZones, 1)
+	assert.Contains(t, service.invalidZones, broken.ID)
+}
+
+func TestCheckBreachesFlightArea(t *testing.T) {
+	ctx := context.Background()
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-002"}
+	mission := &models.DroneMission{ID: uuid.New(), MissionName: "管线巡检", FlightArea: mustGeometry(corridorZone)}
+	// 在飞行区域内起飞后向东飞出
+	track := newTelemetryTrack(drone.ID, 80, [2]float64{31.2, 121.45}, [2]float64{31.2, 121.47})
+
+	alerts := new(MockAlertService)
+	alerts.On("Raise", ctx, mock.MatchedBy(func(alert *models.Alert) bool {
+		return alert.Type == models.AlertTypeFlightAreaBreach &&
+			alert.Severity == models.AlertSeverityWarning &&
+			alert.TriggeredAt.Equal(track[1].Timestamp)
+	})).Return(nil).Once()
+	alerts.On("Clear", ctx, models.AlertTypeNoFlyZoneBreach, models.AlertEntityDrone, drone.ID, track[1].Timestamp).Return(nil).Once()
+
+	service := &droneTelemetryService{zoneRepo: newZoneRepo(), alerts: alerts}
+	breaches, err := service.checkBreaches(ctx,
+		map[uuid.UUID]*telemetryTarget{drone.ID: {drone: drone, mission: mission}},
+		map[uuid.UUID][]models.DronePosition{drone.ID: track},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, breaches)
+	alerts.AssertExpectations(t)
+	alerts.AssertNotCalled(t, "Clear", ctx, models.AlertTypeFlightAreaBreach, mock.Anything, mock.Anything, mock.Anything)
+}
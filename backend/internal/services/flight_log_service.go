@@ -0,0 +1,473 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 起降判定阈值
+const (
+	landedAltitude   = 1  // 相对高度不高于该值（米）且近乎静止视为已落地
+	landedSpeed      = 3  // 落地判定的最大地速（km/h）
+	airborneAltitude = 3  // 相对高度不低于该值（米）视为已起飞
+	lowBatteryLevel  = 20 // 低电量阈值（%）
+	criticalBattery  = 10 // 严重低电量阈值（%）
+)
+
+// 飞行日志汇总参数
+const (
+	flightPathTolerance = 5.0              // 轨迹简化容差（米）
+	telemetryGap        = 30 * time.Second // 相邻位置点间隔超过该值记为遥测中断
+	landingLookback     = 12 * time.Hour   // 降落时向前查找起飞点的最长时间
+	flightLogAlertLimit = 200              // 单次飞行关联的告警上限
+	maxGapPenalty       = 20               // 遥测中断扣分上限
+	segmentSpeedCap     = 300.0            // 由位置计算的速度上限（km/h），超过视为定位跳变
+)
+
+// FlightLogService 无人机飞行日志服务接口
+// 任务结束或无人机降落时从位置流汇总生成飞行日志，查询按运营商范围限制
+type FlightLogService interface {
+	// GenerateForMission 汇总任务尚未生成日志的位置点，没有离地飞行时返回 nil
+	GenerateForMission(ctx context.Context, mission *models.DroneMission) (*models.DroneFlightLog, error)
+	// GenerateForLanding 汇总无人机在 landedAt 降落前的最后一段飞行，没有离地飞行时返回 nil
+	GenerateForLanding(ctx context.Context, drone *models.Drone, landedAt time.Time) (*models.DroneFlightLog, error)
+	ListByDrone(ctx context.Context, droneID uuid.UUID, query *dto.FlightLogQuery, actor Actor) (*dto.PageResponse[dto.FlightLogResponse], error)
+	GetLog(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneFlightLog, error)
+}
+
+type flightLogService struct {
+	repo         repositories.DroneFlightLogRepository
+	positionRepo repositories.DronePositionRepository
+	droneRepo    repositories.DroneRepository
+	alertRepo    repositories.AlertRepository
+	userRepo     repositories.UserRepository
+}
+
+// NewFlightLogService 创建飞行日志服务实例
+func NewFlightLogService(
+	repo repositories.DroneFlightLogRepository,
+	positionRepo repositories.DronePositionRepository,
+	droneRepo repositories.DroneRepository,
+	alertRepo repositories.AlertRepository,
+	userRepo repositories.UserRepository,
+) FlightLogService {
+	return &flightLogService{
+		repo:         repo,
+		positionRepo: positionRepo,
+		droneRepo:    droneRepo,
+		alertRepo:    alertRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GenerateForMission 汇总任务的位置点，已生成过日志时只汇总最近一次降落之后的部分
+func (s *flightLogService) GenerateForMission(ctx context.Context, mission *models.DroneMission) (*models.DroneFlightLog, error) {
+	missionID := mission.ID
+	latest, err := s.latestLog(ctx, repositories.DroneFlightLogFilter{MissionID: &missionID})
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repositories.DronePositionFilter{MissionID: &missionID}
+	if latest != nil {
+		filter.From = &latest.LandingTime
+	}
+	positions, err := s.positionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAirborne(positions) {
+		return nil, nil
+	}
+
+	return s.generate(ctx, mission.DroneID, &missionID, positions)
+}
+
+// GenerateForLanding 汇总降落前的最后一段飞行，起点不早于该无人机上一份日志的降落时间
+func (s *flightLogService) GenerateForLanding(ctx context.Context, drone *models.Drone, landedAt time.Time) (*models.DroneFlightLog, error) {
+	droneID := drone.ID
+	latest, err := s.latestLog(ctx, repositories.DroneFlightLogFilter{DroneID: &droneID})
+	if err != nil {
+		return nil, err
+	}
+
+	from := landedAt.Add(-landingLookback)
+	if latest != nil && latest.LandingTime.After(from) {
+		from = latest.LandingTime
+	}
+	positions, err := s.positionRepo.List(ctx, repositories.DronePositionFilter{DroneID: &droneID, From: &from, To: &landedAt})
+	if err != nil {
+		return nil, err
+	}
+	positions = lastFlightSegment(positions)
+	if positions == nil {
+		return nil, nil
+	}
+
+	return s.generate(ctx, droneID, missionOf(positions), positions)
+}
+
+// ListByDrone 分页查询无人机的飞行日志
+func (s *flightLogService) ListByDrone(ctx context.Context, droneID uuid.UUID, query *dto.FlightLogQuery, actor Actor) (*dto.PageResponse[dto.FlightLogResponse], error) {
+	if _, err := s.findDrone(ctx, droneID, actor); err != nil {
+		return nil, err
+	}
+	query.Normalize()
+
+	logs, total, err := s.repo.List(ctx, repositories.DroneFlightLogFilter{
+		DroneID:   &droneID,
+		MissionID: query.MissionID,
+		From:      query.From,
+		To:        query.To,
+		Offset:    query.Offset(),
+		Limit:     query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToFlightLogResponseList(logs), total, query.PageQuery), nil
+}
+
+// GetLog 获取飞行日志详情，无权访问所属无人机时视为不存在
+func (s *flightLogService) GetLog(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneFlightLog, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	log, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("飞行日志不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.All {
+		drone, err := s.droneRepo.FindByID(ctx, log.DroneID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewInternalError(err)
+		}
+		if drone == nil || !scope.Allows(drone.OperatorID) {
+			return nil, apperr.NewNotFound("飞行日志不存在")
+		}
+	}
+	return log, nil
+}
+
+// generate 汇总位置点和期间的越界告警，写入飞行日志
+func (s *flightLogService) generate(ctx context.Context, droneID uuid.UUID, missionID *uuid.UUID, positions []models.DronePosition) (*models.DroneFlightLog, error) {
+	from := positions[0].Timestamp
+	to := positions[len(positions)-1].Timestamp.Add(time.Second)
+	alerts, _, err := s.alertRepo.List(ctx, repositories.AlertFilter{
+		Types:      []string{models.AlertTypeNoFlyZoneBreach, models.AlertTypeFlightAreaBreach},
+		EntityType: models.AlertEntityDrone,
+		EntityID:   &droneID,
+		From:       &from,
+		To:         &to,
+		Limit:      flightLogAlertLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log := buildFlightLog(droneID, missionID, positions, alerts)
+	if err := s.repo.Create(ctx, log); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("[FlightLogService] 飞行日志已生成: drone=%s, takeoff=%s, duration=%dmin, distance=%.2fkm, score=%d",
+		droneID, log.TakeoffTime.Format(time.RFC3339), *log.FlightDuration, *log.TotalDistance, *log.FlightQualityScore)
+	return log, nil
+}
+
+// latestLog 查询最近一次降落的飞行日志，不存在时返回 nil
+func (s *flightLogService) latestLog(ctx context.Context, filter repositories.DroneFlightLogFilter) (*models.DroneFlightLog, error) {
+	filter.Limit = 1
+	logs, _, err := s.repo.List(ctx, filter)
+	if err != nil || len(logs) == 0 {
+		return nil, err
+	}
+	return &logs[0], nil
+}
+
+// findDrone 查询无人机并校验运营商范围
+func (s *flightLogService) findDrone(ctx context.Context, id uuid.UUID, actor Actor) (*models.Drone, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	drone, err := s.droneRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("无人机不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(drone.OperatorID) {
+		return nil, apperr.NewNotFound("无人机不存在")
+	}
+	return drone, nil
+}
+
+// isLanded 判断位置点是否处于落地状态
+func isLanded(position *models.DronePosition) bool {
+	return position.Altitude <= landedAltitude && (position.Speed == nil || *position.Speed <= landedSpeed)
+}
+
+// isAirborne 判断位置点是否处于飞行状态
+func isAirborne(position *models.DronePosition) bool {
+	return position.Altitude >= airborneAltitude
+}
+
+func hasAirborne(positions []models.DronePosition) bool {
+	for i := range positions {
+		if isAirborne(&positions[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastFlightSegment 截取最后一段飞行：从最后一次离地前的落地点到其后的首个落地点
+// 不存在离地飞行时返回 nil
+func lastFlightSegment(positions []models.DronePosition) []models.DronePosition {
+	last := -1
+	for i := len(positions) - 1; i >= 0; i-- {
+		if isAirborne(&positions[i]) {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+
+	end := len(positions) - 1
+	for i := last + 1; i < len(positions); i++ {
+		if isLanded(&positions[i]) {
+			end = i
+			break
+		}
+	}
+	start := 0
+	for i := last - 1; i >= 0; i-- {
+		if isLanded(&positions[i]) {
+			start = i
+			break
+		}
+	}
+	return positions[start : end+1]
+}
+
+// missionOf 返回位置点关联的任务，取最后一个带任务的点
+func missionOf(positions []models.DronePosition) *uuid.UUID {
+	for i := len(positions) - 1; i >= 0; i-- {
+		if positions[i].MissionID != nil {
+			id := *positions[i].MissionID
+			return &id
+		}
+	}
+	return nil
+}
+
+// buildFlightLog 由按时间升序的位置点和期间的越界告警汇总飞行日志
+// 距离按相邻点大圆距离累加；最大速度优先取上报地速，未上报时由位置推算；
+// 质量评分从 100 分起，按越界、低电量和遥测中断扣分
+func buildFlightLog(droneID uuid.UUID, missionID *uuid.UUID, positions []models.DronePosition, alerts []models.Alert) *models.DroneFlightLog {
+	takeoff, landing := &positions[0], &positions[len(positions)-1]
+	duration := landing.Timestamp.Sub(takeoff.Timestamp)
+
+	var (
+		distance                float64
+		maxAltitude             int
+		reportedSpeed, segSpeed float64
+		hasReportedSpeed        bool
+		firstBattery, battery   *int
+		mode                    string
+		events                  []dto.FlightLogEvent
+		warnings, failures      []string
+		gaps                    int
+		lowBattery, critical    bool
+	)
+	events = append(events, positionEvent(models.FlightEventTakeoff, takeoff, "起飞", nil))
+
+	points := make([]geo.LatLng, len(positions))
+	for i := range positions {
+		p := &positions[i]
+		points[i] = geo.LatLng{Lat: p.Latitude, Lng: p.Longitude}
+		maxAltitude = max(maxAltitude, p.Altitude)
+		if p.Speed != nil {
+			hasReportedSpeed = true
+			reportedSpeed = math.Max(reportedSpeed, float64(*p.Speed))
+		}
+
+		if i > 0 {
+			prev := &positions[i-1]
+			step := geo.Haversine(prev.Latitude, prev.Longitude, p.Latitude, p.Longitude)
+			distance += step
+			if dt := p.Timestamp.Sub(prev.Timestamp); dt > 0 {
+				if speed := step / dt.Seconds() * 3.6; speed <= segmentSpeedCap {
+					segSpeed = math.Max(segSpeed, speed)
+				}
+				if dt > telemetryGap {
+					gaps++
+					seconds := math.Round(dt.Seconds())
+					events = append(events, positionEvent(models.FlightEventSignalLost, prev, fmt.Sprintf("遥测中断 %.0f 秒", seconds), &seconds))
+				}
+			}
+		}
+
+		if p.BatteryLevel != nil {
+			if firstBattery == nil {
+				firstBattery = p.BatteryLevel
+			}
+			battery = p.BatteryLevel
+			level := float64(*p.BatteryLevel)
+			if !lowBattery && *p.BatteryLevel <= lowBatteryLevel {
+				lowBattery = true
+				events = append(events, positionEvent(models.FlightEventLowBattery, p, fmt.Sprintf("电量降至 %d%%", *p.BatteryLevel), &level))
+			}
+			if !critical && *p.BatteryLevel <= criticalBattery {
+				critical = true
+				events = append(events, positionEvent(models.FlightEventCriticalBattery, p, fmt.Sprintf("电量降至 %d%%，低于严重告警阈值", *p.BatteryLevel), &level))
+			}
+		}
+
+		if p.FlightMode != nil && *p.FlightMode != mode {
+			if mode != "" {
+				events = append(events, positionEvent(models.FlightEventModeChange, p, fmt.Sprintf("飞行模式 %s -> %s", mode, *p.FlightMode), nil))
+			}
+			mode = *p.FlightMode
+		}
+	}
+	events = append(events, positionEvent(models.FlightEventLanding, landing, "降落", nil))
+
+	score := 100
+	var zoneBreaches, areaBreaches int
+	for i := range alerts {
+		alert := &alerts[i]
+		value := alert.Value
+		events = append(events, dto.FlightLogEvent{
+			Type:      alert.Type,
+			Time:      alert.TriggeredAt,
+			Message:   alert.Message,
+			Latitude:  alert.Latitude,
+			Longitude: alert.Longitude,
+			Value:     &value,
+		})
+		switch alert.Type {
+		case models.AlertTypeNoFlyZoneBreach:
+			zoneBreaches++
+			failures = append(failures, alert.Message)
+		case models.AlertTypeFlightAreaBreach:
+			areaBreaches++
+			warnings = append(warnings, alert.Message)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+
+	score -= zoneBreaches*30 + areaBreaches*15
+	if critical {
+		score -= 20
+		failures = append(failures, fmt.Sprintf("飞行中电量低于 %d%%", criticalBattery))
+	} else if lowBattery {
+		score -= 10
+		warnings = append(warnings, fmt.Sprintf("飞行中电量低于 %d%%", lowBatteryLevel))
+	}
+	if gaps > 0 {
+		score -= min(gaps*5, maxGapPenalty)
+		warnings = append(warnings, fmt.Sprintf("遥测中断 %d 次", gaps))
+	}
+	score = max(0, min(100, score))
+
+	minutes := int(math.Round(duration.Minutes()))
+	totalDistance := roundTo(distance/1000, 2)
+	maxSpeed := int(math.Round(segSpeed))
+	if hasReportedSpeed {
+		maxSpeed = int(math.Round(reportedSpeed))
+	}
+
+	log := &models.DroneFlightLog{
+		DroneID:            droneID,
+		MissionID:          missionID,
+		FlightDate:         time.Date(takeoff.Timestamp.Year(), takeoff.Timestamp.Month(), takeoff.Timestamp.Day(), 0, 0, 0, 0, takeoff.Timestamp.Location()),
+		TakeoffTime:        takeoff.Timestamp,
+		LandingTime:        landing.Timestamp,
+		FlightDuration:     &minutes,
+		TakeoffLocation:    locationJSON(takeoff),
+		LandingLocation:    locationJSON(landing),
+		MaxAltitude:        &maxAltitude,
+		MaxSpeed:           &maxSpeed,
+		TotalDistance:      &totalDistance,
+		FlightPath:         flightPath(positions, points),
+		Events:             jsonString(events),
+		Warnings:           jsonString(warnings),
+		Errors:             jsonString(failures),
+		FlightQualityScore: &score,
+	}
+	if hours := duration.Hours(); hours > 0 {
+		average := int(math.Round(distance / 1000 / hours))
+		log.AverageSpeed = &average
+	}
+	if firstBattery != nil {
+		consumed := max(0, *firstBattery-*battery)
+		log.BatteryConsumed = &consumed
+	}
+	return log
+}
+
+// flightPath 按容差简化轨迹，坐标带相对高度
+func flightPath(positions []models.DronePosition, points []geo.LatLng) *geo.Geometry {
+	indexes := geo.DouglasPeucker(points, flightPathTolerance)
+	path := make([]geo.Position, 0, len(indexes))
+	for _, i := range indexes {
+		p := &positions[i]
+		path = append(path, geo.NewPosition(p.Latitude, p.Longitude, float64(p.Altitude)))
+	}
+	return geo.NewLineString(path)
+}
+
+func positionEvent(eventType string, position *models.DronePosition, message string, value *float64) dto.FlightLogEvent {
+	latitude, longitude := position.Latitude, position.Longitude
+	return dto.FlightLogEvent{
+		Type:      eventType,
+		Time:      position.Timestamp,
+		Message:   message,
+		Latitude:  &latitude,
+		Longitude: &longitude,
+		Value:     value,
+	}
+}
+
+// locationJSON 起降点按任务起降点格式序列化
+func locationJSON(position *models.DronePosition) string {
+	data, _ := json.Marshal(dto.MissionLocation{Lat: position.Latitude, Lng: position.Longitude})
+	return string(data)
+}
+
+// jsonString 序列化列表字段，空列表返回 nil
+func jsonString[T any](list []T) *string {
+	if len(list) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil
+	}
+	value := string(data)
+	return &value
+}
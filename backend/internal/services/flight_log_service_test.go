@@ -0,0 +1,139 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlight 生成一段向北飞行的轨迹：地面起飞、三个航点、落地，每 10 秒一个点
+func newFlight(droneID uuid.UUID) []models.DronePosition {
+	altitudes := []int{0, 50, 80, 60, 0}
+	batteries := []int{95, 60, 25, 18, 15}
+	modes := []string{"auto", "auto", "auto", "rtl", "rtl"}
+	track := newTelemetryTrack(droneID, 0,
+		[2]float64{31.2000, 121.4}, [2]float64{31.2010, 121.4}, [2]float64{31.2020, 121.4},
+		[2]float64{31.2030, 121.4}, [2]float64{31.2040, 121.4})
+	for i := range track {
+		track[i].Altitude = altitudes[i]
+		track[i].BatteryLevel = &batteries[i]
+		track[i].FlightMode = &modes[i]
+		track[i].Timestamp = track[0].Timestamp.Add(time.Duration(i) * 10 * time.Second)
+	}
+	return track
+}
+
+func TestBuildFlightLog(t *testing.T) {
+	droneID, missionID := uuid.New(), uuid.New()
+	track := newFlight(droneID)
+	alerts := []models.Alert{{
+		Type:        models.AlertTypeFlightAreaBreach,
+		Message:     "飞出任务飞行区域",
+		Value:       35,
+		TriggeredAt: track[2].Timestamp,
+	}}
+
+	log := buildFlightLog(droneID, &missionID, track, alerts)
+
+	assert.Equal(t, track[0].Timestamp, log.TakeoffTime)
+	assert.Equal(t, track[4].Timestamp, log.LandingTime)
+	assert.Equal(t, &missionID, log.MissionID)
+	assert.Equal(t, 1, *log.FlightDuration)
+	assert.Equal(t, 80, *log.MaxAltitude)
+	assert.InDelta(t, 0.44, *log.TotalDistance, 0.005)
+	// 未上报地速时由位置推算：111 米 / 10 秒 ≈ 40 km/h
+	assert.Equal(t, 40, *log.MaxSpeed)
+	assert.Equal(t, 40, *log.AverageSpeed)
+	assert.Equal(t, 80, *log.BatteryConsumed)
+	// 越界 -15，低电量 -10
+	assert.Equal(t, 75, *log.FlightQualityScore)
+
+	var takeoff dto.MissionLocation
+	require.NoError(t, json.Unmarshal([]byte(log.TakeoffLocation), &takeoff))
+	assert.Equal(t, 31.2, takeoff.Lat)
+
+	// 共线轨迹简化为首尾两点，坐标带高度
+	positions := log.FlightPath.Positions()
+	require.Len(t, positions, 2)
+	altitude, ok := positions[1].Altitude()
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, altitude)
+
+	var events []dto.FlightLogEvent
+	require.NotNil(t, log.Events)
+	require.NoError(t, json.Unmarshal([]byte(*log.Events), &events))
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	assert.Equal(t, []string{
+		models.FlightEventTakeoff, models.AlertTypeFlightAreaBreach,
+		models.FlightEventLowBattery, models.FlightEventModeChange, models.FlightEventLanding,
+	}, types)
+
+	var warnings []string
+	require.NoError(t, json.Unmarshal([]byte(*log.Warnings), &warnings))
+	assert.Len(t, warnings, 2)
+	assert.Nil(t, log.Errors)
+}
+
+func TestBuildFlightLogPenalizesGapsAndCriticalBattery(t *testing.T) {
+	droneID := uuid.New()
+	track := newFlight(droneID)
+	speed, critical := 36, 8
+	track[1].Speed = &speed
+	track[4].BatteryLevel = &critical
+	track[4].Timestamp = track[3].Timestamp.Add(45 * time.Second)
+
+	log := buildFlightLog(droneID, nil, track, nil)
+
+	// 优先使用上报地速
+	assert.Equal(t, 36, *log.MaxSpeed)
+	// 严重低电量 -20，遥测中断 -5
+	assert.Equal(t, 75, *log.FlightQualityScore)
+	require.NotNil(t, log.Errors)
+	assert.Contains(t, *log.Errors, "电量低于 10%")
+	assert.Contains(t, *log.Warnings, "遥测中断 1 次")
+}
+
+func TestLastFlightSegment(t *testing.T) {
+	droneID := uuid.New()
+	first, second := newFlight(droneID), newFlight(droneID)
+	for i := range second {
+		second[i].Timestamp = second[i].Timestamp.Add(time.Hour)
+	}
+	// 两次飞行之间在地面停留
+	positions := append(append(first, first[4]), second...)
+
+	segment := lastFlightSegment(positions)
+	require.Len(t, segment, 5)
+	assert.Equal(t, second[0].Timestamp, segment[0].Timestamp)
+	assert.Equal(t, second[4].Timestamp, segment[4].Timestamp)
+
+	assert.Nil(t, lastFlightSegment(positions[:1]))
+	assert.False(t, hasAirborne(positions[:1]))
+}
+
+func TestDetectLandings(t *testing.T) {
+	droneID := uuid.New()
+	track := newFlight(droneID)
+
+	assert.Equal(t, []time.Time{track[4].Timestamp}, detectLandings(&models.Drone{ID: droneID}, track))
+
+	// 上一批次已在空中，本批次只有落地点
+	lastAltitude := 40.0
+	drone := &models.Drone{ID: droneID, LastAltitude: &lastAltitude}
+	assert.Equal(t, []time.Time{track[4].Timestamp}, detectLandings(drone, track[4:]))
+	assert.Empty(t, detectLandings(&models.Drone{ID: droneID}, track[4:]))
+
+	// 低速滑行不视为落地
+	taxi := 10
+	track[4].Speed = &taxi
+	assert.Empty(t, detectLandings(&models.Drone{ID: droneID}, track))
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/internal/stream"
+	"backend/pkg/apperr"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	feetToMeters = 0.3048
+	knotsToKmh   = 1.852
+
+	// maxReportClockSkew 允许上报时间超前服务器时间的最大偏差
+	maxReportClockSkew = 5 * time.Minute
+)
+
+// FlightPositionService 航班位置服务接口
+type FlightPositionService interface {
+	// IngestPositions 批量接收位置报告，逐条校验，无效或无法关联航班的报告会被跳过
+	IngestPositions(ctx context.Context, reports []dto.FlightPositionReport) (*dto.IngestResult, error)
+	// Track 查询航班在时间窗口内的航迹，按 max_points 降采样
+	Track(ctx context.Context, flightID uuid.UUID, query *dto.TrackQuery) (*dto.TrackResponse, error)
+}
+
+type flightPositionService struct {
+	repo         repositories.FlightPositionRepository
+	flightRepo   repositories.FlightRepository
+	aircraftRepo repositories.AircraftRepository
+	deviation    RouteDeviationService
+	proximity    ProximityService
+	hub          *stream.Hub
+}
+
+// NewFlightPositionService 创建航班位置服务实例
+func NewFlightPositionService(
+	repo repositories.FlightPositionRepository,
+	flightRepo repositories.FlightRepository,
+	aircraftRepo repositories.AircraftRepository,
+	deviation RouteDeviationService,
+	proximity ProximityService,
+	hub *stream.Hub,
+) FlightPositionService {
+	return &flightPositionService{
+		repo:         repo,
+		flightRepo:   flightRepo,
+		aircraftRepo: aircraftRepo,
+		deviation:    deviation,
+		proximity:    proximity,
+		hub:          hub,
+	}
+}
+
+// IngestPositions 批量接收位置报告
+// 所有有效位置点批量写入 flight_positions，每个航班仅用比已入库数据更新的点刷新当前位置、推送实时事件并做偏航和接近检测
+func (s *flightPositionService) IngestPositions(ctx context.Context, reports []dto.FlightPositionReport) (*dto.IngestResult, error) {
+	result := &dto.IngestResult{Received: len(reports), Flights: []uuid.UUID{}}
+	now := time.Now()
+
+	resolved := make(map[string]*models.Flight)
+	positions := make([]models.FlightPosition, 0, len(reports))
+	latest := make(map[uuid.UUID]*models.FlightPosition)
+
+	for i := range reports {
+		report := &reports[i]
+		if reason := validatePositionReport(report, now); reason != "" {
+			result.Errors = append(result.Errors, dto.IngestError{Index: i, Reason: reason})
+			continue
+		}
+
+		timestamp := now
+		if report.Timestamp != nil {
+			timestamp = *report.Timestamp
+		}
+
+		flight, err := s.resolveFlight(ctx, resolved, report, timestamp)
+		if err != nil {
+			return nil, apperr.NewInternalError(err)
+		}
+		if flight == nil {
+			result.Errors = append(result.Errors, dto.IngestError{Index: i, Reason: "未找到执行中的航班"})
+			continue
+		}
+
+		positions = append(positions, models.FlightPosition{
+			FlightID:      flight.ID,
+			Latitude:      *report.Latitude,
+			Longitude:     *report.Longitude,
+			Altitude:      report.Altitude,
+			Speed:         report.Speed,
+			Heading:       report.Heading,
+			VerticalSpeed: report.VerticalSpeed,
+			Timestamp:     timestamp,
+		})
+	}
+
+	result.Accepted = len(positions)
+	result.Rejected = result.Received - result.Accepted
+	if len(positions) == 0 {
+		return result, nil
+	}
+
+	for i := range positions {
+		current, ok := latest[positions[i].FlightID]
+		if !ok || positions[i].Timestamp.After(current.Timestamp) {
+			latest[positions[i].FlightID] = &positions[i]
+		}
+	}
+
+	flightIDs := make([]uuid.UUID, 0, len(latest))
+	for id := range latest {
+		flightIDs = append(flightIDs, id)
+	}
+	stored, err := s.repo.LatestTimestamps(ctx, flightIDs)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	if err := s.repo.BulkCreate(ctx, positions); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	flights := make(map[uuid.UUID]*models.Flight, len(resolved))
+	for _, flight := range resolved {
+		if flight != nil {
+			flights[flight.ID] = flight
+		}
+	}
+	events := make([]stream.Event, 0, len(flightIDs))
+	for _, id := range flightIDs {
+		position := latest[id]
+		if last, ok := stored[id]; ok && !position.Timestamp.After(last) {
+			continue
+		}
+		if err := s.updateFlightPosition(ctx, flights[id], position); err != nil {
+			return nil, apperr.NewInternalError(err)
+		}
+		result.Flights = append(result.Flights, id)
+		events = append(events, toFlightPositionEvent(flights[id], position))
+	}
+	s.hub.Publish(events...)
+
+	// 偏航和接近检测只处理比已入库数据更新的位置点，失败不影响本次上报结果
+	fresh := make([]models.FlightPosition, 0, len(positions))
+	for _, position := range positions {
+		if last, ok := stored[position.FlightID]; !ok || position.Timestamp.After(last) {
+			fresh = append(fresh, position)
+		}
+	}
+	if err := s.deviation.CheckPositions(ctx, flights, fresh); err != nil {
+		logger.Errorf("[FlightPositionService] 偏航检测失败: %v", err)
+	}
+	for _, id := range result.Flights {
+		if err := s.proximity.UpdateFlight(ctx, flights[id], latest[id]); err != nil {
+			logger.Errorf("[FlightPositionService] 接近检测失败: flight=%s, err=%v", id, err)
+		}
+	}
+
+	logger.Infof("[FlightPositionService] 位置上报: received=%d, accepted=%d, flights=%d",
+		result.Received, result.Accepted, len(result.Flights))
+	return result, nil
+}
+
+// resolveFlight 根据航班号或 ICAO 地址关联执行中的航班，同一批次内缓存结果
+func (s *flightPositionService) resolveFlight(ctx context.Context, cache map[string]*models.Flight, report *dto.FlightPositionReport, at time.Time) (*models.Flight, error) {
+	flightNumber := strings.ToUpper(strings.TrimSpace(report.FlightNumber))
+	icaoHex := strings.ToUpper(strings.TrimSpace(report.ICAOHex))
+
+	key := "N:" + flightNumber + "|H:" + icaoHex
+	if flight, ok := cache[key]; ok {
+		return flight, nil
+	}
+
+	var flight *models.Flight
+	if flightNumber != "" {
+		found, err := s.flightRepo.FindActiveByNumber(ctx, flightNumber, at)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return nil, err
+		}
+		flight = found
+	}
+	if flight == nil && icaoHex != "" {
+		aircraft, err := s.aircraftRepo.FindByICAOHex(ctx, icaoHex)
+		if err == nil {
+			found, err := s.flightRepo.FindActiveByAircraft(ctx, aircraft.ID, at)
+			if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+				return nil, err
+			}
+			flight = found
+		} else if !errors.Is(err, repositories.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	cache[key] = flight
+	return flight, nil
+}
+
+// updateFlightPosition 将最新位置点写回航班，缺失的高度和速度保留原值
+func (s *flightPositionService) updateFlightPosition(ctx context.Context, flight *models.Flight, position *models.FlightPosition) error {
+	altitude, speed := flight.Altitude, flight.Speed
+	if position.Altitude != nil {
+		altitude = float64(*position.Altitude) * feetToMeters
+	}
+	if position.Speed != nil {
+		speed = float64(*position.Speed) * knotsToKmh
+	}
+
+	if err := s.flightRepo.UpdatePosition(ctx, flight.ID, position.Latitude, position.Longitude, altitude, speed); err != nil {
+		return err
+	}
+
+	latitude, longitude := position.Latitude, position.Longitude
+	flight.Latitude, flight.Longitude = &latitude, &longitude
+	flight.Altitude, flight.Speed = altitude, speed
+	return nil
+}
+
+// toFlightPositionEvent 转换为实时推送事件
+func toFlightPositionEvent(flight *models.Flight, position *models.FlightPosition) stream.Event {
+	return stream.Event{
+		Type:      stream.EntityFlight,
+		Latitude:  position.Latitude,
+		Longitude: position.Longitude,
+		Timestamp: position.Timestamp,
+		Data: dto.FlightPositionEvent{
+			FlightID:      flight.ID,
+			FlightNumber:  flight.FlightNumber,
+			Latitude:      position.Latitude,
+			Longitude:     position.Longitude,
+			Altitude:      position.Altitude,
+			Speed:         position.Speed,
+			Heading:       position.Heading,
+			VerticalSpeed: position.VerticalSpeed,
+			Timestamp:     position.Timestamp,
+		},
+	}
+}
+
+// validatePositionReport 校验位置报告，返回拒绝原因，合法时返回空字符串
+func validatePositionReport(report *dto.FlightPositionReport, now time.Time) string {
+	if strings.TrimSpace(report.FlightNumber) == "" && strings.TrimSpace(report.ICAOHex) == "" {
+		return "flight_number 与 icao_hex 至少提供一个"
+	}
+	if report.Latitude == nil || report.Longitude == nil {
+		return "缺少经纬度"
+	}
+	if *report.Latitude < -90 || *report.Latitude > 90 {
+		return fmt.Sprintf("纬度超出范围: %v", *report.Latitude)
+	}
+	if *report.Longitude < -180 || *report.Longitude > 180 {
+		return fmt.Sprintf("经度超出范围: %v", *report.Longitude)
+	}
+	if report.Heading != nil && (*report.Heading < 0 || *report.Heading > 360) {
+		return fmt.Sprintf("航向超出范围: %d", *report.Heading)
+	}
+	if report.Speed != nil && *report.Speed < 0 {
+		return fmt.Sprintf("速度不能为负数: %d", *report.Speed)
+	}
+	if report.Timestamp != nil && report.Timestamp.After(now.Add(maxReportClockSkew)) {
+		return "上报时间晚于服务器当前时间"
+	}
+	return ""
+}
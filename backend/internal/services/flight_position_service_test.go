@@ -0,0 +1,57 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePositionReport(t *testing.T) {
+	now := time.Now()
+	lat, lng := 31.2, 121.4
+	badLat, badLng := 91.0, -181.0
+	heading, badHeading, badSpeed := 360, 361, -1
+	future := now.Add(time.Hour)
+
+	cases := []struct {
+		name   string
+		report dto.FlightPositionReport
+		valid  bool
+	}{
+		{"航班号", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &lat, Longitude: &lng, Heading: &heading}, true},
+		{"ICAO地址", dto.FlightPositionReport{ICAOHex: "780A3B", Latitude: &lat, Longitude: &lng}, true},
+		{"缺少标识", dto.FlightPositionReport{Latitude: &lat, Longitude: &lng}, false},
+		{"缺少经纬度", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &lat}, false},
+		{"纬度越界", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &badLat, Longitude: &lng}, false},
+		{"经度越界", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &lat, Longitude: &badLng}, false},
+		{"航向越界", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &lat, Longitude: &lng, Heading: &badHeading}, false},
+		{"速度为负", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &lat, Longitude: &lng, Speed: &badSpeed}, false},
+		{"未来时间", dto.FlightPositionReport{FlightNumber: "MU5101", Latitude: &lat, Longitude: &lng, Timestamp: &future}, false},
+	}
+
+	for _, tc := range cases {
+		reason := validatePositionReport(&tc.report, now)
+		assert.Equal(t, tc.valid, reason == "", "%s: %s", tc.name, reason)
+	}
+}
+
+func TestBucketSample(t *testing.T) {
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	positions := make([]models.FlightPosition, 100)
+	for i := range positions {
+		positions[i].Timestamp = start.Add(time.Duration(i) * time.Minute)
+	}
+
+	indices := bucketSample(positions, 10)
+	assert.LessOrEqual(t, len(indices), 10)
+	assert.Equal(t, 0, indices[0])
+	assert.Equal(t, 99, indices[len(indices)-1])
+	for i := 1; i < len(indices); i++ {
+		assert.Greater(t, indices[i], indices[i-1])
+	}
+
+	assert.Len(t, bucketSample(positions[:5], 10), 5)
+}
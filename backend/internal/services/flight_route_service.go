@@ -0,0 +1,177 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FlightRouteService 航班计划航线服务接口
+type FlightRouteService interface {
+	GetRoute(ctx context.Context, flightID uuid.UUID) (*dto.FlightRouteResponse, error)
+	ReplaceRoute(ctx context.Context, flightID uuid.UUID, req *dto.ReplaceFlightRouteRequest) (*dto.FlightRouteResponse, error)
+	UpdateWaypoint(ctx context.Context, flightID, waypointID uuid.UUID, req *dto.UpdateFlightWaypointRequest) (*models.FlightRoute, error)
+	DeleteRoute(ctx context.Context, flightID uuid.UUID) error
+}
+
+type flightRouteService struct {
+	repo       repositories.FlightRouteRepository
+	flightRepo repositories.FlightRepository
+	deviation  RouteDeviationService
+}
+
+// NewFlightRouteService 创建航班计划航线服务实例
+func NewFlightRouteService(repo repositories.FlightRouteRepository, flightRepo repositories.FlightRepository, deviation RouteDeviationService) FlightRouteService {
+	return &flightRouteService{
+		repo:       repo,
+		flightRepo: flightRepo,
+		deviation:  deviation,
+	}
+}
+
+// GetRoute 获取航班计划航线，航班有当前位置时附带偏航情况
+func (s *flightRouteService) GetRoute(ctx context.Context, flightID uuid.UUID) (*dto.FlightRouteResponse, error) {
+	flight, err := s.findFlight(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	waypoints, err := s.repo.ListByFlight(ctx, flightID)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return s.toRouteResponse(flight, waypoints), nil
+}
+
+// ReplaceRoute 整体设置航班计划航线，航点序号按请求顺序从 1 开始
+func (s *flightRouteService) ReplaceRoute(ctx context.Context, flightID uuid.UUID, req *dto.ReplaceFlightRouteRequest) (*dto.FlightRouteResponse, error) {
+	flight, err := s.findFlight(ctx, flightID)
+	if err != nil {
+		return nil, err
+	}
+	if flight.Status == models.FlightStatusArrived || flight.Status == models.FlightStatusCancelled {
+		return nil, apperr.NewBadRequest("已结束的航班不能修改计划航线")
+	}
+
+	waypoints := make([]models.FlightRoute, len(req.Waypoints))
+	for i, wp := range req.Waypoints {
+		waypoints[i] = models.FlightRoute{
+			WaypointName:  strings.ToUpper(strings.TrimSpace(wp.WaypointName)),
+			Latitude:      *wp.Latitude,
+			Longitude:     *wp.Longitude,
+			Altitude:      wp.Altitude,
+			Sequence:      i + 1,
+			EstimatedTime: wp.EstimatedTime,
+		}
+		if i > 0 && wp.EstimatedTime != nil && waypoints[i-1].EstimatedTime != nil && wp.EstimatedTime.Before(*waypoints[i-1].EstimatedTime) {
+			return nil, apperr.NewBadRequest("航点预计时间必须按顺序递增")
+		}
+	}
+
+	if err := s.repo.Replace(ctx, flightID, waypoints); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return s.toRouteResponse(flight, waypoints), nil
+}
+
+// UpdateWaypoint 更新单个航点，仅修改请求中提供的字段
+func (s *flightRouteService) UpdateWaypoint(ctx context.Context, flightID, waypointID uuid.UUID, req *dto.UpdateFlightWaypointRequest) (*models.FlightRoute, error) {
+	waypoint, err := s.repo.FindByID(ctx, waypointID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("航点不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if waypoint.FlightID != flightID {
+		return nil, apperr.NewNotFound("航点不存在")
+	}
+
+	if req.WaypointName != nil {
+		waypoint.WaypointName = strings.ToUpper(strings.TrimSpace(*req.WaypointName))
+	}
+	if req.Latitude != nil {
+		waypoint.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		waypoint.Longitude = *req.Longitude
+	}
+	if req.Altitude != nil {
+		waypoint.Altitude = req.Altitude
+	}
+	if req.EstimatedTime != nil {
+		waypoint.EstimatedTime = req.EstimatedTime
+	}
+	if req.ActualTime != nil {
+		waypoint.ActualTime = req.ActualTime
+	}
+
+	if err := s.repo.Update(ctx, waypoint); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return waypoint, nil
+}
+
+// DeleteRoute 删除航班的计划航线
+func (s *flightRouteService) DeleteRoute(ctx context.Context, flightID uuid.UUID) error {
+	if _, err := s.findFlight(ctx, flightID); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteByFlight(ctx, flightID)
+	if err != nil {
+		return apperr.NewInternalError(err)
+	}
+	if deleted == 0 {
+		return apperr.NewNotFound("航班未设置计划航线")
+	}
+	return nil
+}
+
+func (s *flightRouteService) findFlight(ctx context.Context, flightID uuid.UUID) (*models.Flight, error) {
+	flight, err := s.flightRepo.FindByID(ctx, flightID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("航班不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return flight, nil
+}
+
+// toRouteResponse 组装航线响应，计算航线总长及当前偏航
+func (s *flightRouteService) toRouteResponse(flight *models.Flight, waypoints []models.FlightRoute) *dto.FlightRouteResponse {
+	path := routePath(waypoints)
+
+	distance := 0.0
+	for i := 1; i < len(path); i++ {
+		distance += geo.Haversine(path[i-1].Lat, path[i-1].Lng, path[i].Lat, path[i].Lng)
+	}
+
+	resp := &dto.FlightRouteResponse{
+		FlightID:     flight.ID,
+		FlightNumber: flight.FlightNumber,
+		DistanceKm:   roundTo(distance/1000, 1),
+		Waypoints:    dto.ToFlightWaypointResponseList(waypoints),
+	}
+	if len(path) >= 2 && flight.Latitude != nil && flight.Longitude != nil {
+		resp.Deviation = s.deviation.Measure(geo.LatLng{Lat: *flight.Latitude, Lng: *flight.Longitude}, path)
+	}
+	return resp
+}
+
+// routePath 将航点转换为折线坐标
+func routePath(waypoints []models.FlightRoute) []geo.LatLng {
+	path := make([]geo.LatLng, len(waypoints))
+	for i := range waypoints {
+		path[i] = geo.LatLng{Lat: waypoints[i].Latitude, Lng: waypoints[i].Longitude}
+	}
+	return path
+}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlightService 航班服务接口
+type FlightService interface {
+	ListFlights(ctx context.Context, query *dto.FlightQuery) (*dto.PageResponse[dto.FlightResponse], error)
+	GetFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error)
+	CreateFlight(ctx context.Context, req *dto.CreateFlightRequest) (*models.Flight, error)
+	UpdateFlight(ctx context.Context, id uuid.UUID, req *dto.UpdateFlightRequest) (*models.Flight, error)
+	DeleteFlight(ctx context.Context, id uuid.UUID) error
+	Board(ctx context.Context, airportIDOrCode string, direction repositories.BoardDirection, query *dto.FlightBoardQuery) (*dto.FlightBoardResponse, error)
+	ChangeStatus(ctx context.Context, id uuid.UUID, req *dto.FlightStatusRequest, actor Actor) (*models.Flight, error)
+	ListStatusLogs(ctx context.Context, id uuid.UUID) ([]models.FlightStatusLog, error)
+}
+
+const (
+	defaultBoardLookBehind = 2 * time.Hour
+	defaultBoardLookAhead  = 12 * time.Hour
+	defaultBoardLimit      = 200
+)
+
+type flightService struct {
+	repo         repositories.FlightRepository
+	airportRepo  repositories.AirportRepository
+	airlineRepo  repositories.AirlineRepository
+	aircraftRepo repositories.AircraftRepository
+}
+
+// NewFlightService 创建航班服务实例
+func NewFlightService(
+	repo repositories.FlightRepository,
+	airportRepo repositories.AirportRepository,
+	airlineRepo repositories.AirlineRepository,
+	aircraftRepo repositories.AircraftRepository,
+) FlightService {
+	return &flightService{
+		repo:         repo,
+		airportRepo:  airportRepo,
+		airlineRepo:  airlineRepo,
+		aircraftRepo: aircraftRepo,
+	}
+}
+
+// ListFlights 分页查询航班
+// date 表示按 UTC 自然日过滤计划起飞时间，与 from/to 同时出现时取交集
+func (s *flightService) ListFlights(ctx context.Context, query *dto.FlightQuery) (*dto.PageResponse[dto.FlightResponse], error) {
+	query.Normalize()
+
+	filter := repositories.FlightFilter{
+		FlightNumber: strings.TrimSpace(query.FlightNumber),
+		Statuses:     splitStatuses(query.Status),
+		From:         query.From,
+		To:           query.To,
+		Offset:       query.Offset(),
+		Limit:        query.PageSize,
+	}
+
+	if query.Date != nil {
+		dayStart := time.Date(query.Date.Year(), query.Date.Month(), query.Date.Day(), 0, 0, 0, 0, time.UTC)
+		dayEnd := dayStart.Add(24 * time.Hour)
+		if filter.From == nil || filter.From.Before(dayStart) {
+			filter.From = &dayStart
+		}
+		if filter.To == nil || filter.To.After(dayEnd) {
+			filter.To = &dayEnd
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperr.NewBadRequest("开始时间必须早于结束时间")
+	}
+
+	if airline := strings.TrimSpace(query.Airline); airline != "" {
+		airlineID, err := s.resolveAirlineID(ctx, airline)
+		if err != nil {
+			return nil, err
+		}
+		if airlineID == uuid.Nil {
+			return dto.NewPageResponse([]dto.FlightResponse{}, 0, query.PageQuery), nil
+		}
+		filter.AirlineID = &airlineID
+	}
+
+	flights, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToFlightResponseList(flights), total, query.PageQuery), nil
+}
+
+// GetFlight 获取航班详情
+func (s *flightService) GetFlight(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	flight, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("航班不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return flight, nil
+}
+
+// CreateFlight 创建航班，初始状态为 scheduled
+func (s *flightService) CreateFlight(ctx context.Context, req *dto.CreateFlightRequest) (*models.Flight, error) {
+	flightNumber := strings.ToUpper(strings.TrimSpace(req.FlightNumber))
+	if flightNumber == "" {
+		return nil, apperr.NewBadRequest("航班号不能为空")
+	}
+
+	flight := &models.Flight{
+		FlightNumber:  flightNumber,
+		AirlineID:     req.AirlineID,
+		AircraftID:    req.AircraftID,
+		DepartureID:   req.DepartureID,
+		ArrivalID:     req.ArrivalID,
+		DepartureTime: req.DepartureTime,
+		ArrivalTime:   req.ArrivalTime,
+		Status:        models.FlightStatusScheduled,
+		Gate:          req.Gate,
+		Terminal:      req.Terminal,
+	}
+	if err := s.validate(ctx, flight); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, flight); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return s.GetFlight(ctx, flight.ID)
+}
+
+// UpdateFlight 更新航班计划信息，状态由状态机接口维护
+func (s *flightService) UpdateFlight(ctx context.Context, id uuid.UUID, req *dto.UpdateFlightRequest) (*models.Flight, error) {
+	flight, err := s.GetFlight(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FlightNumber != nil {
+		flight.FlightNumber = strings.ToUpper(strings.TrimSpace(*req.FlightNumber))
+	}
+	if req.AirlineID != nil {
+		flight.AirlineID = req.AirlineID
+		flight.Airline = nil
+	}
+	if req.AircraftID != nil {
+		flight.AircraftID = req.AircraftID
+		flight.Aircraft = nil
+	}
+	if req.DepartureID != nil {
+		flight.DepartureID = req.DepartureID
+		flight.Departure = nil
+	}
+	if req.ArrivalID != nil {
+		flight.ArrivalID = req.ArrivalID
+		flight.Arrival = nil
+	}
+	if req.DepartureTime != nil {
+		flight.DepartureTime = *req.DepartureTime
+	}
+	if req.ArrivalTime != nil {
+		flight.ArrivalTime = *req.ArrivalTime
+	}
+	if req.Gate != nil {
+		flight.Gate = *req.Gate
+	}
+	if req.Terminal != nil {
+		flight.Terminal = *req.Terminal
+	}
+
+	if err := s.validate(ctx, flight); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, flight); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return s.GetFlight(ctx, flight.ID)
+}
+
+// DeleteFlight 删除航班
+func (s *flightService) DeleteFlight(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("航班不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// Board 机场出发/到达航班显示屏
+func (s *flightService) Board(ctx context.Context, airportIDOrCode string, direction repositories.BoardDirection, query *dto.FlightBoardQuery) (*dto.FlightBoardResponse, error) {
+	airport, err := s.findAirport(ctx, airportIDOrCode)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("机场不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+
+	now := time.Now()
+	from, to := now.Add(-defaultBoardLookBehind), now.Add(defaultBoardLookAhead)
+	if query.From != nil {
+		from = *query.From
+	}
+	if query.To != nil {
+		to = *query.To
+	}
+	if !from.Before(to) {
+		return nil, apperr.NewBadRequest("开始时间必须早于结束时间")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultBoardLimit
+	}
+
+	flights, err := s.repo.Board(ctx, airport.ID, direction, from, to, splitStatuses(query.Status), limit)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	entries := make([]dto.FlightBoardEntry, len(flights))
+	for i := range flights {
+		entries[i] = toBoardEntry(&flights[i], direction)
+	}
+
+	return &dto.FlightBoardResponse{
+		Airport:   *dto.ToAirportBrief(airport),
+		Direction: string(direction),
+		From:      from,
+		To:        to,
+		Flights:   entries,
+	}, nil
+}
+
+// validate 校验航班时间以及关联的航空公司、飞机、机场是否存在
+func (s *flightService) validate(ctx context.Context, flight *models.Flight) error {
+	if flight.FlightNumber == "" {
+		return apperr.NewBadRequest("航班号不能为空")
+	}
+	if !flight.ArrivalTime.After(flight.DepartureTime) {
+		return apperr.NewBadRequest("到达时间必须晚于起飞时间")
+	}
+	if flight.DepartureID != nil && flight.ArrivalID != nil && *flight.DepartureID == *flight.ArrivalID {
+		return apperr.NewBadRequest("起飞机场与到达机场不能相同")
+	}
+
+	if flight.AirlineID != nil {
+		if _, err := s.airlineRepo.FindByID(ctx, *flight.AirlineID); err != nil {
+			return referenceError(err, "航空公司不存在")
+		}
+	}
+	if flight.AircraftID != nil {
+		aircraft, err := s.aircraftRepo.FindByID(ctx, *flight.AircraftID)
+		if err != nil {
+			return referenceError(err, "飞机不存在")
+		}
+		if aircraft.Status == models.AircraftStatusRetired {
+			return apperr.NewBadRequest("飞机已退役，不能执飞航班")
+		}
+	}
+	for _, airportID := range []*uuid.UUID{flight.DepartureID, flight.ArrivalID} {
+		if airportID == nil {
+			continue
+		}
+		if _, err := s.airportRepo.FindByID(ctx, *airportID); err != nil {
+			return referenceError(err, "机场不存在")
+		}
+	}
+	return nil
+}
+
+// resolveAirlineID 解析航空公司ID或代码，代码不存在时返回 uuid.Nil
+func (s *flightService) resolveAirlineID(ctx context.Context, idOrCode string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(idOrCode); err == nil {
+		return id, nil
+	}
+	airline, err := s.airlineRepo.FindByCode(ctx, idOrCode)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, apperr.NewInternalError(err)
+	}
+	return airline.ID, nil
+}
+
+func (s *flightService) findAirport(ctx context.Context, idOrCode string) (*models.Airport, error) {
+	if id, err := uuid.Parse(idOrCode); err == nil {
+		return s.airportRepo.FindByID(ctx, id)
+	}
+	return s.airportRepo.FindByCode(ctx, idOrCode)
+}
+
+// toBoardEntry 转换为显示屏条目，对端机场取目的地或出发地
+func toBoardEntry(flight *models.Flight, direction repositories.BoardDirection) dto.FlightBoardEntry {
+	scheduled, counterpart := flight.DepartureTime, flight.Arrival
+	if direction == repositories.BoardArrivals {
+		scheduled, counterpart = flight.ArrivalTime, flight.Departure
+	}
+
+	return dto.FlightBoardEntry{
+		FlightID:      flight.ID,
+		FlightNumber:  flight.FlightNumber,
+		Airline:       dto.ToAirlineBrief(flight.Airline),
+		Aircraft:      dto.ToAircraftBrief(flight.Aircraft),
+		Airport:       dto.ToAirportBrief(counterpart),
+		ScheduledTime: scheduled,
+		EstimatedTime: scheduled.Add(time.Duration(flight.DelayMinutes) * time.Minute),
+		Gate:          flight.Gate,
+		Terminal:      flight.Terminal,
+		Status:        flight.Status,
+		DelayMinutes:  flight.DelayMinutes,
+	}
+}
+
+// referenceError 将关联对象查询错误转换为应用错误
+func referenceError(err error, notFoundMessage string) error {
+	if errors.Is(err, repositories.ErrNotFound) {
+		return apperr.NewBadRequest(notFoundMessage)
+	}
+	return apperr.NewInternalError(err)
+}
+
+// splitStatuses 解析逗号分隔的状态列表
+func splitStatuses(raw string) []string {
+	var statuses []string
+	for _, status := range strings.Split(raw, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, strings.ToLower(status))
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFlightRepository 模拟航班仓储
+type MockFlightRepository struct {
+	mock.Mock
+}
+
+func (m *MockFlightRepository) Create(ctx context.Context, flight *models.Flight) error {
+	return m.Called(ctx, flight).Error(0)
+}
+
+func (m *MockFlightRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Flight, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func (m *MockFlightRepository) Update(ctx context.Context, flight *models.Flight) error {
+	return m.Called(ctx, flight).Error(0)
+}
+
+func (m *MockFlightRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockFlightRepository) List(ctx context.Context, filter repositories.FlightFilter) ([]models.Flight, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Flight), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockFlightRepository) Board(ctx context.Context, airportID uuid.UUID, direction repositories.BoardDirection, from, to time.Time, statuses []string, limit int) ([]models.Flight, error) {
+	args := m.Called(ctx, airportID, direction, from, to, statuses, limit)
+	return args.Get(0).([]models.Flight), args.Error(1)
+}
+
+func (m *MockFlightRepository) ChangeStatus(ctx context.Context, flight *models.Flight, fromStatus string, log *models.FlightStatusLog, history *models.FlightHistory) error {
+	return m.Called(ctx, flight, fromStatus, log, history).Error(0)
+}
+
+func (m *MockFlightRepository) ListStatusLogs(ctx context.Context, flightID uuid.UUID) ([]models.FlightStatusLog, error) {
+	args := m.Called(ctx, flightID)
+	return args.Get(0).([]models.FlightStatusLog), args.Error(1)
+}
+
+func (m *MockFlightRepository) FindActiveByNumber(ctx context.Context, flightNumber string, at time.Time) (*models.Flight, error) {
+	args := m.Called(ctx, flightNumber, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func (m *MockFlightRepository) FindActiveByAircraft(ctx context.Context, aircraftID uuid.UUID, at time.Time) (*models.Flight, error) {
+	args := m.Called(ctx, aircraftID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
+
+func (m *MockFlightRepository) UpdatePosition(ctx context.Context, flightID uuid.UUID, latitude, longitude, altitude, speed float64) error {
+	return m.Called(ctx, flightID, latitude, longitude, altitude, speed).Error(0)
+}
+
+// MockAirportRepository 模拟机场仓储
+type MockAirportRepository struct {
+	mock.Mock
+}
+
+func (m *MockAirportRepository) Create(ctx context.Context, airport *models.Airport) error {
+	return m.Called(ctx, airport).Error(0)
+}
+
+func (m *MockAirportRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Airport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Airport), args.Error(1)
+}
+
+func (m *MockAirportRepository) FindByCode(ctx context.Context, code string) (*models.Airport, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Airport), args.Error(1)
+}
+
+func (m *MockAirportRepository) Update(ctx context.Context, airport *models.Airport) error {
+	return m.Called(ctx, airport).Error(0)
+}
+
+func (m *MockAirportRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAirportRepository) List(ctx context.Context, filter repositories.AirportFilter) ([]models.Airport, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Airport), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAirportRepository) FindInBBox(ctx context.Context, box geo.BBox, filter repositories.AirportFilter) ([]models.Airport, error) {
+	args := m.Called(ctx, box, filter)
+	return args.Get(0).([]models.Airport), args.Error(1)
+}
+
+// MockAirlineRepository 模拟航空公司仓储
+type MockAirlineRepository struct {
+	mock.Mock
+}
+
+func (m *MockAirlineRepository) Create(ctx context.Context, airline *models.Airline) error {
+	return m.Called(ctx, airline).Error(0)
+}
+
+func (m *MockAirlineRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Airline, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Airline), args.Error(1)
+}
+
+func (m *MockAirlineRepository) FindByCode(ctx context.Context, code string) (*models.Airline, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Airline), args.Error(1)
+}
+
+func (m *MockAirlineRepository) Update(ctx context.Context, airline *models.Airline) error {
+	return m.Called(ctx, airline).Error(0)
+}
+
+func (m *MockAirlineRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAirlineRepository) List(ctx context.Context, filter repositories.AirlineFilter) ([]models.Airline, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.Airline), args.Get(1).(int64), args.Error(2)
+}
+
+func TestListFlightsParsesFilters(t *testing.T) {
+	ctx := context.Background()
+	flightRepo := new(MockFlightRepository)
+	airlineRepo := new(MockAirlineRepository)
+	service := NewFlightService(flightRepo, nil, airlineRepo, nil)
+
+	airline := &models.Airline{ID: uuid.New(), Code: "MU"}
+	airlineRepo.On("FindByCode", ctx, "MU").Return(airline, nil)
+
+	// date 与 from 取交集，状态列表去空格并转小写，航空公司代码解析为ID
+	date := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	dayEnd := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	flightRepo.On("List", ctx, repositories.FlightFilter{
+		FlightNumber: "MU51",
+		AirlineID:    &airline.ID,
+		Statuses:     []string{models.FlightStatusScheduled, models.FlightStatusDelayed},
+		From:         &from,
+		To:           &dayEnd,
+		Offset:       0,
+		Limit:        dto.DefaultPageSize,
+	}).Return([]models.Flight{{FlightNumber: "MU5101"}}, int64(1), nil)
+
+	result, err := service.ListFlights(ctx, &dto.FlightQuery{
+		FlightNumber: " MU51 ",
+		Airline:      "MU",
+		Status:       "Scheduled, DELAYED,",
+		Date:         &date,
+		From:         &from,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+	assert.Equal(t, 1, result.Page)
+	flightRepo.AssertExpectations(t)
+}
+
+func TestListFlightsUnknownAirlineAndInvalidRange(t *testing.T) {
+	ctx := context.Background()
+	flightRepo := new(MockFlightRepository)
+	airlineRepo := new(MockAirlineRepository)
+	service := NewFlightService(flightRepo, nil, airlineRepo, nil)
+
+	// 未知航空公司代码返回空结果，不查询航班
+	airlineRepo.On("FindByCode", ctx, "ZZ").Return(nil, repositories.ErrNotFound)
+	result, err := service.ListFlights(ctx, &dto.FlightQuery{Airline: "ZZ"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Items)
+	assert.Equal(t, int64(0), result.Total)
+
+	// date 与 to 的交集为空
+	date := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := date.Add(-time.Hour)
+	_, err = service.ListFlights(ctx, &dto.FlightQuery{Date: &date, To: &to})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+	flightRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
+func TestBoardDefaultWindowAndOrdering(t *testing.T) {
+	ctx := context.Background()
+	flightRepo := new(MockFlightRepository)
+	airportRepo := new(MockAirportRepository)
+	service := NewFlightService(flightRepo, airportRepo, nil, nil)
+
+	airport := &models.Airport{ID: uuid.New(), Code: "PVG", Name: "上海浦东"}
+	airportRepo.On("FindByCode", ctx, "PVG").Return(airport, nil)
+
+	// 仓储按预计时间排序：计划时间早于窗口的长时间延误航班排在按时航班之后
+	now := time.Now()
+	flights := []models.Flight{
+		{ID: uuid.New(), FlightNumber: "MU5101", DepartureTime: now.Add(30 * time.Minute), Status: models.FlightStatusScheduled},
+		{ID: uuid.New(), FlightNumber: "CA1501", DepartureTime: now.Add(-3 * time.Hour), DelayMinutes: 240, Status: models.FlightStatusDelayed},
+	}
+	var from, to time.Time
+	flightRepo.On("Board", ctx, airport.ID, repositories.BoardDepartures, mock.Anything, mock.Anything, []string(nil), defaultBoardLimit).
+		Run(func(args mock.Arguments) {
+			from, to = args.Get(3).(time.Time), args.Get(4).(time.Time)
+		}).Return(flights, nil)
+
+	resp, err := service.Board(ctx, "PVG", repositories.BoardDepartures, &dto.FlightBoardQuery{})
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(-defaultBoardLookBehind), from, time.Second)
+	assert.WithinDuration(t, now.Add(defaultBoardLookAhead), to, time.Second)
+	assert.Equal(t, from, resp.From)
+	assert.Equal(t, to, resp.To)
+	assert.Equal(t, "PVG", resp.Airport.Code)
+	if assert.Len(t, resp.Flights, 2) {
+		assert.Equal(t, "MU5101", resp.Flights[0].FlightNumber)
+		assert.Equal(t, "CA1501", resp.Flights[1].FlightNumber)
+		assert.Equal(t, flights[1].DepartureTime, resp.Flights[1].ScheduledTime)
+		assert.Equal(t, flights[1].DepartureTime.Add(4*time.Hour), resp.Flights[1].EstimatedTime)
+	}
+}
+
+func TestBoardCustomWindow(t *testing.T) {
+	ctx := context.Background()
+	flightRepo := new(MockFlightRepository)
+	airportRepo := new(MockAirportRepository)
+	service := NewFlightService(flightRepo, airportRepo, nil, nil)
+
+	airport := &models.Airport{ID: uuid.New(), Code: "PVG"}
+	airportRepo.On("FindByID", ctx, airport.ID).Return(airport, nil)
+	airportRepo.On("FindByCode", ctx, "XXX").Return(nil, repositories.ErrNotFound)
+
+	from := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	to := from.Add(4 * time.Hour)
+	flightRepo.On("Board", ctx, airport.ID, repositories.BoardArrivals, from, to, []string{models.FlightStatusDelayed}, 20).
+		Return([]models.Flight{}, nil)
+
+	resp, err := service.Board(ctx, airport.ID.String(), repositories.BoardArrivals,
+		&dto.FlightBoardQuery{From: &from, To: &to, Status: "Delayed", Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, "arrivals", resp.Direction)
+	assert.Empty(t, resp.Flights)
+
+	_, err = service.Board(ctx, airport.ID.String(), repositories.BoardArrivals, &dto.FlightBoardQuery{From: &to, To: &from})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	_, err = service.Board(ctx, "XXX", repositories.BoardDepartures, &dto.FlightBoardQuery{})
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+	flightRepo.AssertExpectations(t)
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// flightTransitions 允许的航班状态迁移
+// arrived 与 cancelled 为终态；delayed -> delayed 用于更新延误时长
+var flightTransitions = map[string][]string{
+	models.FlightStatusScheduled: {models.FlightStatusBoarding, models.FlightStatusDelayed, models.FlightStatusDeparted, models.FlightStatusCancelled},
+	models.FlightStatusDelayed:   {models.FlightStatusScheduled, models.FlightStatusDelayed, models.FlightStatusBoarding, models.FlightStatusDeparted, models.FlightStatusCancelled},
+	models.FlightStatusBoarding:  {models.FlightStatusDelayed, models.FlightStatusDeparted, models.FlightStatusCancelled},
+	models.FlightStatusDeparted:  {models.FlightStatusArrived},
+}
+
+// ChangeStatus 按状态机变更航班状态，记录操作人，到达或取消时写入航班历史
+func (s *flightService) ChangeStatus(ctx context.Context, id uuid.UUID, req *dto.FlightStatusRequest, actor Actor) (*models.Flight, error) {
+	flight, err := s.GetFlight(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	occurredAt := time.Now()
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	from := flight.Status
+	if err := applyFlightTransition(flight, req, occurredAt); err != nil {
+		return nil, err
+	}
+
+	log := &models.FlightStatusLog{
+		FlightID:     flight.ID,
+		FromStatus:   from,
+		ToStatus:     flight.Status,
+		DelayMinutes: flight.DelayMinutes,
+		Reason:       req.Reason,
+		ActorID:      actor.UserID,
+		ActorName:    actor.Username,
+		OccurredAt:   occurredAt,
+	}
+
+	var history *models.FlightHistory
+	if flight.Status == models.FlightStatusArrived || flight.Status == models.FlightStatusCancelled {
+		history = buildFlightHistory(flight, req.Reason)
+	}
+
+	if err := s.repo.ChangeStatus(ctx, flight, from, log, history); err != nil {
+		if errors.Is(err, repositories.ErrStaleState) {
+			return nil, apperr.NewConflict("航班状态已被其他操作修改，请刷新后重试")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return flight, nil
+}
+
+// ListStatusLogs 查询航班状态变更记录
+func (s *flightService) ListStatusLogs(ctx context.Context, id uuid.UUID) ([]models.FlightStatusLog, error) {
+	if _, err := s.GetFlight(ctx, id); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.ListStatusLogs(ctx, id)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return logs, nil
+}
+
+// applyFlightTransition 校验迁移并更新航班的状态、延误和实际时间
+// 起飞和到达时按实际时间与计划时间之差重新计算延误分钟数
+func applyFlightTransition(flight *models.Flight, req *dto.FlightStatusRequest, occurredAt time.Time) error {
+	target := req.Status
+	if !canTransitFlight(flight.Status, target) {
+		return apperr.NewConflict(fmt.Sprintf("航班状态不允许从 %s 变更为 %s", flight.Status, target))
+	}
+
+	switch target {
+	case models.FlightStatusDelayed:
+		if req.DelayMinutes == nil || *req.DelayMinutes <= 0 {
+			return apperr.NewBadRequest("延误状态必须提供大于 0 的延误分钟数")
+		}
+		flight.DelayMinutes = *req.DelayMinutes
+	case models.FlightStatusScheduled:
+		flight.DelayMinutes = 0
+	case models.FlightStatusDeparted:
+		flight.ActualDepartureTime = &occurredAt
+		flight.DelayMinutes = delayMinutes(flight.DepartureTime, occurredAt)
+	case models.FlightStatusArrived:
+		if flight.ActualDepartureTime != nil && !occurredAt.After(*flight.ActualDepartureTime) {
+			return apperr.NewBadRequest("到达时间必须晚于实际起飞时间")
+		}
+		flight.ActualArrivalTime = &occurredAt
+		flight.DelayMinutes = delayMinutes(flight.ArrivalTime, occurredAt)
+	}
+
+	flight.Status = target
+	return nil
+}
+
+// buildFlightHistory 根据航班终态生成历史记录
+func buildFlightHistory(flight *models.Flight, reason string) *models.FlightHistory {
+	scheduledDeparture, scheduledArrival := flight.DepartureTime, flight.ArrivalTime
+	delay := flight.DelayMinutes
+	flightDate := scheduledDeparture.UTC()
+
+	history := &models.FlightHistory{
+		FlightNumber:       flight.FlightNumber,
+		FlightDate:         time.Date(flightDate.Year(), flightDate.Month(), flightDate.Day(), 0, 0, 0, 0, time.UTC),
+		AircraftID:         flight.AircraftID,
+		ScheduledDeparture: &scheduledDeparture,
+		ActualDeparture:    flight.ActualDepartureTime,
+		ScheduledArrival:   &scheduledArrival,
+		ActualArrival:      flight.ActualArrivalTime,
+		DelayMinutes:       &delay,
+		Status:             flight.Status,
+	}
+	if flight.Airline != nil {
+		history.AirlineCode = flight.Airline.Code
+	}
+	if flight.Departure != nil {
+		history.DepartureAirport = flight.Departure.Code
+	}
+	if flight.Arrival != nil {
+		history.ArrivalAirport = flight.Arrival.Code
+	}
+	if flight.Status == models.FlightStatusCancelled && reason != "" {
+		history.CancellationReason = &reason
+	}
+	return history
+}
+
+// delayMinutes 计算实际时间相对计划时间的延误分钟数，提前视为 0
+func delayMinutes(scheduled, actual time.Time) int {
+	if !actual.After(scheduled) {
+		return 0
+	}
+	return int(actual.Sub(scheduled).Minutes())
+}
+
+func canTransitFlight(from, to string) bool {
+	for _, allowed := range flightTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFlight(status string) *models.Flight {
+	departure := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	return &models.Flight{
+		FlightNumber:  "CA1234",
+		Status:        status,
+		DepartureTime: departure,
+		ArrivalTime:   departure.Add(2 * time.Hour),
+		Departure:     &models.Airport{Code: "PEK"},
+		Arrival:       &models.Airport{Code: "SHA"},
+	}
+}
+
+func TestApplyFlightTransitionRejectsIllegal(t *testing.T) {
+	cases := []struct{ from, to string }{
+		{models.FlightStatusArrived, models.FlightStatusBoarding},
+		{models.FlightStatusCancelled, models.FlightStatusScheduled},
+		{models.FlightStatusDeparted, models.FlightStatusBoarding},
+		{models.FlightStatusScheduled, models.FlightStatusArrived},
+	}
+
+	for _, tc := range cases {
+		flight := newTestFlight(tc.from)
+		err := applyFlightTransition(flight, &dto.FlightStatusRequest{Status: tc.to}, time.Now())
+		assert.Error(t, err, "%s -> %s", tc.from, tc.to)
+		assert.Equal(t, tc.from, flight.Status)
+	}
+}
+
+func TestApplyFlightTransitionDelay(t *testing.T) {
+	flight := newTestFlight(models.FlightStatusScheduled)
+
+	err := applyFlightTransition(flight, &dto.FlightStatusRequest{Status: models.FlightStatusDelayed}, time.Now())
+	assert.Error(t, err)
+
+	delay := 45
+	err = applyFlightTransition(flight, &dto.FlightStatusRequest{Status: models.FlightStatusDelayed, DelayMinutes: &delay}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, models.FlightStatusDelayed, flight.Status)
+	assert.Equal(t, 45, flight.DelayMinutes)
+}
+
+func TestApplyFlightTransitionFullLifecycle(t *testing.T) {
+	flight := newTestFlight(models.FlightStatusScheduled)
+
+	assert.NoError(t, applyFlightTransition(flight, &dto.FlightStatusRequest{Status: models.FlightStatusBoarding}, flight.DepartureTime.Add(-30*time.Minute)))
+
+	departedAt := flight.DepartureTime.Add(20 * time.Minute)
+	assert.NoError(t, applyFlightTransition(flight, &dto.FlightStatusRequest{Status: models.FlightStatusDeparted}, departedAt))
+	assert.Equal(t, 20, flight.DelayMinutes)
+	assert.Equal(t, departedAt, *flight.ActualDepartureTime)
+
+	arrivedAt := flight.ArrivalTime.Add(5 * time.Minute)
+	assert.NoError(t, applyFlightTransition(flight, &dto.FlightStatusRequest{Status: models.FlightStatusArrived}, arrivedAt))
+	assert.Equal(t, 5, flight.DelayMinutes)
+
+	history := buildFlightHistory(flight, "")
+	assert.Equal(t, "PEK", history.DepartureAirport)
+	assert.Equal(t, "SHA", history.ArrivalAirport)
+	assert.Equal(t, models.FlightStatusArrived, history.Status)
+	assert.Equal(t, arrivedAt, *history.ActualArrival)
+	assert.Equal(t, 5, *history.DelayMinutes)
+	assert.Nil(t, history.CancellationReason)
+}
+
+func TestBuildFlightHistoryCancelled(t *testing.T) {
+	flight := newTestFlight(models.FlightStatusScheduled)
+	assert.NoError(t, applyFlightTransition(flight, &dto.FlightStatusRequest{Status: models.FlightStatusCancelled}, time.Now()))
+
+	history := buildFlightHistory(flight, "天气原因")
+	assert.Equal(t, models.FlightStatusCancelled, history.Status)
+	assert.Nil(t, history.ActualDeparture)
+	assert.Equal(t, "天气原因", *history.CancellationReason)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// defaultTrackMaxPoints 航迹回放默认返回的最大点数
+const defaultTrackMaxPoints = 1000
+
+// Track 查询航班航迹，点数超过 max_points 时降采样
+func (s *flightPositionService) Track(ctx context.Context, flightID uuid.UUID, query *dto.TrackQuery) (*dto.TrackResponse, error) {
+	if query.From != nil && query.To != nil && query.To.Before(*query.From) {
+		return nil, apperr.NewBadRequest("结束时间不能早于开始时间")
+	}
+
+	flight, err := s.flightRepo.FindByID(ctx, flightID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("航班不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+
+	positions, err := s.repo.ListByFlight(ctx, flightID, query.From, query.To)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	maxPoints := query.MaxPoints
+	if maxPoints <= 0 {
+		maxPoints = defaultTrackMaxPoints
+	}
+	method := defaultString(query.Method, dto.TrackMethodDouglasPeucker)
+
+	var indices []int
+	if method == dto.TrackMethodTimeBucket {
+		indices = bucketSample(positions, maxPoints)
+	} else {
+		points := make([]geo.LatLng, len(positions))
+		for i := range positions {
+			points[i] = geo.LatLng{Lat: positions[i].Latitude, Lng: positions[i].Longitude}
+		}
+		indices = geo.SimplifyToCount(points, maxPoints)
+	}
+
+	track := &dto.TrackResponse{
+		FlightID:     flight.ID,
+		FlightNumber: flight.FlightNumber,
+		From:         query.From,
+		To:           query.To,
+		TotalPoints:  len(positions),
+		Method:       method,
+		Points:       make([]dto.TrackPoint, len(indices)),
+	}
+	for i, index := range indices {
+		track.Points[i] = toTrackPoint(&positions[index])
+	}
+	return track, nil
+}
+
+// bucketSample 按时间等分降采样，每个时间桶保留最后一个点，并始终保留首尾点
+// 返回保留点的下标（升序），数量不超过 maxPoints
+func bucketSample(positions []models.FlightPosition, maxPoints int) []int {
+	n := len(positions)
+	if maxPoints < 2 {
+		maxPoints = 2
+	}
+	if n <= maxPoints {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	start := positions[0].Timestamp
+	span := positions[n-1].Timestamp.Sub(start)
+	if span <= 0 {
+		return []int{0, n - 1}
+	}
+
+	// 首点单独保留，其余点分入 maxPoints-1 个桶
+	buckets := maxPoints - 1
+	bucketOf := func(i int) int {
+		b := int(float64(positions[i].Timestamp.Sub(start)) / float64(span) * float64(buckets))
+		if b >= buckets {
+			b = buckets - 1
+		}
+		return b
+	}
+
+	indices := make([]int, 0, maxPoints)
+	indices = append(indices, 0)
+	for i := 1; i < n; i++ {
+		if i == n-1 || bucketOf(i+1) != bucketOf(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// toTrackPoint 转换为航迹点
+func toTrackPoint(position *models.FlightPosition) dto.TrackPoint {
+	return dto.TrackPoint{
+		Latitude:      position.Latitude,
+		Longitude:     position.Longitude,
+		Altitude:      position.Altitude,
+		Speed:         position.Speed,
+		Heading:       position.Heading,
+		VerticalSpeed: position.VerticalSpeed,
+		Timestamp:     position.Timestamp,
+	}
+}
@@ -0,0 +1,390 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// IncidentDetectionService 遥测异常事件检测服务接口
+// 检测到的异常以草稿事件开立，由人工确认或驳回；同一无人机同一规则在去重窗口内只开立一个事件
+type IncidentDetectionService interface {
+	// DetectTelemetry 结合此前一段历史轨迹分析新上报的位置点，检测高度骤降、飞走、遥测中断和定位崩溃
+	DetectTelemetry(ctx context.Context, drone *models.Drone, mission *models.DroneMission, track []models.DronePosition) ([]models.DroneIncident, error)
+	// ReportZoneViolation 为闯入禁飞区的位置点开立违规事件草稿
+	ReportZoneViolation(ctx context.Context, drone *models.Drone, position *models.DronePosition, zones []string) (*models.DroneIncident, error)
+	// DetectSignalLoss 为在空中停止上报遥测超过阈值的无人机开立信号丢失事件草稿，返回开立数量
+	DetectSignalLoss(ctx context.Context, now time.Time) (int, error)
+}
+
+// 遥测异常判定阈值
+const (
+	crashAltitudeDrop = 30    // 相邻位置点高度下降不低于该值（米）
+	crashDescentRate  = 10.0  // 且平均下降速率不低于该值（m/s）视为高度骤降
+	flyawayDistance   = 500.0 // 持续远离期间距起飞点增加的最小距离（米）
+	flyawayTolerance  = 10.0  // 远离判定允许的定位抖动（米）
+	gpsAccuracyLimit  = 10.0  // 水平定位精度劣于该值（米）视为定位崩溃
+	gpsMinSatellites  = 6     // 卫星数少于该值视为定位崩溃
+)
+
+const (
+	flyawayDuration     = time.Minute      // 持续远离起飞点的最短时间
+	detectionLookback   = 3 * time.Minute  // 检测时向前加载的历史轨迹
+	incidentDedupWindow = 30 * time.Minute // 同一无人机同一规则在该时间内只开立一个事件
+	signalLossLookback  = time.Hour        // 定时检测只处理该时间内停止上报的无人机
+)
+
+// telemetryAnomaly 检测到的遥测异常，position 为事件发生位置
+type telemetryAnomaly struct {
+	rule         string
+	incidentType string
+	severity     string
+	position     *models.DronePosition
+	description  string
+}
+
+type incidentDetectionService struct {
+	repo         repositories.DroneIncidentRepository
+	positionRepo repositories.DronePositionRepository
+	droneRepo    repositories.DroneRepository
+	missionRepo  repositories.DroneMissionRepository
+}
+
+// NewIncidentDetectionService 创建遥测异常事件检测服务实例
+func NewIncidentDetectionService(
+	repo repositories.DroneIncidentRepository,
+	positionRepo repositories.DronePositionRepository,
+	droneRepo repositories.DroneRepository,
+	missionRepo repositories.DroneMissionRepository,
+) IncidentDetectionService {
+	return &incidentDetectionService{
+		repo:         repo,
+		positionRepo: positionRepo,
+		droneRepo:    droneRepo,
+		missionRepo:  missionRepo,
+	}
+}
+
+// DetectTelemetry 检测新上报轨迹中的异常，track 需按时间升序且已入库
+func (s *incidentDetectionService) DetectTelemetry(ctx context.Context, drone *models.Drone, mission *models.DroneMission, track []models.DronePosition) ([]models.DroneIncident, error) {
+	if len(track) == 0 {
+		return nil, nil
+	}
+	droneID := drone.ID
+	since := track[0].Timestamp
+	from, to := since.Add(-detectionLookback), track[len(track)-1].Timestamp
+	positions, err := s.positionRepo.List(ctx, repositories.DronePositionFilter{DroneID: &droneID, From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, drone, detectTelemetryAnomalies(drone, mission, positions, since))
+}
+
+// ReportZoneViolation 开立禁飞区违规事件草稿，去重窗口内已开立时返回 nil
+func (s *incidentDetectionService) ReportZoneViolation(ctx context.Context, drone *models.Drone, position *models.DronePosition, zones []string) (*models.DroneIncident, error) {
+	incidents, err := s.open(ctx, drone, []telemetryAnomaly{*zoneViolationAnomaly(drone, position, zones)})
+	if err != nil || len(incidents) == 0 {
+		return nil, err
+	}
+	return &incidents[0], nil
+}
+
+// DetectSignalLoss 以无人机最近一次上报的位置开立信号丢失事件草稿
+func (s *incidentDetectionService) DetectSignalLoss(ctx context.Context, now time.Time) (int, error) {
+	drones, err := s.droneRepo.ListSilentAirborne(ctx, airborneAltitude, now.Add(-signalLossLookback), now.Add(-telemetryGap))
+	if err != nil {
+		return 0, apperr.NewInternalError(err)
+	}
+
+	opened := 0
+	for i := range drones {
+		drone := &drones[i]
+		if drone.LastLatitude == nil || drone.LastLongitude == nil || drone.LastAltitude == nil || drone.LastUpdateTime == nil {
+			continue
+		}
+		position := &models.DronePosition{
+			DroneID:   drone.ID,
+			Latitude:  *drone.LastLatitude,
+			Longitude: *drone.LastLongitude,
+			Altitude:  int(math.Round(*drone.LastAltitude)),
+			Timestamp: *drone.LastUpdateTime,
+		}
+		mission, err := s.missionRepo.FindActiveByDrone(ctx, drone.ID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return opened, apperr.NewInternalError(err)
+		}
+		if mission != nil {
+			position.MissionID = &mission.ID
+		}
+
+		silence := now.Sub(position.Timestamp).Round(time.Second)
+		incidents, err := s.open(ctx, drone, []telemetryAnomaly{*signalLostAnomaly(drone, position, silence)})
+		if err != nil {
+			return opened, apperr.NewInternalError(err)
+		}
+		opened += len(incidents)
+	}
+	return opened, nil
+}
+
+// open 为异常开立草稿事件，去重窗口内同一规则已有事件（含已驳回）时跳过
+func (s *incidentDetectionService) open(ctx context.Context, drone *models.Drone, anomalies []telemetryAnomaly) ([]models.DroneIncident, error) {
+	var opened []models.DroneIncident
+	for i := range anomalies {
+		anomaly := &anomalies[i]
+		droneID := drone.ID
+		from := anomaly.position.Timestamp.Add(-incidentDedupWindow)
+		_, total, err := s.repo.List(ctx, repositories.DroneIncidentFilter{
+			DroneID:       &droneID,
+			DetectionRule: anomaly.rule,
+			From:          &from,
+			Limit:         1,
+		})
+		if err != nil {
+			return opened, err
+		}
+		if total > 0 {
+			continue
+		}
+
+		incident := newDraftIncident(drone, anomaly)
+		if err := s.repo.Create(ctx, incident); err != nil {
+			return opened, err
+		}
+		opened = append(opened, *incident)
+	}
+	return opened, nil
+}
+
+// RunSignalLossDetection 按固定间隔检测空中失联的无人机，启动时立即执行一次，ctx 取消后退出
+func RunSignalLossDetection(ctx context.Context, service IncidentDetectionService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := service.DetectSignalLoss(ctx, time.Now()); err != nil {
+			logger.Errorf("[IncidentDetection] 信号丢失检测失败: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newDraftIncident 由异常生成草稿事件，关联无人机、所属运营商和位置点所属任务
+func newDraftIncident(drone *models.Drone, anomaly *telemetryAnomaly) *models.DroneIncident {
+	position := anomaly.position
+	altitude := position.Altitude
+	rule := anomaly.rule
+	return &models.DroneIncident{
+		DroneID:             &drone.ID,
+		MissionID:           position.MissionID,
+		OperatorID:          drone.OperatorID,
+		IncidentType:        anomaly.incidentType,
+		Severity:            anomaly.severity,
+		IncidentDate:        position.Timestamp,
+		Location:            locationJSON(position),
+		Altitude:            &altitude,
+		Description:         anomaly.description,
+		DetectionRule:       &rule,
+		InvestigationStatus: models.InvestigationStatusDraft,
+	}
+}
+
+// detectTelemetryAnomalies 分析按时间升序的轨迹，只报告 since 及之后的位置点引发的异常，每条规则至多一个
+func detectTelemetryAnomalies(drone *models.Drone, mission *models.DroneMission, positions []models.DronePosition, since time.Time) []telemetryAnomaly {
+	var anomalies []telemetryAnomaly
+	detected := make(map[string]bool)
+	add := func(anomaly *telemetryAnomaly) {
+		if anomaly != nil && !detected[anomaly.rule] {
+			detected[anomaly.rule] = true
+			anomalies = append(anomalies, *anomaly)
+		}
+	}
+
+	for i := 1; i < len(positions); i++ {
+		prev, cur := &positions[i-1], &positions[i]
+		if cur.Timestamp.Before(since) || !isAirborne(prev) {
+			continue
+		}
+		add(altitudeLossAnomaly(drone, prev, cur))
+		add(telemetryGapAnomaly(drone, prev, cur))
+		add(gpsDegradedAnomaly(drone, prev, cur))
+	}
+	if n := len(positions); n > 0 && !positions[n-1].Timestamp.Before(since) {
+		add(flyawayAnomaly(drone, mission, positions))
+	}
+	return anomalies
+}
+
+// altitudeLossAnomaly 相邻位置点间高度骤降，疑似坠机；降至地面时为 critical
+func altitudeLossAnomaly(drone *models.Drone, prev, cur *models.DronePosition) *telemetryAnomaly {
+	dt := cur.Timestamp.Sub(prev.Timestamp)
+	drop := prev.Altitude - cur.Altitude
+	if dt <= 0 || dt > telemetryGap || drop < crashAltitudeDrop {
+		return nil
+	}
+	rate := float64(drop) / dt.Seconds()
+	if rate < crashDescentRate {
+		return nil
+	}
+
+	severity := models.IncidentSeveritySerious
+	if cur.Altitude <= landedAltitude {
+		severity = models.IncidentSeverityCritical
+	}
+	return &telemetryAnomaly{
+		rule:         models.IncidentDetectionAltitudeLoss,
+		incidentType: models.IncidentTypeCrash,
+		severity:     severity,
+		position:     cur,
+		description: fmt.Sprintf("遥测检测：无人机 %s 在 %.0f 秒内由 %d 米降至 %d 米（%.1f m/s），疑似坠机",
+			drone.SerialNumber, dt.Seconds(), prev.Altitude, cur.Altitude, rate),
+	}
+}
+
+// telemetryGapAnomaly 空中遥测中断后恢复，事件位置为中断前最后一个位置点
+func telemetryGapAnomaly(drone *models.Drone, prev, cur *models.DronePosition) *telemetryAnomaly {
+	gap := cur.Timestamp.Sub(prev.Timestamp)
+	if gap <= telemetryGap {
+		return nil
+	}
+	return signalLostAnomaly(drone, prev, gap)
+}
+
+// signalLostAnomaly 空中失去遥测 silence 时长
+func signalLostAnomaly(drone *models.Drone, position *models.DronePosition, silence time.Duration) *telemetryAnomaly {
+	return &telemetryAnomaly{
+		rule:         models.IncidentDetectionSignalLost,
+		incidentType: models.IncidentTypeMalfunction,
+		severity:     models.IncidentSeverityModerate,
+		position:     position,
+		description: fmt.Sprintf("遥测检测：无人机 %s 在 %d 米高度失去遥测信号 %s",
+			drone.SerialNumber, position.Altitude, silence),
+	}
+}
+
+// gpsDegradedAnomaly 定位由正常转为精度崩溃
+func gpsDegradedAnomaly(drone *models.Drone, prev, cur *models.DronePosition) *telemetryAnomaly {
+	if !isAirborne(cur) || !gpsHealthy(prev) || !gpsDegraded(cur) {
+		return nil
+	}
+	details := make([]string, 0, 2)
+	if cur.GpsAccuracy != nil {
+		details = append(details, fmt.Sprintf("水平精度 %.1f 米", *cur.GpsAccuracy))
+	}
+	if cur.GpsSatellites != nil {
+		details = append(details, fmt.Sprintf("卫星数 %d", *cur.GpsSatellites))
+	}
+	return &telemetryAnomaly{
+		rule:         models.IncidentDetectionGpsDegraded,
+		incidentType: models.IncidentTypeMalfunction,
+		severity:     models.IncidentSeverityModerate,
+		position:     cur,
+		description: fmt.Sprintf("遥测检测：无人机 %s 飞行中定位精度崩溃，%s",
+			drone.SerialNumber, strings.Join(details, "，")),
+	}
+}
+
+func gpsDegraded(position *models.DronePosition) bool {
+	return (position.GpsAccuracy != nil && *position.GpsAccuracy > gpsAccuracyLimit) ||
+		(position.GpsSatellites != nil && *position.GpsSatellites < gpsMinSatellites)
+}
+
+func gpsHealthy(position *models.DronePosition) bool {
+	return (position.GpsAccuracy != nil || position.GpsSatellites != nil) && !gpsDegraded(position)
+}
+
+// flyawayAnomaly 轨迹末尾持续远离起飞点：参照点为任务起飞点，没有任务时为本段连续飞行的起点
+// 仍在任务飞行区域内或正在接近任务降落点时不视为飞走
+func flyawayAnomaly(drone *models.Drone, mission *models.DroneMission, positions []models.DronePosition) *telemetryAnomaly {
+	n := len(positions)
+	last := &positions[n-1]
+	if !isAirborne(last) {
+		return nil
+	}
+
+	// 本段连续飞行的起点
+	first := n - 1
+	for first > 0 && isAirborne(&positions[first-1]) && positions[first].Timestamp.Sub(positions[first-1].Timestamp) <= telemetryGap {
+		first--
+	}
+	home := geo.LatLng{Lat: positions[first].Latitude, Lng: positions[first].Longitude}
+	if mission != nil {
+		if departure, ok := missionLocation(mission.DepartureLocation); ok {
+			home = departure
+		}
+	}
+	distance := func(p *models.DronePosition) float64 {
+		return geo.Haversine(home.Lat, home.Lng, p.Latitude, p.Longitude)
+	}
+
+	// 自末尾向前查找持续远离的起点
+	start := n - 1
+	for start > first && distance(&positions[start]) >= distance(&positions[start-1])-flyawayTolerance {
+		start--
+	}
+	origin := &positions[start]
+	gained := distance(last) - distance(origin)
+	if last.Timestamp.Sub(origin.Timestamp) < flyawayDuration || gained < flyawayDistance {
+		return nil
+	}
+
+	if mission != nil {
+		point := geo.LatLng{Lat: last.Latitude, Lng: last.Longitude}
+		if mission.FlightArea != nil && mission.FlightArea.IsArea() && mission.FlightArea.ContainsPoint(point) {
+			return nil
+		}
+		if mission.ArrivalLocation != nil {
+			if arrival, ok := missionLocation(*mission.ArrivalLocation); ok {
+				before := geo.Haversine(arrival.Lat, arrival.Lng, origin.Latitude, origin.Longitude)
+				after := geo.Haversine(arrival.Lat, arrival.Lng, last.Latitude, last.Longitude)
+				if after < before-flyawayTolerance {
+					return nil
+				}
+			}
+		}
+	}
+
+	return &telemetryAnomaly{
+		rule:         models.IncidentDetectionFlyaway,
+		incidentType: models.IncidentTypeFlyaway,
+		severity:     models.IncidentSeveritySerious,
+		position:     last,
+		description: fmt.Sprintf("遥测检测：无人机 %s 持续远离起飞点 %.0f 秒，距离增加 %.0f 米至 %.0f 米，未见返航",
+			drone.SerialNumber, last.Timestamp.Sub(origin.Timestamp).Seconds(), gained, distance(last)),
+	}
+}
+
+// zoneViolationAnomaly 闯入禁飞区
+func zoneViolationAnomaly(drone *models.Drone, position *models.DronePosition, zones []string) *telemetryAnomaly {
+	return &telemetryAnomaly{
+		rule:         models.IncidentDetectionNoFlyZone,
+		incidentType: models.IncidentTypeViolation,
+		severity:     models.IncidentSeveritySerious,
+		position:     position,
+		description: fmt.Sprintf("遥测检测：无人机 %s 在 %d 米高度闯入禁飞区 %s",
+			drone.SerialNumber, position.Altitude, strings.Join(zones, "、")),
+	}
+}
+
+// missionLocation 解析任务起降点，无效时返回 false
+func missionLocation(data string) (geo.LatLng, bool) {
+	var location dto.MissionLocation
+	if err := json.Unmarshal([]byte(data), &location); err != nil {
+		return geo.LatLng{}, false
+	}
+	return geo.LatLng{Lat: location.Lat, Lng: location.Lng}, true
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"backend/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStraightTrack 生成自 (31.2, 121.45) 向北每 interval 前进约 100 米的空中轨迹
+func newStraightTrack(droneID uuid.UUID, count int, interval time.Duration) []models.DronePosition {
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	track := make([]models.DronePosition, count)
+	for i := range track {
+		track[i] = models.DronePosition{
+			DroneID:   droneID,
+			Latitude:  31.2 + 0.0009*float64(i),
+			Longitude: 121.45,
+			Altitude:  120,
+			Timestamp: start.Add(time.Duration(i) * interval),
+		}
+	}
+	return track
+}
+
+func anomalyRules(anomalies []telemetryAnomaly) []string {
+	rules := make([]string, len(anomalies))
+	for i, anomaly := range anomalies {
+		rules[i] = anomaly.rule
+	}
+	return rules
+}
+
+func TestDetectAltitudeLoss(t *testing.T) {
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-001"}
+	track := newStraightTrack(drone.ID, 4, 5*time.Second)
+	track[2].Altitude = 60 // 5 秒下降 60 米
+	track[3].Altitude = 0
+
+	anomalies := detectTelemetryAnomalies(drone, nil, track, track[0].Timestamp)
+	require.Equal(t, []string{models.IncidentDetectionAltitudeLoss}, anomalyRules(anomalies))
+	assert.Equal(t, models.IncidentTypeCrash, anomalies[0].incidentType)
+	assert.Equal(t, models.IncidentSeveritySerious, anomalies[0].severity)
+	assert.Equal(t, track[2].Timestamp, anomalies[0].position.Timestamp)
+
+	// 直接坠地为 critical
+	track[2].Altitude = 120
+	anomalies = detectTelemetryAnomalies(drone, nil, track, track[0].Timestamp)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, models.IncidentSeverityCritical, anomalies[0].severity)
+
+	// 只检测新上报的位置点
+	assert.Empty(t, detectTelemetryAnomalies(drone, nil, track, track[3].Timestamp.Add(time.Second)))
+
+	// 缓慢下降不报
+	track[3].Altitude = 90
+	assert.Empty(t, detectTelemetryAnomalies(drone, nil, track, track[0].Timestamp))
+}
+
+func TestDetectSignalGapAndGpsCollapse(t *testing.T) {
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-002"}
+	track := newStraightTrack(drone.ID, 3, 5*time.Second)
+	accuracy, satellites := []float64{1.5, 1.8, 25}, []int{14, 13, 4}
+	for i := range track {
+		track[i].GpsAccuracy = &accuracy[i]
+		track[i].GpsSatellites = &satellites[i]
+	}
+	track[2].Timestamp = track[1].Timestamp.Add(45 * time.Second)
+
+	anomalies := detectTelemetryAnomalies(drone, nil, track, track[1].Timestamp)
+	require.Equal(t, []string{models.IncidentDetectionSignalLost, models.IncidentDetectionGpsDegraded}, anomalyRules(anomalies))
+	// 信号丢失位置取中断前的最后一个点
+	assert.Equal(t, track[1].Timestamp, anomalies[0].position.Timestamp)
+	assert.Equal(t, models.IncidentTypeMalfunction, anomalies[1].incidentType)
+	assert.Contains(t, anomalies[1].description, "卫星数 4")
+
+	// 地面上的中断和定位不良不报
+	for i := range track {
+		track[i].Altitude = 0
+	}
+	assert.Empty(t, detectTelemetryAnomalies(drone, nil, track, track[1].Timestamp))
+}
+
+func TestDetectFlyaway(t *testing.T) {
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-003"}
+	track := newStraightTrack(drone.ID, 8, 10*time.Second)
+	last := len(track) - 1
+
+	anomalies := detectTelemetryAnomalies(drone, nil, track, track[last].Timestamp)
+	require.Equal(t, []string{models.IncidentDetectionFlyaway}, anomalyRules(anomalies))
+	assert.Equal(t, models.IncidentSeveritySerious, anomalies[0].severity)
+	assert.Equal(t, track[last].Timestamp, anomalies[0].position.Timestamp)
+
+	// 远离时间不足
+	assert.Empty(t, detectTelemetryAnomalies(drone, nil, track[:5], track[4].Timestamp))
+
+	// 折返后重新计时
+	turned := append([]models.DronePosition{}, track...)
+	turned[4].Latitude = turned[1].Latitude
+	assert.Empty(t, detectTelemetryAnomalies(drone, nil, turned, turned[last].Timestamp))
+
+	// 飞向任务降落点
+	arrival := `{"lat":31.3,"lng":121.45}`
+	mission := &models.DroneMission{DepartureLocation: `{"lat":31.2,"lng":121.45}`, ArrivalLocation: &arrival}
+	assert.Empty(t, detectTelemetryAnomalies(drone, mission, track, track[last].Timestamp))
+
+	// 仍在任务飞行区域内
+	mission = &models.DroneMission{DepartureLocation: `{"lat":31.2,"lng":121.45}`, FlightArea: mustGeometry(corridorZone)}
+	assert.Empty(t, detectTelemetryAnomalies(drone, mission, track, track[last].Timestamp))
+}
+
+func TestNewDraftIncident(t *testing.T) {
+	operatorID, missionID := uuid.New(), uuid.New()
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-004", OperatorID: &operatorID}
+	position := &newStraightTrack(drone.ID, 1, time.Second)[0]
+	position.MissionID = &missionID
+
+	incident := newDraftIncident(drone, zoneViolationAnomaly(drone, position, []string{"机场净空区", "临时管制区"}))
+	assert.Equal(t, models.InvestigationStatusDraft, incident.InvestigationStatus)
+	assert.Equal(t, models.IncidentTypeViolation, incident.IncidentType)
+	assert.Equal(t, models.IncidentDetectionNoFlyZone, *incident.DetectionRule)
+	assert.Equal(t, &drone.ID, incident.DroneID)
+	assert.Equal(t, &missionID, incident.MissionID)
+	assert.Equal(t, &operatorID, incident.OperatorID)
+	assert.Equal(t, 120, *incident.Altitude)
+	assert.JSONEq(t, `{"lat":31.2,"lng":121.45}`, incident.Location)
+	assert.Contains(t, incident.Description, "机场净空区、临时管制区")
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IncidentService 无人机事件服务接口
+// 所有无人机相关角色可以上报事件；监管人员、管理员指派调查人，调查人推进 pending -> investigating -> closed
+// 遥测自动检测的草稿事件由范围内的用户确认进入 pending，或由监管人员、管理员驳回
+// 运营商用户和飞手只能查看所属运营商的事件
+type IncidentService interface {
+	ListIncidents(ctx context.Context, query *dto.IncidentQuery, actor Actor) (*dto.PageResponse[dto.IncidentResponse], error)
+	GetIncident(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneIncident, error)
+	FileIncident(ctx context.Context, req *dto.CreateIncidentRequest, actor Actor) (*models.DroneIncident, error)
+	ConfirmIncident(ctx context.Context, id uuid.UUID, req *dto.ConfirmIncidentRequest, actor Actor) (*models.DroneIncident, error)
+	DismissIncident(ctx context.Context, id uuid.UUID, req *dto.DismissIncidentRequest, actor Actor) (*models.DroneIncident, error)
+	AssignInvestigator(ctx context.Context, id uuid.UUID, req *dto.AssignInvestigatorRequest, actor Actor) (*models.DroneIncident, error)
+	StartInvestigation(ctx context.Context, id uuid.UUID, req *dto.StartInvestigationRequest, actor Actor) (*models.DroneIncident, error)
+	CloseIncident(ctx context.Context, id uuid.UUID, req *dto.CloseIncidentRequest, actor Actor) (*models.DroneIncident, error)
+}
+
+// 可以担任调查人、指派调查人的角色
+var investigatorRoles = []string{RoleAdmin, RoleRegulator}
+
+// incidentReportWindow 事件发生时间允许晚于当前时间的最大偏差
+const incidentReportWindow = 5 * time.Minute
+
+type incidentService struct {
+	repo         repositories.DroneIncidentRepository
+	droneRepo    repositories.DroneRepository
+	missionRepo  repositories.DroneMissionRepository
+	operatorRepo repositories.OperatorRepository
+	userRepo     repositories.UserRepository
+}
+
+// NewIncidentService 创建无人机事件服务实例
+func NewIncidentService(
+	repo repositories.DroneIncidentRepository,
+	droneRepo repositories.DroneRepository,
+	missionRepo repositories.DroneMissionRepository,
+	operatorRepo repositories.OperatorRepository,
+	userRepo repositories.UserRepository,
+) IncidentService {
+	return &incidentService{
+		repo:         repo,
+		droneRepo:    droneRepo,
+		missionRepo:  missionRepo,
+		operatorRepo: operatorRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// ListIncidents 分页查询事件，按严重程度由重到轻排序
+func (s *incidentService) ListIncidents(ctx context.Context, query *dto.IncidentQuery, actor Actor) (*dto.PageResponse[dto.IncidentResponse], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.Normalize()
+
+	statuses := splitStatuses(query.Status)
+	if len(statuses) == 0 && query.Open {
+		statuses = []string{models.InvestigationStatusPending, models.InvestigationStatusInvestigating}
+	}
+
+	incidents, total, err := s.repo.List(ctx, repositories.DroneIncidentFilter{
+		OperatorID:     scope.Filter(query.OperatorID),
+		DroneID:        query.DroneID,
+		MissionID:      query.MissionID,
+		InvestigatorID: query.InvestigatorID,
+		Types:          splitStatuses(query.Type),
+		Severities:     splitStatuses(query.Severity),
+		Statuses:       statuses,
+		From:           query.From,
+		To:             query.To,
+		Offset:         query.Offset(),
+		Limit:          query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToIncidentResponseList(incidents), total, query.PageQuery), nil
+}
+
+// GetIncident 获取事件详情，范围外的事件视为不存在
+func (s *incidentService) GetIncident(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneIncident, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.findIncident(ctx, id, scope)
+}
+
+// FileIncident 上报事件，新事件处于待调查状态
+func (s *incidentService) FileIncident(ctx context.Context, req *dto.CreateIncidentRequest, actor Actor) (*models.DroneIncident, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateIncidentReport(req, time.Now()); err != nil {
+		return nil, err
+	}
+
+	incident := &models.DroneIncident{
+		IncidentType:              req.IncidentType,
+		Severity:                  req.Severity,
+		IncidentDate:              req.IncidentDate,
+		Altitude:                  req.Altitude,
+		Description:               strings.TrimSpace(req.Description),
+		Cause:                     req.Cause,
+		DroneDamage:               req.DroneDamage,
+		PropertyDamage:            req.PropertyDamage,
+		PropertyDamageDescription: req.PropertyDamageDescription,
+		Injuries:                  req.Injuries,
+		InjuryDescription:         req.InjuryDescription,
+		InvestigationStatus:       models.InvestigationStatusPending,
+		ReportedToAuthority:       req.ReportedToAuthority,
+		AuthorityCaseNumber:       req.AuthorityCaseNumber,
+	}
+	if err := s.resolveLinks(ctx, incident, req, scope); err != nil {
+		return nil, err
+	}
+
+	location, err := json.Marshal(req.Location)
+	if err != nil {
+		return nil, apperr.NewBadRequest("无效的事件位置")
+	}
+	incident.Location = string(location)
+	if len(req.ContributingFactors) > 0 {
+		factors, err := json.Marshal(req.ContributingFactors)
+		if err != nil {
+			return nil, apperr.NewBadRequest("无效的影响因素")
+		}
+		incident.ContributingFactors = stringPtr(string(factors))
+	}
+
+	if err := s.repo.Create(ctx, incident); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return incident, nil
+}
+
+// ConfirmIncident 确认事件草稿，可修正描述并补充损失情况；类型和严重程度仅监管人员、管理员可以修正
+func (s *incidentService) ConfirmIncident(ctx context.Context, id uuid.UUID, req *dto.ConfirmIncidentRequest, actor Actor) (*models.DroneIncident, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	incident, err := s.findIncident(ctx, id, scope)
+	if err != nil {
+		return nil, err
+	}
+	if incident.InvestigationStatus != models.InvestigationStatusDraft {
+		return nil, apperr.NewConflict("只有待确认的事件草稿可以确认")
+	}
+	if err := validateDamageReport(req.PropertyDamage, req.PropertyDamageDescription, req.Injuries, req.InjuryDescription); err != nil {
+		return nil, err
+	}
+
+	// 检测得出的类型和严重程度只能由监管人员或管理员修正，避免运营方自行降级
+	retyped := req.IncidentType != nil && *req.IncidentType != incident.IncidentType
+	regraded := req.Severity != nil && *req.Severity != incident.Severity
+	if (retyped || regraded) && !actor.HasRole(investigatorRoles...) {
+		return nil, apperr.NewForbidden("只有监管人员或管理员可以修改检测得出的事件类型和严重程度")
+	}
+	if req.IncidentType != nil {
+		incident.IncidentType = *req.IncidentType
+	}
+	if req.Severity != nil {
+		incident.Severity = *req.Severity
+	}
+	if req.Description != nil {
+		description := optionalString(*req.Description)
+		if description == nil {
+			return nil, apperr.NewBadRequest("事件描述不能为空")
+		}
+		incident.Description = *description
+	}
+	if req.Cause != nil {
+		incident.Cause = optionalString(*req.Cause)
+	}
+	if req.DroneDamage != nil {
+		incident.DroneDamage = req.DroneDamage
+	}
+	incident.PropertyDamage = req.PropertyDamage
+	incident.PropertyDamageDescription = req.PropertyDamageDescription
+	incident.Injuries = req.Injuries
+	incident.InjuryDescription = req.InjuryDescription
+	incident.InvestigationStatus = models.InvestigationStatusPending
+
+	if err := s.repo.ConfirmDraft(ctx, incident); err != nil {
+		if errors.Is(err, repositories.ErrStaleState) {
+			return nil, apperr.NewConflict("事件状态已被其他操作修改，请刷新后重试")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return incident, nil
+}
+
+// DismissIncident 驳回误报的事件草稿，驳回原因记入调查记录
+func (s *incidentService) DismissIncident(ctx context.Context, id uuid.UUID, req *dto.DismissIncidentRequest, actor Actor) (*models.DroneIncident, error) {
+	if !actor.HasRole(investigatorRoles...) {
+		return nil, apperr.NewForbidden("只有监管人员或管理员可以驳回事件")
+	}
+	incident, err := s.findIncident(ctx, id, OperatorScope{All: true})
+	if err != nil {
+		return nil, err
+	}
+	if incident.InvestigationStatus != models.InvestigationStatusDraft {
+		return nil, apperr.NewConflict("只有待确认的事件草稿可以驳回")
+	}
+	reason := optionalString(req.Reason)
+	if reason == nil {
+		return nil, apperr.NewBadRequest("驳回必须说明原因")
+	}
+
+	incident.InvestigationStatus = models.InvestigationStatusDismissed
+	incident.InvestigationNotes = reason
+	if err := s.update(ctx, incident, models.InvestigationStatusDraft); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// AssignInvestigator 指派或更换调查人，仅已确认且未结案的事件可以指派
+func (s *incidentService) AssignInvestigator(ctx context.Context, id uuid.UUID, req *dto.AssignInvestigatorRequest, actor Actor) (*models.DroneIncident, error) {
+	if !actor.HasRole(investigatorRoles...) {
+		return nil, apperr.NewForbidden("只有监管人员或管理员可以指派调查人")
+	}
+	incident, err := s.findIncident(ctx, id, OperatorScope{All: true})
+	if err != nil {
+		return nil, err
+	}
+	if incident.InvestigationStatus != models.InvestigationStatusPending && incident.InvestigationStatus != models.InvestigationStatusInvestigating {
+		return nil, apperr.NewConflict(fmt.Sprintf("事件当前为 %s 状态，不能指派调查人", incident.InvestigationStatus))
+	}
+
+	investigator, err := s.userRepo.FindByID(ctx, req.InvestigatorID)
+	if err != nil {
+		return nil, apperr.NewBadRequest("调查人不存在")
+	}
+	if investigator.Role != RoleAdmin && investigator.Role != RoleRegulator {
+		return nil, apperr.NewBadRequest("调查人必须是监管人员或管理员")
+	}
+
+	incident.InvestigatorID = &investigator.ID
+	if err := s.update(ctx, incident, incident.InvestigationStatus); err != nil {
+		return nil, err
+	}
+	incident.Investigator = investigator
+	return incident, nil
+}
+
+// StartInvestigation 开始调查，需已指派调查人并填写调查计划
+func (s *incidentService) StartInvestigation(ctx context.Context, id uuid.UUID, req *dto.StartInvestigationRequest, actor Actor) (*models.DroneIncident, error) {
+	incident, err := s.findForInvestigator(ctx, id, models.InvestigationStatusPending, actor)
+	if err != nil {
+		return nil, err
+	}
+	notes := optionalString(req.Notes)
+	if notes == nil {
+		return nil, apperr.NewBadRequest("开始调查必须填写调查计划")
+	}
+
+	incident.InvestigationStatus = models.InvestigationStatusInvestigating
+	incident.InvestigationNotes = notes
+	if err := s.update(ctx, incident, models.InvestigationStatusPending); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// CloseIncident 结案，需填写根本原因和纠正措施；严重事件须已上报主管部门
+func (s *incidentService) CloseIncident(ctx context.Context, id uuid.UUID, req *dto.CloseIncidentRequest, actor Actor) (*models.DroneIncident, error) {
+	incident, err := s.findForInvestigator(ctx, id, models.InvestigationStatusInvestigating, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCause, actions := optionalString(req.RootCause), optionalString(req.CorrectiveActions)
+	if rootCause == nil || actions == nil {
+		return nil, apperr.NewBadRequest("结案必须填写根本原因和纠正措施")
+	}
+	incident.RootCause = rootCause
+	incident.CorrectiveActions = actions
+	if req.PreventiveMeasures != nil {
+		incident.PreventiveMeasures = optionalString(*req.PreventiveMeasures)
+	}
+	if req.Cause != nil {
+		incident.Cause = optionalString(*req.Cause)
+	}
+	if notes := optionalString(req.Notes); notes != nil {
+		incident.InvestigationNotes = notes
+	}
+	if req.ReportedToAuthority != nil {
+		incident.ReportedToAuthority = *req.ReportedToAuthority
+	}
+	if req.AuthorityCaseNumber != nil {
+		incident.AuthorityCaseNumber = optionalString(*req.AuthorityCaseNumber)
+	}
+	if err := checkAuthorityReport(incident); err != nil {
+		return nil, err
+	}
+
+	incident.InvestigationStatus = models.InvestigationStatusClosed
+	if err := s.update(ctx, incident, models.InvestigationStatusInvestigating); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// resolveLinks 校验并补全事件关联的任务、无人机和运营商
+// 运营商用户和飞手上报的事件固定归属所属运营商，关联对象必须在其范围内
+func (s *incidentService) resolveLinks(ctx context.Context, incident *models.DroneIncident, req *dto.CreateIncidentRequest, scope OperatorScope) error {
+	operatorID := scope.Filter(req.OperatorID)
+	droneID := req.DroneID
+
+	if req.MissionID != nil {
+		mission, err := s.missionRepo.FindByID(ctx, *req.MissionID)
+		if err != nil {
+			return referenceError(err, "任务不存在")
+		}
+		if !scope.Allows(&mission.OperatorID) {
+			return apperr.NewBadRequest("任务不存在")
+		}
+		if droneID != nil && *droneID != mission.DroneID {
+			return apperr.NewBadRequest("无人机与任务不匹配")
+		}
+		incident.MissionID = &mission.ID
+		droneID = &mission.DroneID
+	}
+
+	if droneID != nil {
+		drone, err := s.droneRepo.FindByID(ctx, *droneID)
+		if err != nil {
+			return referenceError(err, "无人机不存在")
+		}
+		if !scope.Allows(drone.OperatorID) {
+			return apperr.NewBadRequest("无人机不存在")
+		}
+		if scope.All && operatorID != nil && (drone.OperatorID == nil || *drone.OperatorID != *operatorID) {
+			return apperr.NewBadRequest("运营商与无人机不匹配")
+		}
+		incident.DroneID = &drone.ID
+		incident.Drone = drone
+		if drone.OperatorID != nil {
+			operatorID = drone.OperatorID
+		}
+	}
+
+	if operatorID != nil && scope.All {
+		if _, err := s.operatorRepo.FindByID(ctx, *operatorID); err != nil {
+			return referenceError(err, "运营商不存在")
+		}
+	}
+	incident.OperatorID = operatorID
+	return nil
+}
+
+// findForInvestigator 查询处于指定状态的事件，并校验操作人为调查人或管理员
+func (s *incidentService) findForInvestigator(ctx context.Context, id uuid.UUID, status string, actor Actor) (*models.DroneIncident, error) {
+	if !actor.HasRole(investigatorRoles...) {
+		return nil, apperr.NewForbidden("只有监管人员或管理员可以处理事件调查")
+	}
+	incident, err := s.findIncident(ctx, id, OperatorScope{All: true})
+	if err != nil {
+		return nil, err
+	}
+	if incident.InvestigationStatus != status {
+		return nil, apperr.NewConflict(fmt.Sprintf("事件当前为 %s 状态，不能执行该操作", incident.InvestigationStatus))
+	}
+	if incident.InvestigatorID == nil {
+		return nil, apperr.NewConflict("请先指派调查人")
+	}
+	if actor.Role != RoleAdmin && !sameUser(incident.InvestigatorID, actor.UserID) {
+		return nil, apperr.NewForbidden("只有指派的调查人可以处理该事件")
+	}
+	return incident, nil
+}
+
+// findIncident 查询事件并校验运营商范围
+func (s *incidentService) findIncident(ctx context.Context, id uuid.UUID, scope OperatorScope) (*models.DroneIncident, error) {
+	incident, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("事件不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(incident.OperatorID) {
+		return nil, apperr.NewNotFound("事件不存在")
+	}
+	return incident, nil
+}
+
+// update 持久化调查字段，并发修改时返回冲突
+func (s *incidentService) update(ctx context.Context, incident *models.DroneIncident, from string) error {
+	if err := s.repo.UpdateInvestigation(ctx, incident, from); err != nil {
+		if errors.Is(err, repositories.ErrStaleState) {
+			return apperr.NewConflict("事件状态已被其他操作修改，请刷新后重试")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// validateIncidentReport 校验上报内容：发生时间不能晚于当前，有损失或伤亡时必须说明
+func validateIncidentReport(req *dto.CreateIncidentRequest, now time.Time) error {
+	if req.IncidentDate.After(now.Add(incidentReportWindow)) {
+		return apperr.NewBadRequest("事件发生时间不能晚于当前时间")
+	}
+	if strings.TrimSpace(req.Description) == "" {
+		return apperr.NewBadRequest("事件描述不能为空")
+	}
+	if err := validateDamageReport(req.PropertyDamage, req.PropertyDamageDescription, req.Injuries, req.InjuryDescription); err != nil {
+		return err
+	}
+	if req.ReportedToAuthority && (req.AuthorityCaseNumber == nil || strings.TrimSpace(*req.AuthorityCaseNumber) == "") {
+		return apperr.NewBadRequest("已上报主管部门时必须填写案件编号")
+	}
+	return nil
+}
+
+// validateDamageReport 有财产损失或人员受伤时必须填写说明
+func validateDamageReport(propertyDamage bool, propertyDescription *string, injuries bool, injuryDescription *string) error {
+	if propertyDamage && (propertyDescription == nil || strings.TrimSpace(*propertyDescription) == "") {
+		return apperr.NewBadRequest("存在财产损失时必须填写损失说明")
+	}
+	if injuries && (injuryDescription == nil || strings.TrimSpace(*injuryDescription) == "") {
+		return apperr.NewBadRequest("存在人员受伤时必须填写伤情说明")
+	}
+	return nil
+}
+
+// checkAuthorityReport 结案前校验主管部门上报：严重及以上或有人员受伤的事件必须上报，上报后必须有案件编号
+func checkAuthorityReport(incident *models.DroneIncident) error {
+	mustReport := incident.Injuries ||
+		incident.Severity == models.IncidentSeveritySerious ||
+		incident.Severity == models.IncidentSeverityCritical
+	if mustReport && !incident.ReportedToAuthority {
+		return apperr.NewBadRequest("严重事件或有人员受伤的事件结案前必须上报主管部门")
+	}
+	if incident.ReportedToAuthority && incident.AuthorityCaseNumber == nil {
+		return apperr.NewBadRequest("已上报主管部门时必须填写案件编号")
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDroneIncidentRepository 模拟无人机事件仓储
+type MockDroneIncidentRepository struct {
+	mock.Mock
+}
+
+func (m *MockDroneIncidentRepository) Create(ctx context.Context, incident *models.DroneIncident) error {
+	args := m.Called(ctx, incident)
+	return args.Error(0)
+}
+
+func (m *MockDroneIncidentRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.DroneIncident, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DroneIncident), args.Error(1)
+}
+
+func (m *MockDroneIncidentRepository) UpdateInvestigation(ctx context.Context, incident *models.DroneIncident, fromStatus string) error {
+	args := m.Called(ctx, incident, fromStatus)
+	return args.Error(0)
+}
+
+func (m *MockDroneIncidentRepository) ConfirmDraft(ctx context.Context, incident *models.DroneIncident) error {
+	args := m.Called(ctx, incident)
+	return args.Error(0)
+}
+
+func (m *MockDroneIncidentRepository) List(ctx context.Context, filter repositories.DroneIncidentFilter) ([]models.DroneIncident, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.DroneIncident), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDroneIncidentRepository) CountBySeverity(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID]map[string]int64, error) {
+	args := m.Called(ctx, operatorID, from, to)
+	return args.Get(0).(map[uuid.UUID]map[string]int64), args.Error(1)
+}
+
+func newIncidentRequest() *dto.CreateIncidentRequest {
+	return &dto.CreateIncidentRequest{
+		IncidentType: models.IncidentTypeCrash,
+		Severity:     models.IncidentSeveritySerious,
+		IncidentDate: time.Now().Add(-time.Hour),
+		Location:     dto.MissionLocation{Lat: 31.2, Lng: 121.4},
+		Description:  "降落时桨叶打到围栏",
+	}
+}
+
+func newInvestigatingIncident(investigatorID uuid.UUID) *models.DroneIncident {
+	return &models.DroneIncident{
+		ID:                  uuid.New(),
+		IncidentType:        models.IncidentTypeCrash,
+		Severity:            models.IncidentSeveritySerious,
+		InvestigationStatus: models.InvestigationStatusInvestigating,
+		InvestigatorID:      &investigatorID,
+	}
+}
+
+func TestFileIncidentLinksMissionDroneAndOperator(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneIncidentRepository)
+	droneRepo := new(MockDroneRepository)
+	missionRepo := new(MockDroneMissionRepository)
+	userRepo := new(MockUserRepository)
+	service := NewIncidentService(repo, droneRepo, missionRepo, nil, userRepo)
+
+	mission := newPendingMission(nil)
+	operatorID := mission.OperatorID
+	drone := &models.Drone{ID: mission.DroneID, OperatorID: &operatorID}
+	missionRepo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	droneRepo.On("FindByID", mock.Anything, drone.ID).Return(drone, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*models.DroneIncident")).Return(nil)
+
+	req := newIncidentRequest()
+	req.MissionID = &mission.ID
+	req.ContributingFactors = []string{"阵风", "起降场地狭小"}
+	otherOperator := uuid.New()
+	req.OperatorID = &otherOperator // 运营商用户指定的运营商被忽略
+
+	incident, err := service.FileIncident(ctx, req, newOperatorActor(userRepo, operatorID))
+	require.NoError(t, err)
+	assert.Equal(t, models.InvestigationStatusPending, incident.InvestigationStatus)
+	assert.Equal(t, &mission.ID, incident.MissionID)
+	assert.Equal(t, &drone.ID, incident.DroneID)
+	assert.Equal(t, &operatorID, incident.OperatorID)
+	assert.JSONEq(t, `{"lat":31.2,"lng":121.4}`, incident.Location)
+	assert.JSONEq(t, `["阵风","起降场地狭小"]`, *incident.ContributingFactors)
+
+	// 范围外的任务视为不存在
+	_, err = service.FileIncident(ctx, req, newOperatorActor(userRepo, uuid.New()))
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestFileIncidentRequiresDamageAndInjuryDescriptions(t *testing.T) {
+	service := NewIncidentService(nil, nil, nil, nil, nil)
+
+	req := newIncidentRequest()
+	req.Injuries = true
+	_, err := service.FileIncident(context.Background(), req, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	req = newIncidentRequest()
+	req.PropertyDamage = true
+	_, err = service.FileIncident(context.Background(), req, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	req = newIncidentRequest()
+	req.IncidentDate = time.Now().Add(time.Hour)
+	_, err = service.FileIncident(context.Background(), req, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+}
+
+func TestAssignInvestigatorRequiresRegulator(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneIncidentRepository)
+	userRepo := new(MockUserRepository)
+	service := NewIncidentService(repo, nil, nil, nil, userRepo)
+
+	incident := &models.DroneIncident{ID: uuid.New(), InvestigationStatus: models.InvestigationStatusPending}
+	pilot := &models.User{ID: uuid.New(), Role: RolePilot}
+	regulator := &models.User{ID: uuid.New(), Role: RoleRegulator}
+	repo.On("FindByID", mock.Anything, incident.ID).Return(incident, nil)
+	userRepo.On("FindByID", mock.Anything, pilot.ID).Return(pilot, nil)
+	userRepo.On("FindByID", mock.Anything, regulator.ID).Return(regulator, nil)
+	repo.On("UpdateInvestigation", mock.Anything, incident, models.InvestigationStatusPending).Return(nil)
+
+	_, err := service.AssignInvestigator(ctx, incident.ID, &dto.AssignInvestigatorRequest{InvestigatorID: pilot.ID}, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	result, err := service.AssignInvestigator(ctx, incident.ID, &dto.AssignInvestigatorRequest{InvestigatorID: regulator.ID}, newReviewer())
+	require.NoError(t, err)
+	assert.Equal(t, &regulator.ID, result.InvestigatorID)
+	assert.Equal(t, models.InvestigationStatusPending, result.InvestigationStatus)
+
+	_, err = service.AssignInvestigator(ctx, incident.ID, &dto.AssignInvestigatorRequest{InvestigatorID: regulator.ID}, Actor{Role: RoleOperator})
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+}
+
+func TestStartInvestigationRequiresAssignedInvestigator(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneIncidentRepository)
+	service := NewIncidentService(repo, nil, nil, nil, nil)
+
+	incident := &models.DroneIncident{ID: uuid.New(), InvestigationStatus: models.InvestigationStatusPending}
+	repo.On("FindByID", mock.Anything, incident.ID).Return(incident, nil)
+	investigator := newReviewer()
+	req := &dto.StartInvestigationRequest{Notes: "调取飞控日志并走访现场"}
+
+	_, err := service.StartInvestigation(ctx, incident.ID, req, investigator)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	incident.InvestigatorID = investigator.UserID
+	_, err = service.StartInvestigation(ctx, incident.ID, req, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+
+	repo.On("UpdateInvestigation", mock.Anything, incident, models.InvestigationStatusPending).Return(repositories.ErrStaleState).Once()
+	_, err = service.StartInvestigation(ctx, incident.ID, req, investigator)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	repo.On("UpdateInvestigation", mock.Anything, incident, models.InvestigationStatusPending).Return(nil).Once()
+	incident.InvestigationStatus = models.InvestigationStatusPending
+	result, err := service.StartInvestigation(ctx, incident.ID, req, investigator)
+	require.NoError(t, err)
+	assert.Equal(t, models.InvestigationStatusInvestigating, result.InvestigationStatus)
+	assert.Equal(t, req.Notes, *result.InvestigationNotes)
+}
+
+func TestCloseIncidentRequiresAuthorityReportForSeriousIncidents(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneIncidentRepository)
+	service := NewIncidentService(repo, nil, nil, nil, nil)
+
+	investigator := newReviewer()
+	incident := newInvestigatingIncident(*investigator.UserID)
+	repo.On("FindByID", mock.Anything, incident.ID).Return(incident, nil)
+	repo.On("UpdateInvestigation", mock.Anything, incident, models.InvestigationStatusInvestigating).Return(nil)
+
+	req := &dto.CloseIncidentRequest{RootCause: "起降场地未按规定清空", CorrectiveActions: "更新起降场地检查单"}
+	_, err := service.CloseIncident(ctx, incident.ID, req, investigator)
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	reported := true
+	req.ReportedToAuthority = &reported
+	_, err = service.CloseIncident(ctx, incident.ID, req, investigator)
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	caseNumber := "CAAC-2024-0815"
+	req.AuthorityCaseNumber = &caseNumber
+	result, err := service.CloseIncident(ctx, incident.ID, req, investigator)
+	require.NoError(t, err)
+	assert.Equal(t, models.InvestigationStatusClosed, result.InvestigationStatus)
+	assert.Equal(t, req.RootCause, *result.RootCause)
+	assert.Equal(t, caseNumber, *result.AuthorityCaseNumber)
+
+	// 已结案的事件不能再次结案
+	_, err = service.CloseIncident(ctx, incident.ID, req, investigator)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+}
+
+func TestConfirmIncidentDraft(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneIncidentRepository)
+	userRepo := new(MockUserRepository)
+	service := NewIncidentService(repo, nil, nil, nil, userRepo)
+
+	operatorID := uuid.New()
+	rule := models.IncidentDetectionAltitudeLoss
+	incident := &models.DroneIncident{
+		ID:                  uuid.New(),
+		OperatorID:          &operatorID,
+		IncidentType:        models.IncidentTypeCrash,
+		Severity:            models.IncidentSeveritySerious,
+		Description:         "遥测检测：疑似坠机",
+		DetectionRule:       &rule,
+		InvestigationStatus: models.InvestigationStatusDraft,
+	}
+	repo.On("FindByID", mock.Anything, incident.ID).Return(incident, nil)
+	repo.On("ConfirmDraft", mock.Anything, incident).Return(nil).Once()
+
+	minor, damage := models.IncidentSeverityMinor, models.DroneDamageMinor
+	req := &dto.ConfirmIncidentRequest{DroneDamage: &damage, Injuries: true}
+	actor := newOperatorActor(userRepo, operatorID)
+	_, err := service.ConfirmIncident(ctx, incident.ID, req, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	// 运营商不能降低检测得出的严重程度
+	injury := "飞手手指被桨叶划伤"
+	req.InjuryDescription = &injury
+	req.Severity = &minor
+	_, err = service.ConfirmIncident(ctx, incident.ID, req, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+	assert.Equal(t, models.IncidentSeveritySerious, incident.Severity)
+
+	serious := models.IncidentSeveritySerious
+	req.Severity = &serious
+	result, err := service.ConfirmIncident(ctx, incident.ID, req, actor)
+	require.NoError(t, err)
+	assert.Equal(t, models.InvestigationStatusPending, result.InvestigationStatus)
+	assert.Equal(t, models.IncidentSeveritySerious, result.Severity)
+	assert.Equal(t, models.IncidentTypeCrash, result.IncidentType)
+	assert.Equal(t, &damage, result.DroneDamage)
+
+	// 已确认的事件不能再次确认，范围外的事件视为不存在
+	_, err = service.ConfirmIncident(ctx, incident.ID, req, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	_, err = service.ConfirmIncident(ctx, incident.ID, req, newOperatorActor(userRepo, uuid.New()))
+	assertAppErrorCode(t, err, apperr.ErrCodeNotFound)
+
+	// 监管人员可以修正检测结果
+	draft := *incident
+	draft.ID = uuid.New()
+	draft.InvestigationStatus = models.InvestigationStatusDraft
+	repo.On("FindByID", mock.Anything, draft.ID).Return(&draft, nil)
+	repo.On("ConfirmDraft", mock.Anything, &draft).Return(nil).Once()
+	malfunction := models.IncidentTypeMalfunction
+	req.IncidentType, req.Severity = &malfunction, &minor
+	result, err = service.ConfirmIncident(ctx, draft.ID, req, newReviewer())
+	require.NoError(t, err)
+	assert.Equal(t, models.IncidentTypeMalfunction, result.IncidentType)
+	assert.Equal(t, models.IncidentSeverityMinor, result.Severity)
+	repo.AssertExpectations(t)
+}
+
+func TestDismissIncidentDraft(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneIncidentRepository)
+	service := NewIncidentService(repo, nil, nil, nil, nil)
+
+	incident := &models.DroneIncident{ID: uuid.New(), InvestigationStatus: models.InvestigationStatusDraft}
+	repo.On("FindByID", mock.Anything, incident.ID).Return(incident, nil)
+	repo.On("UpdateInvestigation", mock.Anything, incident, models.InvestigationStatusDraft).Return(nil).Once()
+	req := &dto.DismissIncidentRequest{Reason: "GPS 多路径干扰，无人机正常降落"}
+
+	_, err := service.DismissIncident(ctx, incident.ID, req, Actor{Role: RoleOperator})
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+
+	result, err := service.DismissIncident(ctx, incident.ID, req, newReviewer())
+	require.NoError(t, err)
+	assert.Equal(t, models.InvestigationStatusDismissed, result.InvestigationStatus)
+	assert.Equal(t, req.Reason, *result.InvestigationNotes)
+
+	// 已驳回的事件不能指派调查人
+	_, err = service.AssignInvestigator(ctx, incident.ID, &dto.AssignInvestigatorRequest{InvestigatorID: uuid.New()}, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+}
@@ -0,0 +1,618 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 维护规则间隔的使用比例达到该值即提示即将到期，达到 1 为超期
+const maintenanceDueRatio = 0.9
+
+// 可以开立、签核、取消维护工单的角色
+var maintenanceRoles = []string{RoleAdmin, RoleOperator}
+
+// MaintenanceChecker 无人机维护状态检查接口，供任务审批和合规统计使用
+type MaintenanceChecker interface {
+	// Overdue 返回无人机已超期的维护项，为空表示可以执行任务
+	Overdue(ctx context.Context, drone *models.Drone) ([]dto.MaintenanceItem, error)
+	// CountOverdueByOperator 按运营商统计维护超期的无人机数量，operatorID 为 nil 时统计全部运营商
+	CountOverdueByOperator(ctx context.Context, operatorID *uuid.UUID) (map[uuid.UUID]int64, error)
+}
+
+// MaintenanceService 无人机维护服务接口
+// 管理员按机型维护规则；按飞行日志累计的飞行时间、次数和日历天数计算各规则的到期情况
+// 管理员和运营商用户开立、签核维护工单，签核后该规则重新计算周期；运营商用户只能访问所属机队
+type MaintenanceService interface {
+	MaintenanceChecker
+
+	ListRules(ctx context.Context, query *dto.MaintenanceRuleQuery) (*dto.PageResponse[dto.MaintenanceRuleResponse], error)
+	GetRule(ctx context.Context, id uuid.UUID) (*models.MaintenanceRule, error)
+	CreateRule(ctx context.Context, req *dto.CreateMaintenanceRuleRequest) (*models.MaintenanceRule, error)
+	UpdateRule(ctx context.Context, id uuid.UUID, req *dto.UpdateMaintenanceRuleRequest) (*models.MaintenanceRule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// DroneStatus 查询单架无人机的维护状态
+	DroneStatus(ctx context.Context, droneID uuid.UUID, actor Actor) (*dto.DroneMaintenanceStatus, error)
+	// ListDue 分页查询即将到期或已超期的无人机，超期优先
+	ListDue(ctx context.Context, query *dto.MaintenanceDueQuery, actor Actor) (*dto.PageResponse[dto.DroneMaintenanceStatus], error)
+
+	ListWorkOrders(ctx context.Context, query *dto.WorkOrderQuery, actor Actor) (*dto.PageResponse[dto.WorkOrderResponse], error)
+	GetWorkOrder(ctx context.Context, id uuid.UUID, actor Actor) (*models.MaintenanceWorkOrder, error)
+	OpenWorkOrder(ctx context.Context, req *dto.CreateWorkOrderRequest, actor Actor) (*models.MaintenanceWorkOrder, error)
+	SignOffWorkOrder(ctx context.Context, id uuid.UUID, req *dto.SignOffWorkOrderRequest, actor Actor) (*models.MaintenanceWorkOrder, error)
+	CancelWorkOrder(ctx context.Context, id uuid.UUID, req *dto.CancelWorkOrderRequest, actor Actor) (*models.MaintenanceWorkOrder, error)
+}
+
+type maintenanceService struct {
+	repo          repositories.MaintenanceRepository
+	droneRepo     repositories.DroneRepository
+	flightLogRepo repositories.DroneFlightLogRepository
+	userRepo      repositories.UserRepository
+}
+
+// NewMaintenanceService 创建无人机维护服务实例
+func NewMaintenanceService(
+	repo repositories.MaintenanceRepository,
+	droneRepo repositories.DroneRepository,
+	flightLogRepo repositories.DroneFlightLogRepository,
+	userRepo repositories.UserRepository,
+) MaintenanceService {
+	return &maintenanceService{
+		repo:          repo,
+		droneRepo:     droneRepo,
+		flightLogRepo: flightLogRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// ListRules 分页查询维护规则
+func (s *maintenanceService) ListRules(ctx context.Context, query *dto.MaintenanceRuleQuery) (*dto.PageResponse[dto.MaintenanceRuleResponse], error) {
+	query.Normalize()
+	rules, total, err := s.repo.ListRules(ctx, repositories.MaintenanceRuleFilter{
+		Model:  strings.TrimSpace(query.Model),
+		Offset: query.Offset(),
+		Limit:  query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return dto.NewPageResponse(dto.ToMaintenanceRuleResponseList(rules), total, query.PageQuery), nil
+}
+
+// GetRule 获取维护规则详情
+func (s *maintenanceService) GetRule(ctx context.Context, id uuid.UUID) (*models.MaintenanceRule, error) {
+	rule, err := s.repo.FindRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("维护规则不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return rule, nil
+}
+
+// CreateRule 创建维护规则
+func (s *maintenanceService) CreateRule(ctx context.Context, req *dto.CreateMaintenanceRuleRequest) (*models.MaintenanceRule, error) {
+	rule := &models.MaintenanceRule{
+		Model:           strings.TrimSpace(req.Model),
+		Name:            strings.TrimSpace(req.Name),
+		IntervalHours:   req.IntervalHours,
+		IntervalFlights: req.IntervalFlights,
+		IntervalDays:    req.IntervalDays,
+	}
+	if req.Description != nil {
+		rule.Description = optionalString(*req.Description)
+	}
+	if err := validateMaintenanceRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return rule, nil
+}
+
+// UpdateRule 更新维护规则，修改后按新的间隔重新计算到期情况
+func (s *maintenanceService) UpdateRule(ctx context.Context, id uuid.UUID, req *dto.UpdateMaintenanceRuleRequest) (*models.MaintenanceRule, error) {
+	rule, err := s.GetRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Model != nil {
+		rule.Model = strings.TrimSpace(*req.Model)
+	}
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		rule.Description = optionalString(*req.Description)
+	}
+	if req.IntervalHours != nil {
+		rule.IntervalHours = req.IntervalHours
+		if *req.IntervalHours == 0 {
+			rule.IntervalHours = nil
+		}
+	}
+	if req.IntervalFlights != nil {
+		rule.IntervalFlights = req.IntervalFlights
+		if *req.IntervalFlights == 0 {
+			rule.IntervalFlights = nil
+		}
+	}
+	if req.IntervalDays != nil {
+		rule.IntervalDays = req.IntervalDays
+		if *req.IntervalDays == 0 {
+			rule.IntervalDays = nil
+		}
+	}
+	if err := validateMaintenanceRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return rule, nil
+}
+
+// DeleteRule 删除维护规则，已有工单的规则不能删除
+func (s *maintenanceService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	_, total, err := s.repo.ListWorkOrders(ctx, repositories.MaintenanceWorkOrderFilter{RuleID: &id, Limit: 1})
+	if err != nil {
+		return apperr.NewInternalError(err)
+	}
+	if total > 0 {
+		return apperr.NewConflict("维护规则已有工单记录，不能删除")
+	}
+
+	if err := s.repo.DeleteRule(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("维护规则不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// DroneStatus 范围外的无人机视为不存在
+func (s *maintenanceService) DroneStatus(ctx context.Context, droneID uuid.UUID, actor Actor) (*dto.DroneMaintenanceStatus, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	drone, err := s.findDrone(ctx, droneID, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.evaluate(ctx, []models.Drone{*drone}, time.Now())
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return &statuses[0], nil
+}
+
+// ListDue 未指定状态时返回即将到期和已超期的无人机，同一状态按序列号排序
+func (s *maintenanceService) ListDue(ctx context.Context, query *dto.MaintenanceDueQuery, actor Actor) (*dto.PageResponse[dto.DroneMaintenanceStatus], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.Normalize()
+
+	_, statuses, err := s.evaluateFleet(ctx, scope.Filter(query.OperatorID))
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	due := make([]dto.DroneMaintenanceStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.Status == models.MaintenanceStatusOK || (query.Status != "" && status.Status != query.Status) {
+			continue
+		}
+		due = append(due, status)
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if due[i].Status != due[j].Status {
+			return due[i].Status == models.MaintenanceStatusOverdue
+		}
+		return due[i].Drone.SerialNumber < due[j].Drone.SerialNumber
+	})
+
+	start := min(query.Offset(), len(due))
+	end := min(start+query.PageSize, len(due))
+	return dto.NewPageResponse(due[start:end], int64(len(due)), query.PageQuery), nil
+}
+
+// Overdue 返回无人机已超期的维护项
+func (s *maintenanceService) Overdue(ctx context.Context, drone *models.Drone) ([]dto.MaintenanceItem, error) {
+	statuses, err := s.evaluate(ctx, []models.Drone{*drone}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	var overdue []dto.MaintenanceItem
+	for _, item := range statuses[0].Items {
+		if item.Status == models.MaintenanceStatusOverdue {
+			overdue = append(overdue, item)
+		}
+	}
+	return overdue, nil
+}
+
+// CountOverdueByOperator 统计各运营商维护超期的无人机数量，未归属运营商的无人机不计入
+func (s *maintenanceService) CountOverdueByOperator(ctx context.Context, operatorID *uuid.UUID) (map[uuid.UUID]int64, error) {
+	drones, statuses, err := s.evaluateFleet(ctx, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64)
+	for i := range drones {
+		if drones[i].OperatorID != nil && statuses[i].Status == models.MaintenanceStatusOverdue {
+			counts[*drones[i].OperatorID]++
+		}
+	}
+	return counts, nil
+}
+
+// ListWorkOrders 分页查询工单，按创建时间倒序
+func (s *maintenanceService) ListWorkOrders(ctx context.Context, query *dto.WorkOrderQuery, actor Actor) (*dto.PageResponse[dto.WorkOrderResponse], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.Normalize()
+
+	orders, total, err := s.repo.ListWorkOrders(ctx, repositories.MaintenanceWorkOrderFilter{
+		OperatorID: scope.Filter(query.OperatorID),
+		DroneID:    query.DroneID,
+		RuleID:     query.RuleID,
+		Statuses:   splitStatuses(query.Status),
+		Offset:     query.Offset(),
+		Limit:      query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToWorkOrderResponseList(orders), total, query.PageQuery), nil
+}
+
+// GetWorkOrder 获取工单详情，范围外的工单视为不存在
+func (s *maintenanceService) GetWorkOrder(ctx context.Context, id uuid.UUID, actor Actor) (*models.MaintenanceWorkOrder, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.findWorkOrder(ctx, id, scope)
+}
+
+// OpenWorkOrder 开立维护工单，同一无人机同一规则只能有一张未关闭的工单
+func (s *maintenanceService) OpenWorkOrder(ctx context.Context, req *dto.CreateWorkOrderRequest, actor Actor) (*models.MaintenanceWorkOrder, error) {
+	if !actor.HasRole(maintenanceRoles...) {
+		return nil, apperr.NewForbidden("无权开立维护工单")
+	}
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	drone, err := s.droneRepo.FindByID(ctx, req.DroneID)
+	if err != nil {
+		return nil, referenceError(err, "无人机不存在")
+	}
+	if !scope.Allows(drone.OperatorID) {
+		return nil, apperr.NewBadRequest("无人机不存在")
+	}
+
+	order := &models.MaintenanceWorkOrder{
+		DroneID:    drone.ID,
+		OperatorID: drone.OperatorID,
+		Title:      strings.TrimSpace(req.Title),
+		Status:     models.WorkOrderStatusOpen,
+		OpenedBy:   actor.UserID,
+	}
+	if req.Description != nil {
+		order.Description = optionalString(*req.Description)
+	}
+
+	if req.RuleID != nil {
+		rule, err := s.repo.FindRuleByID(ctx, *req.RuleID)
+		if err != nil {
+			return nil, referenceError(err, "维护规则不存在")
+		}
+		if !strings.EqualFold(rule.Model, drone.Model) {
+			return nil, apperr.NewBadRequest("维护规则不适用于该无人机型号")
+		}
+		_, open, err := s.repo.ListWorkOrders(ctx, repositories.MaintenanceWorkOrderFilter{
+			DroneID:  &drone.ID,
+			RuleID:   &rule.ID,
+			Statuses: []string{models.WorkOrderStatusOpen},
+			Limit:    1,
+		})
+		if err != nil {
+			return nil, apperr.NewInternalError(err)
+		}
+		if open > 0 {
+			return nil, apperr.NewConflict("该无人机已有此规则未关闭的维护工单")
+		}
+		order.RuleID = &rule.ID
+		order.Rule = rule
+		if order.Title == "" {
+			order.Title = rule.Name
+		}
+	}
+	if order.Title == "" {
+		return nil, apperr.NewBadRequest("工单标题不能为空")
+	}
+
+	if err := s.repo.CreateWorkOrder(ctx, order); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	order.Drone = drone
+	return order, nil
+}
+
+// SignOffWorkOrder 签核工单，记录签核时的累计飞行时间和次数作为规则下一周期的起点
+// 签核人不能是开单人
+func (s *maintenanceService) SignOffWorkOrder(ctx context.Context, id uuid.UUID, req *dto.SignOffWorkOrderRequest, actor Actor) (*models.MaintenanceWorkOrder, error) {
+	order, err := s.findOpenWorkOrder(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	workPerformed := optionalString(req.WorkPerformed)
+	if workPerformed == nil {
+		return nil, apperr.NewBadRequest("签核必须填写维护内容")
+	}
+	if actor.UserID == nil || sameUser(order.OpenedBy, actor.UserID) {
+		return nil, apperr.NewForbidden("维护工单须由开单人以外的人员签核")
+	}
+
+	totals, err := s.flightLogRepo.TotalsByDrone(ctx, []uuid.UUID{order.DroneID})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	now := time.Now()
+	total := totals[order.DroneID]
+	order.Status = models.WorkOrderStatusCompleted
+	order.WorkPerformed = workPerformed
+	order.SignedOffBy = actor.UserID
+	order.CompletedAt = &now
+	order.FlightMinutesAtCompletion = &total.Minutes
+	order.FlightsAtCompletion = &total.Flights
+
+	if err := s.close(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// CancelWorkOrder 取消工单，须说明原因
+func (s *maintenanceService) CancelWorkOrder(ctx context.Context, id uuid.UUID, req *dto.CancelWorkOrderRequest, actor Actor) (*models.MaintenanceWorkOrder, error) {
+	order, err := s.findOpenWorkOrder(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	reason := optionalString(req.Reason)
+	if reason == nil {
+		return nil, apperr.NewBadRequest("取消工单必须说明原因")
+	}
+
+	order.Status = models.WorkOrderStatusCancelled
+	order.CancelReason = reason
+	if err := s.close(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// evaluateFleet 计算运营商全部无人机的维护状态，operatorID 为 nil 时计算全部无人机
+func (s *maintenanceService) evaluateFleet(ctx context.Context, operatorID *uuid.UUID) ([]models.Drone, []dto.DroneMaintenanceStatus, error) {
+	drones, _, err := s.droneRepo.List(ctx, repositories.DroneFilter{OperatorID: operatorID, Limit: -1})
+	if err != nil {
+		return nil, nil, err
+	}
+	statuses, err := s.evaluate(ctx, drones, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return drones, statuses, nil
+}
+
+// evaluate 批量加载规则、飞行累计和最近签核的工单，计算各无人机的维护状态
+func (s *maintenanceService) evaluate(ctx context.Context, drones []models.Drone, now time.Time) ([]dto.DroneMaintenanceStatus, error) {
+	ids := make([]uuid.UUID, len(drones))
+	droneModels := make([]string, 0, len(drones))
+	for i := range drones {
+		ids[i] = drones[i].ID
+		if drones[i].Model != "" {
+			droneModels = append(droneModels, drones[i].Model)
+		}
+	}
+
+	rules, err := s.repo.ListRulesByModels(ctx, droneModels)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.flightLogRepo.TotalsByDrone(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.repo.LatestCompleted(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	completed := make(map[uuid.UUID]map[uuid.UUID]*models.MaintenanceWorkOrder, len(latest))
+	for i := range latest {
+		order := &latest[i]
+		if completed[order.DroneID] == nil {
+			completed[order.DroneID] = make(map[uuid.UUID]*models.MaintenanceWorkOrder)
+		}
+		completed[order.DroneID][*order.RuleID] = order
+	}
+
+	statuses := make([]dto.DroneMaintenanceStatus, len(drones))
+	for i := range drones {
+		statuses[i] = evaluateMaintenance(&drones[i], rules, totals[drones[i].ID], completed[drones[i].ID], now)
+	}
+	return statuses, nil
+}
+
+// findDrone 查询无人机并校验运营商范围
+func (s *maintenanceService) findDrone(ctx context.Context, id uuid.UUID, scope OperatorScope) (*models.Drone, error) {
+	drone, err := s.droneRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("无人机不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(drone.OperatorID) {
+		return nil, apperr.NewNotFound("无人机不存在")
+	}
+	return drone, nil
+}
+
+// findOpenWorkOrder 查询未关闭的工单，并校验操作人角色和运营商范围
+func (s *maintenanceService) findOpenWorkOrder(ctx context.Context, id uuid.UUID, actor Actor) (*models.MaintenanceWorkOrder, error) {
+	if !actor.HasRole(maintenanceRoles...) {
+		return nil, apperr.NewForbidden("无权处理维护工单")
+	}
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.findWorkOrder(ctx, id, scope)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.WorkOrderStatusOpen {
+		return nil, apperr.NewConflict(fmt.Sprintf("工单当前为 %s 状态，不能执行该操作", order.Status))
+	}
+	return order, nil
+}
+
+// findWorkOrder 查询工单并校验运营商范围
+func (s *maintenanceService) findWorkOrder(ctx context.Context, id uuid.UUID, scope OperatorScope) (*models.MaintenanceWorkOrder, error) {
+	order, err := s.repo.FindWorkOrderByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("维护工单不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(order.OperatorID) {
+		return nil, apperr.NewNotFound("维护工单不存在")
+	}
+	return order, nil
+}
+
+// close 持久化签核或取消结果，并发修改时返回冲突
+func (s *maintenanceService) close(ctx context.Context, order *models.MaintenanceWorkOrder) error {
+	if err := s.repo.CloseWorkOrder(ctx, order); err != nil {
+		if errors.Is(err, repositories.ErrStaleState) {
+			return apperr.NewConflict("工单状态已被其他操作修改，请刷新后重试")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// validateMaintenanceRule 校验规则的机型、名称和维护间隔
+func validateMaintenanceRule(rule *models.MaintenanceRule) error {
+	if rule.Model == "" || rule.Name == "" {
+		return apperr.NewBadRequest("机型和规则名称不能为空")
+	}
+	if rule.IntervalHours == nil && rule.IntervalFlights == nil && rule.IntervalDays == nil {
+		return apperr.NewBadRequest("飞行小时、飞行次数、日历天数至少设置一项维护间隔")
+	}
+	return nil
+}
+
+// evaluateMaintenance 计算无人机在适用规则下的维护状态
+// 各规则以最近一次签核的累计飞行时间、次数和签核时间为起点，从未签核时从零和无人机登记时间起算；
+// 任一间隔的使用比例达到 maintenanceDueRatio 为即将到期，达到 1 为超期
+func evaluateMaintenance(drone *models.Drone, rules []models.MaintenanceRule, totals repositories.FlightTotals, completed map[uuid.UUID]*models.MaintenanceWorkOrder, now time.Time) dto.DroneMaintenanceStatus {
+	status := dto.DroneMaintenanceStatus{
+		Drone: &dto.DroneBrief{
+			ID:           drone.ID,
+			SerialNumber: drone.SerialNumber,
+			Name:         drone.Name,
+			Status:       drone.Status,
+		},
+		Model:            drone.Model,
+		Status:           models.MaintenanceStatusOK,
+		TotalFlightHours: roundTo(float64(totals.Minutes)/60, 2),
+		TotalFlights:     totals.Flights,
+		Items:            []dto.MaintenanceItem{},
+	}
+
+	for _, rule := range rules {
+		if !strings.EqualFold(rule.Model, drone.Model) {
+			continue
+		}
+
+		var baseMinutes, baseFlights int64
+		since := drone.CreatedAt
+		item := dto.MaintenanceItem{RuleID: rule.ID, RuleName: rule.Name}
+		if order := completed[rule.ID]; order != nil && order.CompletedAt != nil {
+			if order.FlightMinutesAtCompletion != nil {
+				baseMinutes = *order.FlightMinutesAtCompletion
+			}
+			if order.FlightsAtCompletion != nil {
+				baseFlights = *order.FlightsAtCompletion
+			}
+			since = *order.CompletedAt
+			item.LastCompletedAt = order.CompletedAt
+		}
+		item.HoursSince = roundTo(float64(totals.Minutes-baseMinutes)/60, 2)
+		item.FlightsSince = totals.Flights - baseFlights
+		item.DaysSince = int(now.Sub(since).Hours() / 24)
+
+		ratio := 0.0
+		if rule.IntervalHours != nil {
+			remaining := roundTo(*rule.IntervalHours-item.HoursSince, 2)
+			item.HoursRemaining = &remaining
+			ratio = max(ratio, item.HoursSince / *rule.IntervalHours)
+		}
+		if rule.IntervalFlights != nil {
+			remaining := int64(*rule.IntervalFlights) - item.FlightsSince
+			item.FlightsRemaining = &remaining
+			ratio = max(ratio, float64(item.FlightsSince)/float64(*rule.IntervalFlights))
+		}
+		if rule.IntervalDays != nil {
+			dueDate := since.AddDate(0, 0, *rule.IntervalDays)
+			remaining := *rule.IntervalDays - item.DaysSince
+			item.DueDate = &dueDate
+			item.DaysRemaining = &remaining
+			ratio = max(ratio, now.Sub(since).Hours()/24/float64(*rule.IntervalDays))
+		}
+
+		switch {
+		case ratio >= 1:
+			item.Status = models.MaintenanceStatusOverdue
+			status.Status = models.MaintenanceStatusOverdue
+		case ratio >= maintenanceDueRatio:
+			item.Status = models.MaintenanceStatusDue
+			if status.Status == models.MaintenanceStatusOK {
+				status.Status = models.MaintenanceStatusDue
+			}
+		default:
+			item.Status = models.MaintenanceStatusOK
+		}
+		status.Items = append(status.Items, item)
+	}
+	return status
+}
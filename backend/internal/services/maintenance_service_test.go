@@ -0,0 +1,235 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockMaintenanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockMaintenanceRepository) CreateRule(ctx context.Context, rule *models.MaintenanceRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) FindRuleByID(ctx context.Context, id uuid.UUID) (*models.MaintenanceRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MaintenanceRule), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) UpdateRule(ctx context.Context, rule *models.MaintenanceRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) ListRules(ctx context.Context, filter repositories.MaintenanceRuleFilter) ([]models.MaintenanceRule, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.MaintenanceRule), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMaintenanceRepository) ListRulesByModels(ctx context.Context, droneModels []string) ([]models.MaintenanceRule, error) {
+	args := m.Called(ctx, droneModels)
+	return args.Get(0).([]models.MaintenanceRule), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) CreateWorkOrder(ctx context.Context, order *models.MaintenanceWorkOrder) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) FindWorkOrderByID(ctx context.Context, id uuid.UUID) (*models.MaintenanceWorkOrder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MaintenanceWorkOrder), args.Error(1)
+}
+
+func (m *MockMaintenanceRepository) CloseWorkOrder(ctx context.Context, order *models.MaintenanceWorkOrder) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockMaintenanceRepository) ListWorkOrders(ctx context.Context, filter repositories.MaintenanceWorkOrderFilter) ([]models.MaintenanceWorkOrder, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.MaintenanceWorkOrder), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockMaintenanceRepository) LatestCompleted(ctx context.Context, droneIDs []uuid.UUID) ([]models.MaintenanceWorkOrder, error) {
+	args := m.Called(ctx, droneIDs)
+	return args.Get(0).([]models.MaintenanceWorkOrder), args.Error(1)
+}
+
+type MockMaintenanceChecker struct {
+	mock.Mock
+}
+
+func (m *MockMaintenanceChecker) Overdue(ctx context.Context, drone *models.Drone) ([]dto.MaintenanceItem, error) {
+	args := m.Called(ctx, drone)
+	return args.Get(0).([]dto.MaintenanceItem), args.Error(1)
+}
+
+func (m *MockMaintenanceChecker) CountOverdueByOperator(ctx context.Context, operatorID *uuid.UUID) (map[uuid.UUID]int64, error) {
+	args := m.Called(ctx, operatorID)
+	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
+}
+
+func newAdminActor() Actor {
+	id := uuid.New()
+	return Actor{UserID: &id, Username: "admin", Role: RoleAdmin}
+}
+
+func TestEvaluateMaintenance(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-001", Model: "Matrice 300", CreatedAt: now.AddDate(0, 0, -100)}
+	propellers := models.MaintenanceRule{ID: uuid.New(), Model: "matrice 300", Name: "螺旋桨检查", IntervalHours: floatPtr(50), IntervalFlights: intPtr(200)}
+	annual := models.MaintenanceRule{ID: uuid.New(), Model: "Matrice 300", Name: "年度检修", IntervalDays: intPtr(365)}
+	other := models.MaintenanceRule{ID: uuid.New(), Model: "Mavic 3", Name: "其他机型", IntervalDays: intPtr(1)}
+	rules := []models.MaintenanceRule{propellers, annual, other}
+
+	// 累计 56 小时 150 次，螺旋桨检查从未签核：按飞行小时超期
+	totals := repositories.FlightTotals{Minutes: 56 * 60, Flights: 150}
+	status := evaluateMaintenance(drone, rules, totals, nil, now)
+	assert.Equal(t, models.MaintenanceStatusOverdue, status.Status)
+	assert.Equal(t, 56.0, status.TotalFlightHours)
+	require.Len(t, status.Items, 2)
+	assert.Equal(t, models.MaintenanceStatusOverdue, status.Items[0].Status)
+	assert.Equal(t, -6.0, *status.Items[0].HoursRemaining)
+	assert.Equal(t, int64(50), *status.Items[0].FlightsRemaining)
+	assert.Nil(t, status.Items[0].DaysRemaining)
+	assert.Equal(t, models.MaintenanceStatusOK, status.Items[1].Status)
+	assert.Equal(t, 265, *status.Items[1].DaysRemaining)
+
+	// 10 天前签核时累计 10 小时 20 次，本周期 46 小时 130 次：即将到期
+	completedAt := now.AddDate(0, 0, -10)
+	minutes, flights := int64(10*60), int64(20)
+	completed := map[uuid.UUID]*models.MaintenanceWorkOrder{propellers.ID: {
+		RuleID: &propellers.ID, CompletedAt: &completedAt, FlightMinutesAtCompletion: &minutes, FlightsAtCompletion: &flights,
+	}}
+	status = evaluateMaintenance(drone, rules, totals, completed, now)
+	assert.Equal(t, models.MaintenanceStatusDue, status.Status)
+	assert.Equal(t, 46.0, status.Items[0].HoursSince)
+	assert.Equal(t, int64(130), status.Items[0].FlightsSince)
+	assert.Equal(t, 10, status.Items[0].DaysSince)
+	assert.Equal(t, &completedAt, status.Items[0].LastCompletedAt)
+
+	// 日历天数到期
+	drone.CreatedAt = now.AddDate(0, 0, -366)
+	status = evaluateMaintenance(drone, rules, repositories.FlightTotals{}, nil, now)
+	assert.Equal(t, models.MaintenanceStatusOverdue, status.Items[1].Status)
+	assert.Equal(t, -1, *status.Items[1].DaysRemaining)
+	assert.Equal(t, now.AddDate(0, 0, -1), *status.Items[1].DueDate)
+}
+
+func TestCreateMaintenanceRuleRequiresInterval(t *testing.T) {
+	repo := new(MockMaintenanceRepository)
+	service := NewMaintenanceService(repo, nil, nil, nil)
+
+	_, err := service.CreateRule(context.Background(), &dto.CreateMaintenanceRuleRequest{Model: "Matrice 300", Name: "螺旋桨检查"})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+	repo.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything)
+}
+
+func TestOpenWorkOrderValidatesRule(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	droneRepo := new(MockDroneRepository)
+	service := NewMaintenanceService(repo, droneRepo, nil, new(MockUserRepository))
+
+	drone := &models.Drone{ID: uuid.New(), Model: "Matrice 300"}
+	rule := &models.MaintenanceRule{ID: uuid.New(), Model: "Matrice 300", Name: "螺旋桨检查"}
+	mavic := &models.MaintenanceRule{ID: uuid.New(), Model: "Mavic 3", Name: "电池检查"}
+	droneRepo.On("FindByID", ctx, drone.ID).Return(drone, nil)
+	repo.On("FindRuleByID", ctx, rule.ID).Return(rule, nil)
+	repo.On("FindRuleByID", ctx, mavic.ID).Return(mavic, nil)
+
+	_, err := service.OpenWorkOrder(ctx, &dto.CreateWorkOrderRequest{DroneID: drone.ID, RuleID: &mavic.ID}, newAdminActor())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	repo.On("ListWorkOrders", ctx, mock.MatchedBy(func(f repositories.MaintenanceWorkOrderFilter) bool {
+		return *f.DroneID == drone.ID && *f.RuleID == rule.ID
+	})).Return([]models.MaintenanceWorkOrder{}, int64(1), nil)
+	_, err = service.OpenWorkOrder(ctx, &dto.CreateWorkOrderRequest{DroneID: drone.ID, RuleID: &rule.ID}, newAdminActor())
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	_, err = service.OpenWorkOrder(ctx, &dto.CreateWorkOrderRequest{DroneID: drone.ID}, Actor{Role: RolePilot})
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+	repo.AssertNotCalled(t, "CreateWorkOrder", mock.Anything, mock.Anything)
+}
+
+func TestSignOffWorkOrderRequiresIndependentSigner(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMaintenanceRepository)
+	service := NewMaintenanceService(repo, nil, nil, new(MockUserRepository))
+
+	opener := newAdminActor()
+	order := &models.MaintenanceWorkOrder{ID: uuid.New(), DroneID: uuid.New(), Status: models.WorkOrderStatusOpen, OpenedBy: opener.UserID}
+	repo.On("FindWorkOrderByID", ctx, order.ID).Return(order, nil)
+
+	_, err := service.SignOffWorkOrder(ctx, order.ID, &dto.SignOffWorkOrderRequest{WorkPerformed: "更换螺旋桨"}, opener)
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+	_, err = service.SignOffWorkOrder(ctx, order.ID, &dto.SignOffWorkOrderRequest{WorkPerformed: "  "}, newAdminActor())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	order.Status = models.WorkOrderStatusCancelled
+	_, err = service.SignOffWorkOrder(ctx, order.ID, &dto.SignOffWorkOrderRequest{WorkPerformed: "更换螺旋桨"}, newAdminActor())
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	repo.AssertNotCalled(t, "CloseWorkOrder", mock.Anything, mock.Anything)
+}
+
+func TestMissionApproveBlockedByOverdueMaintenance(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	maintenance := new(MockMaintenanceChecker)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, maintenance)
+
+	mission := newPendingMission(nil)
+	mission.Drone.ID, mission.Drone.SerialNumber = mission.DroneID, "DJI-001"
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	maintenance.On("Overdue", mock.Anything, &mission.Drone).
+		Return([]dto.MaintenanceItem{{RuleName: "螺旋桨检查", Status: models.MaintenanceStatusOverdue}}, nil)
+
+	_, err := service.Approve(context.Background(), mission.ID, &dto.MissionActionRequest{}, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	assert.Contains(t, err.Error(), "螺旋桨检查")
+	repo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMissionStartBlockedByMaintenanceOverdueAfterApproval(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	maintenance := new(MockMaintenanceChecker)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, maintenance)
+
+	// 审批通过后维护才超期
+	mission := newPendingMission(nil)
+	mission.MissionStatus = models.MissionStatusApproved
+	mission.ApprovalStatus = stringPtr(models.ApprovalStatusApproved)
+	mission.Drone.ID, mission.Drone.SerialNumber = mission.DroneID, "DJI-001"
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	maintenance.On("Overdue", mock.Anything, &mission.Drone).
+		Return([]dto.MaintenanceItem{{RuleName: "电池循环检查", Status: models.MaintenanceStatusOverdue}}, nil)
+
+	_, err := service.Start(context.Background(), mission.ID, &dto.MissionActionRequest{}, newAdminActor())
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	assert.Contains(t, err.Error(), "电池循环检查")
+	repo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
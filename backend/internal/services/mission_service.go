@@ -0,0 +1,665 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MissionService 无人机任务服务接口
+// 飞手、运营商提交和执行任务，监管人员、管理员审批；每次操作写入审计记录
+// 提交和修改时返回与禁飞区的冲突，存在冲突的任务不能审批通过；执行中的任务结束时生成飞行日志
+type MissionService interface {
+	ListMissions(ctx context.Context, query *dto.MissionQuery, actor Actor) (*dto.PageResponse[dto.MissionResponse], error)
+	GetMission(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneMission, error)
+	SubmitMission(ctx context.Context, req *dto.CreateMissionRequest, actor Actor) (*models.DroneMission, []dto.ZoneConflict, error)
+	UpdateMission(ctx context.Context, id uuid.UUID, req *dto.UpdateMissionRequest, actor Actor) (*models.DroneMission, []dto.ZoneConflict, error)
+	Conflicts(ctx context.Context, id uuid.UUID, actor Actor) ([]dto.ZoneConflict, error)
+	Approve(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error)
+	Reject(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error)
+	Start(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error)
+	Complete(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error)
+	Cancel(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error)
+	ListLogs(ctx context.Context, id uuid.UUID, actor Actor) ([]models.DroneMissionLog, error)
+}
+
+// missionTransitions 允许的任务状态迁移
+// completed 与 cancelled 为终态；需要审批的任务必须先经 approved 才能开始
+var missionTransitions = map[string][]string{
+	models.MissionStatusPlanned:    {models.MissionStatusApproved, models.MissionStatusInProgress, models.MissionStatusCancelled},
+	models.MissionStatusApproved:   {models.MissionStatusInProgress, models.MissionStatusCancelled},
+	models.MissionStatusInProgress: {models.MissionStatusCompleted, models.MissionStatusCancelled},
+}
+
+// 可以提交和执行任务的角色
+var missionPlannerRoles = []string{RoleAdmin, RoleOperator, RolePilot}
+
+// 可以审批任务的角色
+var missionReviewerRoles = []string{RoleAdmin, RoleRegulator}
+
+type missionService struct {
+	repo        repositories.DroneMissionRepository
+	droneRepo   repositories.DroneRepository
+	userRepo    repositories.UserRepository
+	checker     NoFlyZoneChecker
+	flightLogs  FlightLogService
+	maintenance MaintenanceChecker
+}
+
+// NewMissionService 创建无人机任务服务实例
+// flightLogs 为空时不生成飞行日志，maintenance 为空时不检查维护超期
+func NewMissionService(repo repositories.DroneMissionRepository, droneRepo repositories.DroneRepository, userRepo repositories.UserRepository, checker NoFlyZoneChecker, flightLogs FlightLogService, maintenance MaintenanceChecker) MissionService {
+	return &missionService{
+		repo:        repo,
+		droneRepo:   droneRepo,
+		userRepo:    userRepo,
+		checker:     checker,
+		flightLogs:  flightLogs,
+		maintenance: maintenance,
+	}
+}
+
+// ListMissions 分页查询任务，运营商用户和飞手仅能看到所属运营商的任务
+func (s *missionService) ListMissions(ctx context.Context, query *dto.MissionQuery, actor Actor) (*dto.PageResponse[dto.MissionResponse], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.Normalize()
+
+	missions, total, err := s.repo.List(ctx, repositories.DroneMissionFilter{
+		OperatorID:       scope.Filter(query.OperatorID),
+		DroneID:          query.DroneID,
+		PilotID:          query.PilotID,
+		Statuses:         splitStatuses(query.Status),
+		ApprovalStatuses: splitStatuses(query.ApprovalStatus),
+		From:             query.From,
+		To:               query.To,
+		Offset:           query.Offset(),
+		Limit:            query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToMissionResponseList(missions), total, query.PageQuery), nil
+}
+
+// GetMission 获取任务详情，范围外的任务视为不存在
+func (s *missionService) GetMission(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneMission, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.findMission(ctx, id, scope)
+}
+
+// SubmitMission 提交任务，需要审批的任务进入待审批状态
+// 管理员豁免审批时记录豁免人和时间，并写入审计记录
+func (s *missionService) SubmitMission(ctx context.Context, req *dto.CreateMissionRequest, actor Actor) (*models.DroneMission, []dto.ZoneConflict, error) {
+	if !actor.HasRole(missionPlannerRoles...) {
+		return nil, nil, apperr.NewForbidden("无权提交无人机任务")
+	}
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	drone, err := s.findDrone(ctx, req.DroneID, scope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pilotID := req.PilotID
+	if actor.Role == RolePilot {
+		pilotID = actor.UserID
+	}
+	if err := s.ensurePilot(ctx, pilotID); err != nil {
+		return nil, nil, err
+	}
+
+	// 只有管理员可以豁免审批，其他角色提交的任务一律需要审批
+	waived := req.RequiresApproval != nil && !*req.RequiresApproval && actor.HasRole(RoleAdmin)
+	requiresApproval := !waived
+
+	mission := &models.DroneMission{
+		DroneID:             drone.ID,
+		OperatorID:          *drone.OperatorID,
+		PilotID:             pilotID,
+		SubmittedBy:         actor.UserID,
+		MissionName:         req.MissionName,
+		MissionType:         req.MissionType,
+		MissionStatus:       models.MissionStatusPlanned,
+		Priority:            defaultString(req.Priority, "normal"),
+		PlannedStartTime:    req.PlannedStartTime,
+		PlannedEndTime:      req.PlannedEndTime,
+		PlannedAltitude:     req.PlannedAltitude,
+		PlannedSpeed:        req.PlannedSpeed,
+		PlannedDistance:     req.PlannedDistance,
+		RequiresApproval:    requiresApproval,
+		Description:         req.Description,
+		Objectives:          req.Objectives,
+		SpecialRequirements: req.SpecialRequirements,
+		BackupPlan:          req.BackupPlan,
+	}
+	notes := ""
+	if waived {
+		now := time.Now()
+		mission.ApprovalStatus = stringPtr(models.ApprovalStatusWaived)
+		mission.ApprovedBy = actor.UserID
+		mission.ApprovalTime = &now
+		notes = "管理员豁免审批"
+	} else {
+		mission.ApprovalStatus = stringPtr(models.ApprovalStatusPending)
+	}
+
+	if err := applyMissionLocations(mission, &req.DepartureLocation, req.ArrivalLocation, &req.Waypoints, req.FlightArea); err != nil {
+		return nil, nil, err
+	}
+	if err := validateMissionWindow(mission); err != nil {
+		return nil, nil, err
+	}
+
+	conflicts, err := s.checker.CheckMission(ctx, mission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log := newMissionLog(mission, models.MissionActionSubmit, "", notes, actor)
+	if err := s.repo.Create(ctx, mission, log); err != nil {
+		return nil, nil, apperr.NewInternalError(err)
+	}
+	mission.Drone = *drone
+	return mission, conflicts, nil
+}
+
+// UpdateMission 修改计划中的任务，被驳回的任务修改后重新进入待审批
+// 豁免审批的任务只保留管理员修改后的豁免，其他角色修改后重新进入待审批
+func (s *missionService) UpdateMission(ctx context.Context, id uuid.UUID, req *dto.UpdateMissionRequest, actor Actor) (*models.DroneMission, []dto.ZoneConflict, error) {
+	mission, scope, err := s.findForPlanner(ctx, id, actor)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mission.MissionStatus != models.MissionStatusPlanned {
+		return nil, nil, apperr.NewConflict("只有计划中的任务可以修改")
+	}
+	from, fromApproval := mission.MissionStatus, mission.ApprovalStatus
+
+	if req.DroneID != nil && *req.DroneID != mission.DroneID {
+		drone, err := s.findDrone(ctx, *req.DroneID, scope)
+		if err != nil {
+			return nil, nil, err
+		}
+		if *drone.OperatorID != mission.OperatorID {
+			return nil, nil, apperr.NewBadRequest("不能将任务改派给其他运营商的无人机")
+		}
+		mission.DroneID = drone.ID
+		mission.Drone = *drone
+	}
+	if req.PilotID != nil && actor.Role != RolePilot {
+		if err := s.ensurePilot(ctx, req.PilotID); err != nil {
+			return nil, nil, err
+		}
+		mission.PilotID = req.PilotID
+	}
+	if req.MissionName != nil {
+		mission.MissionName = *req.MissionName
+	}
+	if req.MissionType != nil {
+		mission.MissionType = *req.MissionType
+	}
+	if req.Priority != nil {
+		mission.Priority = *req.Priority
+	}
+	if req.PlannedStartTime != nil {
+		mission.PlannedStartTime = *req.PlannedStartTime
+	}
+	if req.PlannedEndTime != nil {
+		mission.PlannedEndTime = *req.PlannedEndTime
+	}
+	if req.PlannedAltitude != nil {
+		mission.PlannedAltitude = req.PlannedAltitude
+	}
+	if req.PlannedSpeed != nil {
+		mission.PlannedSpeed = req.PlannedSpeed
+	}
+	if req.PlannedDistance != nil {
+		mission.PlannedDistance = req.PlannedDistance
+	}
+	if req.Description != nil {
+		mission.Description = req.Description
+	}
+	if req.Objectives != nil {
+		mission.Objectives = req.Objectives
+	}
+	if req.SpecialRequirements != nil {
+		mission.SpecialRequirements = req.SpecialRequirements
+	}
+	if req.BackupPlan != nil {
+		mission.BackupPlan = req.BackupPlan
+	}
+
+	if err := applyMissionLocations(mission, req.DepartureLocation, req.ArrivalLocation, req.Waypoints, req.FlightArea); err != nil {
+		return nil, nil, err
+	}
+	if err := validateMissionWindow(mission); err != nil {
+		return nil, nil, err
+	}
+
+	// 驳回后修改视为重新提交，清空上一次的审批结论；豁免不随任务转给其他角色修改后的计划
+	rejected := mission.ApprovalStatus != nil && *mission.ApprovalStatus == models.ApprovalStatusRejected
+	waived := mission.ApprovalStatus != nil && *mission.ApprovalStatus == models.ApprovalStatusWaived
+	if rejected || (waived && !actor.HasRole(RoleAdmin)) {
+		mission.RequiresApproval = true
+		mission.ApprovalStatus = stringPtr(models.ApprovalStatusPending)
+		mission.SubmittedBy = actor.UserID
+		mission.ApprovedBy = nil
+		mission.ApprovalTime = nil
+		mission.ApprovalNotes = nil
+	}
+
+	conflicts, err := s.checker.CheckMission(ctx, mission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log := newMissionLog(mission, models.MissionActionUpdate, mission.MissionStatus, "", actor)
+	if err := s.repo.Update(ctx, mission, from, fromApproval, log); err != nil {
+		if errors.Is(err, repositories.ErrStaleState) {
+			return nil, nil, apperr.NewConflict("任务已被其他操作修改，请刷新后重试")
+		}
+		return nil, nil, apperr.NewInternalError(err)
+	}
+	return mission, conflicts, nil
+}
+
+// Conflicts 按当前禁飞区数据重新检查任务冲突
+func (s *missionService) Conflicts(ctx context.Context, id uuid.UUID, actor Actor) ([]dto.ZoneConflict, error) {
+	mission, err := s.GetMission(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.checker.CheckMission(ctx, mission)
+}
+
+// Approve 审批通过任务，提交人不能审批自己的任务，维护超期的无人机不能通过审批
+func (s *missionService) Approve(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error) {
+	mission, err := s.findForReviewer(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureNoConflicts(ctx, mission); err != nil {
+		return nil, err
+	}
+	if err := s.ensureAirworthy(ctx, mission); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from, fromApproval := mission.MissionStatus, mission.ApprovalStatus
+	mission.MissionStatus = models.MissionStatusApproved
+	mission.ApprovalStatus = stringPtr(models.ApprovalStatusApproved)
+	mission.ApprovedBy = actor.UserID
+	mission.ApprovalTime = &now
+	mission.ApprovalNotes = optionalString(req.Notes)
+
+	log := newMissionLog(mission, models.MissionActionApprove, from, req.Notes, actor)
+	return s.changeStatus(ctx, mission, from, fromApproval, log, "")
+}
+
+// Reject 驳回任务，必须填写驳回意见；任务保持计划状态，修改后可重新审批
+func (s *missionService) Reject(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error) {
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		return nil, apperr.NewBadRequest("驳回任务必须填写审批意见")
+	}
+
+	mission, err := s.findForReviewer(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from, fromApproval := mission.MissionStatus, mission.ApprovalStatus
+	mission.ApprovalStatus = stringPtr(models.ApprovalStatusRejected)
+	mission.ApprovedBy = actor.UserID
+	mission.ApprovalTime = &now
+	mission.ApprovalNotes = &notes
+
+	log := newMissionLog(mission, models.MissionActionReject, from, notes, actor)
+	return s.changeStatus(ctx, mission, from, fromApproval, log, "")
+}
+
+// Start 开始执行任务，记录实际开始时间并将无人机置为飞行中
+func (s *missionService) Start(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error) {
+	mission, _, err := s.findForPlanner(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMissionTransition(mission, models.MissionStatusInProgress); err != nil {
+		return nil, err
+	}
+	if mission.RequiresApproval && mission.MissionStatus != models.MissionStatusApproved {
+		return nil, apperr.NewConflict("任务尚未审批通过，不能开始执行")
+	}
+	// 审批后到开始前可能新增禁飞区或发生维护超期，开始时一律重新检查
+	if err := s.ensureNoConflicts(ctx, mission); err != nil {
+		return nil, err
+	}
+	if err := s.ensureAirworthy(ctx, mission); err != nil {
+		return nil, err
+	}
+	if !canTransitDrone(mission.Drone.Status, models.DroneStatusFlying) {
+		return nil, apperr.NewConflict(fmt.Sprintf("无人机当前状态为 %s，不能执行任务", mission.Drone.Status))
+	}
+
+	now := time.Now()
+	from, fromApproval := mission.MissionStatus, mission.ApprovalStatus
+	mission.MissionStatus = models.MissionStatusInProgress
+	mission.ActualStartTime = &now
+
+	log := newMissionLog(mission, models.MissionActionStart, from, req.Notes, actor)
+	return s.changeStatus(ctx, mission, from, fromApproval, log, models.DroneStatusFlying)
+}
+
+// Complete 完成任务，记录实际结束时间并将无人机恢复为空闲
+func (s *missionService) Complete(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error) {
+	mission, _, err := s.findForPlanner(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMissionTransition(mission, models.MissionStatusCompleted); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from, fromApproval := mission.MissionStatus, mission.ApprovalStatus
+	mission.MissionStatus = models.MissionStatusCompleted
+	mission.ActualEndTime = &now
+
+	log := newMissionLog(mission, models.MissionActionComplete, from, req.Notes, actor)
+	if _, err := s.changeStatus(ctx, mission, from, fromApproval, log, landedDroneStatus(mission)); err != nil {
+		return nil, err
+	}
+	s.generateFlightLog(ctx, mission)
+	return mission, nil
+}
+
+// Cancel 取消任务，执行中的任务取消时记录实际结束时间
+func (s *missionService) Cancel(ctx context.Context, id uuid.UUID, req *dto.MissionActionRequest, actor Actor) (*models.DroneMission, error) {
+	mission, _, err := s.findForPlanner(ctx, id, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMissionTransition(mission, models.MissionStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	from, fromApproval := mission.MissionStatus, mission.ApprovalStatus
+	droneStatus := ""
+	if from == models.MissionStatusInProgress {
+		now := time.Now()
+		mission.ActualEndTime = &now
+		droneStatus = landedDroneStatus(mission)
+	}
+	mission.MissionStatus = models.MissionStatusCancelled
+
+	log := newMissionLog(mission, models.MissionActionCancel, from, req.Notes, actor)
+	if _, err := s.changeStatus(ctx, mission, from, fromApproval, log, droneStatus); err != nil {
+		return nil, err
+	}
+	if from == models.MissionStatusInProgress {
+		s.generateFlightLog(ctx, mission)
+	}
+	return mission, nil
+}
+
+// ListLogs 查询任务审计记录
+func (s *missionService) ListLogs(ctx context.Context, id uuid.UUID, actor Actor) ([]models.DroneMissionLog, error) {
+	if _, err := s.GetMission(ctx, id, actor); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.ListLogs(ctx, id)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return logs, nil
+}
+
+// changeStatus 持久化状态变更，并发修改时返回冲突
+func (s *missionService) changeStatus(ctx context.Context, mission *models.DroneMission, from string, fromApproval *string, log *models.DroneMissionLog, droneStatus string) (*models.DroneMission, error) {
+	if err := s.repo.ChangeStatus(ctx, mission, from, fromApproval, log, droneStatus); err != nil {
+		if errors.Is(err, repositories.ErrStaleState) {
+			return nil, apperr.NewConflict("任务或无人机状态已被其他操作修改，请刷新后重试")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if droneStatus != "" {
+		mission.Drone.Status = droneStatus
+	}
+	return mission, nil
+}
+
+// generateFlightLog 汇总任务的位置点生成飞行日志，失败不影响任务状态变更
+func (s *missionService) generateFlightLog(ctx context.Context, mission *models.DroneMission) {
+	if s.flightLogs == nil {
+		return
+	}
+	if _, err := s.flightLogs.GenerateForMission(ctx, mission); err != nil {
+		logger.Errorf("[MissionService] 生成飞行日志失败: mission=%s, err=%v", mission.ID, err)
+	}
+}
+
+// ensureNoConflicts 任务存在禁飞区冲突时返回冲突错误
+func (s *missionService) ensureNoConflicts(ctx context.Context, mission *models.DroneMission) error {
+	conflicts, err := s.checker.CheckMission(ctx, mission)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return apperr.NewConflict(fmt.Sprintf("任务与 %d 个禁飞区冲突（%s 等），请调整航线或飞行区域", len(conflicts), conflicts[0].ZoneName))
+	}
+	return nil
+}
+
+// ensureAirworthy 任务无人机有维护项超期时返回冲突错误
+func (s *missionService) ensureAirworthy(ctx context.Context, mission *models.DroneMission) error {
+	if s.maintenance == nil || mission.Drone.ID == uuid.Nil {
+		return nil
+	}
+	overdue, err := s.maintenance.Overdue(ctx, &mission.Drone)
+	if err != nil {
+		return apperr.NewInternalError(err)
+	}
+	if len(overdue) > 0 {
+		return apperr.NewConflict(fmt.Sprintf("无人机 %s 有 %d 项维护已超期（%s 等），请完成维护后再执行任务", mission.Drone.SerialNumber, len(overdue), overdue[0].RuleName))
+	}
+	return nil
+}
+
+// findForPlanner 查询任务并校验提交/执行权限，飞手只能操作自己的任务
+func (s *missionService) findForPlanner(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneMission, OperatorScope, error) {
+	if !actor.HasRole(missionPlannerRoles...) {
+		return nil, OperatorScope{}, apperr.NewForbidden("无权操作无人机任务")
+	}
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, OperatorScope{}, err
+	}
+	mission, err := s.findMission(ctx, id, scope)
+	if err != nil {
+		return nil, OperatorScope{}, err
+	}
+	if actor.Role == RolePilot && !sameUser(mission.PilotID, actor.UserID) {
+		return nil, OperatorScope{}, apperr.NewForbidden("飞手只能操作自己的任务")
+	}
+	return mission, scope, nil
+}
+
+// findForReviewer 查询待审批任务并校验审批权限
+func (s *missionService) findForReviewer(ctx context.Context, id uuid.UUID, actor Actor) (*models.DroneMission, error) {
+	if !actor.HasRole(missionReviewerRoles...) {
+		return nil, apperr.NewForbidden("只有监管人员或管理员可以审批任务")
+	}
+	mission, err := s.findMission(ctx, id, OperatorScope{All: true})
+	if err != nil {
+		return nil, err
+	}
+	if !mission.RequiresApproval {
+		return nil, apperr.NewConflict("该任务无需审批")
+	}
+	if mission.MissionStatus != models.MissionStatusPlanned ||
+		mission.ApprovalStatus == nil || *mission.ApprovalStatus != models.ApprovalStatusPending {
+		return nil, apperr.NewConflict("任务不处于待审批状态")
+	}
+	if sameUser(mission.PilotID, actor.UserID) {
+		return nil, apperr.NewForbidden("不能审批自己执飞的任务")
+	}
+	if sameUser(mission.SubmittedBy, actor.UserID) {
+		return nil, apperr.NewForbidden("不能审批自己提交的任务")
+	}
+	return mission, nil
+}
+
+// findMission 查询任务并校验运营商范围
+func (s *missionService) findMission(ctx context.Context, id uuid.UUID, scope OperatorScope) (*models.DroneMission, error) {
+	mission, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("任务不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(&mission.OperatorID) {
+		return nil, apperr.NewNotFound("任务不存在")
+	}
+	return mission, nil
+}
+
+// findDrone 查询执行任务的无人机，无人机必须归属运营商
+func (s *missionService) findDrone(ctx context.Context, id uuid.UUID, scope OperatorScope) (*models.Drone, error) {
+	drone, err := s.droneRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewBadRequest("无人机不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	if !scope.Allows(drone.OperatorID) {
+		return nil, apperr.NewBadRequest("无人机不存在")
+	}
+	if drone.OperatorID == nil {
+		return nil, apperr.NewBadRequest("无人机未归属运营商，不能提交任务")
+	}
+	return drone, nil
+}
+
+// ensurePilot 校验飞手存在
+func (s *missionService) ensurePilot(ctx context.Context, pilotID *uuid.UUID) error {
+	if pilotID == nil {
+		return nil
+	}
+	if _, err := s.userRepo.FindByID(ctx, *pilotID); err != nil {
+		return apperr.NewBadRequest("飞手不存在")
+	}
+	return nil
+}
+
+// applyMissionLocations 将请求中的起降点、航点和飞行区域写入任务的 jsonb 字段，nil 表示不修改
+// 飞行区域按右手规则调整环方向后保存
+func applyMissionLocations(mission *models.DroneMission, departure, arrival *dto.MissionLocation, waypoints *[]dto.MissionWaypoint, flightArea *geo.Geometry) error {
+	if departure != nil {
+		data, err := json.Marshal(departure)
+		if err != nil {
+			return apperr.NewBadRequest("无效的起飞点")
+		}
+		mission.DepartureLocation = string(data)
+	}
+	if arrival != nil {
+		data, err := json.Marshal(arrival)
+		if err != nil {
+			return apperr.NewBadRequest("无效的降落点")
+		}
+		mission.ArrivalLocation = stringPtr(string(data))
+	}
+	if waypoints != nil {
+		mission.Waypoints = dto.MissionWaypointsGeometry(*waypoints)
+	}
+	if flightArea != nil {
+		if err := validateFlightArea(flightArea); err != nil {
+			return apperr.NewBadRequest("无效的飞行区域: " + err.Error())
+		}
+		flightArea.Rewind()
+		mission.FlightArea = flightArea
+	}
+	return nil
+}
+
+// validateMissionWindow 校验计划时间窗口
+func validateMissionWindow(mission *models.DroneMission) error {
+	if !mission.PlannedEndTime.After(mission.PlannedStartTime) {
+		return apperr.NewBadRequest("计划结束时间必须晚于计划开始时间")
+	}
+	return nil
+}
+
+// checkMissionTransition 校验任务状态迁移
+func checkMissionTransition(mission *models.DroneMission, target string) error {
+	for _, allowed := range missionTransitions[mission.MissionStatus] {
+		if allowed == target {
+			return nil
+		}
+	}
+	return apperr.NewConflict(fmt.Sprintf("任务状态不允许从 %s 变更为 %s", mission.MissionStatus, target))
+}
+
+// landedDroneStatus 任务结束后无人机的状态，仅飞行中的无人机恢复为空闲
+func landedDroneStatus(mission *models.DroneMission) string {
+	if mission.Drone.Status == models.DroneStatusFlying {
+		return models.DroneStatusIdle
+	}
+	return ""
+}
+
+// newMissionLog 生成任务审计记录
+func newMissionLog(mission *models.DroneMission, action, from, notes string, actor Actor) *models.DroneMissionLog {
+	return &models.DroneMissionLog{
+		MissionID:      mission.ID,
+		Action:         action,
+		FromStatus:     from,
+		ToStatus:       mission.MissionStatus,
+		ApprovalStatus: mission.ApprovalStatus,
+		Notes:          notes,
+		ActorID:        actor.UserID,
+		ActorName:      actor.Username,
+		ActorRole:      actor.Role,
+	}
+}
+
+func sameUser(a, b *uuid.UUID) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDroneMissionRepository 模拟无人机任务仓储
+type MockDroneMissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockDroneMissionRepository) Create(ctx context.Context, mission *models.DroneMission, log *models.DroneMissionLog) error {
+	args := m.Called(ctx, mission, log)
+	return args.Error(0)
+}
+
+func (m *MockDroneMissionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.DroneMission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DroneMission), args.Error(1)
+}
+
+func (m *MockDroneMissionRepository) FindActiveByDrone(ctx context.Context, droneID uuid.UUID) (*models.DroneMission, error) {
+	args := m.Called(ctx, droneID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DroneMission), args.Error(1)
+}
+
+func (m *MockDroneMissionRepository) Update(ctx context.Context, mission *models.DroneMission, fromStatus string, fromApproval *string, log *models.DroneMissionLog) error {
+	args := m.Called(ctx, mission, fromStatus, fromApproval, log)
+	return args.Error(0)
+}
+
+func (m *MockDroneMissionRepository) ChangeStatus(ctx context.Context, mission *models.DroneMission, fromStatus string, fromApproval *string, log *models.DroneMissionLog, droneStatus string) error {
+	args := m.Called(ctx, mission, fromStatus, fromApproval, log, droneStatus)
+	return args.Error(0)
+}
+
+func (m *MockDroneMissionRepository) List(ctx context.Context, filter repositories.DroneMissionFilter) ([]models.DroneMission, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.DroneMission), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDroneMissionRepository) ListLogs(ctx context.Context, missionID uuid.UUID) ([]models.DroneMissionLog, error) {
+	args := m.Called(ctx, missionID)
+	return args.Get(0).([]models.DroneMissionLog), args.Error(1)
+}
+
+func (m *MockDroneMissionRepository) ApprovalCountsByOperator(ctx context.Context, operatorID *uuid.UUID, from, to time.Time) (map[uuid.UUID][]repositories.MissionApprovalCount, error) {
+	args := m.Called(ctx, operatorID, from, to)
+	return args.Get(0).(map[uuid.UUID][]repositories.MissionApprovalCount), args.Error(1)
+}
+
+// MockNoFlyZoneRepository 模拟禁飞区仓储
+type MockNoFlyZoneRepository struct {
+	mock.Mock
+}
+
+func (m *MockNoFlyZoneRepository) Create(ctx context.Context, zone *models.NoFlyZone) error {
+	args := m.Called(ctx, zone)
+	return args.Error(0)
+}
+
+func (m *MockNoFlyZoneRepository) CreateBatch(ctx context.Context, zones []models.NoFlyZone) error {
+	args := m.Called(ctx, zones)
+	return args.Error(0)
+}
+
+func (m *MockNoFlyZoneRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.NoFlyZone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NoFlyZone), args.Error(1)
+}
+
+func (m *MockNoFlyZoneRepository) Update(ctx context.Context, zone *models.NoFlyZone) error {
+	args := m.Called(ctx, zone)
+	return args.Error(0)
+}
+
+func (m *MockNoFlyZoneRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockNoFlyZoneRepository) List(ctx context.Context, filter repositories.NoFlyZoneFilter) ([]models.NoFlyZone, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]models.NoFlyZone), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNoFlyZoneRepository) ListActive(ctx context.Context, from, to time.Time) ([]models.NoFlyZone, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]models.NoFlyZone), args.Error(1)
+}
+
+func (m *MockNoFlyZoneRepository) ExpireEnded(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// newZoneRepo 创建返回指定禁飞区的模拟仓储
+func newZoneRepo(zones ...models.NoFlyZone) *MockNoFlyZoneRepository {
+	repo := new(MockNoFlyZoneRepository)
+	repo.On("ListActive", mock.Anything, mock.Anything, mock.Anything).Return(zones, nil)
+	return repo
+}
+
+func newPendingMission(pilotID *uuid.UUID) *models.DroneMission {
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	return &models.DroneMission{
+		ID:                uuid.New(),
+		DroneID:           uuid.New(),
+		OperatorID:        uuid.New(),
+		PilotID:           pilotID,
+		MissionName:       "电力巡检",
+		MissionStatus:     models.MissionStatusPlanned,
+		PlannedStartTime:  start,
+		PlannedEndTime:    start.Add(time.Hour),
+		DepartureLocation: `{"lat":31.20,"lng":121.40}`,
+		Waypoints:         geo.NewMultiPoint([]geo.Position{geo.NewPosition(31.20, 121.50, 120)}),
+		RequiresApproval:  true,
+		ApprovalStatus:    stringPtr(models.ApprovalStatusPending),
+		Drone:             models.Drone{Status: models.DroneStatusIdle},
+	}
+}
+
+func newReviewer() Actor {
+	id := uuid.New()
+	return Actor{UserID: &id, Username: "caac", Role: RoleRegulator}
+}
+
+func TestMissionApproveRecordsReviewer(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	mission := newPendingMission(nil)
+	reviewer := newReviewer()
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	repo.On("ChangeStatus", mock.Anything, mission, models.MissionStatusPlanned,
+		mock.MatchedBy(func(s *string) bool { return s != nil && *s == models.ApprovalStatusPending }),
+		mock.MatchedBy(func(log *models.DroneMissionLog) bool {
+			return log.Action == models.MissionActionApprove && log.ActorRole == RoleRegulator && log.ToStatus == models.MissionStatusApproved
+		}), "").Return(nil)
+
+	result, err := service.Approve(context.Background(), mission.ID, &dto.MissionActionRequest{Notes: " 同意 "}, reviewer)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MissionStatusApproved, result.MissionStatus)
+	assert.Equal(t, models.ApprovalStatusApproved, *result.ApprovalStatus)
+	assert.Equal(t, reviewer.UserID, result.ApprovedBy)
+	assert.NotNil(t, result.ApprovalTime)
+	assert.Equal(t, "同意", *result.ApprovalNotes)
+	repo.AssertExpectations(t)
+}
+
+func TestMissionApproveRejectsInvalidReviewer(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneMissionRepository)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	reviewer := newReviewer()
+	own := newPendingMission(reviewer.UserID)
+	repo.On("FindByID", mock.Anything, own.ID).Return(own, nil)
+
+	_, err := service.Approve(ctx, own.ID, &dto.MissionActionRequest{}, reviewer)
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+
+	pilotID := uuid.New()
+	_, err = service.Approve(ctx, own.ID, &dto.MissionActionRequest{}, Actor{UserID: &pilotID, Role: RolePilot})
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+
+	approved := newPendingMission(nil)
+	approved.MissionStatus = models.MissionStatusApproved
+	approved.ApprovalStatus = stringPtr(models.ApprovalStatusApproved)
+	repo.On("FindByID", mock.Anything, approved.ID).Return(approved, nil)
+	_, err = service.Approve(ctx, approved.ID, &dto.MissionActionRequest{}, reviewer)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	repo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMissionApproveRejectsSubmitter(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneMissionRepository)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	// 管理员提交并指派其他飞手的任务，不能由自己审批
+	admin := newAdminActor()
+	pilotID := uuid.New()
+	mission := newPendingMission(&pilotID)
+	mission.SubmittedBy = admin.UserID
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+
+	_, err := service.Approve(ctx, mission.ID, &dto.MissionActionRequest{}, admin)
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+	_, err = service.Reject(ctx, mission.ID, &dto.MissionActionRequest{Notes: "航线需调整"}, admin)
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+	repo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 其他审批人可以审批
+	repo.On("ChangeStatus", mock.Anything, mission, models.MissionStatusPlanned, mock.Anything, mock.Anything, "").Return(nil).Once()
+	result, err := service.Approve(ctx, mission.ID, &dto.MissionActionRequest{}, newReviewer())
+	require.NoError(t, err)
+	assert.Equal(t, models.ApprovalStatusApproved, *result.ApprovalStatus)
+}
+
+func TestMissionSubmitApprovalWaiverOnlyForAdmin(t *testing.T) {
+	ctx := context.Background()
+	operatorID := uuid.New()
+	drone := &models.Drone{ID: uuid.New(), OperatorID: &operatorID, Status: models.DroneStatusIdle}
+	pilotID := uuid.New()
+	admin := newAdminActor()
+
+	droneRepo := new(MockDroneRepository)
+	droneRepo.On("FindByID", ctx, drone.ID).Return(drone, nil)
+	userRepo := new(MockUserRepository)
+	userRepo.On("FindByID", ctx, pilotID).Return(&models.User{ID: pilotID, OperatorID: &operatorID}, nil)
+	repo := new(MockDroneMissionRepository)
+	repo.On("Create", ctx, mock.Anything, mock.Anything).Return(nil)
+	service := NewMissionService(repo, droneRepo, userRepo, NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	newRequest := func() *dto.CreateMissionRequest {
+		waive := false
+		return &dto.CreateMissionRequest{
+			DroneID:           drone.ID,
+			PilotID:           &pilotID,
+			MissionName:       "电力巡检",
+			MissionType:       "inspection",
+			PlannedStartTime:  start,
+			PlannedEndTime:    start.Add(time.Hour),
+			DepartureLocation: dto.MissionLocation{Lat: 31.20, Lng: 121.40},
+			RequiresApproval:  &waive,
+		}
+	}
+
+	// 飞手提交时忽略 requires_approval=false
+	mission, _, err := service.SubmitMission(ctx, newRequest(), Actor{UserID: &pilotID, Username: "pilot", Role: RolePilot})
+	assert.NoError(t, err)
+	assert.True(t, mission.RequiresApproval)
+	assert.Equal(t, models.ApprovalStatusPending, *mission.ApprovalStatus)
+
+	// 管理员豁免审批，记录豁免人并写入审计记录
+	mission, _, err = service.SubmitMission(ctx, newRequest(), admin)
+	assert.NoError(t, err)
+	assert.False(t, mission.RequiresApproval)
+	assert.Equal(t, models.ApprovalStatusWaived, *mission.ApprovalStatus)
+	assert.Equal(t, admin.UserID, mission.ApprovedBy)
+	assert.NotNil(t, mission.ApprovalTime)
+	repo.AssertCalled(t, "Create", ctx, mission, mock.MatchedBy(func(log *models.DroneMissionLog) bool {
+		return log.Action == models.MissionActionSubmit && log.ActorRole == RoleAdmin &&
+			*log.ApprovalStatus == models.ApprovalStatusWaived && log.Notes != ""
+	}))
+}
+
+func TestMissionUpdateResubmitsRejectedAndDetectsConcurrentReview(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneMissionRepository)
+	userRepo := new(MockUserRepository)
+	service := NewMissionService(repo, nil, userRepo, NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	mission := newPendingMission(nil)
+	mission.ApprovalStatus = stringPtr(models.ApprovalStatusRejected)
+	actor := newOperatorActor(userRepo, mission.OperatorID)
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	// 以读取时的审批状态作为更新条件，期间被其他操作修改时返回冲突
+	repo.On("Update", mock.Anything, mission, models.MissionStatusPlanned,
+		mock.MatchedBy(func(s *string) bool { return s != nil && *s == models.ApprovalStatusRejected }),
+		mock.Anything).Return(repositories.ErrStaleState).Once()
+
+	name := "电力巡检（修订）"
+	_, _, err := service.UpdateMission(ctx, mission.ID, &dto.UpdateMissionRequest{MissionName: &name}, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	assert.Equal(t, models.ApprovalStatusPending, *mission.ApprovalStatus)
+	repo.AssertExpectations(t)
+}
+
+func TestMissionUpdateWaivedByNonAdminRequiresApproval(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneMissionRepository)
+	userRepo := new(MockUserRepository)
+	service := NewMissionService(repo, nil, userRepo, NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	admin := newAdminActor()
+	newWaived := func() *models.DroneMission {
+		mission := newPendingMission(nil)
+		mission.RequiresApproval = false
+		mission.ApprovalStatus = stringPtr(models.ApprovalStatusWaived)
+		mission.SubmittedBy = admin.UserID
+		mission.ApprovedBy = admin.UserID
+		approvedAt := time.Now()
+		mission.ApprovalTime = &approvedAt
+		return mission
+	}
+	isWaived := mock.MatchedBy(func(s *string) bool { return s != nil && *s == models.ApprovalStatusWaived })
+	name := "电力巡检（改线）"
+
+	// 运营商修改后豁免失效，重新进入待审批
+	mission := newWaived()
+	operator := newOperatorActor(userRepo, mission.OperatorID)
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	repo.On("Update", mock.Anything, mission, models.MissionStatusPlanned, isWaived, mock.Anything).Return(nil).Once()
+	result, _, err := service.UpdateMission(ctx, mission.ID, &dto.UpdateMissionRequest{MissionName: &name}, operator)
+	require.NoError(t, err)
+	assert.True(t, result.RequiresApproval)
+	assert.Equal(t, models.ApprovalStatusPending, *result.ApprovalStatus)
+	assert.Equal(t, operator.UserID, result.SubmittedBy)
+	assert.Nil(t, result.ApprovedBy)
+	assert.Nil(t, result.ApprovalTime)
+
+	// 管理员修改保留豁免
+	mission = newWaived()
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	repo.On("Update", mock.Anything, mission, models.MissionStatusPlanned, isWaived, mock.Anything).Return(nil).Once()
+	result, _, err = service.UpdateMission(ctx, mission.ID, &dto.UpdateMissionRequest{MissionName: &name}, admin)
+	require.NoError(t, err)
+	assert.False(t, result.RequiresApproval)
+	assert.Equal(t, models.ApprovalStatusWaived, *result.ApprovalStatus)
+	repo.AssertExpectations(t)
+}
+
+func TestMissionRejectRequiresNotes(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	_, err := service.Reject(context.Background(), uuid.New(), &dto.MissionActionRequest{Notes: "  "}, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+	repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestMissionStartRequiresApprovalAndIdleDrone(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockDroneMissionRepository)
+	userRepo := new(MockUserRepository)
+	service := NewMissionService(repo, nil, userRepo, NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	mission := newPendingMission(nil)
+	actor := newOperatorActor(userRepo, mission.OperatorID)
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+
+	_, err := service.Start(ctx, mission.ID, &dto.MissionActionRequest{}, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	mission.MissionStatus = models.MissionStatusApproved
+	mission.Drone.Status = models.DroneStatusMaintenance
+	_, err = service.Start(ctx, mission.ID, &dto.MissionActionRequest{}, actor)
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+
+	mission.Drone.Status = models.DroneStatusIdle
+	repo.On("ChangeStatus", mock.Anything, mission, models.MissionStatusApproved, mock.Anything, mock.Anything, models.DroneStatusFlying).Return(nil)
+	result, err := service.Start(ctx, mission.ID, &dto.MissionActionRequest{}, actor)
+	assert.NoError(t, err)
+	assert.Equal(t, models.MissionStatusInProgress, result.MissionStatus)
+	assert.NotNil(t, result.ActualStartTime)
+	assert.Equal(t, models.DroneStatusFlying, result.Drone.Status)
+}
+
+func TestMissionStartConflictsWhenDroneTakenConcurrently(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	mission := newPendingMission(nil)
+	mission.MissionStatus = models.MissionStatusApproved
+	mission.ApprovalStatus = stringPtr(models.ApprovalStatusApproved)
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	// 另一个任务已先将同一无人机置为飞行中，条件更新未命中
+	repo.On("ChangeStatus", mock.Anything, mission, models.MissionStatusApproved, mock.Anything, mock.Anything, models.DroneStatusFlying).
+		Return(repositories.ErrStaleState)
+
+	_, err := service.Start(context.Background(), mission.ID, &dto.MissionActionRequest{}, Actor{Role: RoleAdmin})
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	assert.Equal(t, models.DroneStatusIdle, mission.Drone.Status)
+}
+
+func TestMissionStartRechecksZonesCreatedAfterApproval(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	// 任务审批通过后新发布的禁飞区，与航线冲突
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo(newZone("临时管制区", corridorZone, 0, 500))), nil, nil)
+
+	mission := newPendingMission(nil)
+	mission.MissionStatus = models.MissionStatusApproved
+	mission.ApprovalStatus = stringPtr(models.ApprovalStatusApproved)
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+
+	_, err := service.Start(context.Background(), mission.ID, &dto.MissionActionRequest{}, Actor{Role: RoleAdmin})
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	assert.Equal(t, models.MissionStatusApproved, mission.MissionStatus)
+	repo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMissionCompleteStampsEndAndLandsDrone(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	mission := newPendingMission(nil)
+	mission.MissionStatus = models.MissionStatusInProgress
+	mission.Drone.Status = models.DroneStatusFlying
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+	repo.On("ChangeStatus", mock.Anything, mission, models.MissionStatusInProgress, mock.Anything, mock.Anything, models.DroneStatusIdle).Return(nil)
+
+	result, err := service.Complete(context.Background(), mission.ID, &dto.MissionActionRequest{}, Actor{Role: RoleAdmin})
+	assert.NoError(t, err)
+	assert.Equal(t, models.MissionStatusCompleted, result.MissionStatus)
+	assert.NotNil(t, result.ActualEndTime)
+	assert.Equal(t, models.DroneStatusIdle, result.Drone.Status)
+}
+
+func TestMissionPilotCanOnlyOperateOwnMissions(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	userRepo := new(MockUserRepository)
+	service := NewMissionService(repo, nil, userRepo, NewNoFlyZoneChecker(newZoneRepo()), nil, nil)
+
+	otherPilot := uuid.New()
+	mission := newPendingMission(&otherPilot)
+	pilot := newOperatorActor(userRepo, mission.OperatorID)
+	pilot.Role = RolePilot
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+
+	_, err := service.Cancel(context.Background(), mission.ID, &dto.MissionActionRequest{}, pilot)
+	assertAppErrorCode(t, err, apperr.ErrCodeForbidden)
+}
+
+func TestMissionApproveBlockedByNoFlyZone(t *testing.T) {
+	repo := new(MockDroneMissionRepository)
+	zone := models.NoFlyZone{
+		ID:       uuid.New(),
+		Name:     "虹桥机场禁飞区",
+		Geometry: *geo.NewCircle(geo.LatLng{Lat: 31.20, Lng: 121.45}, 2000),
+		Status:   models.NoFlyZoneStatusActive,
+	}
+	service := NewMissionService(repo, nil, new(MockUserRepository), NewNoFlyZoneChecker(newZoneRepo(zone)), nil, nil)
+
+	mission := newPendingMission(nil)
+	repo.On("FindByID", mock.Anything, mission.ID).Return(mission, nil)
+
+	_, err := service.Approve(context.Background(), mission.ID, &dto.MissionActionRequest{}, newReviewer())
+	assertAppErrorCode(t, err, apperr.ErrCodeConflict)
+	repo.AssertNotCalled(t, "ChangeStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
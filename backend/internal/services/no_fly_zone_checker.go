@@ -0,0 +1,171 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"encoding/json"
+	"math"
+)
+
+// NoFlyZoneChecker 禁飞区冲突检查接口
+type NoFlyZoneChecker interface {
+	// CheckMission 检查任务航线和飞行区域在计划时间窗口内与有效禁飞区的冲突
+	CheckMission(ctx context.Context, mission *models.DroneMission) ([]dto.ZoneConflict, error)
+}
+
+type noFlyZoneChecker struct {
+	repo repositories.NoFlyZoneRepository
+}
+
+// NewNoFlyZoneChecker 创建禁飞区冲突检查实例
+func NewNoFlyZoneChecker(repo repositories.NoFlyZoneRepository) NoFlyZoneChecker {
+	return &noFlyZoneChecker{
+		repo: repo,
+	}
+}
+
+// missionPoint 任务航线上的点，Altitude 为空表示未指定高度
+type missionPoint struct {
+	geo.LatLng
+	Altitude *float64
+}
+
+// CheckMission 检查任务与禁飞区的冲突
+// 航线由起飞点、航点和降落点依次连接，航点未指定高度时使用计划高度
+// 同一禁飞区对航线和飞行区域各最多报告一次冲突；几何无效的禁飞区无法判定，按冲突报告
+func (c *noFlyZoneChecker) CheckMission(ctx context.Context, mission *models.DroneMission) ([]dto.ZoneConflict, error) {
+	path, area, err := missionGeometry(mission)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := c.repo.ListActive(ctx, mission.PlannedStartTime, mission.PlannedEndTime)
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	planned := plannedAltitude(mission)
+	conflicts := make([]dto.ZoneConflict, 0)
+	for i := range zones {
+		zone := &zones[i]
+		if !zoneActiveDuring(zone, mission.PlannedStartTime, mission.PlannedEndTime) {
+			continue
+		}
+		shape, err := zoneGeometry(zone)
+		if err != nil {
+			logger.Warnf("[NoFlyZoneChecker] 禁飞区几何数据无效，按冲突处理: zone=%s, err=%v", zone.ID.String(), err)
+			conflicts = append(conflicts, newZoneConflict(zone, dto.ConflictSourceInvalidGeometry))
+			continue
+		}
+		band := zoneAltitudeBand(zone)
+
+		if segment, ok := pathConflict(shape, band, path, planned); ok {
+			conflict := newZoneConflict(zone, dto.ConflictSourcePath)
+			conflict.Segment = &segment
+			conflicts = append(conflicts, conflict)
+		}
+		if area != nil && band.overlaps(areaAltitudeBand(planned)) && shape.Intersects(area) {
+			conflicts = append(conflicts, newZoneConflict(zone, dto.ConflictSourceArea))
+		}
+	}
+	return conflicts, nil
+}
+
+// pathConflict 返回第一条进入禁飞区的航段起点下标
+// 只有一个点时按该点（零长度线段）判断
+func pathConflict(shape *geo.Geometry, band altitudeBand, path []missionPoint, planned *float64) (int, bool) {
+	if len(path) == 1 {
+		path = append(path, path[0])
+	}
+	for i := 0; i < len(path)-1; i++ {
+		a, b := path[i], path[i+1]
+		if !band.overlaps(segmentAltitudeBand(a, b, planned)) {
+			continue
+		}
+		if shape.IntersectsSegment(a.LatLng, b.LatLng) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// segmentAltitudeBand 航段经过的高度区间，任一端点高度未知时视为从地面到无上限
+func segmentAltitudeBand(a, b missionPoint, planned *float64) altitudeBand {
+	altA, altB := a.Altitude, b.Altitude
+	if altA == nil {
+		altA = planned
+	}
+	if altB == nil {
+		altB = planned
+	}
+	if altA == nil || altB == nil {
+		return altitudeBand{Min: 0, Max: math.Inf(1)}
+	}
+	return altitudeBand{Min: math.Min(*altA, *altB), Max: math.Max(*altA, *altB)}
+}
+
+// areaAltitudeBand 飞行区域的高度区间，从地面到计划高度
+func areaAltitudeBand(planned *float64) altitudeBand {
+	if planned == nil {
+		return altitudeBand{Min: 0, Max: math.Inf(1)}
+	}
+	return altitudeBand{Min: 0, Max: *planned}
+}
+
+func plannedAltitude(mission *models.DroneMission) *float64 {
+	if mission.PlannedAltitude == nil {
+		return nil
+	}
+	altitude := float64(*mission.PlannedAltitude)
+	return &altitude
+}
+
+// missionGeometry 解析任务的航线和飞行区域
+func missionGeometry(mission *models.DroneMission) ([]missionPoint, *geo.Geometry, error) {
+	var path []missionPoint
+
+	var departure dto.MissionLocation
+	if err := json.Unmarshal([]byte(mission.DepartureLocation), &departure); err != nil {
+		return nil, nil, apperr.NewBadRequest("无效的起飞点")
+	}
+	path = append(path, missionPoint{LatLng: geo.LatLng{Lat: departure.Lat, Lng: departure.Lng}})
+
+	for _, wp := range dto.ToMissionWaypoints(mission.Waypoints) {
+		path = append(path, missionPoint{LatLng: geo.LatLng{Lat: wp.Lat, Lng: wp.Lng}, Altitude: wp.Altitude})
+	}
+
+	if mission.ArrivalLocation != nil {
+		var arrival dto.MissionLocation
+		if err := json.Unmarshal([]byte(*mission.ArrivalLocation), &arrival); err != nil {
+			return nil, nil, apperr.NewBadRequest("无效的降落点")
+		}
+		path = append(path, missionPoint{LatLng: geo.LatLng{Lat: arrival.Lat, Lng: arrival.Lng}})
+	}
+
+	if mission.FlightArea != nil {
+		if err := validateFlightArea(mission.FlightArea); err != nil {
+			return nil, nil, apperr.NewBadRequest("无效的飞行区域: " + err.Error())
+		}
+	}
+	return path, mission.FlightArea, nil
+}
+
+func newZoneConflict(zone *models.NoFlyZone, source string) dto.ZoneConflict {
+	return dto.ZoneConflict{
+		ZoneID:      zone.ID,
+		ZoneName:    zone.Name,
+		ZoneType:    zone.Type,
+		Authority:   zone.Authority,
+		Reason:      zone.Reason,
+		Source:      source,
+		MinAltitude: zone.MinAltitude,
+		MaxAltitude: zone.MaxAltitude,
+		StartTime:   zone.StartTime,
+		EndTime:     zone.EndTime,
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// 跨越经度 121.45 的南北向矩形禁飞区
+const corridorZone = `{"type":"Polygon","coordinates":[[[121.44,31.10],[121.46,31.10],[121.46,31.30],[121.44,31.30],[121.44,31.10]]]}`
+
+// 缺少半径的点，不能作为禁飞区几何
+const invalidZone = `{"type":"Point","coordinates":[121.45,31.20]}`
+
+// discardLogs 丢弃测试中的日志输出
+func discardLogs() {
+	discard := log.New(io.Discard, "", 0)
+	logger.InfoLogger, logger.WarnLogger, logger.ErrorLogger, logger.DebugLogger = discard, discard, discard, discard
+}
+
+// mustGeometry 解析测试用 GeoJSON
+func mustGeometry(data string) *geo.Geometry {
+	var geometry geo.Geometry
+	if err := json.Unmarshal([]byte(data), &geometry); err != nil {
+		panic(err)
+	}
+	return &geometry
+}
+
+func newZone(name, geometry string, minAlt, maxAlt float64) models.NoFlyZone {
+	return models.NoFlyZone{
+		ID:          uuid.New(),
+		Name:        name,
+		Type:        models.NoFlyZoneTypePermanent,
+		Geometry:    *mustGeometry(geometry),
+		MinAltitude: minAlt,
+		MaxAltitude: maxAlt,
+		Status:      models.NoFlyZoneStatusActive,
+	}
+}
+
+func TestCheckMissionPathCrossesZone(t *testing.T) {
+	mission := newPendingMission(nil)
+	altitude := 120
+	mission.PlannedAltitude = &altitude
+	checker := NewNoFlyZoneChecker(newZoneRepo(
+		newZone("走廊", corridorZone, 0, 500),
+		newZone("高空管制区", corridorZone, 300, 1000),
+	))
+
+	conflicts, err := checker.CheckMission(context.Background(), mission)
+	assert.NoError(t, err)
+	if assert.Len(t, conflicts, 1, "高空管制区高于计划高度") {
+		assert.Equal(t, "走廊", conflicts[0].ZoneName)
+		assert.Equal(t, dto.ConflictSourcePath, conflicts[0].Source)
+		assert.Equal(t, 0, *conflicts[0].Segment)
+	}
+}
+
+func TestCheckMissionReportsInvalidZoneGeometry(t *testing.T) {
+	discardLogs()
+	mission := newPendingMission(nil)
+	checker := NewNoFlyZoneChecker(newZoneRepo(newZone("数据损坏", invalidZone, 0, 500)))
+
+	conflicts, err := checker.CheckMission(context.Background(), mission)
+	assert.NoError(t, err)
+	if assert.Len(t, conflicts, 1, "几何无效的禁飞区不能放行") {
+		assert.Equal(t, dto.ConflictSourceInvalidGeometry, conflicts[0].Source)
+		assert.Nil(t, conflicts[0].Segment)
+	}
+}
+
+func TestCheckMissionHonorsZoneWindow(t *testing.T) {
+	mission := newPendingMission(nil)
+	ended := mission.PlannedStartTime.Add(-time.Hour)
+	zone := newZone("临时管制", corridorZone, 0, 0)
+	zone.Type = models.NoFlyZoneTypeTemporary
+	zone.EndTime = &ended
+
+	conflicts, err := NewNoFlyZoneChecker(newZoneRepo(zone)).CheckMission(context.Background(), mission)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	later := mission.PlannedStartTime.Add(30 * time.Minute)
+	zone.EndTime = nil
+	zone.StartTime = &later
+	conflicts, err = NewNoFlyZoneChecker(newZoneRepo(zone)).CheckMission(context.Background(), mission)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+}
+
+func TestCheckMissionFlightArea(t *testing.T) {
+	mission := newPendingMission(nil)
+	mission.Waypoints = nil
+	mission.DepartureLocation = `{"lat":30.00,"lng":120.00}`
+	mission.FlightArea = mustGeometry(`{"type":"Polygon","coordinates":[[[120.0,30.0],[120.1,30.0],[120.1,30.1],[120.0,30.1],[120.0,30.0]]]}`)
+	altitude := 100
+	mission.PlannedAltitude = &altitude
+
+	zone := newZone("区域内圆形禁飞区", `{"type":"Point","coordinates":[120.05,30.05],"properties":{"radius":500}}`, 0, 150)
+	conflicts, err := NewNoFlyZoneChecker(newZoneRepo(zone)).CheckMission(context.Background(), mission)
+	assert.NoError(t, err)
+	if assert.Len(t, conflicts, 1) {
+		assert.Equal(t, dto.ConflictSourceArea, conflicts[0].Source)
+		assert.Nil(t, conflicts[0].Segment)
+	}
+
+	mission.FlightArea = mustGeometry(`{"type":"LineString","coordinates":[[120.0,30.0],[120.1,30.1]]}`)
+	_, err = NewNoFlyZoneChecker(newZoneRepo(zone)).CheckMission(context.Background(), mission)
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+}
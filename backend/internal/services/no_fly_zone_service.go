@@ -0,0 +1,315 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"backend/pkg/utils/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NoFlyZoneService 禁飞区管理服务接口
+type NoFlyZoneService interface {
+	ListZones(ctx context.Context, query *dto.NoFlyZoneQuery) (*dto.PageResponse[dto.NoFlyZoneResponse], error)
+	GetZone(ctx context.Context, id uuid.UUID) (*models.NoFlyZone, error)
+	CreateZone(ctx context.Context, req *dto.CreateNoFlyZoneRequest) (*models.NoFlyZone, error)
+	UpdateZone(ctx context.Context, id uuid.UUID, req *dto.UpdateNoFlyZoneRequest) (*models.NoFlyZone, error)
+	DeleteZone(ctx context.Context, id uuid.UUID) error
+	// ImportZones 从 GeoJSON FeatureCollection 批量创建禁飞区，校验失败的要素跳过并在结果中报告
+	ImportZones(ctx context.Context, collection *geo.FeatureCollection) (*dto.NoFlyZoneImportResult, error)
+	// ExportZones 按条件查询全部禁飞区用于导出
+	ExportZones(ctx context.Context, query *dto.NoFlyZoneExportQuery) ([]models.NoFlyZone, error)
+	// ExpireZones 将 now 之前结束的临时禁飞区置为过期
+	ExpireZones(ctx context.Context, now time.Time) (int64, error)
+}
+
+// maxImportFeatures 单次导入的要素数量上限
+const maxImportFeatures = 5000
+
+type noFlyZoneService struct {
+	repo repositories.NoFlyZoneRepository
+}
+
+// NewNoFlyZoneService 创建禁飞区管理服务实例
+func NewNoFlyZoneService(repo repositories.NoFlyZoneRepository) NoFlyZoneService {
+	return &noFlyZoneService{
+		repo: repo,
+	}
+}
+
+// ListZones 分页查询禁飞区
+func (s *noFlyZoneService) ListZones(ctx context.Context, query *dto.NoFlyZoneQuery) (*dto.PageResponse[dto.NoFlyZoneResponse], error) {
+	query.Normalize()
+	zones, total, err := s.repo.List(ctx, repositories.NoFlyZoneFilter{
+		Status:  query.Status,
+		Type:    query.Type,
+		Keyword: strings.TrimSpace(query.Q),
+		Offset:  query.Offset(),
+		Limit:   query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return dto.NewPageResponse(dto.ToNoFlyZoneResponseList(zones), total, query.PageQuery), nil
+}
+
+// GetZone 获取禁飞区详情
+func (s *noFlyZoneService) GetZone(ctx context.Context, id uuid.UUID) (*models.NoFlyZone, error) {
+	zone, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("禁飞区不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return zone, nil
+}
+
+// CreateZone 创建禁飞区，新建禁飞区状态为 active
+func (s *noFlyZoneService) CreateZone(ctx context.Context, req *dto.CreateNoFlyZoneRequest) (*models.NoFlyZone, error) {
+	zone := &models.NoFlyZone{
+		Name:        strings.TrimSpace(req.Name),
+		Type:        defaultString(req.Type, models.NoFlyZoneTypePermanent),
+		Geometry:    *req.Geometry,
+		MinAltitude: req.MinAltitude,
+		MaxAltitude: req.MaxAltitude,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Reason:      req.Reason,
+		Authority:   req.Authority,
+		Status:      models.NoFlyZoneStatusActive,
+		Description: req.Description,
+	}
+	if err := normalizeZone(zone, time.Now()); err != nil {
+		return nil, apperr.NewBadRequest(err.Error())
+	}
+
+	if err := s.repo.Create(ctx, zone); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return zone, nil
+}
+
+// UpdateZone 更新禁飞区
+// 将已过期的禁飞区改回 active 时需同时设置未来的结束时间
+func (s *noFlyZoneService) UpdateZone(ctx context.Context, id uuid.UUID, req *dto.UpdateNoFlyZoneRequest) (*models.NoFlyZone, error) {
+	zone, err := s.GetZone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		zone.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Type != nil {
+		zone.Type = *req.Type
+	}
+	if req.Geometry != nil {
+		zone.Geometry = *req.Geometry
+	}
+	if req.MinAltitude != nil {
+		zone.MinAltitude = *req.MinAltitude
+	}
+	if req.MaxAltitude != nil {
+		zone.MaxAltitude = *req.MaxAltitude
+	}
+	if req.StartTime != nil {
+		zone.StartTime = req.StartTime
+	}
+	if req.EndTime != nil {
+		zone.EndTime = req.EndTime
+	}
+	if req.Reason != nil {
+		zone.Reason = *req.Reason
+	}
+	if req.Authority != nil {
+		zone.Authority = *req.Authority
+	}
+	if req.Status != nil {
+		zone.Status = *req.Status
+	}
+	if req.Description != nil {
+		zone.Description = *req.Description
+	}
+	if err := normalizeZone(zone, time.Now()); err != nil {
+		return nil, apperr.NewBadRequest(err.Error())
+	}
+
+	if err := s.repo.Update(ctx, zone); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return zone, nil
+}
+
+// DeleteZone 删除禁飞区
+func (s *noFlyZoneService) DeleteZone(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("禁飞区不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// ImportZones 批量导入禁飞区
+// 每个要素的 properties 按 dto.NoFlyZoneProperties 解析，通过校验的要素在同一事务中创建
+func (s *noFlyZoneService) ImportZones(ctx context.Context, collection *geo.FeatureCollection) (*dto.NoFlyZoneImportResult, error) {
+	if collection.Type != geo.TypeFeatureCollection {
+		return nil, apperr.NewBadRequest("导入文件必须为 GeoJSON FeatureCollection")
+	}
+	if len(collection.Features) == 0 {
+		return nil, apperr.NewBadRequest("导入文件不包含任何要素")
+	}
+	if len(collection.Features) > maxImportFeatures {
+		return nil, apperr.NewBadRequest("单次导入的要素数量过多")
+	}
+
+	now := time.Now()
+	result := &dto.NoFlyZoneImportResult{Total: len(collection.Features), Errors: []dto.NoFlyZoneImportError{}}
+	zones := make([]models.NoFlyZone, 0, len(collection.Features))
+	for i := range collection.Features {
+		zone, err := zoneFromFeature(&collection.Features[i], now)
+		if err != nil {
+			importErr := dto.NoFlyZoneImportError{Index: i, Error: err.Error()}
+			if zone != nil {
+				importErr.Name = zone.Name
+			}
+			result.Errors = append(result.Errors, importErr)
+			continue
+		}
+		zones = append(zones, *zone)
+	}
+
+	if err := s.repo.CreateBatch(ctx, zones); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	result.Imported = len(zones)
+	return result, nil
+}
+
+// ExportZones 查询导出的禁飞区
+func (s *noFlyZoneService) ExportZones(ctx context.Context, query *dto.NoFlyZoneExportQuery) ([]models.NoFlyZone, error) {
+	zones, _, err := s.repo.List(ctx, repositories.NoFlyZoneFilter{
+		Status:  query.Status,
+		Type:    query.Type,
+		Keyword: strings.TrimSpace(query.Q),
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return zones, nil
+}
+
+// ExpireZones 将已结束的临时禁飞区置为过期
+func (s *noFlyZoneService) ExpireZones(ctx context.Context, now time.Time) (int64, error) {
+	count, err := s.repo.ExpireEnded(ctx, now)
+	if err != nil {
+		return 0, apperr.NewInternalError(err)
+	}
+	return count, nil
+}
+
+// RunZoneExpiry 按固定间隔执行禁飞区过期检查，启动时立即执行一次，ctx 取消后退出
+func RunZoneExpiry(ctx context.Context, service NoFlyZoneService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := service.ExpireZones(ctx, time.Now()); err != nil {
+			logger.Errorf("[NoFlyZoneExpiry] 禁飞区过期检查失败: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// zoneFromFeature 将 GeoJSON 要素转换为禁飞区，properties.radius 作为圆形禁飞区半径
+// 解析出名称后才返回的错误同时返回禁飞区，便于在结果中标识
+func zoneFromFeature(feature *geo.Feature, now time.Time) (*models.NoFlyZone, error) {
+	if feature.Geometry == nil {
+		return nil, errors.New("要素缺少 geometry")
+	}
+
+	var props dto.NoFlyZoneProperties
+	data, err := json.Marshal(feature.Properties)
+	if err == nil {
+		err = json.Unmarshal(data, &props)
+	}
+	if err != nil {
+		return nil, errors.New("无效的 properties: " + err.Error())
+	}
+
+	zone := &models.NoFlyZone{
+		Name:        strings.TrimSpace(props.Name),
+		Type:        defaultString(props.Type, models.NoFlyZoneTypePermanent),
+		Geometry:    *feature.Geometry,
+		MinAltitude: props.MinAltitude,
+		MaxAltitude: props.MaxAltitude,
+		StartTime:   props.StartTime,
+		EndTime:     props.EndTime,
+		Reason:      props.Reason,
+		Authority:   props.Authority,
+		Status:      defaultString(props.Status, models.NoFlyZoneStatusActive),
+		Description: props.Description,
+	}
+	if zone.Geometry.Type == geo.TypePoint && props.Radius > 0 {
+		zone.Geometry.Radius = props.Radius
+	}
+
+	if zone.Name == "" {
+		return zone, errors.New("缺少名称 properties.name")
+	}
+	switch zone.Type {
+	case models.NoFlyZoneTypePermanent, models.NoFlyZoneTypeTemporary, models.NoFlyZoneTypeConditional:
+	default:
+		return zone, errors.New("无效的禁飞区类型: " + zone.Type)
+	}
+	switch zone.Status {
+	case models.NoFlyZoneStatusActive, models.NoFlyZoneStatusExpired, models.NoFlyZoneStatusCancelled:
+	default:
+		return zone, errors.New("无效的禁飞区状态: " + zone.Status)
+	}
+	if zone.MinAltitude < 0 || zone.MaxAltitude < 0 {
+		return zone, errors.New("限制高度不能为负数")
+	}
+	return zone, normalizeZone(zone, now)
+}
+
+// normalizeZone 校验禁飞区的几何、高度和生效时间，并按右手规则调整多边形环方向
+// 有效的临时禁飞区必须设置晚于 now 的结束时间
+func normalizeZone(zone *models.NoFlyZone, now time.Time) error {
+	zone.Geometry.Rewind()
+	if err := zone.Geometry.Validate(); err != nil {
+		return errors.New("无效的禁飞区几何: " + err.Error())
+	}
+	if !zone.Geometry.IsArea() {
+		return errors.New("禁飞区几何必须为多边形或带半径的圆形区域")
+	}
+	if zone.MaxAltitude > 0 && zone.MaxAltitude <= zone.MinAltitude {
+		return errors.New("最高限制高度必须大于最低限制高度")
+	}
+	if zone.StartTime != nil && zone.EndTime != nil && !zone.EndTime.After(*zone.StartTime) {
+		return errors.New("结束时间必须晚于开始时间")
+	}
+	if zone.Type == models.NoFlyZoneTypeTemporary {
+		if zone.EndTime == nil {
+			return errors.New("临时禁飞区必须设置结束时间")
+		}
+		if zone.Status == models.NoFlyZoneStatusActive && !zone.EndTime.After(now) {
+			return errors.New("临时禁飞区的结束时间已过")
+		}
+	}
+	return nil
+}
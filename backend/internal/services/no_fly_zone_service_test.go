@@ -0,0 +1,125 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/pkg/apperr"
+	"backend/pkg/geo"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 顺时针的外环，保存前应被调整为逆时针
+const clockwiseSquare = `{"type":"Polygon","coordinates":[[[121.0,31.0],[121.0,31.1],[121.1,31.1],[121.1,31.0],[121.0,31.0]]]}`
+
+func TestCreateZoneRewindsAndValidates(t *testing.T) {
+	repo := new(MockNoFlyZoneRepository)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	service := NewNoFlyZoneService(repo)
+
+	zone, err := service.CreateZone(context.Background(), &dto.CreateNoFlyZoneRequest{
+		Name:        "浦东新区临时管制",
+		Geometry:    mustGeometry(clockwiseSquare),
+		MaxAltitude: 300,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.NoFlyZoneTypePermanent, zone.Type)
+	assert.Equal(t, models.NoFlyZoneStatusActive, zone.Status)
+	assert.NoError(t, zone.Geometry.Validate())
+
+	// 临时禁飞区必须设置结束时间
+	_, err = service.CreateZone(context.Background(), &dto.CreateNoFlyZoneRequest{
+		Name:     "演习区域",
+		Type:     models.NoFlyZoneTypeTemporary,
+		Geometry: mustGeometry(clockwiseSquare),
+	})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+
+	// 缺少半径的 Point 不是面状区域
+	_, err = service.CreateZone(context.Background(), &dto.CreateNoFlyZoneRequest{
+		Name:     "无半径",
+		Geometry: geo.NewPoint(geo.NewPosition(31, 121)),
+	})
+	assertAppErrorCode(t, err, apperr.ErrCodeBadRequest)
+	repo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestImportZonesSkipsInvalidFeatures(t *testing.T) {
+	data := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[116.40,39.90]},"properties":{"name":"天安门","radius":5000,"max_altitude":500,"authority":"公安部"}},
+		{"type":"Feature","geometry":` + clockwiseSquare + `,"properties":{"name":"演习区域","type":"temporary","end_time":"2099-01-01T00:00:00Z"}},
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[116.40,39.90]},"properties":{"name":"缺少半径"}},
+		{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0],[1,1]]},"properties":{"name":"线"}},
+		{"type":"Feature","geometry":` + clockwiseSquare + `,"properties":{}}
+	]}`
+	var collection geo.FeatureCollection
+	require.NoError(t, json.Unmarshal([]byte(data), &collection))
+
+	repo := new(MockNoFlyZoneRepository)
+	var created []models.NoFlyZone
+	repo.On("CreateBatch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).([]models.NoFlyZone)
+	}).Return(nil)
+
+	result, err := NewNoFlyZoneService(repo).ImportZones(context.Background(), &collection)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 2, result.Imported)
+	if assert.Len(t, result.Errors, 3) {
+		assert.Equal(t, 2, result.Errors[0].Index)
+		assert.Equal(t, "缺少半径", result.Errors[0].Name)
+		assert.Equal(t, 4, result.Errors[2].Index)
+	}
+
+	require.Len(t, created, 2)
+	assert.True(t, created[0].Geometry.IsCircle())
+	assert.Equal(t, 5000.0, created[0].Geometry.Radius)
+	assert.Equal(t, "公安部", created[0].Authority)
+	assert.Equal(t, models.NoFlyZoneTypeTemporary, created[1].Type)
+	assert.NotNil(t, created[1].EndTime)
+}
+
+func TestExportedZonesCanBeReimported(t *testing.T) {
+	end := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	zones := []models.NoFlyZone{
+		newZone("走廊", corridorZone, 0, 500),
+		newZone("圆形", `{"type":"Point","coordinates":[121.45,31.20],"properties":{"radius":2000}}`, 0, 0),
+	}
+	zones[1].Type = models.NoFlyZoneTypeTemporary
+	zones[1].EndTime = &end
+
+	data, err := json.Marshal(dto.ToNoFlyZoneFeatureCollection(zones))
+	require.NoError(t, err)
+	var collection geo.FeatureCollection
+	require.NoError(t, json.Unmarshal(data, &collection))
+
+	for i := range collection.Features {
+		zone, err := zoneFromFeature(&collection.Features[i], time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, zones[i].Name, zone.Name)
+		assert.Equal(t, zones[i].Geometry, zone.Geometry)
+		assert.Equal(t, zones[i].MaxAltitude, zone.MaxAltitude)
+		assert.Equal(t, zones[i].Type, zone.Type)
+	}
+	zone, err := zoneFromFeature(&collection.Features[1], time.Now())
+	require.NoError(t, err)
+	if assert.NotNil(t, zone.EndTime) {
+		assert.True(t, end.Equal(*zone.EndTime))
+	}
+}
+
+func TestExpireZones(t *testing.T) {
+	repo := new(MockNoFlyZoneRepository)
+	now := time.Now()
+	repo.On("ExpireEnded", mock.Anything, now).Return(int64(3), nil)
+
+	count, err := NewNoFlyZoneService(repo).ExpireZones(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"errors"
+	"math"
+	"time"
+)
+
+// zoneGeometry 返回可用于空间判定的禁飞区几何
+// 环方向不影响判定，不视为错误；非面状几何（如缺少半径的 Point）返回错误
+func zoneGeometry(zone *models.NoFlyZone) (*geo.Geometry, error) {
+	geometry := &zone.Geometry
+	if err := geometry.Validate(); err != nil && !errors.Is(err, geo.ErrWindingOrder) {
+		return nil, err
+	}
+	if !geometry.IsArea() {
+		return nil, errors.New("禁飞区几何必须为多边形或带半径的圆形区域")
+	}
+	return geometry, nil
+}
+
+// validateFlightArea 校验任务飞行区域为合法的 Polygon 或 MultiPolygon
+func validateFlightArea(area *geo.Geometry) error {
+	if area.Type != geo.TypePolygon && area.Type != geo.TypeMultiPolygon {
+		return errors.New("飞行区域必须为 Polygon 或 MultiPolygon")
+	}
+	if err := area.Validate(); err != nil && !errors.Is(err, geo.ErrWindingOrder) {
+		return err
+	}
+	return nil
+}
+
+// altitudeBand 高度区间（米），Max 为 +Inf 表示无上限
+type altitudeBand struct {
+	Min float64
+	Max float64
+}
+
+func (b altitudeBand) overlaps(other altitudeBand) bool {
+	return b.Min <= other.Max && b.Max >= other.Min
+}
+
+// zoneAltitudeBand 禁飞区限制高度区间，MaxAltitude 不大于 0 视为无上限
+func zoneAltitudeBand(zone *models.NoFlyZone) altitudeBand {
+	band := altitudeBand{Min: zone.MinAltitude, Max: zone.MaxAltitude}
+	if band.Max <= 0 {
+		band.Max = math.Inf(1)
+	}
+	return band
+}
+
+// zoneActiveDuring 判断禁飞区生效时间是否与 [from, to] 有交集
+func zoneActiveDuring(zone *models.NoFlyZone, from, to time.Time) bool {
+	if zone.Status != models.NoFlyZoneStatusActive {
+		return false
+	}
+	if zone.StartTime != nil && zone.StartTime.After(to) {
+		return false
+	}
+	if zone.EndTime != nil && zone.EndTime.Before(from) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OperatorScope 操作人可访问的运营商范围
+// 管理员和监管人员不受限制，运营商用户和飞手只能访问所属运营商的数据
+type OperatorScope struct {
+	All        bool
+	OperatorID uuid.UUID
+}
+
+// Allows 判断是否可以访问指定运营商的数据，未归属运营商的数据仅不受限的管理员和监管人员可访问
+func (s OperatorScope) Allows(operatorID *uuid.UUID) bool {
+	if s.All {
+		return true
+	}
+	return operatorID != nil && *operatorID == s.OperatorID
+}
+
+// Filter 返回列表查询使用的运营商过滤条件，管理员和监管人员返回 requested（可为空）
+func (s OperatorScope) Filter(requested *uuid.UUID) *uuid.UUID {
+	if s.All {
+		return requested
+	}
+	id := s.OperatorID
+	return &id
+}
+
+// resolveOperatorScope 根据操作人角色解析运营商访问范围
+// 运营商用户和飞手需要绑定运营商，其他角色无权访问运营商数据
+// 写操作的角色限制由路由和各服务自行校验
+func resolveOperatorScope(ctx context.Context, userRepo repositories.UserRepository, actor Actor) (OperatorScope, error) {
+	if actor.HasRole(RoleAdmin, RoleRegulator) {
+		return OperatorScope{All: true}, nil
+	}
+	if !actor.HasRole(RoleOperator, RolePilot) || actor.UserID == nil {
+		return OperatorScope{}, apperr.NewForbidden("无权访问运营商数据")
+	}
+
+	user, err := userRepo.FindByID(ctx, *actor.UserID)
+	if err != nil {
+		return OperatorScope{}, apperr.NewForbidden("用户不存在或已被删除")
+	}
+	if user.OperatorID == nil {
+		return OperatorScope{}, apperr.NewForbidden("当前用户未绑定运营商")
+	}
+	return OperatorScope{OperatorID: *user.OperatorID}, nil
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/apperr"
+	"backend/pkg/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// OperatorService 无人机运营商服务接口
+// 查询按操作人的运营商范围过滤，运营商用户只能看到所属运营商
+type OperatorService interface {
+	ListOperators(ctx context.Context, query *dto.OperatorQuery, actor Actor) (*dto.PageResponse[dto.OperatorResponse], error)
+	GetOperator(ctx context.Context, id uuid.UUID, actor Actor) (*models.Operator, error)
+	CreateOperator(ctx context.Context, req *dto.CreateOperatorRequest) (*models.Operator, error)
+	UpdateOperator(ctx context.Context, id uuid.UUID, req *dto.UpdateOperatorRequest) (*models.Operator, error)
+	DeleteOperator(ctx context.Context, id uuid.UUID) error
+	// BindUser 将用户绑定到运营商，用户的角色需为 operator 才能按运营商范围访问
+	BindUser(ctx context.Context, operatorID, userID uuid.UUID) error
+}
+
+type operatorService struct {
+	repo      repositories.OperatorRepository
+	droneRepo repositories.DroneRepository
+	userRepo  repositories.UserRepository
+}
+
+// NewOperatorService 创建运营商服务实例
+func NewOperatorService(repo repositories.OperatorRepository, droneRepo repositories.DroneRepository, userRepo repositories.UserRepository) OperatorService {
+	return &operatorService{
+		repo:      repo,
+		droneRepo: droneRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// ListOperators 分页查询运营商
+func (s *operatorService) ListOperators(ctx context.Context, query *dto.OperatorQuery, actor Actor) (*dto.PageResponse[dto.OperatorResponse], error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	query.Normalize()
+
+	operators, total, err := s.repo.List(ctx, repositories.OperatorFilter{
+		ID:      scope.Filter(nil),
+		Type:    query.Type,
+		Status:  query.Status,
+		Keyword: strings.TrimSpace(query.Q),
+		Offset:  query.Offset(),
+		Limit:   query.PageSize,
+	})
+	if err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	return dto.NewPageResponse(dto.ToOperatorResponseList(operators), total, query.PageQuery), nil
+}
+
+// GetOperator 获取运营商详情，范围外的运营商视为不存在
+func (s *operatorService) GetOperator(ctx context.Context, id uuid.UUID, actor Actor) (*models.Operator, error) {
+	scope, err := resolveOperatorScope(ctx, s.userRepo, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Allows(&id) {
+		return nil, apperr.NewNotFound("运营商不存在")
+	}
+	return s.findOperator(ctx, id)
+}
+
+// CreateOperator 创建运营商，代码全局唯一
+func (s *operatorService) CreateOperator(ctx context.Context, req *dto.CreateOperatorRequest) (*models.Operator, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	if _, err := s.repo.FindByCode(ctx, code); err == nil {
+		return nil, apperr.NewConflict("运营商代码已存在")
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return nil, apperr.NewInternalError(err)
+	}
+
+	operator := &models.Operator{
+		Code:             code,
+		Name:             req.Name,
+		LicenseNo:        req.LicenseNo,
+		LicenseExpiresAt: req.LicenseExpiresAt,
+		Contact:          req.Contact,
+		Phone:            req.Phone,
+		Email:            req.Email,
+		Address:          req.Address,
+		Type:             defaultString(req.Type, "commercial"),
+		Status:           defaultString(req.Status, models.OperatorStatusActive),
+		Description:      req.Description,
+	}
+
+	if err := s.repo.Create(ctx, operator); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return operator, nil
+}
+
+// UpdateOperator 更新运营商，仅修改请求中提供的字段
+func (s *operatorService) UpdateOperator(ctx context.Context, id uuid.UUID, req *dto.UpdateOperatorRequest) (*models.Operator, error) {
+	operator, err := s.findOperator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		operator.Name = *req.Name
+	}
+	if req.LicenseNo != nil {
+		operator.LicenseNo = *req.LicenseNo
+	}
+	if req.LicenseExpiresAt != nil {
+		operator.LicenseExpiresAt = req.LicenseExpiresAt
+	}
+	if req.Contact != nil {
+		operator.Contact = *req.Contact
+	}
+	if req.Phone != nil {
+		operator.Phone = *req.Phone
+	}
+	if req.Email != nil {
+		operator.Email = *req.Email
+	}
+	if req.Address != nil {
+		operator.Address = *req.Address
+	}
+	if req.Type != nil {
+		operator.Type = *req.Type
+	}
+	if req.Status != nil {
+		operator.Status = *req.Status
+	}
+	if req.Description != nil {
+		operator.Description = *req.Description
+	}
+
+	if err := s.repo.Update(ctx, operator); err != nil {
+		return nil, apperr.NewInternalError(err)
+	}
+	return operator, nil
+}
+
+// DeleteOperator 删除运营商，名下仍有无人机时拒绝删除
+func (s *operatorService) DeleteOperator(ctx context.Context, id uuid.UUID) error {
+	count, err := s.droneRepo.CountByOperator(ctx, id)
+	if err != nil {
+		return apperr.NewInternalError(err)
+	}
+	if count > 0 {
+		return apperr.NewConflict(fmt.Sprintf("运营商名下仍有 %d 架无人机，无法删除", count))
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return apperr.NewNotFound("运营商不存在")
+		}
+		return apperr.NewInternalError(err)
+	}
+	return nil
+}
+
+// BindUser 将用户绑定到运营商
+func (s *operatorService) BindUser(ctx context.Context, operatorID, userID uuid.UUID) error {
+	if _, err := s.findOperator(ctx, operatorID); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return apperr.NewBadRequest("用户不存在")
+	}
+	if user.Role == RoleAdmin {
+		return apperr.NewBadRequest("管理员不能绑定运营商")
+	}
+
+	user.OperatorID = &operatorID
+	if _, err := s.userRepo.Update(ctx, user); err != nil {
+		return apperr.NewInternalError(err)
+	}
+
+	logger.Infof("[OperatorService] 用户绑定运营商: user=%s, operator=%s", user.Username, operatorID.String())
+	return nil
+}
+
+func (s *operatorService) findOperator(ctx context.Context, id uuid.UUID) (*models.Operator, error) {
+	operator, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, apperr.NewNotFound("运营商不存在")
+		}
+		return nil, apperr.NewInternalError(err)
+	}
+	return operator, nil
+}
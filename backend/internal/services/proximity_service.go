@@ -0,0 +1,302 @@
+package services
+
+import (
+	"backend/internal/models"
+	"backend/internal/proximity"
+	"backend/pkg/utils/logger"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultNearMissHorizontal 默认接近告警水平间隔标准（米）
+	DefaultNearMissHorizontal = 500.0
+	// DefaultNearMissVertical 默认接近告警垂直间隔标准（米）
+	DefaultNearMissVertical = 150.0
+	// DefaultNearMissLookahead 默认最近会遇点预测时长
+	DefaultNearMissLookahead = 60 * time.Second
+
+	// proximityCellSize 空间索引网格边长（度），约 11 公里
+	proximityCellSize = 0.1
+	// proximityMaxAge 超过该时间未更新的位置视为已离开，不再参与比对
+	proximityMaxAge = 2 * time.Minute
+	// maxClosingSpeed 查询周边实体时假定的最大接近速度（米/秒）
+	maxClosingSpeed = 300.0
+)
+
+// ProximityService 航班与无人机接近检测服务接口
+// 在空间索引中保存每个执行中航班和空中无人机的最新位置，位置更新时与周边另一类实体比对间隔：
+// 当前间隔不足触发 critical 接近告警，按航向和速度预测的最近会遇点间隔不足触发 warning 告警，恢复后自动解除
+type ProximityService interface {
+	// UpdateFlight 更新航班位置并检测与周边无人机的间隔
+	UpdateFlight(ctx context.Context, flight *models.Flight, position *models.FlightPosition) error
+	// UpdateDrone 更新无人机位置并检测与周边航班的间隔，落地的无人机移出索引并解除相关告警
+	UpdateDrone(ctx context.Context, drone *models.Drone, position *models.DronePosition) error
+	// Restore 从未解除的接近告警恢复实体对，重启后实体对恢复间隔或离开时仍能解除此前的告警
+	Restore(ctx context.Context) error
+}
+
+// proximityPair 航班-无人机对
+type proximityPair struct {
+	flightID uuid.UUID
+	droneID  uuid.UUID
+}
+
+type proximityService struct {
+	alerts    AlertService
+	index     *proximity.Index
+	minima    proximity.Minima
+	lookahead time.Duration
+
+	mu     sync.Mutex
+	active map[proximityPair]bool // 存在未解除接近告警的航班-无人机对
+}
+
+// NewProximityService 创建接近检测服务实例
+// minima 为最小间隔标准（米），lookahead 为最近会遇点预测时长，不大于 0 时使用默认值
+func NewProximityService(alerts AlertService, minima proximity.Minima, lookahead time.Duration) ProximityService {
+	if minima.Horizontal <= 0 {
+		minima.Horizontal = DefaultNearMissHorizontal
+	}
+	if minima.Vertical <= 0 {
+		minima.Vertical = DefaultNearMissVertical
+	}
+	if lookahead <= 0 {
+		lookahead = DefaultNearMissLookahead
+	}
+	return &proximityService{
+		alerts:    alerts,
+		index:     proximity.NewIndex(proximityCellSize, proximityMaxAge),
+		minima:    minima,
+		lookahead: lookahead,
+		active:    make(map[proximityPair]bool),
+	}
+}
+
+// RunProximityRestore 服务启动时恢复未解除的接近告警，失败只记录日志
+func RunProximityRestore(ctx context.Context, service ProximityService) {
+	if err := service.Restore(ctx); err != nil {
+		logger.Errorf("[ProximityService] 恢复未解除接近告警失败: %v", err)
+	}
+}
+
+// Restore 将未解除的接近告警载入 active
+func (s *proximityService) Restore(ctx context.Context) error {
+	open, err := s.alerts.ListActive(ctx, models.AlertTypeNearMiss)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, alert := range open {
+		if alert.EntityType != models.AlertEntityDrone || alert.RelatedID == nil {
+			continue
+		}
+		s.active[proximityPair{flightID: *alert.RelatedID, droneID: alert.EntityID}] = true
+	}
+	return nil
+}
+
+// UpdateFlight 已到达或取消的航班移出索引
+func (s *proximityService) UpdateFlight(ctx context.Context, flight *models.Flight, position *models.FlightPosition) error {
+	if flight == nil {
+		return nil
+	}
+	key := proximity.Key{Kind: proximity.KindFlight, ID: flight.ID}
+	if flight.Status == models.FlightStatusArrived || flight.Status == models.FlightStatusCancelled {
+		s.index.Remove(key)
+		return s.resolve(ctx, key, nil, position.Timestamp)
+	}
+	return s.update(ctx, flightTrack(flight, position))
+}
+
+// UpdateDrone 高度低于起飞判定高度的无人机视为已落地
+func (s *proximityService) UpdateDrone(ctx context.Context, drone *models.Drone, position *models.DronePosition) error {
+	if drone == nil {
+		return nil
+	}
+	key := proximity.Key{Kind: proximity.KindDrone, ID: drone.ID}
+	if !isAirborne(position) {
+		s.index.Remove(key)
+		return s.resolve(ctx, key, nil, position.Timestamp)
+	}
+	return s.update(ctx, droneTrack(drone, position))
+}
+
+// update 写入索引后比对周边另一类实体，并解除已不在周边的实体对的告警
+func (s *proximityService) update(ctx context.Context, track proximity.Track) error {
+	if !s.index.Upsert(track) {
+		return nil
+	}
+
+	radius := s.minima.Horizontal + maxClosingSpeed*s.lookahead.Seconds()
+	nearby := make(map[proximityPair]bool)
+	for _, other := range s.index.Nearby(track.Latitude, track.Longitude, radius, track.Timestamp) {
+		if other.Kind == track.Kind {
+			continue
+		}
+		flight, drone := other, track
+		if track.Kind == proximity.KindFlight {
+			flight, drone = track, other
+		}
+		pair := proximityPair{flightID: flight.ID, droneID: drone.ID}
+		nearby[pair] = true
+
+		alert := s.assess(flight, drone, proximity.ClosestApproach(drone, flight, s.lookahead), track.Timestamp)
+		if alert == nil {
+			if err := s.clearPair(ctx, pair, track.Timestamp); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := s.alerts.Raise(ctx, alert); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.active[pair] = true
+		s.mu.Unlock()
+	}
+	return s.resolve(ctx, track.Key, nearby, track.Timestamp)
+}
+
+// assess 按当前间隔和预测的最近会遇点生成接近告警，间隔充足时返回 nil
+// 告警以无人机为对象、航班为关联对象，触发值为水平间隔
+func (s *proximityService) assess(flight, drone proximity.Track, approach proximity.Approach, at time.Time) *models.Alert {
+	var (
+		severity   string
+		horizontal float64
+		message    string
+	)
+	switch {
+	case s.minima.Violated(approach.Horizontal, approach.Vertical):
+		severity, horizontal = models.AlertSeverityCritical, approach.Horizontal
+		message = fmt.Sprintf("无人机 %s 与航班 %s 间隔不足：水平 %.0f 米，垂直 %s",
+			drone.Label, flight.Label, approach.Horizontal, formatSeparation(approach.Vertical))
+	case approach.TimeToCPA > 0 && s.minima.Violated(approach.CPAHorizontal, approach.CPAVertical):
+		severity, horizontal = models.AlertSeverityWarning, approach.CPAHorizontal
+		message = fmt.Sprintf("无人机 %s 与航班 %s 预计 %.0f 秒后间隔不足：水平 %.0f 米，垂直 %s",
+			drone.Label, flight.Label, approach.TimeToCPA.Seconds(), approach.CPAHorizontal, formatSeparation(approach.CPAVertical))
+	default:
+		return nil
+	}
+
+	relatedType := models.AlertEntityFlight
+	latitude, longitude := drone.Latitude, drone.Longitude
+	return &models.Alert{
+		Type:        models.AlertTypeNearMiss,
+		Severity:    severity,
+		EntityType:  models.AlertEntityDrone,
+		EntityID:    drone.ID,
+		RelatedType: &relatedType,
+		RelatedID:   &flight.ID,
+		Message:     message,
+		Value:       roundTo(horizontal, 1),
+		Threshold:   s.minima.Horizontal,
+		Latitude:    &latitude,
+		Longitude:   &longitude,
+		TriggeredAt: at,
+	}
+}
+
+// resolve 解除 key 参与的、不在 keep 中的实体对的接近告警
+func (s *proximityService) resolve(ctx context.Context, key proximity.Key, keep map[proximityPair]bool, at time.Time) error {
+	s.mu.Lock()
+	stale := make([]proximityPair, 0)
+	for pair := range s.active {
+		involved := (key.Kind == proximity.KindFlight && pair.flightID == key.ID) ||
+			(key.Kind == proximity.KindDrone && pair.droneID == key.ID)
+		if involved && !keep[pair] {
+			stale = append(stale, pair)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, pair := range stale {
+		if err := s.clearPair(ctx, pair, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearPair 解除实体对的接近告警，没有未解除告警的实体对直接跳过
+func (s *proximityService) clearPair(ctx context.Context, pair proximityPair, at time.Time) error {
+	s.mu.Lock()
+	active := s.active[pair]
+	s.mu.Unlock()
+	if !active {
+		return nil
+	}
+
+	if err := s.alerts.ClearRelated(ctx, models.AlertTypeNearMiss, models.AlertEntityDrone, pair.droneID, pair.flightID, at); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.active, pair)
+	s.mu.Unlock()
+	return nil
+}
+
+// flightTrack 航班位置转为索引条目：高度英尺、速度节、垂直速度英尺/分钟换算为米和米/秒
+func flightTrack(flight *models.Flight, position *models.FlightPosition) proximity.Track {
+	track := proximity.Track{
+		Key:       proximity.Key{Kind: proximity.KindFlight, ID: flight.ID},
+		Label:     flight.FlightNumber,
+		Latitude:  position.Latitude,
+		Longitude: position.Longitude,
+		Timestamp: position.Timestamp,
+	}
+	if position.Altitude != nil {
+		altitude := float64(*position.Altitude) * feetToMeters
+		track.Altitude = &altitude
+	}
+	if position.Speed != nil {
+		track.GroundSpeed = float64(*position.Speed) * knotsToKmh / 3.6
+	}
+	if position.Heading != nil {
+		heading := float64(*position.Heading)
+		track.Heading = &heading
+	}
+	if position.VerticalSpeed != nil {
+		track.VerticalSpeed = float64(*position.VerticalSpeed) * feetToMeters / 60
+	}
+	return track
+}
+
+// droneTrack 无人机位置转为索引条目：优先使用海拔高度与航班比对，缺失时退回相对地面高度；速度 km/h 换算为米/秒
+func droneTrack(drone *models.Drone, position *models.DronePosition) proximity.Track {
+	altitude := float64(position.Altitude)
+	if position.AltitudeMSL != nil {
+		altitude = float64(*position.AltitudeMSL)
+	}
+	track := proximity.Track{
+		Key:       proximity.Key{Kind: proximity.KindDrone, ID: drone.ID},
+		Label:     drone.SerialNumber,
+		Latitude:  position.Latitude,
+		Longitude: position.Longitude,
+		Altitude:  &altitude,
+		Timestamp: position.Timestamp,
+	}
+	if position.Speed != nil {
+		track.GroundSpeed = float64(*position.Speed) / 3.6
+	}
+	if position.Heading != nil {
+		heading := float64(*position.Heading)
+		track.Heading = &heading
+	}
+	if position.VerticalSpeed != nil {
+		track.VerticalSpeed = float64(*position.VerticalSpeed)
+	}
+	return track
+}
+
+func formatSeparation(vertical *float64) string {
+	if vertical == nil {
+		return "未知"
+	}
+	return fmt.Sprintf("%.0f 米", *vertical)
+}
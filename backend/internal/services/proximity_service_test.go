@@ -0,0 +1,129 @@
+package services
+
+import (
+	"backend/internal/models"
+	"backend/internal/proximity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestProximityServiceRaisesAndClearsNearMiss(t *testing.T) {
+	ctx := context.Background()
+	alerts := new(MockAlertService)
+	service := NewProximityService(alerts, proximity.Minima{}, 0)
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-001"}
+	flight := &models.Flight{ID: uuid.New(), FlightNumber: "MU5101", Status: models.FlightStatusDeparted}
+	hover := models.DronePosition{DroneID: drone.ID, Latitude: 31.2, Longitude: 121.45, Altitude: 120, AltitudeMSL: intPtr(300), Timestamp: start}
+
+	// 航班在南侧约 3 公里以 150 节向北飞行，约 40 秒后经过无人机上空 5 米
+	var raised []*models.Alert
+	alerts.On("Raise", ctx, mock.AnythingOfType("*models.Alert")).
+		Run(func(args mock.Arguments) { raised = append(raised, args.Get(1).(*models.Alert)) }).
+		Return(nil)
+	require.NoError(t, service.UpdateDrone(ctx, drone, &hover))
+	require.NoError(t, service.UpdateFlight(ctx, flight, &models.FlightPosition{
+		FlightID: flight.ID, Latitude: 31.173, Longitude: 121.45,
+		Altitude: intPtr(1000), Speed: intPtr(150), Heading: intPtr(0), Timestamp: start,
+	}))
+	require.Len(t, raised, 1)
+	assert.Equal(t, models.AlertTypeNearMiss, raised[0].Type)
+	assert.Equal(t, models.AlertSeverityWarning, raised[0].Severity)
+	assert.Equal(t, models.AlertEntityDrone, raised[0].EntityType)
+	assert.Equal(t, drone.ID, raised[0].EntityID)
+	assert.Equal(t, flight.ID, *raised[0].RelatedID)
+	assert.Equal(t, DefaultNearMissHorizontal, raised[0].Threshold)
+	assert.Less(t, raised[0].Value, 100.0)
+	assert.Contains(t, raised[0].Message, "MU5101")
+
+	// 航班到达无人机上空
+	require.NoError(t, service.UpdateFlight(ctx, flight, &models.FlightPosition{
+		FlightID: flight.ID, Latitude: 31.2, Longitude: 121.45,
+		Altitude: intPtr(1000), Speed: intPtr(150), Heading: intPtr(0), Timestamp: start.Add(40 * time.Second),
+	}))
+	require.Len(t, raised, 2)
+	assert.Equal(t, models.AlertSeverityCritical, raised[1].Severity)
+
+	// 无人机降落后解除
+	landed := hover
+	landed.Altitude, landed.Timestamp = 0, start.Add(45*time.Second)
+	alerts.On("ClearRelated", ctx, models.AlertTypeNearMiss, models.AlertEntityDrone, drone.ID, flight.ID, landed.Timestamp).Return(nil).Once()
+	require.NoError(t, service.UpdateDrone(ctx, drone, &landed))
+	alerts.AssertExpectations(t)
+}
+
+func TestProximityServiceIgnoresSeparatedTraffic(t *testing.T) {
+	ctx := context.Background()
+	alerts := new(MockAlertService)
+	service := NewProximityService(alerts, proximity.Minima{Horizontal: 500, Vertical: 150}, time.Minute)
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-002"}
+	flight := &models.Flight{ID: uuid.New(), FlightNumber: "CA1501", Status: models.FlightStatusDeparted}
+	require.NoError(t, service.UpdateDrone(ctx, drone, &models.DronePosition{
+		DroneID: drone.ID, Latitude: 31.2, Longitude: 121.45, Altitude: 120, Timestamp: start,
+	}))
+
+	// 正上方但垂直间隔充足
+	require.NoError(t, service.UpdateFlight(ctx, flight, &models.FlightPosition{
+		FlightID: flight.ID, Latitude: 31.2, Longitude: 121.45, Altitude: intPtr(10000), Timestamp: start,
+	}))
+	// 同高度但背离飞行
+	require.NoError(t, service.UpdateFlight(ctx, flight, &models.FlightPosition{
+		FlightID: flight.ID, Latitude: 31.19, Longitude: 121.45,
+		Altitude: intPtr(400), Speed: intPtr(150), Heading: intPtr(180), Timestamp: start.Add(time.Second),
+	}))
+	alerts.AssertNotCalled(t, "Raise", mock.Anything, mock.Anything)
+	alerts.AssertNotCalled(t, "ClearRelated", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProximityServiceClearsAlertsRaisedBeforeRestart(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	drone := &models.Drone{ID: uuid.New(), SerialNumber: "DJI-003"}
+	flight := &models.Flight{ID: uuid.New(), FlightNumber: "FM9101", Status: models.FlightStatusDeparted}
+	related := models.AlertEntityFlight
+
+	// 重启前遗留的未解除告警
+	alerts := new(MockAlertService)
+	alerts.On("ListActive", mock.Anything, models.AlertTypeNearMiss).Return([]models.Alert{{
+		Type: models.AlertTypeNearMiss, EntityType: models.AlertEntityDrone, EntityID: drone.ID,
+		RelatedType: &related, RelatedID: &flight.ID, Status: models.AlertStatusOpen,
+	}}, nil)
+	service := NewProximityService(alerts, proximity.Minima{}, 0)
+	require.NoError(t, service.Restore(ctx))
+
+	// 重启后无人机已远离航班，首次上报即解除
+	alerts.On("ClearRelated", ctx, models.AlertTypeNearMiss, models.AlertEntityDrone, drone.ID, flight.ID, start).Return(nil).Once()
+	require.NoError(t, service.UpdateDrone(ctx, drone, &models.DronePosition{
+		DroneID: drone.ID, Latitude: 31.2, Longitude: 121.45, Altitude: 120, Timestamp: start,
+	}))
+	require.NoError(t, service.UpdateFlight(ctx, flight, &models.FlightPosition{
+		FlightID: flight.ID, Latitude: 30.2, Longitude: 121.45, Altitude: intPtr(10000), Timestamp: start,
+	}))
+	alerts.AssertExpectations(t)
+}
+
+func TestProximityServiceRestoresOnlyWhenStarted(t *testing.T) {
+	discardLogs()
+	alerts := new(MockAlertService)
+	service := NewProximityService(alerts, proximity.Minima{}, 0)
+	alerts.AssertNotCalled(t, "ListActive", mock.Anything, mock.Anything)
+
+	alerts.On("ListActive", mock.Anything, models.AlertTypeNearMiss).Return([]models.Alert(nil), errors.New("connection reset"))
+	assert.Error(t, service.Restore(context.Background()))
+	RunProximityRestore(context.Background(), service)
+	alerts.AssertNumberOfCalls(t, "ListActive", 2)
+}
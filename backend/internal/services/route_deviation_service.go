@@ -0,0 +1,195 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/internal/repositories"
+	"backend/pkg/geo"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultRouteDeviationThreshold 默认偏航告警阈值（米），约 5 海里
+	DefaultRouteDeviationThreshold = 9260.0
+
+	// waypointCaptureRadius 距航点该距离（米）以内即视为已过点，约 1 海里
+	waypointCaptureRadius = 1852.0
+)
+
+// RouteDeviationService 航线偏离检测服务接口
+type RouteDeviationService interface {
+	// CheckPositions 将新上报的位置点与计划航线比对：记录航点实际过点时间，
+	// 并按各航班最新位置的侧向偏差触发或解除偏航告警
+	CheckPositions(ctx context.Context, flights map[uuid.UUID]*models.Flight, positions []models.FlightPosition) error
+	// Measure 计算单个位置相对航线折线的偏离情况
+	Measure(position geo.LatLng, path []geo.LatLng) *dto.RouteDeviation
+}
+
+type routeDeviationService struct {
+	routeRepo repositories.FlightRouteRepository
+	alerts    AlertService
+	threshold float64
+}
+
+// NewRouteDeviationService 创建航线偏离检测服务实例
+// threshold 为偏航告警阈值（米），不大于 0 时使用默认值
+func NewRouteDeviationService(routeRepo repositories.FlightRouteRepository, alerts AlertService, threshold float64) RouteDeviationService {
+	if threshold <= 0 {
+		threshold = DefaultRouteDeviationThreshold
+	}
+	return &routeDeviationService{
+		routeRepo: routeRepo,
+		alerts:    alerts,
+		threshold: threshold,
+	}
+}
+
+// CheckPositions 比对位置点与计划航线，未设置航线（少于两个航点）的航班跳过
+func (s *routeDeviationService) CheckPositions(ctx context.Context, flights map[uuid.UUID]*models.Flight, positions []models.FlightPosition) error {
+	byFlight := make(map[uuid.UUID][]models.FlightPosition)
+	for _, position := range positions {
+		byFlight[position.FlightID] = append(byFlight[position.FlightID], position)
+	}
+	flightIDs := make([]uuid.UUID, 0, len(byFlight))
+	for id := range byFlight {
+		flightIDs = append(flightIDs, id)
+	}
+
+	routes, err := s.routeRepo.ListByFlights(ctx, flightIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range flightIDs {
+		waypoints := routes[id]
+		if len(waypoints) < 2 {
+			continue
+		}
+		track := byFlight[id]
+		sort.Slice(track, func(i, j int) bool { return track[i].Timestamp.Before(track[j].Timestamp) })
+
+		if err := s.markPassedWaypoints(ctx, waypoints, track); err != nil {
+			return err
+		}
+		if err := s.checkDeviation(ctx, flights[id], waypoints, &track[len(track)-1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Measure 计算位置相对航线的偏离情况
+func (s *routeDeviationService) Measure(position geo.LatLng, path []geo.LatLng) *dto.RouteDeviation {
+	projection := geo.ProjectOntoPath(position, path)
+	return &dto.RouteDeviation{
+		CrossTrackMeters: roundTo(projection.Distance, 1),
+		ThresholdMeters:  s.threshold,
+		Segment:          projection.Segment,
+		Deviated:         projection.Distance > s.threshold,
+	}
+}
+
+// markPassedWaypoints 根据实际观测到的位置点判断已通过的航点，写入首次过点时间
+// 进入航点捕获半径，或位置点由某一航段前进到其终点之后，才视为通过该航点，过点时间取该位置点的时间
+// 偏航期间的位置点不参与判断，避免远离航线时误投影到其他航段
+func (s *routeDeviationService) markPassedWaypoints(ctx context.Context, waypoints []models.FlightRoute, track []models.FlightPosition) error {
+	path := routePath(waypoints)
+	passedAt := make(map[time.Time][]uuid.UUID)
+	var prev *geo.PathProjection
+	for _, position := range track {
+		point := geo.LatLng{Lat: position.Latitude, Lng: position.Longitude}
+		projection := geo.ProjectOntoPath(point, path)
+		if projection.Distance > s.threshold {
+			continue
+		}
+		for _, index := range passedWaypoints(prev, projection, capturedWaypoints(point, path)) {
+			if waypoints[index].ActualTime != nil {
+				continue
+			}
+			at := position.Timestamp
+			waypoints[index].ActualTime = &at
+			passedAt[at] = append(passedAt[at], waypoints[index].ID)
+		}
+		prev = &projection
+	}
+
+	for at, ids := range passedAt {
+		if err := s.routeRepo.MarkPassed(ctx, ids, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDeviation 按最新位置触发或解除偏航告警，超过阈值两倍时为严重级别
+func (s *routeDeviationService) checkDeviation(ctx context.Context, flight *models.Flight, waypoints []models.FlightRoute, latest *models.FlightPosition) error {
+	if flight == nil {
+		return nil
+	}
+
+	deviation := s.Measure(geo.LatLng{Lat: latest.Latitude, Lng: latest.Longitude}, routePath(waypoints))
+	if !deviation.Deviated {
+		return s.alerts.Clear(ctx, models.AlertTypeRouteDeviation, models.AlertEntityFlight, flight.ID, latest.Timestamp)
+	}
+
+	severity := models.AlertSeverityWarning
+	if deviation.CrossTrackMeters > 2*s.threshold {
+		severity = models.AlertSeverityCritical
+	}
+	latitude, longitude := latest.Latitude, latest.Longitude
+	_, err := s.alerts.Raise(ctx, &models.Alert{
+		Type:        models.AlertTypeRouteDeviation,
+		Severity:    severity,
+		EntityType:  models.AlertEntityFlight,
+		EntityID:    flight.ID,
+		Message:     fmt.Sprintf("航班 %s 偏离计划航线 %.1f 公里", flight.FlightNumber, deviation.CrossTrackMeters/1000),
+		Value:       deviation.CrossTrackMeters,
+		Threshold:   s.threshold,
+		Latitude:    &latitude,
+		Longitude:   &longitude,
+		TriggeredAt: latest.Timestamp,
+	})
+	return err
+}
+
+// passedWaypoints 返回当前位置点确认通过的航点下标
+// captured 为进入捕获半径的航点；prev 为上一个航线附近位置点的投影，为空时只按捕获半径判断
+// 在第 a 段上越过起点或终点时对应航点已通过；跨越多段时上一段的终点和当前航段的起点已通过，中间未观测到的航点不标记
+func passedWaypoints(prev *geo.PathProjection, projection geo.PathProjection, captured []int) []int {
+	indices := append([]int(nil), captured...)
+	if prev == nil || prev.Fraction >= 1 {
+		return indices
+	}
+
+	switch {
+	case projection.Segment == prev.Segment:
+		if prev.Fraction < 0 && projection.Fraction >= 0 {
+			indices = append(indices, prev.Segment)
+		}
+		if projection.Fraction >= 1 {
+			indices = append(indices, prev.Segment+1)
+		}
+	case projection.Segment > prev.Segment:
+		indices = append(indices, prev.Segment+1)
+		if projection.Segment > prev.Segment+1 && projection.Fraction >= 0 {
+			indices = append(indices, projection.Segment)
+		}
+	}
+	return indices
+}
+
+// capturedWaypoints 返回位置点捕获半径内的航点下标
+func capturedWaypoints(point geo.LatLng, path []geo.LatLng) []int {
+	var indices []int
+	for i, waypoint := range path {
+		if geo.Haversine(point.Lat, point.Lng, waypoint.Lat, waypoint.Lng) <= waypointCaptureRadius {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
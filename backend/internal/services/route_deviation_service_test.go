@@ -0,0 +1,210 @@
+package services
+
+import (
+	"backend/internal/dto"
+	"backend/internal/models"
+	"backend/pkg/geo"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFlightRouteRepository 是 FlightRouteRepository 的 Mock 实现
+type MockFlightRouteRepository struct {
+	mock.Mock
+}
+
+func (m *MockFlightRouteRepository) ListByFlight(ctx context.Context, flightID uuid.UUID) ([]models.FlightRoute, error) {
+	args := m.Called(ctx, flightID)
+	return args.Get(0).([]models.FlightRoute), args.Error(1)
+}
+
+func (m *MockFlightRouteRepository) ListByFlights(ctx context.Context, flightIDs []uuid.UUID) (map[uuid.UUID][]models.FlightRoute, error) {
+	args := m.Called(ctx, flightIDs)
+	return args.Get(0).(map[uuid.UUID][]models.FlightRoute), args.Error(1)
+}
+
+func (m *MockFlightRouteRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.FlightRoute, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FlightRoute), args.Error(1)
+}
+
+func (m *MockFlightRouteRepository) Replace(ctx context.Context, flightID uuid.UUID, waypoints []models.FlightRoute) error {
+	return m.Called(ctx, flightID, waypoints).Error(0)
+}
+
+func (m *MockFlightRouteRepository) Update(ctx context.Context, waypoint *models.FlightRoute) error {
+	return m.Called(ctx, waypoint).Error(0)
+}
+
+func (m *MockFlightRouteRepository) DeleteByFlight(ctx context.Context, flightID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, flightID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFlightRouteRepository) MarkPassed(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	return m.Called(ctx, ids, at).Error(0)
+}
+
+// MockAlertService 是 AlertService 的 Mock 实现
+type MockAlertService struct {
+	mock.Mock
+}
+
+func (m *MockAlertService) ListAlerts(ctx context.Context, query *dto.AlertQuery) (*dto.PageResponse[dto.AlertResponse], error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*dto.PageResponse[dto.AlertResponse]), args.Error(1)
+}
+
+func (m *MockAlertService) GetAlert(ctx context.Context, id uuid.UUID) (*models.Alert, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*models.Alert), args.Error(1)
+}
+
+func (m *MockAlertService) Acknowledge(ctx context.Context, id uuid.UUID, actor Actor) (*models.Alert, error) {
+	args := m.Called(ctx, id, actor)
+	return args.Get(0).(*models.Alert), args.Error(1)
+}
+
+func (m *MockAlertService) Resolve(ctx context.Context, id uuid.UUID, actor Actor) (*models.Alert, error) {
+	args := m.Called(ctx, id, actor)
+	return args.Get(0).(*models.Alert), args.Error(1)
+}
+
+func (m *MockAlertService) Raise(ctx context.Context, alert *models.Alert) (*models.Alert, error) {
+	args := m.Called(ctx, alert)
+	return alert, args.Error(0)
+}
+
+func (m *MockAlertService) Clear(ctx context.Context, alertType, entityType string, entityID uuid.UUID, at time.Time) error {
+	return m.Called(ctx, alertType, entityType, entityID, at).Error(0)
+}
+
+func (m *MockAlertService) ClearRelated(ctx context.Context, alertType, entityType string, entityID, relatedID uuid.UUID, at time.Time) error {
+	return m.Called(ctx, alertType, entityType, entityID, relatedID, at).Error(0)
+}
+
+func (m *MockAlertService) ListActive(ctx context.Context, alertType string) ([]models.Alert, error) {
+	args := m.Called(ctx, alertType)
+	return args.Get(0).([]models.Alert), args.Error(1)
+}
+
+// testRoute 沿赤道向东每隔 1 度一个航点
+func testRoute(flightID uuid.UUID, count int) []models.FlightRoute {
+	waypoints := make([]models.FlightRoute, count)
+	for i := range waypoints {
+		waypoints[i] = models.FlightRoute{ID: uuid.New(), FlightID: flightID, Latitude: 0, Longitude: float64(i), Sequence: i + 1}
+	}
+	return waypoints
+}
+
+func TestPassedWaypoints(t *testing.T) {
+	// 没有上一个位置点时只按捕获半径判断
+	assert.Empty(t, passedWaypoints(nil, geo.PathProjection{Segment: 2, Fraction: 0.5}, nil))
+	assert.Equal(t, []int{3}, passedWaypoints(nil, geo.PathProjection{Segment: 2, Fraction: 0.99}, []int{3}))
+
+	// 同一航段内越过起点或终点
+	assert.Equal(t, []int{0}, passedWaypoints(&geo.PathProjection{Segment: 0, Fraction: -0.1}, geo.PathProjection{Segment: 0, Fraction: 0.2}, nil))
+	assert.Equal(t, []int{2}, passedWaypoints(&geo.PathProjection{Segment: 1, Fraction: 0.8}, geo.PathProjection{Segment: 1, Fraction: 1.2}, nil))
+	assert.Empty(t, passedWaypoints(&geo.PathProjection{Segment: 1, Fraction: 0.2}, geo.PathProjection{Segment: 1, Fraction: 0.8}, nil))
+
+	// 进入下一航段
+	assert.Equal(t, []int{2}, passedWaypoints(&geo.PathProjection{Segment: 1, Fraction: 0.8}, geo.PathProjection{Segment: 2, Fraction: 0.1}, nil))
+	// 跨越多段时中间未观测到的航点不标记
+	assert.Equal(t, []int{2, 4}, passedWaypoints(&geo.PathProjection{Segment: 1, Fraction: 0.8}, geo.PathProjection{Segment: 4, Fraction: 0.1}, nil))
+	// 航线倒退不标记
+	assert.Empty(t, passedWaypoints(&geo.PathProjection{Segment: 2, Fraction: 0.5}, geo.PathProjection{Segment: 1, Fraction: 0.5}, nil))
+}
+
+func TestCheckPositionsMarksWaypointsAndClearsAlert(t *testing.T) {
+	ctx := context.Background()
+	flight := &models.Flight{ID: uuid.New(), FlightNumber: "MU5101"}
+	route := testRoute(flight.ID, 3)
+	at := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+
+	routeRepo := new(MockFlightRouteRepository)
+	alerts := new(MockAlertService)
+	service := NewRouteDeviationService(routeRepo, alerts, 0)
+
+	routeRepo.On("ListByFlights", ctx, []uuid.UUID{flight.ID}).Return(map[uuid.UUID][]models.FlightRoute{flight.ID: route}, nil)
+	routeRepo.On("MarkPassed", ctx, []uuid.UUID{route[0].ID}, at).Return(nil)
+	routeRepo.On("MarkPassed", ctx, []uuid.UUID{route[1].ID}, at.Add(20*time.Minute)).Return(nil)
+	alerts.On("Clear", ctx, models.AlertTypeRouteDeviation, models.AlertEntityFlight, flight.ID, at.Add(20*time.Minute)).Return(nil)
+
+	// 从起点附近出发，经第一航段中部进入第二航段中部（偏离约 1.1 公里）
+	positions := []models.FlightPosition{
+		{FlightID: flight.ID, Latitude: 0, Longitude: 0.01, Timestamp: at},
+		{FlightID: flight.ID, Latitude: 0, Longitude: 0.5, Timestamp: at.Add(10 * time.Minute)},
+		{FlightID: flight.ID, Latitude: 0.01, Longitude: 1.5, Timestamp: at.Add(20 * time.Minute)},
+	}
+	err := service.CheckPositions(ctx, map[uuid.UUID]*models.Flight{flight.ID: flight}, positions)
+	require.NoError(t, err)
+
+	routeRepo.AssertExpectations(t)
+	alerts.AssertExpectations(t)
+	alerts.AssertNotCalled(t, "Raise", mock.Anything, mock.Anything)
+}
+
+func TestCheckPositionsTrackingStartsMidRoute(t *testing.T) {
+	ctx := context.Background()
+	flight := &models.Flight{ID: uuid.New(), FlightNumber: "MU5101"}
+	route := testRoute(flight.ID, 5)
+	at := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+
+	routeRepo := new(MockFlightRouteRepository)
+	alerts := new(MockAlertService)
+	service := NewRouteDeviationService(routeRepo, alerts, 0)
+
+	routeRepo.On("ListByFlights", ctx, []uuid.UUID{flight.ID}).Return(map[uuid.UUID][]models.FlightRoute{flight.ID: route}, nil)
+	routeRepo.On("MarkPassed", ctx, []uuid.UUID{route[3].ID}, at.Add(10*time.Minute)).Return(nil)
+	alerts.On("Clear", ctx, models.AlertTypeRouteDeviation, models.AlertEntityFlight, flight.ID, at.Add(10*time.Minute)).Return(nil)
+
+	// 首次收到位置时已在第三航段中部，之前的航点没有观测依据，不标记
+	positions := []models.FlightPosition{
+		{FlightID: flight.ID, Latitude: 0, Longitude: 2.5, Timestamp: at},
+		{FlightID: flight.ID, Latitude: 0, Longitude: 3.4, Timestamp: at.Add(10 * time.Minute)},
+	}
+	err := service.CheckPositions(ctx, map[uuid.UUID]*models.Flight{flight.ID: flight}, positions)
+	require.NoError(t, err)
+
+	routeRepo.AssertExpectations(t)
+	routeRepo.AssertNumberOfCalls(t, "MarkPassed", 1)
+	for _, waypoint := range route[:3] {
+		assert.Nil(t, waypoint.ActualTime)
+	}
+}
+
+func TestCheckPositionsRaisesDeviationAlert(t *testing.T) {
+	ctx := context.Background()
+	flight := &models.Flight{ID: uuid.New(), FlightNumber: "MU5101"}
+	route := testRoute(flight.ID, 3)
+	at := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+
+	routeRepo := new(MockFlightRouteRepository)
+	alerts := new(MockAlertService)
+	service := NewRouteDeviationService(routeRepo, alerts, 10000)
+
+	routeRepo.On("ListByFlights", ctx, []uuid.UUID{flight.ID}).Return(map[uuid.UUID][]models.FlightRoute{flight.ID: route}, nil)
+	alerts.On("Raise", ctx, mock.MatchedBy(func(alert *models.Alert) bool {
+		return alert.EntityID == flight.ID &&
+			alert.Type == models.AlertTypeRouteDeviation &&
+			alert.Severity == models.AlertSeverityCritical &&
+			alert.Value > 20000
+	})).Return(nil)
+
+	// 偏离约 33 公里，超过阈值两倍
+	positions := []models.FlightPosition{{FlightID: flight.ID, Latitude: 0.3, Longitude: 1.5, Timestamp: at}}
+	err := service.CheckPositions(ctx, map[uuid.UUID]*models.Flight{flight.ID: flight}, positions)
+	require.NoError(t, err)
+
+	alerts.AssertExpectations(t)
+	routeRepo.AssertNotCalled(t, "MarkPassed", mock.Anything, mock.Anything, mock.Anything)
+}
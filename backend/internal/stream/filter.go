@@ -0,0 +1,61 @@
+package stream
+
+import (
+	"backend/pkg/geo"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseTypes 解析逗号分隔的实体类型列表，空字符串表示全部类型
+func ParseTypes(raw string) ([]EntityType, error) {
+	var types []EntityType
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		t := EntityType(part)
+		if t != EntityFlight && t != EntityDrone && t != EntityAlert {
+			return nil, fmt.Errorf("不支持的实体类型: %s", part)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// ParseBBox 解析 "minLat,minLng,maxLat,maxLng" 格式的范围，空字符串表示不限范围
+func ParseBBox(raw string) (*geo.BBox, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("bbox 格式应为 minLat,minLng,maxLat,maxLng")
+	}
+
+	values := make([]float64, 4)
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bbox 数值无效: %s", part)
+		}
+		values[i] = v
+	}
+	return NewBBox(values)
+}
+
+// NewBBox 根据 [minLat, minLng, maxLat, maxLng] 构造并校验范围
+func NewBBox(values []float64) (*geo.BBox, error) {
+	if len(values) != 4 {
+		return nil, errors.New("bbox 需要 4 个数值")
+	}
+	box := geo.BBox{MinLat: values[0], MinLng: values[1], MaxLat: values[2], MaxLng: values[3]}
+	if !box.Valid() {
+		return nil, errors.New("bbox 范围无效")
+	}
+	return &box, nil
+}
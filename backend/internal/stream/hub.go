@@ -0,0 +1,162 @@
+// Package stream 提供进程内的实时位置事件分发
+//
+// 数据接入服务将位置事件发布到 Hub，WebSocket/SSE 连接按实体类型和范围订阅。
+// 订阅者消费过慢时新事件会被丢弃，不会阻塞发布方。
+package stream
+
+import (
+	"backend/pkg/geo"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EntityType 事件实体类型
+type EntityType string
+
+const (
+	// EntityFlight 航班位置
+	EntityFlight EntityType = "flight"
+	// EntityDrone 无人机位置
+	EntityDrone EntityType = "drone"
+	// EntityAlert 告警
+	EntityAlert EntityType = "alert"
+)
+
+// subscriptionBuffer 每个订阅者的事件缓冲区大小
+const subscriptionBuffer = 256
+
+// Event 实时事件
+type Event struct {
+	Type      EntityType `json:"type"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Timestamp time.Time  `json:"timestamp"`
+	Data      any        `json:"data"`
+}
+
+// Filter 订阅过滤条件，Types 为空表示全部类型，BBox 为空表示不限范围
+type Filter struct {
+	Types []EntityType
+	BBox  *geo.BBox
+}
+
+// Match 判断事件是否满足过滤条件
+func (f Filter) Match(event *Event) bool {
+	if len(f.Types) > 0 {
+		matched := false
+		for _, t := range f.Types {
+			if t == event.Type {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return f.BBox == nil || f.BBox.Contains(event.Latitude, event.Longitude)
+}
+
+// Subscription 一个订阅，通过 C 接收事件
+type Subscription struct {
+	C <-chan Event
+
+	hub     *Hub
+	ch      chan Event
+	mu      sync.RWMutex
+	filter  Filter
+	dropped atomic.Int64
+	closed  bool
+}
+
+// SetFilter 更新订阅过滤条件
+func (s *Subscription) SetFilter(filter Filter) {
+	s.mu.Lock()
+	s.filter = filter
+	s.mu.Unlock()
+}
+
+// Filter 返回当前过滤条件
+func (s *Subscription) Filter() Filter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.filter
+}
+
+// Dropped 返回因缓冲区已满而丢弃的事件数
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close 取消订阅并关闭事件通道，可重复调用
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// Hub 事件分发中心，可在多个 goroutine 中并发使用
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewHub 创建事件分发中心
+func NewHub() *Hub {
+	return &Hub{
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe 按过滤条件订阅事件
+func (h *Hub) Subscribe(filter Filter) *Subscription {
+	ch := make(chan Event, subscriptionBuffer)
+	sub := &Subscription{C: ch, hub: h, ch: ch, filter: filter}
+
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// Publish 向所有匹配的订阅者发布事件，不会阻塞
+func (h *Hub) Publish(events ...Event) {
+	if h == nil || len(events) == 0 {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs {
+		filter := sub.Filter()
+		for i := range events {
+			if !filter.Match(&events[i]) {
+				continue
+			}
+			select {
+			case sub.ch <- events[i]:
+			default:
+				sub.dropped.Add(1)
+			}
+		}
+	}
+}
+
+// Subscribers 返回当前订阅者数量
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs)
+}
+
+func (h *Hub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(h.subs, sub)
+	close(sub.ch)
+}
@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"backend/pkg/geo"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubFiltersByTypeAndBBox(t *testing.T) {
+	hub := NewHub()
+	shanghai := &geo.BBox{MinLat: 30.5, MinLng: 120.8, MaxLat: 31.9, MaxLng: 122.2}
+
+	all := hub.Subscribe(Filter{})
+	drones := hub.Subscribe(Filter{Types: []EntityType{EntityDrone}})
+	local := hub.Subscribe(Filter{BBox: shanghai})
+	defer all.Close()
+	defer drones.Close()
+	defer local.Close()
+
+	hub.Publish(
+		Event{Type: EntityFlight, Latitude: 31.2, Longitude: 121.4},
+		Event{Type: EntityDrone, Latitude: 39.9, Longitude: 116.4},
+	)
+
+	assert.Len(t, all.C, 2)
+	require.Len(t, drones.C, 1)
+	assert.Equal(t, EntityDrone, (<-drones.C).Type)
+	require.Len(t, local.C, 1)
+	assert.Equal(t, EntityFlight, (<-local.C).Type)
+}
+
+func TestSubscriptionUpdateAndClose(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(Filter{Types: []EntityType{EntityFlight}})
+
+	sub.SetFilter(Filter{Types: []EntityType{EntityAlert}})
+	hub.Publish(Event{Type: EntityFlight}, Event{Type: EntityAlert})
+	require.Len(t, sub.C, 1)
+	assert.Equal(t, EntityAlert, (<-sub.C).Type)
+
+	sub.Close()
+	sub.Close()
+	assert.Equal(t, 0, hub.Subscribers())
+	_, ok := <-sub.C
+	assert.False(t, ok)
+
+	hub.Publish(Event{Type: EntityAlert})
+}
+
+func TestSlowSubscriberDropsEvents(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(Filter{})
+	defer sub.Close()
+
+	for i := 0; i < subscriptionBuffer+10; i++ {
+		hub.Publish(Event{Type: EntityFlight})
+	}
+	assert.Len(t, sub.C, subscriptionBuffer)
+	assert.Equal(t, int64(10), sub.Dropped())
+}
+
+func TestParseFilter(t *testing.T) {
+	types, err := ParseTypes("flight, Drone")
+	require.NoError(t, err)
+	assert.Equal(t, []EntityType{EntityFlight, EntityDrone}, types)
+
+	_, err = ParseTypes("ship")
+	assert.Error(t, err)
+
+	box, err := ParseBBox("30.5,120.8,31.9,122.2")
+	require.NoError(t, err)
+	assert.True(t, box.Contains(31.2, 121.4))
+
+	box, err = ParseBBox("")
+	assert.NoError(t, err)
+	assert.Nil(t, box)
+
+	_, err = ParseBBox("91,0,92,1")
+	assert.Error(t, err)
+	_, err = ParseBBox("1,2,3")
+	assert.Error(t, err)
+}
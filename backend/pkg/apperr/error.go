@@ -63,11 +63,21 @@ func NewBadRequest(message string) *AppError {
 	return New(ErrCodeBadRequest, message)
 }
 
+// NewForbidden 创建无权访问错误
+func NewForbidden(message string) *AppError {
+	return New(ErrCodeForbidden, message)
+}
+
 // NewNotFound 创建未找到错误
 func NewNotFound(message string) *AppError {
 	return New(ErrCodeNotFound, message)
 }
 
+// NewConflict 创建资源冲突错误
+func NewConflict(message string) *AppError {
+	return New(ErrCodeConflict, message)
+}
+
 // NewInternalError 创建内部错误
 func NewInternalError(err error) *AppError {
 	return Wrap(err, ErrCodeInternalServerError, "服务器内部错误")
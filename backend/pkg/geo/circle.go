@@ -0,0 +1,45 @@
+package geo
+
+import "math"
+
+// DefaultCircleSegments 圆形区域转换为多边形时的默认边数
+const DefaultCircleSegments = 64
+
+// Destination 计算从 p 出发沿大圆航向 bearing（度）行进 distance（米）后的位置
+func Destination(p LatLng, bearing, distance float64) LatLng {
+	delta := distance / EarthRadius
+	theta := toRadians(bearing)
+	phi1 := toRadians(p.Lat)
+	lambda1 := toRadians(p.Lng)
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(phi1),
+		math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2),
+	)
+	return LatLng{Lat: toDegrees(phi2), Lng: NormalizeLng(toDegrees(lambda2))}
+}
+
+// Buffer 将点按半径（米）缓冲为近似圆的多边形，环方向为逆时针并已闭合
+// segments 小于 3 时使用 DefaultCircleSegments
+func Buffer(center LatLng, radius float64, segments int) *Geometry {
+	if segments < 3 {
+		segments = DefaultCircleSegments
+	}
+	ring := make([]Position, 0, segments+1)
+	for i := 0; i < segments; i++ {
+		// 航向顺时针增加，取负方向得到逆时针的外环
+		p := Destination(center, 360-float64(i)*360/float64(segments), radius)
+		ring = append(ring, NewPosition(p.Lat, p.Lng))
+	}
+	ring = append(ring, ring[0])
+	return NewPolygon([][]Position{ring})
+}
+
+// ToPolygon 将圆形区域转换为多边形，其他几何原样返回
+func (g *Geometry) ToPolygon(segments int) *Geometry {
+	if !g.IsCircle() {
+		return g
+	}
+	return Buffer(g.Point.LatLng(), g.Radius, segments)
+}
@@ -0,0 +1,97 @@
+// Package geo 提供地理空间计算工具
+//
+// 所有距离单位均为米，角度单位均为十进制度（WGS84）。
+package geo
+
+import "math"
+
+// EarthRadius 地球平均半径（米）
+const EarthRadius = 6371008.8
+
+// Haversine 计算两点之间的大圆距离（米）
+func Haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := toRadians(lat1)
+	phi2 := toRadians(lat2)
+	dPhi := toRadians(lat2 - lat1)
+	dLambda := toRadians(lng2 - lng1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BBox 经纬度包围盒
+// MinLng > MaxLng 表示跨越 180° 经线
+type BBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// Valid 判断包围盒坐标是否在合法范围内
+func (b BBox) Valid() bool {
+	return b.MinLat >= -90 && b.MaxLat <= 90 && b.MinLat <= b.MaxLat &&
+		b.MinLng >= -180 && b.MinLng <= 180 && b.MaxLng >= -180 && b.MaxLng <= 180
+}
+
+// CrossesAntimeridian 判断包围盒是否跨越 180° 经线
+func (b BBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// Contains 判断点是否位于包围盒内（含边界）
+func (b BBox) Contains(lat, lng float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return lng >= b.MinLng || lng <= b.MaxLng
+	}
+	return lng >= b.MinLng && lng <= b.MaxLng
+}
+
+// BBoxAround 计算以某点为中心、给定半径（米）的外接包围盒
+// 用作距离查询的预过滤条件，结果需再用 Haversine 精确筛选
+func BBoxAround(lat, lng, radius float64) BBox {
+	dLat := toDegrees(radius / EarthRadius)
+	box := BBox{
+		MinLat: lat - dLat,
+		MaxLat: lat + dLat,
+	}
+
+	// 覆盖极点时经度方向取全范围
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		box.MinLng, box.MaxLng = -180, 180
+		return box
+	}
+
+	dLng := toDegrees(math.Asin(math.Min(1, math.Sin(radius/EarthRadius)/math.Cos(toRadians(lat)))))
+	box.MinLng = NormalizeLng(lng - dLng)
+	box.MaxLng = NormalizeLng(lng + dLng)
+	if dLng >= 180 {
+		box.MinLng, box.MaxLng = -180, 180
+	}
+	return box
+}
+
+// NormalizeLng 将经度规范到 [-180, 180]
+func NormalizeLng(lng float64) float64 {
+	for lng > 180 {
+		lng -= 360
+	}
+	for lng < -180 {
+		lng += 360
+	}
+	return lng
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,114 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaversine(t *testing.T) {
+	// 北京首都机场 -> 上海浦东机场，约 1100 公里
+	d := Haversine(40.0801, 116.5846, 31.1443, 121.8083)
+	assert.InDelta(t, 1100000, d, 5000)
+
+	assert.Equal(t, 0.0, Haversine(10, 20, 10, 20))
+}
+
+func TestBBoxAround(t *testing.T) {
+	box := BBoxAround(40, 116, 100000)
+	assert.True(t, box.Valid())
+	assert.True(t, box.Contains(40, 116))
+	assert.InDelta(t, 0.9, box.MaxLat-40, 0.01)
+
+	// 四个方向上半径处的点都应落在包围盒内
+	assert.True(t, box.Contains(40.89, 116))
+	assert.True(t, box.Contains(40, 117.15))
+	assert.False(t, box.Contains(41, 116))
+}
+
+func TestBBoxAntimeridian(t *testing.T) {
+	box := BBoxAround(0, 179.9, 50000)
+	assert.True(t, box.CrossesAntimeridian())
+	assert.True(t, box.Contains(0, -179.9))
+	assert.True(t, box.Contains(0, 179.8))
+	assert.False(t, box.Contains(0, 0))
+}
+
+func TestDouglasPeucker(t *testing.T) {
+	// 沿赤道的直线上插入一个偏离约 1.1 公里的点
+	points := []LatLng{{0, 0}, {0, 0.1}, {0.01, 0.2}, {0, 0.3}, {0, 0.4}}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, DouglasPeucker(points, 100))
+	assert.Equal(t, []int{0, 2, 4}, DouglasPeucker(points, 600))
+	assert.Equal(t, []int{0, 4}, DouglasPeucker(points, 2000))
+	assert.Equal(t, []int{0, 1}, DouglasPeucker(points[:2], 100))
+}
+
+func TestSimplifyToCount(t *testing.T) {
+	points := make([]LatLng, 1000)
+	for i := range points {
+		points[i] = LatLng{Lat: 30 + float64(i)*0.001, Lng: 120 + 0.01*float64(i%7)}
+	}
+
+	indices := SimplifyToCount(points, 100)
+	assert.LessOrEqual(t, len(indices), 100)
+	assert.Greater(t, len(indices), 50)
+	assert.Equal(t, 0, indices[0])
+	assert.Equal(t, 999, indices[len(indices)-1])
+
+	assert.Len(t, SimplifyToCount(points[:10], 100), 10)
+}
+
+func TestCrossTrackDistance(t *testing.T) {
+	// 沿赤道向东的航线，北侧 0.1 度约 11.1 公里，位于航线左侧
+	a, b := LatLng{Lat: 0, Lng: 0}, LatLng{Lat: 0, Lng: 10}
+	p := LatLng{Lat: 0.1, Lng: 5}
+	assert.InDelta(t, -11120, CrossTrackDistance(p, a, b), 20)
+	assert.InDelta(t, 556000, AlongTrackDistance(p, a, b), 1000)
+	assert.Less(t, AlongTrackDistance(LatLng{Lat: 0, Lng: -1}, a, b), 0.0)
+}
+
+func TestProjectOntoPath(t *testing.T) {
+	path := []LatLng{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}}
+
+	proj := ProjectOntoPath(LatLng{Lat: 0.5, Lng: 1.01}, path)
+	assert.Equal(t, 1, proj.Segment)
+	assert.InDelta(t, 0.5, proj.Fraction, 0.01)
+	assert.InDelta(t, 1112, proj.Distance, 10)
+
+	// 起点之前取到端点的距离
+	proj = ProjectOntoPath(LatLng{Lat: 0, Lng: -0.01}, path)
+	assert.Equal(t, 0, proj.Segment)
+	assert.Less(t, proj.Fraction, 0.0)
+	assert.InDelta(t, 1112, proj.Distance, 10)
+}
+
+func TestPointInPolygon(t *testing.T) {
+	square := [][]LatLng{
+		{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}, {Lat: 10, Lng: 0}},
+		{{Lat: 4, Lng: 4}, {Lat: 4, Lng: 6}, {Lat: 6, Lng: 6}, {Lat: 6, Lng: 4}},
+	}
+	assert.True(t, PointInPolygon(LatLng{Lat: 2, Lng: 2}, square))
+	assert.False(t, PointInPolygon(LatLng{Lat: 5, Lng: 5}, square), "洞内的点")
+	assert.False(t, PointInPolygon(LatLng{Lat: 11, Lng: 5}, square))
+}
+
+func TestSegmentIntersectsPolygon(t *testing.T) {
+	square := [][]LatLng{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1, Lng: 0}, {Lat: 0, Lng: 0}}}
+
+	// 两端都在多边形外但穿越多边形
+	assert.True(t, SegmentIntersectsPolygon(LatLng{Lat: 0.5, Lng: -1}, LatLng{Lat: 0.5, Lng: 2}, square))
+	assert.False(t, SegmentIntersectsPolygon(LatLng{Lat: 2, Lng: -1}, LatLng{Lat: 2, Lng: 2}, square))
+
+	inner := [][]LatLng{{{Lat: 0.4, Lng: 0.4}, {Lat: 0.4, Lng: 0.6}, {Lat: 0.6, Lng: 0.6}}}
+	assert.True(t, PolygonsIntersect(square, inner))
+	assert.True(t, PolygonsIntersect(inner, square))
+}
+
+func TestCircleIntersectsPolygon(t *testing.T) {
+	square := [][]LatLng{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1, Lng: 0}}}
+	center := LatLng{Lat: 0.5, Lng: 1.05} // 距东边约 5.5 公里
+
+	assert.True(t, CircleIntersectsPolygon(center, 6000, square))
+	assert.False(t, CircleIntersectsPolygon(center, 5000, square))
+}
@@ -0,0 +1,263 @@
+package geo
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GeoJSON 对象类型（RFC 7946）
+const (
+	TypePoint             = "Point"
+	TypeMultiPoint        = "MultiPoint"
+	TypeLineString        = "LineString"
+	TypePolygon           = "Polygon"
+	TypeMultiPolygon      = "MultiPolygon"
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+)
+
+// ErrUnsupportedGeometry 不支持的 GeoJSON 几何类型
+var ErrUnsupportedGeometry = errors.New("geojson: 不支持的几何类型")
+
+// Position GeoJSON 坐标，顺序为 [经度, 纬度] 或 [经度, 纬度, 高度(米)]
+type Position []float64
+
+// NewPosition 创建坐标，alt 可选
+func NewPosition(lat, lng float64, alt ...float64) Position {
+	p := Position{lng, lat}
+	if len(alt) > 0 {
+		p = append(p, alt[0])
+	}
+	return p
+}
+
+// Lng 经度
+func (p Position) Lng() float64 {
+	return p[0]
+}
+
+// Lat 纬度
+func (p Position) Lat() float64 {
+	return p[1]
+}
+
+// Altitude 高度（米），未提供时第二个返回值为 false
+func (p Position) Altitude() (float64, bool) {
+	if len(p) < 3 {
+		return 0, false
+	}
+	return p[2], true
+}
+
+// LatLng 转换为经纬度坐标点
+func (p Position) LatLng() LatLng {
+	return LatLng{Lat: p[1], Lng: p[0]}
+}
+
+// Geometry GeoJSON 几何对象，只有与 Type 对应的坐标字段有效
+// 圆形区域以 Point 加 Radius（米）表示，序列化时写入 properties.radius 以兼容既有禁飞区数据
+// 实现 sql.Scanner 和 driver.Valuer，可以直接作为模型字段存储为 JSON 文本
+type Geometry struct {
+	Type         string
+	Point        Position
+	MultiPoint   []Position
+	LineString   []Position
+	Polygon      [][]Position
+	MultiPolygon [][][]Position
+	Radius       float64
+}
+
+// NewPoint 创建 Point
+func NewPoint(p Position) *Geometry {
+	return &Geometry{Type: TypePoint, Point: p}
+}
+
+// NewCircle 创建以 Point 加半径（米）表示的圆形区域
+func NewCircle(center LatLng, radius float64) *Geometry {
+	return &Geometry{Type: TypePoint, Point: NewPosition(center.Lat, center.Lng), Radius: radius}
+}
+
+// NewMultiPoint 创建 MultiPoint
+func NewMultiPoint(points []Position) *Geometry {
+	return &Geometry{Type: TypeMultiPoint, MultiPoint: points}
+}
+
+// NewLineString 创建 LineString
+func NewLineString(points []Position) *Geometry {
+	return &Geometry{Type: TypeLineString, LineString: points}
+}
+
+// NewPolygon 创建 Polygon，rings[0] 为外环
+func NewPolygon(rings [][]Position) *Geometry {
+	return &Geometry{Type: TypePolygon, Polygon: rings}
+}
+
+// NewMultiPolygon 创建 MultiPolygon
+func NewMultiPolygon(polygons [][][]Position) *Geometry {
+	return &Geometry{Type: TypeMultiPolygon, MultiPolygon: polygons}
+}
+
+// IsCircle 是否为带半径的圆形区域
+func (g *Geometry) IsCircle() bool {
+	return g.Type == TypePoint && g.Radius > 0
+}
+
+// IsArea 是否为面状几何（多边形或圆形区域）
+func (g *Geometry) IsArea() bool {
+	return g.Type == TypePolygon || g.Type == TypeMultiPolygon || g.IsCircle()
+}
+
+type geometryJSON struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometry    json.RawMessage `json:"geometry,omitempty"`
+	Properties  *circleJSON     `json:"properties,omitempty"`
+}
+
+type circleJSON struct {
+	Radius float64 `json:"radius,omitempty"`
+}
+
+// MarshalJSON 序列化为 GeoJSON 几何对象，空几何输出 null
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	if g.Type == "" {
+		return []byte("null"), nil
+	}
+
+	var coordinates any
+	switch g.Type {
+	case TypePoint:
+		coordinates = g.Point
+	case TypeMultiPoint:
+		coordinates = g.MultiPoint
+	case TypeLineString:
+		coordinates = g.LineString
+	case TypePolygon:
+		coordinates = g.Polygon
+	case TypeMultiPolygon:
+		coordinates = g.MultiPolygon
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedGeometry, g.Type)
+	}
+
+	raw, err := json.Marshal(coordinates)
+	if err != nil {
+		return nil, err
+	}
+	out := geometryJSON{Type: g.Type, Coordinates: raw}
+	if g.IsCircle() {
+		out.Properties = &circleJSON{Radius: g.Radius}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON 解析 GeoJSON 几何对象，也接受包装几何的 Feature
+// Feature 的 properties.radius 作为圆形区域半径
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var raw geometryJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Type == TypeFeature {
+		if len(raw.Geometry) == 0 || string(raw.Geometry) == "null" {
+			return errors.New("geojson: Feature 缺少 geometry")
+		}
+		if err := g.UnmarshalJSON(raw.Geometry); err != nil {
+			return err
+		}
+		if raw.Properties != nil && raw.Properties.Radius > 0 && g.Type == TypePoint {
+			g.Radius = raw.Properties.Radius
+		}
+		return nil
+	}
+
+	*g = Geometry{Type: raw.Type}
+	var target any
+	switch raw.Type {
+	case TypePoint:
+		target = &g.Point
+	case TypeMultiPoint:
+		target = &g.MultiPoint
+	case TypeLineString:
+		target = &g.LineString
+	case TypePolygon:
+		target = &g.Polygon
+	case TypeMultiPolygon:
+		target = &g.MultiPolygon
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedGeometry, raw.Type)
+	}
+	if err := json.Unmarshal(raw.Coordinates, target); err != nil {
+		return fmt.Errorf("geojson: %s 坐标格式错误: %w", raw.Type, err)
+	}
+	if raw.Properties != nil && g.Type == TypePoint {
+		g.Radius = raw.Properties.Radius
+	}
+	return nil
+}
+
+// Value 实现 driver.Valuer，空几何存储为 NULL
+func (g Geometry) Value() (driver.Value, error) {
+	if g.Type == "" {
+		return nil, nil
+	}
+	data, err := g.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan 实现 sql.Scanner
+// 空字符串或无法解析的内容读取为空几何而不报错，避免单行脏数据导致整个查询失败，由 Validate 报告
+func (g *Geometry) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*g = Geometry{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("geojson: 无法从 %T 读取几何数据", src)
+	}
+	if err := g.UnmarshalJSON(data); err != nil {
+		*g = Geometry{}
+	}
+	return nil
+}
+
+// Feature GeoJSON Feature
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         any            `json:"id,omitempty"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// NewFeature 创建 Feature
+func NewFeature(geometry *Geometry, properties map[string]any) *Feature {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	return &Feature{Type: TypeFeature, Geometry: geometry, Properties: properties}
+}
+
+// FeatureCollection GeoJSON FeatureCollection
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeatureCollection 创建 FeatureCollection
+func NewFeatureCollection(features ...Feature) *FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return &FeatureCollection{Type: TypeFeatureCollection, Features: features}
+}
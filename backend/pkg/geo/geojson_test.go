@@ -0,0 +1,142 @@
+package geo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryJSONRoundTrip(t *testing.T) {
+	cases := []string{
+		`{"type":"Point","coordinates":[116.4,39.9,120]}`,
+		`{"type":"LineString","coordinates":[[116.4,39.9],[116.5,40]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]],[[[2,2],[3,2],[3,3],[2,2]]]]}`,
+		`{"type":"Point","coordinates":[116.4,39.9],"properties":{"radius":5000}}`,
+	}
+	for _, c := range cases {
+		var g Geometry
+		require.NoError(t, json.Unmarshal([]byte(c), &g), c)
+		out, err := json.Marshal(g)
+		require.NoError(t, err)
+		assert.JSONEq(t, c, string(out))
+	}
+}
+
+func TestGeometryUnmarshalFeature(t *testing.T) {
+	var g Geometry
+	data := `{"type":"Feature","geometry":{"type":"Point","coordinates":[116.4,39.9]},"properties":{"radius":3000}}`
+	require.NoError(t, json.Unmarshal([]byte(data), &g))
+	assert.True(t, g.IsCircle())
+	assert.Equal(t, 3000.0, g.Radius)
+	assert.Equal(t, 39.9, g.Point.Lat())
+
+	err := json.Unmarshal([]byte(`{"type":"GeometryCollection","geometries":[]}`), &g)
+	assert.ErrorIs(t, err, ErrUnsupportedGeometry)
+}
+
+func TestGeometryScanValue(t *testing.T) {
+	var empty Geometry
+	v, err := empty.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	line := NewLineString([]Position{NewPosition(39.9, 116.4, 100), NewPosition(40, 116.5)})
+	v, err = line.Value()
+	require.NoError(t, err)
+
+	var scanned Geometry
+	require.NoError(t, scanned.Scan([]byte(v.(string))))
+	assert.Equal(t, *line, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Equal(t, "", scanned.Type)
+
+	// 脏数据读取为空几何，由 Validate 报告
+	for _, bad := range []any{"", []byte("not json"), `{"type":"Polygon","coordinates":"x"}`, `{"type":"GeometryCollection"}`} {
+		scanned = *line
+		require.NoError(t, scanned.Scan(bad))
+		assert.Equal(t, Geometry{}, scanned)
+		assert.ErrorIs(t, scanned.Validate(), ErrEmptyGeometry)
+	}
+	assert.Error(t, scanned.Scan(42))
+}
+
+func TestGeometryValidate(t *testing.T) {
+	ccw := [][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+	assert.NoError(t, NewPolygon(ccw).Validate())
+
+	open := [][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}
+	assert.ErrorIs(t, NewPolygon(open).Validate(), ErrRingNotClosed)
+
+	short := [][]Position{{{0, 0}, {1, 0}, {0, 0}}}
+	assert.ErrorIs(t, NewPolygon(short).Validate(), ErrRingTooShort)
+
+	assert.ErrorIs(t, NewLineString([]Position{{0, 0}}).Validate(), ErrLineTooShort)
+	assert.ErrorIs(t, NewPoint(Position{200, 0}).Validate(), ErrInvalidPosition)
+
+	// 顺时针外环，Rewind 后通过校验
+	cw := NewPolygon([][]Position{{{0, 0}, {0, 1}, {1, 1}, {1, 0}, {0, 0}}})
+	assert.ErrorIs(t, cw.Validate(), ErrWindingOrder)
+	cw.Rewind()
+	assert.NoError(t, cw.Validate())
+	assert.Greater(t, RingArea(cw.Polygon[0]), 0.0)
+}
+
+func TestGeometryContainsAndIntersects(t *testing.T) {
+	square := NewPolygon([][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}})
+	assert.True(t, square.ContainsPoint(LatLng{Lat: 0.5, Lng: 0.5}))
+	assert.False(t, square.ContainsPoint(LatLng{Lat: 1.5, Lng: 0.5}))
+	assert.True(t, square.IntersectsSegment(LatLng{Lat: 0.5, Lng: -1}, LatLng{Lat: 0.5, Lng: 2}))
+
+	circle := NewCircle(LatLng{Lat: 0.5, Lng: 1.05}, 6000)
+	assert.True(t, circle.ContainsPoint(LatLng{Lat: 0.5, Lng: 1.05}))
+	assert.True(t, circle.Intersects(square))
+	assert.True(t, square.Intersects(circle))
+	assert.False(t, NewCircle(LatLng{Lat: 0.5, Lng: 1.05}, 5000).Intersects(square))
+
+	far := NewCircle(LatLng{Lat: 0.5, Lng: 1.2}, 12000) // 圆心相距约 16.7 公里
+	assert.True(t, far.Intersects(circle))
+}
+
+func TestGeometryBoundaryDistance(t *testing.T) {
+	square := NewPolygon([][]Position{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}})
+	// 距东边 0.1 度，约 11.1 公里
+	assert.InDelta(t, 11119, square.BoundaryDistance(LatLng{Lat: 0.5, Lng: 0.9}), 20)
+	assert.InDelta(t, 11119, square.BoundaryDistance(LatLng{Lat: 0.5, Lng: 1.1}), 20)
+	assert.Equal(t, 0.0, square.SegmentBoundaryDistance(LatLng{Lat: 0.5, Lng: 0.5}, LatLng{Lat: 0.5, Lng: 2}))
+	assert.InDelta(t, 11119, square.SegmentBoundaryDistance(LatLng{Lat: 0.4, Lng: 1.1}, LatLng{Lat: 0.6, Lng: 1.2}), 20)
+
+	circle := NewCircle(LatLng{Lat: 0, Lng: 0}, 5000)
+	assert.InDelta(t, 5000, circle.BoundaryDistance(LatLng{Lat: 0, Lng: 0}), 1)
+	assert.Equal(t, 0.0, circle.SegmentBoundaryDistance(LatLng{Lat: 0, Lng: 0}, LatLng{Lat: 0, Lng: 1}))
+	// 线段完全位于圆内时为到边界的最小距离
+	assert.InDelta(t, 5000-1112, circle.SegmentBoundaryDistance(LatLng{Lat: 0, Lng: 0}, LatLng{Lat: 0, Lng: 0.01}), 2)
+}
+
+func TestGeometryBBox(t *testing.T) {
+	line := NewLineString([]Position{{116, 39}, {117, 41}, {115.5, 40}})
+	box := line.BBox()
+	assert.Equal(t, BBox{MinLat: 39, MinLng: 115.5, MaxLat: 41, MaxLng: 117}, box)
+
+	circle := NewCircle(LatLng{Lat: 40, Lng: 116}, 100000)
+	assert.Equal(t, BBoxAround(40, 116, 100000), circle.BBox())
+}
+
+func TestBuffer(t *testing.T) {
+	center := LatLng{Lat: 39.9, Lng: 116.4}
+	polygon := Buffer(center, 5000, 32)
+	require.NoError(t, polygon.Validate())
+	assert.Len(t, polygon.Polygon[0], 33)
+
+	for _, p := range polygon.Polygon[0] {
+		assert.InDelta(t, 5000, Haversine(center.Lat, center.Lng, p.Lat(), p.Lng()), 1)
+	}
+	assert.True(t, polygon.ContainsPoint(center))
+
+	dest := Destination(LatLng{Lat: 0, Lng: 0}, 90, 111195)
+	assert.InDelta(t, 1, dest.Lng, 0.001)
+	assert.InDelta(t, 0, dest.Lat, 0.001)
+}
@@ -0,0 +1,344 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// 几何校验错误
+var (
+	ErrEmptyGeometry   = errors.New("geojson: 几何数据为空")
+	ErrInvalidPosition = errors.New("geojson: 无效的坐标")
+	ErrLineTooShort    = errors.New("geojson: LineString 至少需要 2 个坐标")
+	ErrRingTooShort    = errors.New("geojson: 多边形的环至少需要 4 个坐标")
+	ErrRingNotClosed   = errors.New("geojson: 多边形的环未闭合")
+	ErrWindingOrder    = errors.New("geojson: 环的方向不符合右手规则")
+	ErrInvalidRadius   = errors.New("geojson: 圆形区域半径必须大于 0")
+)
+
+// Validate 校验几何结构：坐标范围、LineString 点数、环的点数与闭合，
+// 以及 RFC 7946 右手规则（外环逆时针、内环顺时针）
+// 仅方向不符时返回 ErrWindingOrder，可调用 Rewind 修正
+func (g *Geometry) Validate() error {
+	switch g.Type {
+	case "":
+		return ErrEmptyGeometry
+	case TypePoint:
+		if g.Radius < 0 || math.IsNaN(g.Radius) {
+			return ErrInvalidRadius
+		}
+		return validatePosition(g.Point)
+	case TypeMultiPoint:
+		if len(g.MultiPoint) == 0 {
+			return ErrEmptyGeometry
+		}
+		return validatePositions(g.MultiPoint)
+	case TypeLineString:
+		if len(g.LineString) < 2 {
+			return ErrLineTooShort
+		}
+		return validatePositions(g.LineString)
+	case TypePolygon:
+		return validatePolygon(g.Polygon)
+	case TypeMultiPolygon:
+		if len(g.MultiPolygon) == 0 {
+			return ErrEmptyGeometry
+		}
+		for i, polygon := range g.MultiPolygon {
+			if err := validatePolygon(polygon); err != nil {
+				return fmt.Errorf("第 %d 个多边形: %w", i+1, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedGeometry, g.Type)
+	}
+}
+
+// Rewind 按右手规则调整多边形各环的方向（外环逆时针、内环顺时针）
+func (g *Geometry) Rewind() {
+	switch g.Type {
+	case TypePolygon:
+		rewindPolygon(g.Polygon)
+	case TypeMultiPolygon:
+		for _, polygon := range g.MultiPolygon {
+			rewindPolygon(polygon)
+		}
+	}
+}
+
+// RingArea 计算环在经纬度平面上的有向面积（鞋带公式），逆时针为正
+func RingArea(ring []Position) float64 {
+	area := 0.0
+	n := len(ring)
+	for i := 0; i < n; i++ {
+		a, b := ring[i], ring[(i+1)%n]
+		area += a.Lng()*b.Lat() - b.Lng()*a.Lat()
+	}
+	return area / 2
+}
+
+// Polygons 返回多边形列表，每个多边形的 rings[0] 为外环
+// 非 Polygon/MultiPolygon 几何返回 nil
+func (g *Geometry) Polygons() [][][]LatLng {
+	switch g.Type {
+	case TypePolygon:
+		return [][][]LatLng{toLatLngRings(g.Polygon)}
+	case TypeMultiPolygon:
+		polygons := make([][][]LatLng, len(g.MultiPolygon))
+		for i, polygon := range g.MultiPolygon {
+			polygons[i] = toLatLngRings(polygon)
+		}
+		return polygons
+	default:
+		return nil
+	}
+}
+
+// Positions 返回几何中的全部坐标
+func (g *Geometry) Positions() []Position {
+	switch g.Type {
+	case TypePoint:
+		if g.Point == nil {
+			return nil
+		}
+		return []Position{g.Point}
+	case TypeMultiPoint:
+		return g.MultiPoint
+	case TypeLineString:
+		return g.LineString
+	case TypePolygon:
+		return flattenRings(g.Polygon)
+	case TypeMultiPolygon:
+		var positions []Position
+		for _, polygon := range g.MultiPolygon {
+			positions = append(positions, flattenRings(polygon)...)
+		}
+		return positions
+	default:
+		return nil
+	}
+}
+
+// Path 返回 LineString 或 MultiPoint 的坐标序列
+func (g *Geometry) Path() []LatLng {
+	var positions []Position
+	switch g.Type {
+	case TypeLineString:
+		positions = g.LineString
+	case TypeMultiPoint:
+		positions = g.MultiPoint
+	default:
+		return nil
+	}
+	return toLatLngs(positions)
+}
+
+// ContainsPoint 判断点是否位于面状几何内，圆形区域按大圆距离判断
+func (g *Geometry) ContainsPoint(p LatLng) bool {
+	if g.IsCircle() {
+		return Haversine(p.Lat, p.Lng, g.Point.Lat(), g.Point.Lng()) <= g.Radius
+	}
+	for _, polygon := range g.Polygons() {
+		if PointInPolygon(p, polygon) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsSegment 判断线段是否与面状几何相交或位于其内
+func (g *Geometry) IntersectsSegment(a, b LatLng) bool {
+	if g.IsCircle() {
+		return PointSegmentDistance(g.Point.LatLng(), a, b) <= g.Radius
+	}
+	for _, polygon := range g.Polygons() {
+		if SegmentIntersectsPolygon(a, b, polygon) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsPolygon 判断多边形是否与面状几何重叠
+func (g *Geometry) IntersectsPolygon(rings [][]LatLng) bool {
+	if g.IsCircle() {
+		return CircleIntersectsPolygon(g.Point.LatLng(), g.Radius, rings)
+	}
+	for _, polygon := range g.Polygons() {
+		if PolygonsIntersect(rings, polygon) {
+			return true
+		}
+	}
+	return false
+}
+
+// Intersects 判断两个面状几何是否重叠
+func (g *Geometry) Intersects(other *Geometry) bool {
+	if g.IsCircle() && other.IsCircle() {
+		d := Haversine(g.Point.Lat(), g.Point.Lng(), other.Point.Lat(), other.Point.Lng())
+		return d <= g.Radius+other.Radius
+	}
+	if g.IsCircle() {
+		return other.Intersects(g)
+	}
+	for _, polygon := range g.Polygons() {
+		if other.IntersectsPolygon(polygon) {
+			return true
+		}
+	}
+	return false
+}
+
+// BoundaryDistance 计算点到面状几何边界的最短距离（米），不区分点在内部还是外部
+func (g *Geometry) BoundaryDistance(p LatLng) float64 {
+	if g.IsCircle() {
+		return math.Abs(Haversine(p.Lat, p.Lng, g.Point.Lat(), g.Point.Lng()) - g.Radius)
+	}
+	best := math.Inf(1)
+	for _, polygon := range g.Polygons() {
+		for _, ring := range polygon {
+			n := len(ring)
+			for i := 0; i < n; i++ {
+				best = math.Min(best, PointSegmentDistance(p, ring[i], ring[(i+1)%n]))
+			}
+		}
+	}
+	return best
+}
+
+// SegmentBoundaryDistance 计算线段到面状几何边界的最短距离（米），穿越边界时为 0
+func (g *Geometry) SegmentBoundaryDistance(a, b LatLng) float64 {
+	if g.IsCircle() {
+		center := g.Point.LatLng()
+		near := PointSegmentDistance(center, a, b)
+		far := math.Max(Haversine(center.Lat, center.Lng, a.Lat, a.Lng), Haversine(center.Lat, center.Lng, b.Lat, b.Lng))
+		switch {
+		case near > g.Radius:
+			return near - g.Radius
+		case far < g.Radius:
+			return g.Radius - far
+		default:
+			return 0
+		}
+	}
+	best := math.Inf(1)
+	for _, polygon := range g.Polygons() {
+		for _, ring := range polygon {
+			n := len(ring)
+			for i := 0; i < n; i++ {
+				best = math.Min(best, SegmentsDistance(a, b, ring[i], ring[(i+1)%n]))
+			}
+		}
+	}
+	return best
+}
+
+// BBox 计算几何的包围盒，圆形区域取外接包围盒
+// 坐标经度跨越 180° 经线时结果不准确
+func (g *Geometry) BBox() BBox {
+	if g.IsCircle() {
+		return BBoxAround(g.Point.Lat(), g.Point.Lng(), g.Radius)
+	}
+	positions := g.Positions()
+	if len(positions) == 0 {
+		return BBox{}
+	}
+	box := BBox{
+		MinLat: math.Inf(1), MinLng: math.Inf(1),
+		MaxLat: math.Inf(-1), MaxLng: math.Inf(-1),
+	}
+	for _, p := range positions {
+		box.MinLat = math.Min(box.MinLat, p.Lat())
+		box.MaxLat = math.Max(box.MaxLat, p.Lat())
+		box.MinLng = math.Min(box.MinLng, p.Lng())
+		box.MaxLng = math.Max(box.MaxLng, p.Lng())
+	}
+	return box
+}
+
+func validatePosition(p Position) error {
+	if len(p) < 2 || len(p) > 3 {
+		return ErrInvalidPosition
+	}
+	for _, v := range p {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ErrInvalidPosition
+		}
+	}
+	if p.Lng() < -180 || p.Lng() > 180 || p.Lat() < -90 || p.Lat() > 90 {
+		return fmt.Errorf("%w: [%g, %g] 超出经纬度范围", ErrInvalidPosition, p.Lng(), p.Lat())
+	}
+	return nil
+}
+
+func validatePositions(positions []Position) error {
+	for _, p := range positions {
+		if err := validatePosition(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePolygon 校验多边形，结构错误优先于方向错误返回
+func validatePolygon(rings [][]Position) error {
+	if len(rings) == 0 {
+		return ErrEmptyGeometry
+	}
+	for _, ring := range rings {
+		if len(ring) < 4 {
+			return ErrRingTooShort
+		}
+		if err := validatePositions(ring); err != nil {
+			return err
+		}
+		first, last := ring[0], ring[len(ring)-1]
+		if first.Lng() != last.Lng() || first.Lat() != last.Lat() {
+			return ErrRingNotClosed
+		}
+	}
+	for i, ring := range rings {
+		area := RingArea(ring)
+		if (i == 0 && area < 0) || (i > 0 && area > 0) {
+			return ErrWindingOrder
+		}
+	}
+	return nil
+}
+
+func rewindPolygon(rings [][]Position) {
+	for i, ring := range rings {
+		area := RingArea(ring)
+		if (i == 0 && area < 0) || (i > 0 && area > 0) {
+			for l, r := 0, len(ring)-1; l < r; l, r = l+1, r-1 {
+				ring[l], ring[r] = ring[r], ring[l]
+			}
+		}
+	}
+}
+
+func toLatLngs(positions []Position) []LatLng {
+	points := make([]LatLng, len(positions))
+	for i, p := range positions {
+		points[i] = p.LatLng()
+	}
+	return points
+}
+
+func toLatLngRings(rings [][]Position) [][]LatLng {
+	out := make([][]LatLng, len(rings))
+	for i, ring := range rings {
+		out[i] = toLatLngs(ring)
+	}
+	return out
+}
+
+func flattenRings(rings [][]Position) []Position {
+	var positions []Position
+	for _, ring := range rings {
+		positions = append(positions, ring...)
+	}
+	return positions
+}
@@ -0,0 +1,123 @@
+package geo
+
+// 平面几何判定在经纬度平面上进行，适用于不跨越 180° 经线的区域
+
+// PointInRing 判断点是否位于闭合环内（射线法，边界上的点视为不在环内）
+// ring 首尾点可以相同也可以不同
+func PointInRing(p LatLng, ring []LatLng) bool {
+	inside := false
+	n := len(ring)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// PointInPolygon 判断点是否位于多边形内
+// rings[0] 为外环，其余为内环（洞），位于洞内的点不在多边形内
+func PointInPolygon(p LatLng, rings [][]LatLng) bool {
+	if len(rings) == 0 || !PointInRing(p, rings[0]) {
+		return false
+	}
+	for _, hole := range rings[1:] {
+		if PointInRing(p, hole) {
+			return false
+		}
+	}
+	return true
+}
+
+// SegmentsIntersect 判断线段 p1p2 与 q1q2 是否相交（含端点接触和共线重叠）
+func SegmentsIntersect(p1, p2, q1, q2 LatLng) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+// SegmentIntersectsPolygon 判断线段是否与多边形相交或位于多边形内
+func SegmentIntersectsPolygon(a, b LatLng, rings [][]LatLng) bool {
+	if PointInPolygon(a, rings) || PointInPolygon(b, rings) {
+		return true
+	}
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			if SegmentsIntersect(a, b, ring[i], ring[(i+1)%n]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolygonsIntersect 判断两个多边形是否相交（含包含关系）
+func PolygonsIntersect(a, b [][]LatLng) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	outer := a[0]
+	n := len(outer)
+	for i := 0; i < n; i++ {
+		if SegmentIntersectsPolygon(outer[i], outer[(i+1)%n], b) {
+			return true
+		}
+	}
+	// a 的外环完全位于 b 的外环之外时，b 仍可能被 a 完全包含
+	return len(b[0]) > 0 && PointInPolygon(b[0][0], a)
+}
+
+// PointSegmentDistance 计算点到线段的最短距离（米）
+func PointSegmentDistance(p, a, b LatLng) float64 {
+	return segmentDistance(p, a, b)
+}
+
+// SegmentsDistance 计算线段 p1p2 与 q1q2 之间的最短距离（米），相交时为 0
+func SegmentsDistance(p1, p2, q1, q2 LatLng) float64 {
+	if SegmentsIntersect(p1, p2, q1, q2) {
+		return 0
+	}
+	return min(
+		segmentDistance(p1, q1, q2), segmentDistance(p2, q1, q2),
+		segmentDistance(q1, p1, p2), segmentDistance(q2, p1, p2),
+	)
+}
+
+// CircleIntersectsPolygon 判断圆（圆心与半径，米）是否与多边形相交
+func CircleIntersectsPolygon(center LatLng, radius float64, rings [][]LatLng) bool {
+	if PointInPolygon(center, rings) {
+		return true
+	}
+	for _, ring := range rings {
+		n := len(ring)
+		for i := 0; i < n; i++ {
+			if segmentDistance(center, ring[i], ring[(i+1)%n]) <= radius {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orientation 返回 c 相对有向线段 ab 的方位：大于 0 在左侧，小于 0 在右侧，0 为共线
+func orientation(a, b, c LatLng) float64 {
+	return (b.Lng-a.Lng)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lng-a.Lng)
+}
+
+// onSegment 判断与 ab 共线的点 c 是否落在线段 ab 的范围内
+func onSegment(a, b, c LatLng) bool {
+	return c.Lng >= min(a.Lng, b.Lng) && c.Lng <= max(a.Lng, b.Lng) &&
+		c.Lat >= min(a.Lat, b.Lat) && c.Lat <= max(a.Lat, b.Lat)
+}
@@ -0,0 +1,84 @@
+package geo
+
+import "math"
+
+// PathProjection 点在折线上的投影结果
+type PathProjection struct {
+	Distance float64 // 点到折线的最短距离（米）
+	Segment  int     // 最近线段的起点下标
+	Fraction float64 // 沿航迹位置占最近线段长度的比例，小于 0 表示在起点之前，大于 1 表示已越过终点
+}
+
+// InitialBearing 计算从 a 到 b 的大圆初始航向（度，0-360）
+func InitialBearing(a, b LatLng) float64 {
+	lat1, lat2 := toRadians(a.Lat), toRadians(b.Lat)
+	dLng := toRadians(b.Lng - a.Lng)
+
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(toDegrees(math.Atan2(y, x))+360, 360)
+}
+
+// CrossTrackDistance 计算点 p 偏离大圆航线 a→b 的侧向距离（米）
+// 航线右侧为正，左侧为负
+func CrossTrackDistance(p, a, b LatLng) float64 {
+	d13 := Haversine(a.Lat, a.Lng, p.Lat, p.Lng) / EarthRadius
+	theta13 := toRadians(InitialBearing(a, p))
+	theta12 := toRadians(InitialBearing(a, b))
+	return math.Asin(math.Sin(d13)*math.Sin(theta13-theta12)) * EarthRadius
+}
+
+// AlongTrackDistance 计算点 p 在大圆航线 a→b 上的投影距起点 a 的距离（米）
+// 投影在 a 之前时为负
+func AlongTrackDistance(p, a, b LatLng) float64 {
+	d13 := Haversine(a.Lat, a.Lng, p.Lat, p.Lng) / EarthRadius
+	theta13 := toRadians(InitialBearing(a, p))
+	theta12 := toRadians(InitialBearing(a, b))
+	dxt := math.Asin(math.Sin(d13) * math.Sin(theta13-theta12))
+
+	ratio := math.Cos(d13) / math.Cos(dxt)
+	ratio = math.Max(-1, math.Min(1, ratio))
+	distance := math.Acos(ratio) * EarthRadius
+	if math.Cos(theta13-theta12) < 0 {
+		return -distance
+	}
+	return distance
+}
+
+// ProjectOntoPath 计算点到折线（按大圆分段）的最短距离及所在线段
+// 投影落在线段外时取到端点的距离；path 为空时返回零值
+func ProjectOntoPath(p LatLng, path []LatLng) PathProjection {
+	switch len(path) {
+	case 0:
+		return PathProjection{}
+	case 1:
+		return PathProjection{Distance: Haversine(p.Lat, p.Lng, path[0].Lat, path[0].Lng)}
+	}
+
+	best := PathProjection{Distance: math.Inf(1)}
+	for i := 0; i < len(path)-1; i++ {
+		a, b := path[i], path[i+1]
+		length := Haversine(a.Lat, a.Lng, b.Lat, b.Lng)
+
+		var distance, fraction float64
+		if length == 0 {
+			distance = Haversine(p.Lat, p.Lng, a.Lat, a.Lng)
+		} else {
+			along := AlongTrackDistance(p, a, b)
+			fraction = along / length
+			switch {
+			case along < 0:
+				distance = Haversine(p.Lat, p.Lng, a.Lat, a.Lng)
+			case along > length:
+				distance = Haversine(p.Lat, p.Lng, b.Lat, b.Lng)
+			default:
+				distance = math.Abs(CrossTrackDistance(p, a, b))
+			}
+		}
+
+		if distance < best.Distance {
+			best = PathProjection{Distance: distance, Segment: i, Fraction: fraction}
+		}
+	}
+	return best
+}
@@ -0,0 +1,110 @@
+package geo
+
+import "math"
+
+// LatLng 经纬度坐标点
+type LatLng struct {
+	Lat float64
+	Lng float64
+}
+
+// maxSimplifyIterations 按目标点数简化时二分查找容差的最大次数
+const maxSimplifyIterations = 40
+
+// DouglasPeucker 使用 Douglas-Peucker 算法简化折线
+// tolerance 为允许的最大偏离距离（米），返回保留点的下标（升序，始终包含首尾点）
+func DouglasPeucker(points []LatLng, tolerance float64) []int {
+	n := len(points)
+	if n <= 2 {
+		return sequence(n)
+	}
+
+	keep := make([]bool, n)
+	keep[0], keep[n-1] = true, true
+
+	// 使用显式栈避免长航迹递归过深
+	stack := [][2]int{{0, n - 1}}
+	for len(stack) > 0 {
+		seg := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		first, last := seg[0], seg[1]
+		index, maxDist := -1, 0.0
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(points[i], points[first], points[last]); d > maxDist {
+				index, maxDist = i, d
+			}
+		}
+		if index >= 0 && maxDist > tolerance {
+			keep[index] = true
+			stack = append(stack, [2]int{first, index}, [2]int{index, last})
+		}
+	}
+
+	indices := make([]int, 0, n)
+	for i, k := range keep {
+		if k {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SimplifyToCount 使用 Douglas-Peucker 将折线简化到不超过 maxPoints 个点
+// 通过二分查找选取最小的满足点数要求的容差，maxPoints 小于 2 时按 2 处理
+func SimplifyToCount(points []LatLng, maxPoints int) []int {
+	if maxPoints < 2 {
+		maxPoints = 2
+	}
+	if len(points) <= maxPoints {
+		return sequence(len(points))
+	}
+
+	low, high := 0.0, 1.0
+	best := DouglasPeucker(points, high)
+	for len(best) > maxPoints {
+		low, high = high, high*4
+		best = DouglasPeucker(points, high)
+		if high > math.Pi*EarthRadius {
+			break
+		}
+	}
+
+	for i := 0; i < maxSimplifyIterations && high-low > 1; i++ {
+		mid := (low + high) / 2
+		if indices := DouglasPeucker(points, mid); len(indices) <= maxPoints {
+			best, high = indices, mid
+		} else {
+			low = mid
+		}
+	}
+	return best
+}
+
+// segmentDistance 计算点到线段的距离（米）
+// 以线段起点为原点做等距圆柱投影，适用于航迹点间距较小的场景
+func segmentDistance(p, a, b LatLng) float64 {
+	cosLat := math.Cos(toRadians(a.Lat))
+	project := func(q LatLng) (float64, float64) {
+		return toRadians(NormalizeLng(q.Lng-a.Lng)) * cosLat * EarthRadius, toRadians(q.Lat-a.Lat) * EarthRadius
+	}
+
+	px, py := project(p)
+	bx, by := project(b)
+
+	lengthSq := bx*bx + by*by
+	if lengthSq == 0 {
+		return math.Hypot(px, py)
+	}
+
+	t := math.Max(0, math.Min(1, (px*bx+py*by)/lengthSq))
+	return math.Hypot(px-t*bx, py-t*by)
+}
+
+func sequence(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
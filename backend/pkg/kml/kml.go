@@ -0,0 +1,148 @@
+// Package kml 生成 KML 2.2 文档，用于在 Google Earth 等工具中查看航迹和空域
+package kml
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ContentType KML 文档的 MIME 类型
+const ContentType = "application/vnd.google-earth.kml+xml"
+
+const namespace = "http://www.opengis.net/kml/2.2"
+
+// 高度模式
+const (
+	AltitudeClampToGround    = "clampToGround"
+	AltitudeRelativeToGround = "relativeToGround"
+	AltitudeAbsolute         = "absolute"
+)
+
+// Coordinate KML 坐标，高度单位为米
+type Coordinate struct {
+	Lng float64
+	Lat float64
+	Alt float64
+}
+
+// Coordinates 坐标序列，序列化为 "lng,lat,alt lng,lat,alt ..."
+type Coordinates []Coordinate
+
+// MarshalXML 实现 xml.Marshaler
+func (c Coordinates) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	parts := make([]string, len(c))
+	for i, p := range c {
+		parts[i] = formatFloat(p.Lng) + "," + formatFloat(p.Lat) + "," + formatFloat(p.Alt)
+	}
+	return e.EncodeElement(strings.Join(parts, " "), start)
+}
+
+// Document KML 文档
+type Document struct {
+	Name        string      `xml:"name,omitempty"`
+	Description string      `xml:"description,omitempty"`
+	Styles      []Style     `xml:"Style,omitempty"`
+	Placemarks  []Placemark `xml:"Placemark"`
+}
+
+// Style 样式，颜色格式为 aabbggrr
+type Style struct {
+	ID        string     `xml:"id,attr"`
+	LineStyle *LineStyle `xml:"LineStyle,omitempty"`
+	PolyStyle *PolyStyle `xml:"PolyStyle,omitempty"`
+}
+
+// LineStyle 线样式
+type LineStyle struct {
+	Color string  `xml:"color,omitempty"`
+	Width float64 `xml:"width,omitempty"`
+}
+
+// PolyStyle 面样式
+type PolyStyle struct {
+	Color string `xml:"color,omitempty"`
+}
+
+// Placemark 地标
+type Placemark struct {
+	Name          string         `xml:"name,omitempty"`
+	Description   string         `xml:"description,omitempty"`
+	StyleURL      string         `xml:"styleUrl,omitempty"`
+	ExtendedData  *ExtendedData  `xml:"ExtendedData,omitempty"`
+	Point         *Point         `xml:"Point,omitempty"`
+	LineString    *LineString    `xml:"LineString,omitempty"`
+	Polygon       *Polygon       `xml:"Polygon,omitempty"`
+	MultiGeometry *MultiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+// ExtendedData 扩展属性
+type ExtendedData struct {
+	Data []Data `xml:"Data"`
+}
+
+// Data 单个扩展属性
+type Data struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// Point 点
+type Point struct {
+	AltitudeMode string      `xml:"altitudeMode,omitempty"`
+	Coordinates  Coordinates `xml:"coordinates"`
+}
+
+// LineString 折线
+type LineString struct {
+	Tessellate   int         `xml:"tessellate,omitempty"`
+	AltitudeMode string      `xml:"altitudeMode,omitempty"`
+	Coordinates  Coordinates `xml:"coordinates"`
+}
+
+// Polygon 多边形，Extrude 为 1 时从地面拉伸到坐标高度形成立体空域
+type Polygon struct {
+	Extrude         int        `xml:"extrude,omitempty"`
+	AltitudeMode    string     `xml:"altitudeMode,omitempty"`
+	OuterBoundaryIs Boundary   `xml:"outerBoundaryIs"`
+	InnerBoundaryIs []Boundary `xml:"innerBoundaryIs,omitempty"`
+}
+
+// Boundary 多边形边界
+type Boundary struct {
+	LinearRing LinearRing `xml:"LinearRing"`
+}
+
+// LinearRing 闭合环，首尾坐标相同
+type LinearRing struct {
+	Coordinates Coordinates `xml:"coordinates"`
+}
+
+// MultiGeometry 多个多边形组成的复合几何
+type MultiGeometry struct {
+	Polygons []Polygon `xml:"Polygon"`
+}
+
+// Encode 将文档写为完整的 KML
+func Encode(w io.Writer, doc *Document) error {
+	root := struct {
+		XMLName  xml.Name  `xml:"kml"`
+		XMLNS    string    `xml:"xmlns,attr"`
+		Document *Document `xml:"Document"`
+	}{XMLNS: namespace, Document: doc}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,53 @@
+package kml
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	doc := &Document{
+		Name: "MU5101",
+		Placemarks: []Placemark{{
+			Name: "track",
+			LineString: &LineString{
+				Tessellate:  1,
+				Coordinates: Coordinates{{Lng: 121.4, Lat: 31.2, Alt: 300}, {Lng: 116.6, Lat: 40.1}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, doc))
+	out := buf.String()
+	assert.Contains(t, out, `<kml xmlns="http://www.opengis.net/kml/2.2">`)
+	assert.Contains(t, out, "<coordinates>121.4,31.2,300 116.6,40.1,0</coordinates>")
+	assert.Contains(t, out, "<name>MU5101</name>")
+}
+
+func TestEncodePolygon(t *testing.T) {
+	ring := Coordinates{{Lng: 0, Lat: 0, Alt: 120}, {Lng: 1, Lat: 0, Alt: 120}, {Lng: 1, Lat: 1, Alt: 120}, {Lng: 0, Lat: 0, Alt: 120}}
+	doc := &Document{
+		Styles: []Style{{ID: "zone", PolyStyle: &PolyStyle{Color: "7f0000ff"}}},
+		Placemarks: []Placemark{{
+			Name:     "zone",
+			StyleURL: "#zone",
+			Polygon: &Polygon{
+				Extrude:         1,
+				AltitudeMode:    AltitudeRelativeToGround,
+				OuterBoundaryIs: Boundary{LinearRing: LinearRing{Coordinates: ring}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, doc))
+	out := buf.String()
+	assert.Contains(t, out, "<extrude>1</extrude>")
+	assert.Contains(t, out, "<outerBoundaryIs>")
+	assert.Contains(t, out, "<coordinates>0,0,120 1,0,120 1,1,120 0,0,120</coordinates>")
+	assert.NotContains(t, out, "innerBoundaryIs")
+}
@@ -0,0 +1,14 @@
+package mavlink
+
+// crcInit X.25（CRC-16/MCRF4XX）校验初始值
+const crcInit uint16 = 0xFFFF
+
+// crcAccumulate 将 data 累加到 X.25 校验值
+func crcAccumulate(crc uint16, data []byte) uint16 {
+	for _, b := range data {
+		tmp := b ^ uint8(crc)
+		tmp ^= tmp << 4
+		crc = crc>>8 ^ uint16(tmp)<<8 ^ uint16(tmp)<<3 ^ uint16(tmp)>>4
+	}
+	return crc
+}
@@ -0,0 +1,211 @@
+// Package mavlink 解码 MAVLink v1/v2 遥测帧
+//
+// 只解析无人机位置上报需要的常用消息：HEARTBEAT、SYS_STATUS、GPS_RAW_INT、
+// GLOBAL_POSITION_INT 和 VFR_HUD。帧格式：
+//
+//	v1: 0xFE len seq sysid compid msgid payload crc(2)
+//	v2: 0xFD len incompat compat seq sysid compid msgid(3) payload crc(2) [signature(13)]
+//
+// 本包提供从字节流中重新同步并解析帧的 Decoder、按系统 ID 聚合飞行状态的 Tracker
+// 以及接收 UDP 遥测的 Listener。
+package mavlink
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// MagicV1 MAVLink v1 帧起始字节
+	MagicV1 = 0xFE
+	// MagicV2 MAVLink v2 帧起始字节
+	MagicV2 = 0xFD
+
+	headerLenV1  = 6
+	headerLenV2  = 10
+	checksumLen  = 2
+	signatureLen = 13
+	flagSigned   = 0x01
+)
+
+var (
+	// ErrIncomplete 数据不足一个完整帧
+	ErrIncomplete = errors.New("mavlink: 帧数据不完整")
+	// ErrMalformed 帧格式错误
+	ErrMalformed = errors.New("mavlink: 帧格式错误")
+	// ErrChecksum 校验和不匹配
+	ErrChecksum = errors.New("mavlink: 校验和错误")
+	// ErrUnsupported 不支持的消息类型
+	ErrUnsupported = errors.New("mavlink: 不支持的消息类型")
+)
+
+// Frame 一个校验通过的 MAVLink 帧
+// v2 帧的载荷可能被截去末尾的零字节，解码消息时按消息长度补齐
+type Frame struct {
+	Version     int // 1 或 2
+	Sequence    uint8
+	SystemID    uint8
+	ComponentID uint8
+	MessageID   uint32
+	Payload     []byte
+	Signed      bool // v2 帧带签名（签名不做校验）
+}
+
+// Message 解码后的消息
+func (f *Frame) Message() (Message, error) {
+	spec, ok := messageSpecs[f.MessageID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupported, f.MessageID)
+	}
+	payload := f.Payload
+	if len(payload) < spec.length {
+		payload = make([]byte, spec.length)
+		copy(payload, f.Payload)
+	}
+	return spec.decode(payload), nil
+}
+
+// ParseFrame 从 data 开头解析一个帧，返回帧及其占用的字节数
+// 数据不足时返回 ErrIncomplete；不支持的消息缺少 CRC_EXTRA 无法校验，返回 ErrUnsupported
+func ParseFrame(data []byte) (*Frame, int, error) {
+	if len(data) == 0 {
+		return nil, 0, ErrIncomplete
+	}
+
+	var (
+		frame     Frame
+		headerLen int
+		size      int
+	)
+	switch data[0] {
+	case MagicV1:
+		headerLen = headerLenV1
+		if len(data) < headerLen {
+			return nil, 0, ErrIncomplete
+		}
+		frame.Version = 1
+		frame.Sequence, frame.SystemID, frame.ComponentID = data[2], data[3], data[4]
+		frame.MessageID = uint32(data[5])
+		size = headerLen + int(data[1]) + checksumLen
+	case MagicV2:
+		headerLen = headerLenV2
+		if len(data) < headerLen {
+			return nil, 0, ErrIncomplete
+		}
+		incompat := data[2]
+		if incompat&^flagSigned != 0 {
+			return nil, 0, fmt.Errorf("%w: 未知的不兼容标志 0x%02x", ErrMalformed, incompat)
+		}
+		frame.Version = 2
+		frame.Signed = incompat&flagSigned != 0
+		frame.Sequence, frame.SystemID, frame.ComponentID = data[4], data[5], data[6]
+		frame.MessageID = uint32(data[7]) | uint32(data[8])<<8 | uint32(data[9])<<16
+		size = headerLen + int(data[1]) + checksumLen
+		if frame.Signed {
+			size += signatureLen
+		}
+	default:
+		return nil, 0, fmt.Errorf("%w: 起始字节 0x%02x", ErrMalformed, data[0])
+	}
+	if len(data) < size {
+		return nil, 0, ErrIncomplete
+	}
+
+	spec, ok := messageSpecs[frame.MessageID]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %d", ErrUnsupported, frame.MessageID)
+	}
+
+	payloadLen := int(data[1])
+	if payloadLen > spec.length || (frame.Version == 1 && payloadLen != spec.baseLength) {
+		return nil, 0, fmt.Errorf("%w: 消息 %d 载荷长度 %d", ErrMalformed, frame.MessageID, payloadLen)
+	}
+
+	end := headerLen + payloadLen
+	crc := crcAccumulate(crcInit, data[1:end])
+	crc = crcAccumulate(crc, []byte{spec.crcExtra})
+	if crc != binary.LittleEndian.Uint16(data[end:]) {
+		return nil, 0, fmt.Errorf("%w: 消息 %d", ErrChecksum, frame.MessageID)
+	}
+
+	frame.Payload = append([]byte(nil), data[headerLen:end]...)
+	return &frame, size, nil
+}
+
+// Decoder 从字节流中读取帧
+// 遇到无效字节、校验失败或不支持的消息时向后查找下一个起始字节；
+// 不按声明长度整体跳过，避免噪声中的伪起始字节吞掉后续的有效帧
+type Decoder struct {
+	r   io.Reader
+	buf []byte
+}
+
+// NewDecoder 创建字节流解码器
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Next 返回下一个校验通过的帧，数据读完时返回 io.EOF
+func (d *Decoder) Next() (*Frame, error) {
+	for {
+		frame, size, err := ParseFrame(d.buf)
+		switch {
+		case err == nil:
+			d.buf = d.buf[size:]
+			return frame, nil
+		case !errors.Is(err, ErrIncomplete):
+			d.buf = d.buf[nextMagic(d.buf):]
+			continue
+		}
+
+		if err := d.fill(); err != nil {
+			// 流结束时剩余的不完整数据可能以伪起始字节开头，继续向后查找
+			if err == io.EOF && len(d.buf) > 0 {
+				d.buf = d.buf[nextMagic(d.buf):]
+				continue
+			}
+			return nil, err
+		}
+	}
+}
+
+// nextMagic 返回 data 中首字节之后下一个帧起始字节的位置，不存在时返回 len(data)
+func nextMagic(data []byte) int {
+	for i := 1; i < len(data); i++ {
+		if data[i] == MagicV1 || data[i] == MagicV2 {
+			return i
+		}
+	}
+	return len(data)
+}
+
+// fill 从底层读取更多数据追加到缓冲区
+func (d *Decoder) fill() error {
+	chunk := make([]byte, 512)
+	n, err := d.r.Read(chunk)
+	d.buf = append(d.buf, chunk[:n]...)
+	if n > 0 {
+		return nil
+	}
+	if err == nil {
+		err = io.ErrNoProgress
+	}
+	return err
+}
+
+// DecodeAll 解析 data 中的全部帧，无效数据被跳过
+func DecodeAll(data []byte) []*Frame {
+	var frames []*Frame
+	for len(data) > 0 {
+		frame, size, err := ParseFrame(data)
+		if err != nil {
+			data = data[nextMagic(data):]
+			continue
+		}
+		frames = append(frames, frame)
+		data = data[size:]
+	}
+	return frames
+}
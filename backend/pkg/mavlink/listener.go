@@ -0,0 +1,73 @@
+package mavlink
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// DefaultPort 地面站接收 MAVLink 遥测的默认 UDP 端口
+const DefaultPort = "14550"
+
+// maxDatagramSize UDP 数据报最大长度
+const maxDatagramSize = 65535
+
+// Packet 一条解码后的消息及其来源
+type Packet struct {
+	Frame    *Frame
+	Message  Message
+	Source   net.Addr
+	Received time.Time
+}
+
+// Handler 处理一条解码成功的消息
+type Handler func(pkt *Packet)
+
+// Listener MAVLink UDP 监听器
+// 一个数据报可包含多个帧，无效数据和不支持的消息被跳过
+type Listener struct {
+	Addr     string              // 监听地址，如 :14550
+	OnListen func(addr net.Addr) // 开始监听回调，可为空
+}
+
+// Run 在 Addr 上监听 UDP 数据报并持续解码，直到 ctx 取消
+func (l *Listener) Run(ctx context.Context, handle Handler) error {
+	var lc net.ListenConfig
+	conn, err := lc.ListenPacket(ctx, "udp", l.Addr)
+	if err != nil {
+		return err
+	}
+	if l.OnListen != nil {
+		l.OnListen(conn.LocalAddr())
+	}
+	return Serve(ctx, conn, handle)
+}
+
+// Serve 从已建立的连接读取数据报并解码，直到 ctx 取消或读取出错，返回前关闭连接
+func Serve(ctx context.Context, conn net.PacketConn, handle Handler) error {
+	defer conn.Close()
+
+	// ctx 取消时关闭连接以中断阻塞的读取
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		received := time.Now()
+		for _, frame := range DecodeAll(buf[:n]) {
+			msg, err := frame.Message()
+			if err != nil {
+				continue
+			}
+			handle(&Packet{Frame: frame, Message: msg, Source: addr, Received: received})
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package mavlink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadFixture 读取十六进制样本，返回每行对应的字节序列
+func loadFixture(t *testing.T, name string) [][]byte {
+	t.Helper()
+
+	f, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var chunks [][]byte
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		data, err := hex.DecodeString(strings.ReplaceAll(line, " ", ""))
+		require.NoError(t, err)
+		chunks = append(chunks, data)
+	}
+	require.NoError(t, scanner.Err())
+	return chunks
+}
+
+func TestCRCAccumulate(t *testing.T) {
+	// CRC-16/MCRF4XX 标准校验值
+	assert.Equal(t, uint16(0x6F91), crcAccumulate(crcInit, []byte("123456789")))
+}
+
+func TestParseFrameV1Heartbeat(t *testing.T) {
+	chunks := loadFixture(t, "telemetry.hex")
+
+	frame, size, err := ParseFrame(chunks[0])
+	require.NoError(t, err)
+	assert.Equal(t, len(chunks[0]), size)
+	assert.Equal(t, 1, frame.Version)
+	assert.Equal(t, uint8(0x4E), frame.Sequence)
+	assert.Equal(t, uint8(1), frame.SystemID)
+	assert.Equal(t, MsgIDHeartbeat, frame.MessageID)
+
+	msg, err := frame.Message()
+	require.NoError(t, err)
+	hb := msg.(*Heartbeat)
+	assert.Equal(t, uint8(2), hb.Type)
+	assert.Equal(t, AutopilotArduPilot, hb.Autopilot)
+	assert.Equal(t, "stabilize", hb.FlightMode())
+	assert.False(t, hb.Armed())
+
+	_, _, err = ParseFrame(chunks[0][:10])
+	assert.ErrorIs(t, err, ErrIncomplete)
+
+	corrupted := bytes.Clone(chunks[0])
+	corrupted[8] ^= 0xFF
+	_, _, err = ParseFrame(corrupted)
+	assert.ErrorIs(t, err, ErrChecksum)
+}
+
+func TestParseFrameV2Messages(t *testing.T) {
+	chunks := loadFixture(t, "telemetry.hex")
+
+	frame, _, err := ParseFrame(chunks[3])
+	require.NoError(t, err)
+	msg, err := frame.Message()
+	require.NoError(t, err)
+	pos := msg.(*GlobalPositionInt)
+	assert.Equal(t, int32(312304000), pos.Lat)
+	assert.Equal(t, int32(1214737000), pos.Lon)
+	assert.Equal(t, int32(120000), pos.RelativeAlt)
+	assert.Equal(t, int16(-150), pos.Vz)
+	assert.Equal(t, uint16(9050), pos.Hdg)
+
+	// 载荷被截断的 SYS_STATUS 按完整长度补零解码
+	frame, _, err = ParseFrame(chunks[4])
+	require.NoError(t, err)
+	assert.Len(t, frame.Payload, 31)
+	msg, err = frame.Message()
+	require.NoError(t, err)
+	status := msg.(*SysStatus)
+	assert.Equal(t, uint16(15800), status.VoltageBattery)
+	assert.Equal(t, int8(76), status.BatteryRemaining)
+
+	frame, _, err = ParseFrame(chunks[5])
+	require.NoError(t, err)
+	msg, err = frame.Message()
+	require.NoError(t, err)
+	gps := msg.(*GPSRawInt)
+	assert.Equal(t, uint8(3), gps.FixType)
+	assert.Equal(t, uint8(14), gps.SatellitesVisible)
+	assert.Equal(t, uint32(1200), gps.HAcc)
+
+	frame, _, err = ParseFrame(chunks[6])
+	require.NoError(t, err)
+	msg, err = frame.Message()
+	require.NoError(t, err)
+	hud := msg.(*VFRHUD)
+	assert.InDelta(t, 5.83, hud.Groundspeed, 1e-5)
+	assert.Equal(t, uint16(48), hud.Throttle)
+
+	_, _, err = ParseFrame(chunks[7])
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	// 带签名的 PX4 心跳
+	frame, size, err := ParseFrame(chunks[9])
+	require.NoError(t, err)
+	assert.True(t, frame.Signed)
+	assert.Equal(t, len(chunks[9]), size)
+	msg, err = frame.Message()
+	require.NoError(t, err)
+	assert.Equal(t, "mission", msg.(*Heartbeat).FlightMode())
+}
+
+func TestDecoderResynchronizes(t *testing.T) {
+	stream := bytes.Join(loadFixture(t, "telemetry.hex"), nil)
+
+	var ids []uint32
+	decoder := NewDecoder(iotest.OneByteReader(bytes.NewReader(stream)))
+	for {
+		frame, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, frame.MessageID)
+	}
+
+	// 噪声、不支持的消息和校验失败的帧被跳过
+	assert.Equal(t, []uint32{
+		MsgIDHeartbeat, MsgIDHeartbeat, MsgIDGlobalPositionInt, MsgIDSysStatus,
+		MsgIDGPSRawInt, MsgIDVFRHUD, MsgIDHeartbeat, MsgIDHeartbeat,
+	}, ids)
+	assert.Len(t, DecodeAll(stream), len(ids))
+}
+
+func TestTrackerMergesMessages(t *testing.T) {
+	tracker := NewTracker()
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	positioned := 0
+	for i, frame := range DecodeAll(bytes.Join(loadFixture(t, "telemetry.hex"), nil)) {
+		msg, err := frame.Message()
+		require.NoError(t, err)
+		if _, ok := tracker.Update(frame.SystemID, msg, at.Add(time.Duration(i)*time.Second)); ok {
+			positioned++
+		}
+	}
+	assert.Equal(t, 1, positioned)
+
+	vehicles := tracker.Snapshot()
+	require.Len(t, vehicles, 2, "地面站心跳不应产生飞行器")
+
+	v := vehicles[0]
+	assert.Equal(t, uint8(1), v.SystemID)
+	assert.Equal(t, "loiter", v.FlightMode)
+	assert.True(t, v.Armed)
+	require.True(t, v.HasPosition())
+	assert.InDelta(t, 31.2304, *v.Latitude, 1e-7)
+	assert.InDelta(t, 121.4737, *v.Longitude, 1e-7)
+	assert.InDelta(t, 120.0, *v.Altitude, 1e-9)
+	assert.InDelta(t, 150.5, *v.AltitudeMSL, 1e-9)
+	assert.InDelta(t, 90.5, *v.Heading, 1e-9)
+	assert.InDelta(t, 1.5, *v.VerticalSpeed, 1e-5)
+	assert.InDelta(t, 5.83, *v.GroundSpeed, 1e-5)
+	assert.Equal(t, 76, *v.BatteryLevel)
+	assert.InDelta(t, 15.8, *v.Voltage, 1e-9)
+	assert.Equal(t, 14, *v.GpsSatellites)
+	assert.InDelta(t, 1.2, *v.GpsAccuracy, 1e-9)
+	assert.Equal(t, at.Add(2*time.Second), v.PositionTime)
+
+	assert.Equal(t, "mission", vehicles[1].FlightMode)
+	assert.False(t, vehicles[1].HasPosition())
+
+	assert.Equal(t, 1, tracker.Prune(at.Add(6*time.Second)))
+}
+
+func TestServeUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	packets := make(chan *Packet, 16)
+	done := make(chan error, 1)
+	go func() { done <- Serve(ctx, conn, func(pkt *Packet) { packets <- pkt }) }()
+
+	sender, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer sender.Close()
+
+	// 一个数据报包含心跳和位置两帧
+	chunks := loadFixture(t, "telemetry.hex")
+	_, err = sender.Write(append(bytes.Clone(chunks[1]), chunks[3]...))
+	require.NoError(t, err)
+
+	for _, want := range []uint32{MsgIDHeartbeat, MsgIDGlobalPositionInt} {
+		select {
+		case pkt := <-packets:
+			assert.Equal(t, want, pkt.Message.MessageID())
+			assert.NotNil(t, pkt.Source)
+		case <-time.After(2 * time.Second):
+			t.Fatal("未收到 UDP 消息")
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Serve 未在 ctx 取消后退出")
+	}
+}
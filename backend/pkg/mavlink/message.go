@@ -0,0 +1,174 @@
+package mavlink
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// 支持的消息 ID
+const (
+	MsgIDHeartbeat         uint32 = 0
+	MsgIDSysStatus         uint32 = 1
+	MsgIDGPSRawInt         uint32 = 24
+	MsgIDGlobalPositionInt uint32 = 33
+	MsgIDVFRHUD            uint32 = 74
+)
+
+// Message 解码后的 MAVLink 消息
+type Message interface {
+	// MessageID 返回消息 ID
+	MessageID() uint32
+}
+
+// messageSpec 消息的载荷长度、CRC_EXTRA 和解码函数
+// baseLength 为 v1 载荷长度，length 为包含 v2 扩展字段的完整长度
+type messageSpec struct {
+	baseLength int
+	length     int
+	crcExtra   byte
+	decode     func(p []byte) Message
+}
+
+var messageSpecs = map[uint32]messageSpec{
+	MsgIDHeartbeat:         {baseLength: 9, length: 9, crcExtra: 50, decode: decodeHeartbeat},
+	MsgIDSysStatus:         {baseLength: 31, length: 43, crcExtra: 124, decode: decodeSysStatus},
+	MsgIDGPSRawInt:         {baseLength: 30, length: 52, crcExtra: 24, decode: decodeGPSRawInt},
+	MsgIDGlobalPositionInt: {baseLength: 28, length: 28, crcExtra: 104, decode: decodeGlobalPositionInt},
+	MsgIDVFRHUD:            {baseLength: 20, length: 20, crcExtra: 20, decode: decodeVFRHUD},
+}
+
+// Heartbeat HEARTBEAT (#0) 心跳，携带飞行器类型、飞控类型和飞行模式
+type Heartbeat struct {
+	CustomMode     uint32 // 飞控自定义模式
+	Type           uint8  // MAV_TYPE
+	Autopilot      uint8  // MAV_AUTOPILOT
+	BaseMode       uint8  // MAV_MODE_FLAG 位组合
+	SystemStatus   uint8  // MAV_STATE
+	MavlinkVersion uint8
+}
+
+// SysStatus SYS_STATUS (#1) 系统状态，携带电池信息
+type SysStatus struct {
+	SensorsPresent   uint32
+	SensorsEnabled   uint32
+	SensorsHealth    uint32
+	Load             uint16 // 0.1%
+	VoltageBattery   uint16 // mV，UINT16_MAX 表示未知
+	CurrentBattery   int16  // 10mA，-1 表示未知
+	DropRateComm     uint16 // 0.01%
+	ErrorsComm       uint16
+	BatteryRemaining int8 // 百分比，-1 表示未知
+}
+
+// GPSRawInt GPS_RAW_INT (#24) GPS 原始数据
+type GPSRawInt struct {
+	TimeUsec          uint64
+	Lat               int32  // 度 * 1e7
+	Lon               int32  // 度 * 1e7
+	Alt               int32  // 海拔 mm
+	Eph               uint16 // 水平精度因子 * 100，UINT16_MAX 表示未知
+	Epv               uint16
+	Vel               uint16 // 地速 cm/s
+	Cog               uint16 // 航迹角 cdeg
+	FixType           uint8  // GPS_FIX_TYPE
+	SatellitesVisible uint8  // UINT8_MAX 表示未知
+	HAcc              uint32 // 水平精度 mm（v2 扩展字段，0 表示未提供）
+}
+
+// GlobalPositionInt GLOBAL_POSITION_INT (#33) 融合后的全球位置
+type GlobalPositionInt struct {
+	TimeBootMs  uint32
+	Lat         int32  // 度 * 1e7
+	Lon         int32  // 度 * 1e7
+	Alt         int32  // 海拔 mm
+	RelativeAlt int32  // 相对起飞点高度 mm
+	Vx          int16  // 北向速度 cm/s
+	Vy          int16  // 东向速度 cm/s
+	Vz          int16  // 地向速度 cm/s（向下为正）
+	Hdg         uint16 // 航向 cdeg，UINT16_MAX 表示未知
+}
+
+// VFRHUD VFR_HUD (#74) 平视显示数据
+type VFRHUD struct {
+	Airspeed    float32 // m/s
+	Groundspeed float32 // m/s
+	Alt         float32 // 海拔 m
+	Climb       float32 // 爬升率 m/s（向上为正）
+	Heading     int16   // 度（0-360）
+	Throttle    uint16  // 百分比
+}
+
+func (Heartbeat) MessageID() uint32         { return MsgIDHeartbeat }
+func (SysStatus) MessageID() uint32         { return MsgIDSysStatus }
+func (GPSRawInt) MessageID() uint32         { return MsgIDGPSRawInt }
+func (GlobalPositionInt) MessageID() uint32 { return MsgIDGlobalPositionInt }
+func (VFRHUD) MessageID() uint32            { return MsgIDVFRHUD }
+
+// 载荷字段按 MAVLink 规则以字段大小降序排列，扩展字段追加在末尾
+var le = binary.LittleEndian
+
+func decodeHeartbeat(p []byte) Message {
+	return &Heartbeat{
+		CustomMode:     le.Uint32(p[0:]),
+		Type:           p[4],
+		Autopilot:      p[5],
+		BaseMode:       p[6],
+		SystemStatus:   p[7],
+		MavlinkVersion: p[8],
+	}
+}
+
+func decodeSysStatus(p []byte) Message {
+	return &SysStatus{
+		SensorsPresent:   le.Uint32(p[0:]),
+		SensorsEnabled:   le.Uint32(p[4:]),
+		SensorsHealth:    le.Uint32(p[8:]),
+		Load:             le.Uint16(p[12:]),
+		VoltageBattery:   le.Uint16(p[14:]),
+		CurrentBattery:   int16(le.Uint16(p[16:])),
+		DropRateComm:     le.Uint16(p[18:]),
+		ErrorsComm:       le.Uint16(p[20:]),
+		BatteryRemaining: int8(p[30]),
+	}
+}
+
+func decodeGPSRawInt(p []byte) Message {
+	return &GPSRawInt{
+		TimeUsec:          le.Uint64(p[0:]),
+		Lat:               int32(le.Uint32(p[8:])),
+		Lon:               int32(le.Uint32(p[12:])),
+		Alt:               int32(le.Uint32(p[16:])),
+		Eph:               le.Uint16(p[20:]),
+		Epv:               le.Uint16(p[22:]),
+		Vel:               le.Uint16(p[24:]),
+		Cog:               le.Uint16(p[26:]),
+		FixType:           p[28],
+		SatellitesVisible: p[29],
+		HAcc:              le.Uint32(p[34:]),
+	}
+}
+
+func decodeGlobalPositionInt(p []byte) Message {
+	return &GlobalPositionInt{
+		TimeBootMs:  le.Uint32(p[0:]),
+		Lat:         int32(le.Uint32(p[4:])),
+		Lon:         int32(le.Uint32(p[8:])),
+		Alt:         int32(le.Uint32(p[12:])),
+		RelativeAlt: int32(le.Uint32(p[16:])),
+		Vx:          int16(le.Uint16(p[20:])),
+		Vy:          int16(le.Uint16(p[22:])),
+		Vz:          int16(le.Uint16(p[24:])),
+		Hdg:         le.Uint16(p[26:]),
+	}
+}
+
+func decodeVFRHUD(p []byte) Message {
+	return &VFRHUD{
+		Airspeed:    math.Float32frombits(le.Uint32(p[0:])),
+		Groundspeed: math.Float32frombits(le.Uint32(p[4:])),
+		Alt:         math.Float32frombits(le.Uint32(p[8:])),
+		Climb:       math.Float32frombits(le.Uint32(p[12:])),
+		Heading:     int16(le.Uint16(p[16:])),
+		Throttle:    le.Uint16(p[18:]),
+	}
+}
@@ -0,0 +1,89 @@
+package mavlink
+
+// MAV_AUTOPILOT 飞控类型
+const (
+	AutopilotArduPilot uint8 = 3
+	AutopilotPX4       uint8 = 12
+)
+
+// MAV_MODE_FLAG 基础模式标志位
+const (
+	ModeFlagCustomModeEnabled uint8 = 1
+	ModeFlagGuidedEnabled     uint8 = 8
+	ModeFlagAutoEnabled       uint8 = 4
+	ModeFlagManualInput       uint8 = 64
+	ModeFlagSafetyArmed       uint8 = 128
+)
+
+// MAV_TYPE 中的固定翼类型，其余按多旋翼处理
+const mavTypeFixedWing uint8 = 1
+
+// arduCopterModes ArduCopter 自定义模式名称
+var arduCopterModes = map[uint32]string{
+	0: "stabilize", 1: "acro", 2: "alt_hold", 3: "auto", 4: "guided", 5: "loiter",
+	6: "rtl", 7: "circle", 9: "land", 11: "drift", 13: "sport", 14: "flip",
+	15: "autotune", 16: "poshold", 17: "brake", 18: "throw", 19: "avoid_adsb",
+	20: "guided_nogps", 21: "smart_rtl", 22: "flowhold", 23: "follow", 24: "zigzag",
+	25: "systemid", 26: "autorotate", 27: "auto_rtl",
+}
+
+// arduPlaneModes ArduPlane 自定义模式名称
+var arduPlaneModes = map[uint32]string{
+	0: "manual", 1: "circle", 2: "stabilize", 3: "training", 4: "acro", 5: "fbwa",
+	6: "fbwb", 7: "cruise", 8: "autotune", 10: "auto", 11: "rtl", 12: "loiter",
+	13: "takeoff", 14: "avoid_adsb", 15: "guided", 17: "qstabilize", 18: "qhover",
+	19: "qloiter", 20: "qland", 21: "qrtl", 22: "qautotune", 23: "qacro", 24: "thermal",
+}
+
+// px4MainModes PX4 主模式名称（custom_mode 第 3 字节）
+var px4MainModes = map[uint32]string{
+	1: "manual", 2: "altctl", 3: "posctl", 4: "auto", 5: "acro", 6: "offboard",
+	7: "stabilized", 8: "rattitude",
+}
+
+// px4AutoModes PX4 AUTO 子模式名称（custom_mode 第 4 字节）
+var px4AutoModes = map[uint32]string{
+	1: "ready", 2: "takeoff", 3: "loiter", 4: "mission", 5: "rtl", 6: "land",
+	8: "follow", 9: "precland",
+}
+
+// FlightMode 返回心跳对应的飞行模式名称（小写），无法识别时根据基础模式标志推断
+func (h *Heartbeat) FlightMode() string {
+	if h.BaseMode&ModeFlagCustomModeEnabled != 0 {
+		switch h.Autopilot {
+		case AutopilotArduPilot:
+			modes := arduCopterModes
+			if h.Type == mavTypeFixedWing {
+				modes = arduPlaneModes
+			}
+			if name, ok := modes[h.CustomMode]; ok {
+				return name
+			}
+		case AutopilotPX4:
+			main := h.CustomMode >> 16 & 0xFF
+			if main == 4 {
+				if name, ok := px4AutoModes[h.CustomMode>>24&0xFF]; ok {
+					return name
+				}
+			}
+			if name, ok := px4MainModes[main]; ok {
+				return name
+			}
+		}
+	}
+
+	switch {
+	case h.BaseMode&ModeFlagAutoEnabled != 0:
+		return "auto"
+	case h.BaseMode&ModeFlagGuidedEnabled != 0:
+		return "guided"
+	case h.BaseMode&ModeFlagManualInput != 0:
+		return "manual"
+	}
+	return ""
+}
+
+// Armed 判断飞行器是否已解锁
+func (h *Heartbeat) Armed() bool {
+	return h.BaseMode&ModeFlagSafetyArmed != 0
+}
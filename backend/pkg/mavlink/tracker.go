@@ -0,0 +1,210 @@
+package mavlink
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// 未知值哨兵
+const (
+	unknownUint8  = 0xFF
+	unknownUint16 = 0xFFFF
+)
+
+// 不代表飞行器本身的心跳来源
+const (
+	mavTypeGCS       uint8 = 6 // 地面站
+	autopilotInvalid uint8 = 8 // 云台、相机等非飞控组件
+)
+
+// Vehicle 按系统 ID 聚合后的飞行器状态
+// 指针字段为 nil 表示尚未收到对应数据
+type Vehicle struct {
+	SystemID      uint8
+	Type          uint8 // MAV_TYPE
+	Autopilot     uint8 // MAV_AUTOPILOT
+	FlightMode    string
+	Armed         bool
+	Latitude      *float64
+	Longitude     *float64
+	Altitude      *float64 // 相对起飞点高度（米）
+	AltitudeMSL   *float64 // 海拔（米）
+	GroundSpeed   *float64 // 地速（m/s）
+	Heading       *float64 // 航向（度）
+	VerticalSpeed *float64 // 垂直速度（m/s，向上为正）
+	BatteryLevel  *int     // 剩余电量百分比
+	Voltage       *float64 // 电池电压（V）
+	GpsFixType    *int     // GPS_FIX_TYPE
+	GpsSatellites *int
+	GpsAccuracy   *float64  // 水平精度（米）
+	LastSeen      time.Time // 最近一条消息的接收时间
+	PositionTime  time.Time // 最近一次位置更新的接收时间
+	Messages      int       // 累计消息数
+}
+
+// HasPosition 判断是否已获得经纬度
+func (v *Vehicle) HasPosition() bool {
+	return v.Latitude != nil && v.Longitude != nil
+}
+
+// Tracker 按系统 ID 关联各类消息，维护每架飞行器的最新状态
+// 可在多个 goroutine 中并发使用
+type Tracker struct {
+	mu       sync.Mutex
+	vehicles map[uint8]*Vehicle
+}
+
+// NewTracker 创建飞行器状态跟踪器
+func NewTracker() *Tracker {
+	return &Tracker{
+		vehicles: make(map[uint8]*Vehicle),
+	}
+}
+
+// Update 合并一条消息到对应飞行器的状态，at 为接收时间
+// 返回合并后的状态副本，以及本条消息是否更新了位置；地面站和非飞控组件的心跳被忽略
+func (t *Tracker) Update(systemID uint8, msg Message, at time.Time) (Vehicle, bool) {
+	if hb, ok := msg.(*Heartbeat); ok && (hb.Type == mavTypeGCS || hb.Autopilot == autopilotInvalid) {
+		return Vehicle{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.vehicles[systemID]
+	if !ok {
+		state = &Vehicle{SystemID: systemID}
+		t.vehicles[systemID] = state
+	}
+	if at.After(state.LastSeen) {
+		state.LastSeen = at
+	}
+	state.Messages++
+
+	positioned := false
+	switch m := msg.(type) {
+	case *Heartbeat:
+		state.Type, state.Autopilot = m.Type, m.Autopilot
+		state.FlightMode = m.FlightMode()
+		state.Armed = m.Armed()
+	case *SysStatus:
+		if m.BatteryRemaining >= 0 {
+			state.BatteryLevel = intPtr(int(m.BatteryRemaining))
+		}
+		if m.VoltageBattery != unknownUint16 {
+			state.Voltage = floatPtr(float64(m.VoltageBattery) / 1000)
+		}
+	case *GPSRawInt:
+		state.GpsFixType = intPtr(int(m.FixType))
+		if m.SatellitesVisible != unknownUint8 {
+			state.GpsSatellites = intPtr(int(m.SatellitesVisible))
+		}
+		if m.HAcc > 0 {
+			state.GpsAccuracy = floatPtr(float64(m.HAcc) / 1000)
+		}
+	case *GlobalPositionInt:
+		// 经纬度均为 0 表示尚未定位
+		if (m.Lat != 0 || m.Lon != 0) && !at.Before(state.PositionTime) {
+			state.Latitude = floatPtr(float64(m.Lat) / 1e7)
+			state.Longitude = floatPtr(float64(m.Lon) / 1e7)
+			state.Altitude = floatPtr(float64(m.RelativeAlt) / 1000)
+			state.AltitudeMSL = floatPtr(float64(m.Alt) / 1000)
+			state.VerticalSpeed = floatPtr(-float64(m.Vz) / 100)
+			if m.Hdg != unknownUint16 {
+				state.Heading = floatPtr(float64(m.Hdg) / 100)
+			}
+			state.PositionTime = at
+			positioned = true
+		}
+	case *VFRHUD:
+		state.GroundSpeed = floatPtr(float64(m.Groundspeed))
+		state.VerticalSpeed = floatPtr(float64(m.Climb))
+		if state.Heading == nil {
+			state.Heading = floatPtr(float64(m.Heading))
+		}
+	}
+
+	return state.clone(), positioned
+}
+
+// Get 获取指定飞行器的状态副本
+func (t *Tracker) Get(systemID uint8) (Vehicle, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.vehicles[systemID]
+	if !ok {
+		return Vehicle{}, false
+	}
+	return state.clone(), true
+}
+
+// Snapshot 返回所有飞行器状态副本，按系统 ID 排序
+func (t *Tracker) Snapshot() []Vehicle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	list := make([]Vehicle, 0, len(t.vehicles))
+	for _, state := range t.vehicles {
+		list = append(list, state.clone())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SystemID < list[j].SystemID })
+	return list
+}
+
+// Prune 移除 before 之前就不再出现的飞行器，返回移除数量
+func (t *Tracker) Prune(before time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	removed := 0
+	for id, state := range t.vehicles {
+		if state.LastSeen.Before(before) {
+			delete(t.vehicles, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (v *Vehicle) clone() Vehicle {
+	c := *v
+	c.Latitude = copyFloat(v.Latitude)
+	c.Longitude = copyFloat(v.Longitude)
+	c.Altitude = copyFloat(v.Altitude)
+	c.AltitudeMSL = copyFloat(v.AltitudeMSL)
+	c.GroundSpeed = copyFloat(v.GroundSpeed)
+	c.Heading = copyFloat(v.Heading)
+	c.VerticalSpeed = copyFloat(v.VerticalSpeed)
+	c.BatteryLevel = copyInt(v.BatteryLevel)
+	c.Voltage = copyFloat(v.Voltage)
+	c.GpsFixType = copyInt(v.GpsFixType)
+	c.GpsSatellites = copyInt(v.GpsSatellites)
+	c.GpsAccuracy = copyFloat(v.GpsAccuracy)
+	return c
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func copyInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func copyFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
@@ -0,0 +1,90 @@
+package sbs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"time"
+)
+
+// DefaultPort BaseStation 文本输出默认端口
+const DefaultPort = "30003"
+
+const defaultReconnectDelay = 5 * time.Second
+
+// Handler 处理一条解析成功的消息
+type Handler func(msg *Message)
+
+// Client SBS-1 TCP 客户端，断线后自动重连
+type Client struct {
+	Addr           string          // 数据源地址，如 localhost:30003
+	Parser         Parser          // 消息解析器
+	ReconnectDelay time.Duration   // 断线重连间隔，默认 5 秒
+	DialTimeout    time.Duration   // 连接超时，默认 10 秒
+	OnConnect      func()          // 连接成功回调，可为空
+	OnDisconnect   func(err error) // 连接断开回调，可为空
+}
+
+// Run 连接数据源并持续读取消息，直到 ctx 取消
+func (c *Client) Run(ctx context.Context, handle Handler) error {
+	delay := c.ReconnectDelay
+	if delay <= 0 {
+		delay = defaultReconnectDelay
+	}
+
+	for {
+		err := c.runOnce(ctx, handle)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if c.OnDisconnect != nil {
+			c.OnDisconnect(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) runOnce(ctx context.Context, handle Handler) error {
+	timeout := c.DialTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.Addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// ctx 取消时关闭连接以中断阻塞的读取
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if c.OnConnect != nil {
+		c.OnConnect()
+	}
+	if err := c.Parser.ReadAll(conn, handle); err != nil {
+		return err
+	}
+	return io.EOF
+}
+
+// ReadAll 从 r 中逐行读取并解析消息，非 MSG 行和格式错误的行会被跳过
+func (p Parser) ReadAll(r io.Reader, handle Handler) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		msg, err := p.Parse(scanner.Text())
+		if err != nil {
+			continue
+		}
+		handle(msg)
+	}
+	return scanner.Err()
+}
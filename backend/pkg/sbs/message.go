@@ -0,0 +1,98 @@
+// Package sbs 解析 SBS-1（BaseStation，端口 30003）格式的 ADS-B 文本数据
+//
+// 每行是一条逗号分隔的消息，例如：
+//
+//	MSG,3,1,1,4840D6,1,2008/11/28,23:48:18.611,2008/11/28,23:53:19.161,,37000,,,51.45735,-1.02826,,,0,0,0,0
+//
+// 本包只处理 MSG 类型消息（传输类型 1-8），并提供按 ICAO 地址聚合飞机状态的 Tracker
+// 以及从 TCP 数据源读取消息的 Client。
+package sbs
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransmissionType MSG 消息的传输类型
+type TransmissionType int
+
+const (
+	// Identification 识别消息（呼号）
+	Identification TransmissionType = 1
+	// SurfacePosition 地面位置消息
+	SurfacePosition TransmissionType = 2
+	// AirbornePosition 空中位置消息
+	AirbornePosition TransmissionType = 3
+	// AirborneVelocity 空中速度消息
+	AirborneVelocity TransmissionType = 4
+	// SurveillanceAltitude 监视高度消息
+	SurveillanceAltitude TransmissionType = 5
+	// SurveillanceID 监视识别消息（应答码）
+	SurveillanceID TransmissionType = 6
+	// AirToAir 空空消息
+	AirToAir TransmissionType = 7
+	// AllCallReply 全呼应答消息
+	AllCallReply TransmissionType = 8
+)
+
+var transmissionTypeNames = map[TransmissionType]string{
+	Identification:       "identification",
+	SurfacePosition:      "surface_position",
+	AirbornePosition:     "airborne_position",
+	AirborneVelocity:     "airborne_velocity",
+	SurveillanceAltitude: "surveillance_altitude",
+	SurveillanceID:       "surveillance_id",
+	AirToAir:             "air_to_air",
+	AllCallReply:         "all_call_reply",
+}
+
+// String 返回传输类型名称
+func (t TransmissionType) String() string {
+	if name, ok := transmissionTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// Valid 判断是否为 1-8 之间的合法传输类型
+func (t TransmissionType) Valid() bool {
+	return t >= Identification && t <= AllCallReply
+}
+
+// Message 一条解析后的 MSG 消息
+// 指针字段为 nil 表示该消息未携带对应数据
+type Message struct {
+	Type       TransmissionType
+	SessionID  string
+	AircraftID string
+	HexIdent   string // ICAO 24 位地址，大写十六进制
+	FlightID   string
+	Generated  time.Time // 消息生成时间
+	Logged     time.Time // 消息记录时间
+
+	Callsign     string   // 呼号（类型 1）
+	Altitude     *int     // 气压高度（英尺）
+	GroundSpeed  *float64 // 地速（节）
+	Track        *float64 // 航迹角（度）
+	Latitude     *float64
+	Longitude    *float64
+	VerticalRate *int   // 垂直速度（英尺/分钟）
+	Squawk       string // 应答机编码（类型 6）
+	Alert        *bool  // 应答码变化告警
+	Emergency    *bool  // 紧急状态
+	SPI          *bool  // 特殊位置识别
+	OnGround     *bool
+}
+
+// HasPosition 判断消息是否携带经纬度
+func (m *Message) HasPosition() bool {
+	return m.Latitude != nil && m.Longitude != nil
+}
+
+// Time 返回消息时间，优先使用生成时间
+func (m *Message) Time() time.Time {
+	if !m.Generated.IsZero() {
+		return m.Generated
+	}
+	return m.Logged
+}
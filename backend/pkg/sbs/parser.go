@@ -0,0 +1,180 @@
+package sbs
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fieldCount = 22
+
+	dateLayout = "2006/01/02"
+	timeLayout = "15:04:05.000"
+)
+
+var (
+	// ErrUnsupported 非 MSG 类型的消息（SEL、ID、AIR、STA、CLK）
+	ErrUnsupported = errors.New("sbs: 不支持的消息类型")
+	// ErrMalformed 消息格式错误
+	ErrMalformed = errors.New("sbs: 消息格式错误")
+)
+
+// Parser SBS-1 消息解析器
+// Location 为接收机时间戳所在时区，为空时按 UTC 解析
+type Parser struct {
+	Location *time.Location
+}
+
+// Parse 使用 UTC 时区解析一行消息
+func Parse(line string) (*Message, error) {
+	return Parser{}.Parse(line)
+}
+
+// Parse 解析一行 SBS-1 消息
+func (p Parser) Parse(line string) (*Message, error) {
+	line = strings.TrimRight(line, "\r\n")
+	fields := strings.Split(line, ",")
+	if len(fields) == 0 || fields[0] == "" {
+		return nil, fmt.Errorf("%w: 空行", ErrMalformed)
+	}
+	if fields[0] != "MSG" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, fields[0])
+	}
+	if len(fields) < fieldCount {
+		return nil, fmt.Errorf("%w: 字段数 %d，期望 %d", ErrMalformed, len(fields), fieldCount)
+	}
+
+	typ, err := strconv.Atoi(fields[1])
+	if err != nil || !TransmissionType(typ).Valid() {
+		return nil, fmt.Errorf("%w: 传输类型 %q", ErrMalformed, fields[1])
+	}
+
+	hex := strings.ToUpper(strings.TrimSpace(fields[4]))
+	if !isHexIdent(hex) {
+		return nil, fmt.Errorf("%w: ICAO 地址 %q", ErrMalformed, fields[4])
+	}
+
+	loc := p.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	msg := &Message{
+		Type:       TransmissionType(typ),
+		SessionID:  fields[2],
+		AircraftID: fields[3],
+		HexIdent:   hex,
+		FlightID:   fields[5],
+		Callsign:   strings.TrimSpace(fields[10]),
+		Squawk:     strings.TrimSpace(fields[17]),
+	}
+
+	if msg.Generated, err = parseTimestamp(fields[6], fields[7], loc); err != nil {
+		return nil, err
+	}
+	if msg.Logged, err = parseTimestamp(fields[8], fields[9], loc); err != nil {
+		return nil, err
+	}
+
+	if msg.Altitude, err = parseInt(fields[11], "altitude"); err != nil {
+		return nil, err
+	}
+	if msg.GroundSpeed, err = parseFloat(fields[12], "ground_speed"); err != nil {
+		return nil, err
+	}
+	if msg.Track, err = parseFloat(fields[13], "track"); err != nil {
+		return nil, err
+	}
+	if msg.Latitude, err = parseFloat(fields[14], "latitude"); err != nil {
+		return nil, err
+	}
+	if msg.Longitude, err = parseFloat(fields[15], "longitude"); err != nil {
+		return nil, err
+	}
+	if msg.VerticalRate, err = parseInt(fields[16], "vertical_rate"); err != nil {
+		return nil, err
+	}
+
+	msg.Alert = parseFlag(fields[18])
+	msg.Emergency = parseFlag(fields[19])
+	msg.SPI = parseFlag(fields[20])
+	msg.OnGround = parseFlag(fields[21])
+
+	if msg.HasPosition() && (*msg.Latitude < -90 || *msg.Latitude > 90 || *msg.Longitude < -180 || *msg.Longitude > 180) {
+		return nil, fmt.Errorf("%w: 经纬度超出范围", ErrMalformed)
+	}
+
+	return msg, nil
+}
+
+func parseTimestamp(date, clock string, loc *time.Location) (time.Time, error) {
+	date, clock = strings.TrimSpace(date), strings.TrimSpace(clock)
+	if date == "" || clock == "" {
+		return time.Time{}, nil
+	}
+
+	layout := dateLayout + " " + timeLayout
+	if !strings.Contains(clock, ".") {
+		layout = dateLayout + " 15:04:05"
+	}
+	t, err := time.ParseInLocation(layout, date+" "+clock, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: 时间 %q %q", ErrMalformed, date, clock)
+	}
+	return t, nil
+}
+
+func parseInt(value, name string) (*int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	// 部分接收机会输出带小数的高度
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %q", ErrMalformed, name, value)
+	}
+	n := int(f)
+	return &n, nil
+}
+
+func parseFloat(value, name string) (*float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %q", ErrMalformed, name, value)
+	}
+	return &f, nil
+}
+
+// parseFlag 解析布尔标志，-1 或 1 为真，0 为假，空值为 nil
+func parseFlag(value string) *bool {
+	switch strings.TrimSpace(value) {
+	case "-1", "1":
+		v := true
+		return &v
+	case "0":
+		v := false
+		return &v
+	default:
+		return nil
+	}
+}
+
+func isHexIdent(value string) bool {
+	if len(value) != 6 {
+		return false
+	}
+	for _, c := range value {
+		if !(c >= '0' && c <= '9' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
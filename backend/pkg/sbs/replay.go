@@ -0,0 +1,45 @@
+package sbs
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Replay 在监听器上回放录制的消息，用于本地联调和测试
+// 每个连接都会从头按 interval 间隔逐行发送 lines，发送完毕后关闭连接
+func Replay(ctx context.Context, ln net.Listener, lines []string, interval time.Duration) error {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go replayTo(ctx, conn, lines, interval)
+	}
+}
+
+func replayTo(ctx context.Context, conn net.Conn, lines []string, interval time.Duration) {
+	defer conn.Close()
+
+	for _, line := range lines {
+		if _, err := conn.Write([]byte(line + "\r\n")); err != nil {
+			return
+		}
+		if interval <= 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package sbs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadFixture(t *testing.T, name string) []string {
+	t.Helper()
+
+	f, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func TestParseAirbornePosition(t *testing.T) {
+	msg, err := Parse("MSG,3,496,211,4840d6,10057,2008/11/28,23:48:19.500,2008/11/28,23:48:19.500,,37000,,,51.45735,-1.02826,,,0,0,0,0")
+	require.NoError(t, err)
+
+	assert.Equal(t, AirbornePosition, msg.Type)
+	assert.Equal(t, "4840D6", msg.HexIdent)
+	assert.Equal(t, 37000, *msg.Altitude)
+	assert.InDelta(t, 51.45735, *msg.Latitude, 1e-9)
+	assert.InDelta(t, -1.02826, *msg.Longitude, 1e-9)
+	assert.Nil(t, msg.GroundSpeed)
+	assert.False(t, *msg.OnGround)
+	assert.Equal(t, time.Date(2008, 11, 28, 23, 48, 19, 500_000_000, time.UTC), msg.Generated)
+}
+
+func TestParseIdentificationAndVelocity(t *testing.T) {
+	msg, err := Parse("MSG,1,145,29315,4840D6,27215,2008/11/28,23:48:18.611,2008/11/28,23:53:19.161,KLM1023 ,,,,,,,,,,,")
+	require.NoError(t, err)
+	assert.Equal(t, Identification, msg.Type)
+	assert.Equal(t, "KLM1023", msg.Callsign)
+	assert.False(t, msg.HasPosition())
+
+	msg, err = Parse("MSG,4,496,469,4840D6,27854,2008/11/28,23:48:20.100,2008/11/28,23:48:20.100,,,451.2,69.8,,,-128,,,,,0")
+	require.NoError(t, err)
+	assert.Equal(t, AirborneVelocity, msg.Type)
+	assert.InDelta(t, 451.2, *msg.GroundSpeed, 1e-9)
+	assert.InDelta(t, 69.8, *msg.Track, 1e-9)
+	assert.Equal(t, -128, *msg.VerticalRate)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("SEL,,496,2286,4CA4E5,27215,2010/02/19,18:06:07.710,2010/02/19,18:06:07.710,RYR1427")
+	assert.True(t, errors.Is(err, ErrUnsupported))
+
+	for _, line := range []string{
+		"",
+		"MSG,3,496,211,4840D6,10057,2008/11/28,23:48:28.000",
+		"MSG,9,496,211,4840D6,10057,2008/11/28,23:48:19.500,2008/11/28,23:48:19.500,,37000,,,51.45735,-1.02826,,,0,0,0,0",
+		"MSG,3,496,211,ZZZZZZ,10057,2008/11/28,23:48:19.500,2008/11/28,23:48:19.500,,37000,,,51.45735,-1.02826,,,0,0,0,0",
+		"MSG,3,496,211,4840D6,10057,2008/11/28,23:48:19.500,2008/11/28,23:48:19.500,,37000,,,151.45735,-1.02826,,,0,0,0,0",
+		"MSG,3,496,211,4840D6,10057,2008/11/28,23:48:19.500,2008/11/28,23:48:19.500,,abc,,,51.45735,-1.02826,,,0,0,0,0",
+	} {
+		_, err := Parse(line)
+		assert.True(t, errors.Is(err, ErrMalformed), line)
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	msg, err := Parser{Location: loc}.Parse("MSG,8,496,194,405F4E,27884,2008/11/28,23:48:26.000,2008/11/28,23:48:26.000,,,,,,,,,,,,0")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2008, 11, 28, 15, 48, 26, 0, time.UTC), msg.Generated.UTC())
+}
+
+func TestTrackerCorrelatesFixture(t *testing.T) {
+	tracker := NewTracker()
+	positions := 0
+	err := Parser{}.ReadAll(fixtureReader(t, "basestation.txt"), func(msg *Message) {
+		if _, positioned := tracker.Update(msg); positioned {
+			positions++
+		}
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, positions)
+	assert.Len(t, tracker.Snapshot(), 4)
+
+	klm, ok := tracker.Get("4840D6")
+	require.True(t, ok)
+	assert.Equal(t, "KLM1023", klm.Callsign)
+	assert.Equal(t, "1200", klm.Squawk)
+	assert.Equal(t, 36925, *klm.Altitude)
+	assert.InDelta(t, 451.2, *klm.GroundSpeed, 1e-9)
+	assert.InDelta(t, 69.8, *klm.Track, 1e-9)
+	assert.Equal(t, -128, *klm.VerticalRate)
+	assert.InDelta(t, 51.46210, *klm.Latitude, 1e-9)
+	assert.Equal(t, 6, klm.Messages)
+	assert.Equal(t, time.Date(2008, 11, 28, 23, 48, 23, 250_000_000, time.UTC), klm.PositionTime)
+
+	ground, ok := tracker.Get("400CB6")
+	require.True(t, ok)
+	assert.True(t, ground.OnGround)
+	assert.True(t, ground.HasPosition())
+
+	removed := tracker.Prune(time.Date(2008, 11, 28, 23, 48, 25, 0, time.UTC))
+	assert.Equal(t, 2, removed)
+}
+
+func TestTrackerIgnoresStalePosition(t *testing.T) {
+	tracker := NewTracker()
+	newer, _ := Parse("MSG,3,1,1,4840D6,1,2008/11/28,23:48:23.000,2008/11/28,23:48:23.000,,37000,,,51.5,-1.0,,,0,0,0,0")
+	older, _ := Parse("MSG,3,1,1,4840D6,1,2008/11/28,23:48:20.000,2008/11/28,23:48:20.000,,37000,,,51.4,-1.1,,,0,0,0,0")
+
+	_, positioned := tracker.Update(newer)
+	assert.True(t, positioned)
+	state, positioned := tracker.Update(older)
+	assert.False(t, positioned)
+	assert.InDelta(t, 51.5, *state.Latitude, 1e-9)
+}
+
+func TestClientAgainstReplayServer(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go Replay(ctx, ln, loadFixture(t, "basestation.txt"), 0)
+
+	var (
+		mu       sync.Mutex
+		received []*Message
+	)
+	client := &Client{
+		Addr:           ln.Addr().String(),
+		ReconnectDelay: time.Hour,
+		OnDisconnect:   func(error) { cancel() },
+	}
+	err = client.Run(ctx, func(msg *Message) {
+		mu.Lock()
+		received = append(received, msg)
+		mu.Unlock()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, received, 9)
+	assert.Equal(t, Identification, received[0].Type)
+}
+
+func fixtureReader(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
@@ -0,0 +1,165 @@
+package sbs
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Aircraft 按 ICAO 地址聚合后的飞机状态
+type Aircraft struct {
+	HexIdent     string
+	Callsign     string
+	Squawk       string
+	Altitude     *int
+	GroundSpeed  *float64
+	Track        *float64
+	Latitude     *float64
+	Longitude    *float64
+	VerticalRate *int
+	OnGround     bool
+	Emergency    bool
+	LastSeen     time.Time // 最近一条消息的时间
+	PositionTime time.Time // 最近一次位置更新的时间
+	Messages     int       // 累计消息数
+}
+
+// HasPosition 判断是否已获得经纬度
+func (a *Aircraft) HasPosition() bool {
+	return a.Latitude != nil && a.Longitude != nil
+}
+
+// Tracker 按 ICAO 地址关联各类 MSG 消息，维护每架飞机的最新状态
+// 可在多个 goroutine 中并发使用
+type Tracker struct {
+	mu       sync.Mutex
+	aircraft map[string]*Aircraft
+}
+
+// NewTracker 创建飞机状态跟踪器
+func NewTracker() *Tracker {
+	return &Tracker{
+		aircraft: make(map[string]*Aircraft),
+	}
+}
+
+// Update 合并一条消息到对应飞机的状态
+// 返回合并后的状态副本，以及本条消息是否更新了位置
+func (t *Tracker) Update(msg *Message) (Aircraft, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.aircraft[msg.HexIdent]
+	if !ok {
+		state = &Aircraft{HexIdent: msg.HexIdent}
+		t.aircraft[msg.HexIdent] = state
+	}
+
+	at := msg.Time()
+	if at.After(state.LastSeen) {
+		state.LastSeen = at
+	}
+	state.Messages++
+
+	if msg.Callsign != "" {
+		state.Callsign = msg.Callsign
+	}
+	if msg.Squawk != "" {
+		state.Squawk = msg.Squawk
+	}
+	if msg.Altitude != nil {
+		state.Altitude = copyInt(msg.Altitude)
+	}
+	if msg.GroundSpeed != nil {
+		state.GroundSpeed = copyFloat(msg.GroundSpeed)
+	}
+	if msg.Track != nil {
+		state.Track = copyFloat(msg.Track)
+	}
+	if msg.VerticalRate != nil {
+		state.VerticalRate = copyInt(msg.VerticalRate)
+	}
+	if msg.OnGround != nil {
+		state.OnGround = *msg.OnGround
+	}
+	if msg.Emergency != nil {
+		state.Emergency = *msg.Emergency
+	}
+
+	positioned := false
+	if msg.HasPosition() && !at.Before(state.PositionTime) {
+		state.Latitude = copyFloat(msg.Latitude)
+		state.Longitude = copyFloat(msg.Longitude)
+		state.PositionTime = at
+		positioned = true
+	}
+
+	return state.clone(), positioned
+}
+
+// Get 获取指定飞机的状态副本
+func (t *Tracker) Get(hexIdent string) (Aircraft, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.aircraft[hexIdent]
+	if !ok {
+		return Aircraft{}, false
+	}
+	return state.clone(), true
+}
+
+// Snapshot 返回所有飞机状态副本，按 ICAO 地址排序
+func (t *Tracker) Snapshot() []Aircraft {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	list := make([]Aircraft, 0, len(t.aircraft))
+	for _, state := range t.aircraft {
+		list = append(list, state.clone())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].HexIdent < list[j].HexIdent })
+	return list
+}
+
+// Prune 移除 before 之前就不再出现的飞机，返回移除数量
+func (t *Tracker) Prune(before time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	removed := 0
+	for hex, state := range t.aircraft {
+		if state.LastSeen.Before(before) {
+			delete(t.aircraft, hex)
+			removed++
+		}
+	}
+	return removed
+}
+
+func (a *Aircraft) clone() Aircraft {
+	c := *a
+	c.Altitude = copyInt(a.Altitude)
+	c.GroundSpeed = copyFloat(a.GroundSpeed)
+	c.Track = copyFloat(a.Track)
+	c.Latitude = copyFloat(a.Latitude)
+	c.Longitude = copyFloat(a.Longitude)
+	c.VerticalRate = copyInt(a.VerticalRate)
+	return c
+}
+
+func copyInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func copyFloat(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
@@ -0,0 +1,84 @@
+// create_feeder 创建数据接入（feeder 角色）账号并签发长期访问令牌，供 sbs-feeder、mavlink-feeder 使用
+//
+//	go run scripts/create_feeder.go -username station-pvg -password <密码> -ttl 8760h
+//
+// 账号已存在且为 feeder 角色时仅重新签发令牌；已存在的其他角色账号不会被改为 feeder。
+package main
+
+import (
+	"backend/internal/config"
+	"backend/internal/database"
+	"backend/internal/models"
+	"backend/internal/services"
+	"backend/pkg/utils/crypto"
+	"backend/pkg/utils/jwt"
+	"backend/pkg/utils/logger"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func main() {
+	username := flag.String("username", "feeder", "接收站账号用户名")
+	password := flag.String("password", "", "账号密码，创建新账号时必填")
+	ttl := flag.Duration("ttl", 365*24*time.Hour, "令牌有效期")
+	flag.Parse()
+
+	// 加载配置
+	config.Init()
+	cfg := config.AppConfig
+
+	// 初始化日志
+	logger.Init()
+
+	// 初始化数据库
+	dbManager, err := database.NewManager(cfg)
+	if err != nil {
+		log.Fatalf("初始化数据库失败: %v", err)
+	}
+	defer dbManager.Close()
+
+	db := dbManager.GetDB()
+
+	var user models.User
+	if result := db.Where("username = ?", *username).First(&user); result.Error == nil {
+		if user.Role != services.RoleFeeder {
+			log.Fatalf("用户 %s 已存在且角色为 %s，不能用作接收站账号", user.Username, user.Role)
+		}
+		log.Printf("接收站账号 %s 已存在，重新签发令牌", user.Username)
+	} else {
+		if len(*password) < 6 {
+			log.Fatalf("创建账号需要通过 -password 提供至少 6 位密码")
+		}
+		hashedPassword, err := crypto.BcryptHash(*password, 10)
+		if err != nil {
+			log.Fatalf("密码加密失败: %v", err)
+		}
+
+		user = models.User{
+			ID:       uuid.New(),
+			Username: *username,
+			Email:    *username + "@feeder.local",
+			Password: hashedPassword,
+			Role:     services.RoleFeeder,
+			Status:   "active",
+		}
+		if err := db.Create(&user).Error; err != nil {
+			log.Fatalf("创建接收站账号失败: %v", err)
+		}
+		log.Printf("创建接收站账号成功: %s", user.Username)
+	}
+
+	token, err := jwt.GenerateToken(user.ID.String(), user.Username, user.Role, "", *ttl)
+	if err != nil {
+		log.Fatalf("签发令牌失败: %v", err)
+	}
+
+	log.Println("\n==========================================")
+	log.Printf("  令牌有效期至 %s", time.Now().Add(*ttl).Format(time.RFC3339))
+	log.Println("  通过 -token 参数或 SBS_FEEDER_TOKEN / MAVLINK_FEEDER_TOKEN 环境变量提供给接收程序")
+	log.Println("==========================================")
+	log.Println(token)
+}
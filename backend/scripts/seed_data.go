@@ -4,6 +4,7 @@ import (
 	"backend/internal/config"
 	"backend/internal/database"
 	"backend/internal/models"
+	"backend/pkg/geo"
 	"backend/pkg/utils/logger"
 	"encoding/csv"
 	"fmt"
@@ -483,9 +484,9 @@ func seedNoFlyZones(db *gorm.DB, now time.Time) {
 
 	count := 0
 	for _, d := range defs {
-		geometry := fmt.Sprintf(`{"type":"Point","coordinates":[%f,%f],"properties":{"radius":%d}}`, d.Lon, d.Lat, d.Radius)
+		geometry := geo.NewCircle(geo.LatLng{Lat: d.Lat, Lng: d.Lon}, float64(d.Radius))
 		nfz := models.NoFlyZone{
-			Name: d.Name, Type: d.Type, Geometry: geometry,
+			Name: d.Name, Type: d.Type, Geometry: *geometry,
 			MinAltitude: d.MinAlt, MaxAltitude: d.MaxAlt,
 			Reason: d.Reason, Authority: d.Authority,
 			Status: "active", CreatedAt: now, UpdatedAt: now,
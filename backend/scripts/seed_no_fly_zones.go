@@ -4,6 +4,7 @@ import (
 	"backend/internal/config"
 	"backend/internal/database"
 	"backend/internal/models"
+	"backend/pkg/geo"
 	"backend/pkg/utils/logger"
 	"fmt"
 	"math/rand"
@@ -73,9 +74,8 @@ func main() {
 		radiusVariation := 0.7 + rand.Float64()*0.6
 		radius := int(float64(template.BaseRadius) * radiusVariation)
 
-		// 构建 GeoJSON 格式的几何数据
-		geometry := fmt.Sprintf(`{"type":"Point","coordinates":[%.6f,%.6f],"properties":{"radius":%d}}`,
-			longitude, latitude, radius)
+		// 圆形禁飞区：圆心加半径
+		geometry := geo.NewCircle(geo.LatLng{Lat: latitude, Lng: longitude}, float64(radius))
 
 		name := fmt.Sprintf("%s%s-%d", city, template.NamePrefix, i+1)
 
@@ -83,7 +83,7 @@ func main() {
 			ID:          uuid.New(),
 			Name:        name,
 			Type:        template.Type,
-			Geometry:    geometry,
+			Geometry:    *geometry,
 			MinAltitude: 0,
 			MaxAltitude: 500,
 			Reason:      fmt.Sprintf("%s禁飞区域", name),